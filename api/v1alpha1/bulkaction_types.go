@@ -0,0 +1,103 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=RotateCertificates;Pause;Resume
+
+type BulkActionType string
+
+const (
+	// BulkActionRotateCertificates generates again the API Server, kubelet client, and front-proxy client certificates.
+	BulkActionRotateCertificates BulkActionType = "RotateCertificates"
+	// BulkActionPause pauses the reconciliation of the Tenant Control Planes, with the PausedAnnotation.
+	BulkActionPause BulkActionType = "Pause"
+	// BulkActionResume resumes the reconciliation of the paused Tenant Control Planes.
+	BulkActionResume BulkActionType = "Resume"
+)
+
+// BulkActionSpec defines the action, and the Tenant Control Planes it targets.
+type BulkActionSpec struct {
+	// Action performed on each targeted Tenant Control Plane.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the action is immutable"
+	Action BulkActionType `json:"action"`
+	// Selector targets the Tenant Control Planes by their labels: an empty selector targets all of them.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the selector is immutable"
+	Selector metav1.LabelSelector `json:"selector"`
+	// NamespaceSelector restricts the targeted Tenant Control Planes to the Namespaces matching the labels.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the namespace selector is immutable"
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// DataStore restricts the targeted Tenant Control Planes to the ones using the given DataStore.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the DataStore is immutable"
+	DataStore string `json:"dataStore,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Succeeded;Failed
+
+type BulkActionResultType string
+
+const (
+	BulkActionResultSucceeded BulkActionResultType = "Succeeded"
+	BulkActionResultFailed    BulkActionResultType = "Failed"
+)
+
+// BulkActionTarget reports the result of the action performed on a Tenant Control Plane.
+type BulkActionTarget struct {
+	Namespace string               `json:"namespace"`
+	Name      string               `json:"name"`
+	Result    BulkActionResultType `json:"result"`
+	// Message reports the reason of the failure, if any.
+	Message string `json:"message,omitempty"`
+	// Time is when the action has been performed.
+	Time metav1.Time `json:"time"`
+}
+
+// BulkActionStatus defines the observed state of BulkAction.
+type BulkActionStatus struct {
+	// Targets reports the result of the action for each Tenant Control Plane matching the selectors:
+	// the action is performed once for each of them, the failed ones must be targeted by a new BulkAction.
+	Targets []BulkActionTarget `json:"targets,omitempty"`
+	// Succeeded is the number of Tenant Control Planes the action has been performed on.
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// Failed is the number of Tenant Control Planes the action failed for.
+	Failed int32 `json:"failed,omitempty"`
+	// CompletionTime is when the action has been performed on all the targeted Tenant Control Planes.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action",description="Action"
+//+kubebuilder:printcolumn:name="Succeeded",type="integer",JSONPath=".status.succeeded",description="Tenant Control Planes the action has been performed on"
+//+kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed",description="Tenant Control Planes the action failed for"
+//+kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime",description="Completion time"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// BulkAction is the Schema for the bulkactions API: the action is performed once on the Tenant Control Planes matching
+// the selectors at the time of the processing, such as rotating the certificates of all the tenants of a team,
+// or pausing all the tenants of a DataStore, reporting the result for each of them.
+type BulkAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BulkActionSpec   `json:"spec,omitempty"`
+	Status BulkActionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BulkActionList contains a list of BulkAction.
+type BulkActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BulkAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BulkAction{}, &BulkActionList{})
+}
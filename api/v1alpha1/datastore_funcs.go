@@ -4,10 +4,17 @@
 package v1alpha1
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -37,3 +44,294 @@ func (in *ContentRef) GetContent(ctx context.Context, client client.Client) ([]b
 
 	return v, nil
 }
+
+// ApplyCredentialsFrom sets the Secret references of the credentials mapped by the CredentialsFrom field,
+// overriding the ones specified in the basic authentication and TLS configuration.
+func (in *DataStore) ApplyCredentialsFrom() {
+	source := in.Spec.CredentialsFrom
+	if source == nil {
+		return
+	}
+
+	refFn := func(key string) ContentRef {
+		return ContentRef{
+			SecretRef: &SecretReference{
+				SecretReference: source.SecretReference,
+				KeyPath:         secretReferKeyPath(key),
+			},
+		}
+	}
+
+	if len(source.KeyMapping.Username) > 0 || len(source.KeyMapping.Password) > 0 {
+		if in.Spec.BasicAuth == nil {
+			in.Spec.BasicAuth = &BasicAuth{}
+		}
+
+		if len(source.KeyMapping.Username) > 0 {
+			in.Spec.BasicAuth.Username = refFn(source.KeyMapping.Username)
+		}
+
+		if len(source.KeyMapping.Password) > 0 {
+			in.Spec.BasicAuth.Password = refFn(source.KeyMapping.Password)
+		}
+	}
+
+	if len(source.KeyMapping.CertificateAuthorityCertificate) > 0 {
+		in.Spec.TLSConfig.CertificateAuthority.Certificate = refFn(source.KeyMapping.CertificateAuthorityCertificate)
+	}
+
+	if len(source.KeyMapping.CertificateAuthorityPrivateKey) > 0 {
+		ref := refFn(source.KeyMapping.CertificateAuthorityPrivateKey)
+		in.Spec.TLSConfig.CertificateAuthority.PrivateKey = &ref
+	}
+
+	if len(source.KeyMapping.ClientCertificate) > 0 {
+		in.Spec.TLSConfig.ClientCertificate.Certificate = refFn(source.KeyMapping.ClientCertificate)
+	}
+
+	if len(source.KeyMapping.ClientPrivateKey) > 0 {
+		in.Spec.TLSConfig.ClientCertificate.PrivateKey = refFn(source.KeyMapping.ClientPrivateKey)
+	}
+}
+
+// PostgreSQLParameters returns the DSN parameters used to connect to the PostgreSQL data store:
+// the typed fields have precedence over the arbitrary parameters.
+func (in *DataStore) PostgreSQLParameters() url.Values {
+	values := url.Values{}
+
+	if in.Spec.Driver != KinePostgreSQLDriver {
+		return values
+	}
+	// Connecting to the primary only when multiple endpoints are available, unless differently specified.
+	if len(in.Spec.Endpoints) > 1 {
+		values.Set("target_session_attrs", "read-write")
+	}
+
+	if in.Spec.PostgreSQL == nil {
+		return values
+	}
+
+	for k, v := range in.Spec.PostgreSQL.Parameters {
+		values.Set(k, v)
+	}
+
+	if sslMode := in.Spec.PostgreSQL.SSLMode; len(sslMode) > 0 {
+		values.Set("sslmode", sslMode)
+	}
+
+	if timeout := in.Spec.PostgreSQL.ConnectTimeout; timeout != nil {
+		values.Set("connect_timeout", strconv.Itoa(int(*timeout)))
+	}
+
+	if attrs := in.Spec.PostgreSQL.TargetSessionAttrs; len(attrs) > 0 {
+		values.Set("target_session_attrs", attrs)
+	}
+
+	return values
+}
+
+// MySQLParameters returns the DSN parameters used to connect to the MySQL data store, with the templated ones not rendered.
+func (in *DataStore) MySQLParameters() url.Values {
+	values := url.Values{}
+
+	if in.Spec.Driver != KineMySQLDriver || in.Spec.MySQL == nil {
+		return values
+	}
+
+	for k, v := range in.Spec.MySQL.Parameters {
+		values.Set(k, v)
+	}
+
+	return values
+}
+
+// ConnectionParametersData are the values of the Tenant Control Plane available to the templated DSN parameters.
+// +kubebuilder:object:generate=false
+type ConnectionParametersData struct {
+	Name      string
+	Namespace string
+	Schema    string
+}
+
+// KineParameters returns the DSN parameters appended to the connection string used by kine, rendering the templated
+// ones with the given Tenant Control Plane values: the parameters failing the rendering are dropped, since the templates
+// have been already validated upon admission.
+func (in *DataStore) KineParameters(data ConnectionParametersData) url.Values {
+	var values url.Values
+
+	switch in.Spec.Driver {
+	case KineMySQLDriver:
+		values = in.MySQLParameters()
+	case KinePostgreSQLDriver:
+		values = in.PostgreSQLParameters()
+	default:
+		return url.Values{}
+	}
+
+	for key := range values {
+		rendered, err := renderConnectionParameter(key, values.Get(key), data)
+		if err != nil {
+			values.Del(key)
+
+			continue
+		}
+
+		values.Set(key, rendered)
+	}
+
+	return values
+}
+
+// WithoutTemplatedParameters returns the given DSN parameters, except the templated ones:
+// these are rendered for the connections of kine only, while the Kamaji ones are not bound to a Tenant Control Plane.
+func WithoutTemplatedParameters(values url.Values) url.Values {
+	static := url.Values{}
+
+	for key := range values {
+		if value := values.Get(key); !isTemplatedParameter(value) {
+			static.Set(key, value)
+		}
+	}
+
+	return static
+}
+
+func isTemplatedParameter(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func renderConnectionParameter(key, value string, data ConnectionParametersData) (string, error) {
+	if !isTemplatedParameter(value) {
+		return value, nil
+	}
+
+	tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// PostgreSQLSharedDatabase returns the database holding the schemas of the tenants,
+// or an empty string when the PostgreSQL data store is isolating them by database.
+func (in *DataStore) PostgreSQLSharedDatabase() string {
+	if in.Spec.Driver != KinePostgreSQLDriver || in.Spec.PostgreSQL == nil || in.Spec.PostgreSQL.IsolationMode != PostgreSQLSchemaIsolation {
+		return ""
+	}
+
+	if db := in.Spec.PostgreSQL.SharedDatabase; len(db) > 0 {
+		return db
+	}
+
+	return "kamaji"
+}
+
+// DefaultDataStoreAnnotation selects, on a namespace, the DataStore of the Tenant Control Planes created in it
+// with no DataStore, nor selector: it overrides the default DataStore of the Kamaji operator.
+const DefaultDataStoreAnnotation = "kamaji.clastix.io/default-datastore"
+
+// IsNamespaceAllowed returns true when the Tenant Control Planes of the given namespace are allowed to use the DataStore.
+func (in *DataStore) IsNamespaceAllowed(namespace *corev1.Namespace) (bool, error) {
+	allowed := in.Spec.AllowedNamespaces
+	if allowed == nil {
+		return true, nil
+	}
+
+	for _, name := range allowed.Names {
+		if name == namespace.GetName() {
+			return true, nil
+		}
+	}
+
+	if allowed.Selector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(allowed.Selector)
+	if err != nil {
+		return false, fmt.Errorf("unable to parse the allowed namespaces selector: %w", err)
+	}
+
+	return selector.Matches(labels.Set(namespace.GetLabels())), nil
+}
+
+// EndpointsForZone returns the endpoints ordered according to the zone affinity: the ones in the given zone first,
+// followed by the others, unless required, where the ones in other zones are dropped.
+// When no endpoint belongs to the zone, all of them are returned, keeping the declared order.
+func (in *DataStore) EndpointsForZone(affinity *DataStoreZoneAffinity) []string {
+	if affinity == nil || len(in.Spec.EndpointZones) == 0 {
+		return in.Spec.Endpoints
+	}
+
+	local, remote := make([]string, 0, len(in.Spec.Endpoints)), make([]string, 0, len(in.Spec.Endpoints))
+
+	for _, ep := range in.Spec.Endpoints {
+		if in.Spec.EndpointZones[ep] == affinity.Zone {
+			local = append(local, ep)
+
+			continue
+		}
+
+		remote = append(remote, ep)
+	}
+
+	if len(local) == 0 {
+		return in.Spec.Endpoints
+	}
+
+	if affinity.Policy == DataStoreZoneAffinityRequired {
+		return local
+	}
+
+	return append(local, remote...)
+}
+
+// GetProvisioned returns the given user, or schema, when created by this Kamaji installation.
+func (in *DataStoreStatus) GetProvisioned(kind DataStoreResourceKind, name string) *DataStoreProvisionedResource {
+	for i := range in.Provisioned {
+		if in.Provisioned[i].Kind == kind && in.Provisioned[i].Name == name {
+			return &in.Provisioned[i]
+		}
+	}
+
+	return nil
+}
+
+// AddProvisioned records the given user, or schema, returning false if already recorded.
+func (in *DataStoreStatus) AddProvisioned(kind DataStoreResourceKind, name string, creationTimestamp metav1.Time) bool {
+	if in.GetProvisioned(kind, name) != nil {
+		return false
+	}
+
+	in.Provisioned = append(in.Provisioned, DataStoreProvisionedResource{Kind: kind, Name: name, CreationTimestamp: creationTimestamp})
+
+	return true
+}
+
+// RemoveProvisioned removes the given user, or schema, returning false if not recorded.
+func (in *DataStoreStatus) RemoveProvisioned(kind DataStoreResourceKind, name string) bool {
+	for i := range in.Provisioned {
+		if in.Provisioned[i].Kind == kind && in.Provisioned[i].Name == name {
+			in.Provisioned = append(in.Provisioned[:i], in.Provisioned[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetCompactionSchedule returns the schedule of the compaction, if any.
+func (in *DataStoreMaintenance) GetCompactionSchedule() *MaintenanceSchedule {
+	if in.Compaction == nil {
+		return nil
+	}
+
+	return &in.Compaction.MaintenanceSchedule
+}
@@ -0,0 +1,200 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"net/url"
+	"testing"
+
+	"k8s.io/utils/pointer"
+)
+
+func TestDataStoreParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       DataStoreSpec
+		postgreSQL url.Values
+		mySQL      url.Values
+	}{
+		{
+			name:       "etcd has no parameters",
+			spec:       DataStoreSpec{Driver: EtcdDriver, Endpoints: []string{"etcd-0:2379", "etcd-1:2379"}},
+			postgreSQL: url.Values{},
+			mySQL:      url.Values{},
+		},
+		{
+			name:       "PostgreSQL with a single endpoint",
+			spec:       DataStoreSpec{Driver: KinePostgreSQLDriver, Endpoints: []string{"postgres:5432"}},
+			postgreSQL: url.Values{},
+			mySQL:      url.Values{},
+		},
+		{
+			name:       "PostgreSQL with multiple endpoints connects to the primary",
+			spec:       DataStoreSpec{Driver: KinePostgreSQLDriver, Endpoints: []string{"postgres-0:5432", "postgres-1:5432"}},
+			postgreSQL: url.Values{"target_session_attrs": {"read-write"}},
+			mySQL:      url.Values{},
+		},
+		{
+			name: "PostgreSQL typed fields have precedence over the parameters",
+			spec: DataStoreSpec{
+				Driver:    KinePostgreSQLDriver,
+				Endpoints: []string{"postgres-0:5432", "postgres-1:5432"},
+				PostgreSQL: &PostgreSQLSpec{
+					SSLMode:            "verify-full",
+					ConnectTimeout:     pointer.Int32(10),
+					TargetSessionAttrs: "primary",
+					Parameters: map[string]string{
+						"sslmode":          "disable",
+						"connect_timeout":  "1",
+						"application_name": "kamaji",
+					},
+				},
+			},
+			postgreSQL: url.Values{
+				"sslmode":              {"verify-full"},
+				"connect_timeout":      {"10"},
+				"target_session_attrs": {"primary"},
+				"application_name":     {"kamaji"},
+			},
+			mySQL: url.Values{},
+		},
+		{
+			name:       "MySQL with no parameters",
+			spec:       DataStoreSpec{Driver: KineMySQLDriver, Endpoints: []string{"mysql:3306"}},
+			postgreSQL: url.Values{},
+			mySQL:      url.Values{},
+		},
+		{
+			name: "MySQL parameters",
+			spec: DataStoreSpec{
+				Driver:    KineMySQLDriver,
+				Endpoints: []string{"mysql:3306"},
+				MySQL:     &MySQLSpec{Parameters: map[string]string{"timeout": "5s", "charset": "utf8mb4"}},
+			},
+			postgreSQL: url.Values{},
+			mySQL:      url.Values{"timeout": {"5s"}, "charset": {"utf8mb4"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := &DataStore{Spec: tt.spec}
+
+			if actual := ds.PostgreSQLParameters().Encode(); actual != tt.postgreSQL.Encode() {
+				t.Errorf("expected the PostgreSQL parameters %q, got %q", tt.postgreSQL.Encode(), actual)
+			}
+
+			if actual := ds.MySQLParameters().Encode(); actual != tt.mySQL.Encode() {
+				t.Errorf("expected the MySQL parameters %q, got %q", tt.mySQL.Encode(), actual)
+			}
+		})
+	}
+}
+
+func TestDataStoreKineParameters(t *testing.T) {
+	data := ConnectionParametersData{Name: "tcp", Namespace: "tenant", Schema: "tenant_tcp"}
+
+	tests := []struct {
+		name       string
+		driver     Driver
+		parameters map[string]string
+		kine       url.Values
+		static     url.Values
+	}{
+		{
+			name:       "MySQL static parameters",
+			driver:     KineMySQLDriver,
+			parameters: map[string]string{"timeout": "5s"},
+			kine:       url.Values{"timeout": {"5s"}},
+			static:     url.Values{"timeout": {"5s"}},
+		},
+		{
+			name:       "MySQL templated parameters",
+			driver:     KineMySQLDriver,
+			parameters: map[string]string{"timeout": "5s", "connectionAttributes": "tenant:{{ .Namespace }}/{{ .Name }}"},
+			kine:       url.Values{"timeout": {"5s"}, "connectionAttributes": {"tenant:tenant/tcp"}},
+			static:     url.Values{"timeout": {"5s"}},
+		},
+		{
+			name:       "PostgreSQL templated parameters",
+			driver:     KinePostgreSQLDriver,
+			parameters: map[string]string{"application_name": "kine-{{ .Schema }}", "sslmode": "require"},
+			kine:       url.Values{"application_name": {"kine-tenant_tcp"}, "sslmode": {"require"}},
+			static:     url.Values{"sslmode": {"require"}},
+		},
+		{
+			name:       "bad templates are dropped",
+			driver:     KinePostgreSQLDriver,
+			parameters: map[string]string{"application_name": "kine-{{ .Schema", "sslmode": "require"},
+			kine:       url.Values{"sslmode": {"require"}},
+			static:     url.Values{"sslmode": {"require"}},
+		},
+		{
+			name:       "missing keys are dropped",
+			driver:     KineMySQLDriver,
+			parameters: map[string]string{"connectionAttributes": "tenant:{{ .Cluster }}", "timeout": "5s"},
+			kine:       url.Values{"timeout": {"5s"}},
+			static:     url.Values{"timeout": {"5s"}},
+		},
+		{
+			name:       "etcd has no parameters",
+			driver:     EtcdDriver,
+			parameters: map[string]string{"timeout": "5s"},
+			kine:       url.Values{},
+			static:     url.Values{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := &DataStore{Spec: DataStoreSpec{Driver: tt.driver, Endpoints: []string{"db:5432"}}}
+
+			var parameters url.Values
+
+			switch tt.driver {
+			case KineMySQLDriver:
+				ds.Spec.MySQL = &MySQLSpec{Parameters: tt.parameters}
+				parameters = ds.MySQLParameters()
+			case KinePostgreSQLDriver:
+				ds.Spec.PostgreSQL = &PostgreSQLSpec{Parameters: tt.parameters}
+				parameters = ds.PostgreSQLParameters()
+			default:
+				parameters = url.Values{}
+			}
+
+			if actual := ds.KineParameters(data).Encode(); actual != tt.kine.Encode() {
+				t.Errorf("expected the kine parameters %q, got %q", tt.kine.Encode(), actual)
+			}
+
+			if actual := WithoutTemplatedParameters(parameters).Encode(); actual != tt.static.Encode() {
+				t.Errorf("expected the static parameters %q, got %q", tt.static.Encode(), actual)
+			}
+		})
+	}
+}
+
+func TestDataStoreValidateParameterTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{name: "static value", value: "utf8mb4"},
+		{name: "templated value", value: "{{ .Namespace }}-{{ .Name }}-{{ .Schema }}"},
+		{name: "unterminated action", value: "{{ .Name", expectErr: true},
+		{name: "unknown function", value: "{{ upper .Name }}", expectErr: true},
+		{name: "missing key", value: "{{ .Cluster }}", expectErr: true},
+	}
+
+	validator := &dataStoreValidator{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateParameterTemplate("key", tt.value)
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expected the error to be %t, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
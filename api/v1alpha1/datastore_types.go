@@ -28,12 +28,166 @@ type DataStoreSpec struct {
 	Driver Driver `json:"driver"`
 	// List of the endpoints to connect to the shared datastore.
 	// No need for protocol, just bare IP/FQDN and port.
+	// When multiple endpoints are specified for the SQL drivers, the first writable one is used, surviving the primary failover.
 	Endpoints Endpoints `json:"endpoints"`
 	// In case of authentication enabled for the given data store, specifies the username and password pair.
 	// This value is optional.
 	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+	// PrivilegedAuth specifies the username and password pair used only for the privileged operations, such as creating,
+	// and deleting, the per-tenant users, databases, and schemas: these credentials are loaded upon such operations only,
+	// rather than being held by the long-lived connections, letting basicAuth reference a less privileged user,
+	// used for the routine operations such as the health checks. When omitted, basicAuth is used for all the operations.
+	// This is available only for the MySQL and PostgreSQL drivers.
+	PrivilegedAuth *BasicAuth `json:"privilegedAuth,omitempty"`
 	// Defines the TLS/SSL configuration required to connect to the data store in a secure way.
 	TLSConfig TLSConfig `json:"tlsConfig"`
+	// Maintenance defines the periodic maintenance operations performed by Kamaji on the data store.
+	// This is available only for the etcd driver.
+	Maintenance *DataStoreMaintenance `json:"maintenance,omitempty"`
+	// MaintenanceMode pauses the scheduling of new Tenant Control Planes onto the data store, along with the reconciliation
+	// of the ones using it, marked as Degraded: this allows database maintenance windows with no misleading errors.
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
+	// MaxTenants limits the number of Tenant Control Planes placed on the data store:
+	// once reached, new ones are refused, and the Saturated condition is reported.
+	// +kubebuilder:validation:Minimum=1
+	MaxTenants *int32 `json:"maxTenants,omitempty"`
+	// PostgreSQL defines the connection parameters specific to the PostgreSQL driver,
+	// appended to the connection string used by kine.
+	PostgreSQL *PostgreSQLSpec `json:"postgreSQL,omitempty"`
+	// MySQL defines the connection parameters specific to the MySQL driver,
+	// appended to the connection string used by kine.
+	MySQL *MySQLSpec `json:"mySQL,omitempty"`
+	// CredentialsFrom references a Secret provisioned by an external secret manager, such as Vault or the External Secrets Operator,
+	// mapping its keys to the basic authentication and TLS credentials: the mapped ones take precedence over basicAuth and tlsConfig.
+	// The Secret can be provisioned after the data store creation, and its changes are resolved again automatically.
+	CredentialsFrom *CredentialsSource `json:"credentialsFrom,omitempty"`
+	// IAMAuthentication enables the AWS IAM database authentication, available for the MySQL and PostgreSQL drivers only:
+	// Kamaji connects using short-lived tokens generated with the AWS credentials of the operator, rather than a static password.
+	// The per-tenant users used by kine are still authenticated with the passwords generated by Kamaji.
+	IAMAuthentication *IAMAuthentication `json:"iamAuthentication,omitempty"`
+	// AzureADAuthentication enables the Azure AD authentication, available for the PostgreSQL driver only:
+	// Kamaji connects using the access tokens of the operator workload identity, rather than a static password.
+	// The per-tenant users used by kine are still authenticated with the passwords generated by Kamaji.
+	AzureADAuthentication *AzureADAuthentication `json:"azureADAuthentication,omitempty"`
+	// AllowedNamespaces dedicates the data store to the Tenant Control Planes of the given namespaces:
+	// when omitted, it can be used from any namespace.
+	AllowedNamespaces *DataStoreAllowedNamespaces `json:"allowedNamespaces,omitempty"`
+	// EndpointZones maps the endpoints to the zone they're running in, such as the topology.kubernetes.io/zone label value
+	// of their nodes, letting the Tenant Control Planes prefer the endpoints of their own zone.
+	// This is available only for the etcd driver.
+	EndpointZones map[string]string `json:"endpointZones,omitempty"`
+}
+
+// DataStoreAllowedNamespaces defines the namespaces whose Tenant Control Planes are allowed to use the data store:
+// a namespace is allowed when either listed by name, or matching the selector.
+type DataStoreAllowedNamespaces struct {
+	Names []string `json:"names,omitempty"`
+	// Selector matches the labels of the allowed namespaces.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// AzureADAuthentication defines the database role authenticated with the Azure AD workload identity,
+// such as for Azure Database for PostgreSQL.
+type AzureADAuthentication struct {
+	// Username is the database role mapped to the managed identity, or to the application, of the operator.
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+	// ClientID of the workload identity: when not specified, the one injected by the Azure Workload Identity webhook is used.
+	ClientID string `json:"clientID,omitempty"`
+}
+
+// IAMAuthentication defines the database user authenticated with AWS IAM, such as for Amazon RDS and Aurora.
+type IAMAuthentication struct {
+	// Region of the database instance, used to sign the authentication tokens.
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+	// Username is the database user enabled to the IAM authentication,
+	// such as with the rds_iam role for PostgreSQL, or the AWSAuthenticationPlugin for MySQL.
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+}
+
+// CredentialsSource maps the keys of an externally provisioned Secret to the data store credentials.
+type CredentialsSource struct {
+	corev1.SecretReference `json:",inline"`
+	// KeyMapping defines the Secret key storing each credential: the unmapped ones are not affected.
+	KeyMapping CredentialsKeyMapping `json:"keyMapping"`
+}
+
+type CredentialsKeyMapping struct {
+	Username                        string `json:"username,omitempty"`
+	Password                        string `json:"password,omitempty"`
+	CertificateAuthorityCertificate string `json:"certificateAuthorityCertificate,omitempty"`
+	CertificateAuthorityPrivateKey  string `json:"certificateAuthorityPrivateKey,omitempty"`
+	ClientCertificate               string `json:"clientCertificate,omitempty"`
+	ClientPrivateKey                string `json:"clientPrivateKey,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Database;Schema
+
+type PostgreSQLIsolationMode string
+
+var (
+	PostgreSQLDatabaseIsolation PostgreSQLIsolationMode = "Database"
+	PostgreSQLSchemaIsolation   PostgreSQLIsolationMode = "Schema"
+)
+
+// PostgreSQLSpec defines the DSN parameters used to connect to a PostgreSQL data store.
+type PostgreSQLSpec struct {
+	// IsolationMode defines how the data of the Tenant Control Planes is isolated: Database creates a dedicated database
+	// per tenant, for a stronger isolation, and an easier per-tenant backup and restore, while Schema creates a schema per tenant
+	// in the shared database. It cannot be changed while the data store is used.
+	// +kubebuilder:default=Database
+	IsolationMode PostgreSQLIsolationMode `json:"isolationMode,omitempty"`
+	// SharedDatabase is the existing database holding the schemas of the tenants, when isolated by schema.
+	// +kubebuilder:default=kamaji
+	SharedDatabase string `json:"sharedDatabase,omitempty"`
+	// SSLMode defines the TLS negotiation with the server: when not specified, the full verification is performed.
+	// +kubebuilder:validation:Enum=disable;allow;prefer;require;verify-ca;verify-full
+	SSLMode string `json:"sslMode,omitempty"`
+	// ConnectTimeout is the maximum time to wait while connecting, in seconds.
+	// +kubebuilder:validation:Minimum=0
+	ConnectTimeout *int32 `json:"connectTimeout,omitempty"`
+	// TargetSessionAttrs defines the acceptable server session when multiple hosts are specified.
+	// +kubebuilder:validation:Enum=any;read-write;read-only;primary;standby;prefer-standby
+	TargetSessionAttrs string `json:"targetSessionAttrs,omitempty"`
+	// Parameters are arbitrary DSN parameters, such as application_name: the ones managed by Kamaji,
+	// like the credentials, the host, the database, and the certificates, are not allowed.
+	// The values can be Go templates, such as {{ .Namespace }}-{{ .Name }}, rendered with the Name, the Namespace,
+	// and the Schema of each Tenant Control Plane: the templated parameters are used by kine only.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// MySQLSpec defines the DSN parameters used to connect to a MySQL data store.
+type MySQLSpec struct {
+	// Parameters are arbitrary DSN parameters of the Go MySQL driver, such as readTimeout, or charset:
+	// the tls one, managed by kine according to the data store certificates, is not allowed.
+	// The values can be Go templates, such as {{ .Namespace }}-{{ .Name }}, rendered with the Name, the Namespace,
+	// and the Schema of each Tenant Control Plane: the templated parameters are used by kine only.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// DataStoreMaintenance defines the periodic maintenance operations of an etcd data store.
+type DataStoreMaintenance struct {
+	// Compaction discards the superseded revisions of the keys, freeing up space:
+	// the Tenant Control Planes API Servers are not compacting the shared data store.
+	Compaction *CompactionSchedule `json:"compaction,omitempty"`
+	// Defragmentation releases the free space to the file system, a member at a time to preserve the quorum,
+	// with the leader as the last one.
+	Defragmentation *MaintenanceSchedule `json:"defragmentation,omitempty"`
+}
+
+type MaintenanceSchedule struct {
+	// Interval between two consecutive runs of the maintenance operation.
+	Interval metav1.Duration `json:"interval"`
+}
+
+type CompactionSchedule struct {
+	MaintenanceSchedule `json:",inline"`
+	// Retention is the minimum number of the latest revisions retained by the compaction, besides the ones written
+	// since the previous run, which are always retained to let the watchers resume from their last observed revision.
+	// +kubebuilder:validation:Minimum=0
+	Retention int64 `json:"retention,omitempty"`
 }
 
 // TLSConfig contains the information used to connect to the data store using a secured connection.
@@ -82,6 +236,67 @@ type SecretReference struct {
 type DataStoreStatus struct {
 	// List of the Tenant Control Planes, namespaced named, using this data store.
 	UsedBy []string `json:"usedBy,omitempty"`
+	// Maintenance reports the results of the last maintenance operations.
+	Maintenance *DataStoreMaintenanceStatus `json:"maintenance,omitempty"`
+	// Conditions reports the observations of the DataStore state, such as the validity of its credentials.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Provisioned lists the users, and the schemas, created by this Kamaji installation for the Tenant Control Planes:
+	// the garbage collection ignores the ones created by other installations sharing the DataStore, or imported.
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Provisioned []DataStoreProvisionedResource `json:"provisioned,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=User;Schema
+
+type DataStoreResourceKind string
+
+var (
+	DataStoreResourceUser   DataStoreResourceKind = "User"
+	DataStoreResourceSchema DataStoreResourceKind = "Schema"
+)
+
+// DataStoreProvisionedResource is a user, or a schema, created in the DataStore for a Tenant Control Plane.
+type DataStoreProvisionedResource struct {
+	Kind DataStoreResourceKind `json:"kind"`
+	Name string                `json:"name"`
+	// CreationTimestamp is the time the resource has been created.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+}
+
+const (
+	// ConditionTypeDataStoreCredentialsReady reports if the DataStore credentials allow the connection,
+	// and the management of the per-tenant users and schemas.
+	ConditionTypeDataStoreCredentialsReady = "CredentialsReady"
+	// ConditionTypeDataStoreSaturated reports if the DataStore reached the maximum number of Tenant Control Planes.
+	ConditionTypeDataStoreSaturated = "Saturated"
+)
+
+type DataStoreMaintenanceStatus struct {
+	Compaction      *MaintenanceRunStatus `json:"compaction,omitempty"`
+	Defragmentation *MaintenanceRunStatus `json:"defragmentation,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Succeeded;Failed
+
+type MaintenanceResult string
+
+var (
+	MaintenanceResultSucceeded MaintenanceResult = "Succeeded"
+	MaintenanceResultFailed    MaintenanceResult = "Failed"
+)
+
+type MaintenanceRunStatus struct {
+	// LastRun is the time of the last execution of the maintenance operation.
+	LastRun metav1.Time       `json:"lastRun,omitempty"`
+	Result  MaintenanceResult `json:"result,omitempty"`
+	// Message contains the details of the last execution, such as the compacted revision, or the error.
+	Message string `json:"message,omitempty"`
+	// Revision is the head revision observed by the last compaction: the next one discards the revisions up to it.
+	Revision int64 `json:"revision,omitempty"`
 }
 
 //+kubebuilder:object:root=true
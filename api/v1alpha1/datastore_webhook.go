@@ -6,13 +6,20 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,7 +27,14 @@ import (
 //+kubebuilder:webhook:path=/mutate-kamaji-clastix-io-v1alpha1-datastore,mutating=true,failurePolicy=fail,sideEffects=None,groups=kamaji.clastix.io,resources=datastores,verbs=create;update,versions=v1alpha1,name=mdatastore.kb.io,admissionReviewVersions=v1
 //+kubebuilder:webhook:path=/validate-kamaji-clastix-io-v1alpha1-datastore,mutating=false,failurePolicy=fail,sideEffects=None,groups=kamaji.clastix.io,resources=datastores,verbs=create;update;delete,versions=v1alpha1,name=vdatastore.kb.io,admissionReviewVersions=v1
 
-func (in *DataStore) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// dataStoreConnectionCheckTimeout keeps the connection check below the default timeout of the admission webhooks.
+const dataStoreConnectionCheckTimeout = 5 * time.Second
+
+// DataStoreConnectionCheckFn establishes a connection to the given DataStore, returning the error of the driver.
+// +kubebuilder:object:generate=false
+type DataStoreConnectionCheckFn func(ctx context.Context, ds DataStore) error
+
+func (in *DataStore) SetupWebhookWithManager(mgr ctrl.Manager, connectionCheckFn DataStoreConnectionCheckFn) error {
 	secretValidator := &dataStoreSecretValidator{
 		log:    mgr.GetLogger().WithName("datastore-secret-webhook"),
 		client: mgr.GetClient(),
@@ -31,8 +45,9 @@ func (in *DataStore) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	}
 
 	dsValidator := &dataStoreValidator{
-		log:    mgr.GetLogger().WithName("datastore-webhook"),
-		client: mgr.GetClient(),
+		log:               mgr.GetLogger().WithName("datastore-webhook"),
+		client:            mgr.GetClient(),
+		connectionCheckFn: connectionCheckFn,
 	}
 
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -43,8 +58,9 @@ func (in *DataStore) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 type dataStoreValidator struct {
-	log    logr.Logger
-	client client.Client
+	log               logr.Logger
+	client            client.Client
+	connectionCheckFn DataStoreConnectionCheckFn
 }
 
 func (d *dataStoreValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
@@ -57,6 +73,10 @@ func (d *dataStoreValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 		return err
 	}
 
+	if err := d.validateConnection(ctx, ds); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -77,9 +97,20 @@ func (d *dataStoreValidator) ValidateUpdate(ctx context.Context, oldObj, newObj
 		return fmt.Errorf("driver of a DataStore cannot be changed")
 	}
 
+	if ds.PostgreSQLSharedDatabase() != old.PostgreSQLSharedDatabase() && len(old.Status.UsedBy) > 0 {
+		return fmt.Errorf("the PostgreSQL isolation mode of a DataStore cannot be changed while used by Tenant Control Planes")
+	}
+
 	if err := d.validate(ctx, ds); err != nil {
 		return err
 	}
+	// Checking the connection only upon the specification changes, allowing the metadata updates, such as the finalizers,
+	// when the DataStore is not reachable.
+	if !equality.Semantic.DeepEqual(old.Spec, ds.Spec) {
+		if err := d.validateConnection(ctx, ds); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -103,7 +134,14 @@ func (d *dataStoreValidator) ValidateDelete(ctx context.Context, obj runtime.Obj
 	return nil
 }
 
-func (d *dataStoreValidator) Default(context.Context, runtime.Object) error {
+func (d *dataStoreValidator) Default(_ context.Context, obj runtime.Object) error {
+	ds, ok := obj.(*DataStore)
+	if !ok {
+		return fmt.Errorf("expected *kamajiv1alpha1.DataStore")
+	}
+
+	ds.ApplyCredentialsFrom()
+
 	return nil
 }
 
@@ -114,27 +152,335 @@ func (d *dataStoreValidator) validate(ctx context.Context, ds *DataStore) error
 		}
 	}
 
+	if ds.Spec.PrivilegedAuth != nil {
+		if err := d.validatePrivilegedAuth(ctx, ds); err != nil {
+			return err
+		}
+	}
+
 	if err := d.validateTLSConfig(ctx, ds); err != nil {
 		return err
 	}
 
+	if ds.Spec.Maintenance != nil {
+		if err := d.validateMaintenance(ds); err != nil {
+			return err
+		}
+	}
+
+	if ds.Spec.PostgreSQL != nil {
+		if err := d.validatePostgreSQL(ds); err != nil {
+			return err
+		}
+	}
+
+	if ds.Spec.MySQL != nil {
+		if err := d.validateMySQL(ds); err != nil {
+			return err
+		}
+	}
+
+	if ds.Spec.CredentialsFrom != nil {
+		if err := d.validateCredentialsFrom(ds); err != nil {
+			return err
+		}
+	}
+
+	if ds.Spec.IAMAuthentication != nil {
+		if err := d.validateIAMAuthentication(ds); err != nil {
+			return err
+		}
+	}
+
+	if ds.Spec.AzureADAuthentication != nil {
+		if err := d.validateAzureADAuthentication(ds); err != nil {
+			return err
+		}
+	}
+
+	if len(ds.Spec.EndpointZones) > 0 {
+		if err := d.validateEndpointZones(ds); err != nil {
+			return err
+		}
+	}
+
+	if ds.Spec.AllowedNamespaces != nil {
+		if err := d.validateAllowedNamespaces(ds); err != nil {
+			return err
+		}
+	}
+
+	if err := d.validateSharedBackend(ctx, ds); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSharedBackend ensures the DataStore objects pointing at the same backend, sharing any endpoint,
+// agree on the driver and the TLS settings: the users, and the schemas, of the tenants would be managed
+// with different setups otherwise, corrupting the bookkeeping of the Tenant Control Planes.
+func (d *dataStoreValidator) validateSharedBackend(ctx context.Context, ds *DataStore) error {
+	dsList := &DataStoreList{}
+	if err := d.client.List(ctx, dsList); err != nil {
+		return err
+	}
+
+	endpoints := sets.NewString()
+	for _, ep := range ds.Spec.Endpoints {
+		endpoints.Insert(normalizeEndpoint(ep))
+	}
+
+	for _, other := range dsList.Items {
+		if other.GetName() == ds.GetName() {
+			continue
+		}
+
+		var shared []string
+
+		for _, ep := range other.Spec.Endpoints {
+			if endpoints.Has(normalizeEndpoint(ep)) {
+				shared = append(shared, ep)
+			}
+		}
+
+		if len(shared) == 0 {
+			continue
+		}
+
+		if other.Spec.Driver != ds.Spec.Driver {
+			return fmt.Errorf("the DataStore %s is pointing at the same backend (%s) with the %s driver", other.GetName(), strings.Join(shared, ", "), other.Spec.Driver)
+		}
+
+		if !equality.Semantic.DeepEqual(other.Spec.TLSConfig, ds.Spec.TLSConfig) {
+			return fmt.Errorf("the DataStore %s is pointing at the same backend (%s) with different TLS settings", other.GetName(), strings.Join(shared, ", "))
+		}
+	}
+
+	return nil
+}
+
+// normalizeEndpoint returns the endpoint in a comparable form, regardless of the host case, and the IPv6 notation.
+func normalizeEndpoint(endpoint string) string {
+	host, port, err := net.SplitHostPort(strings.TrimSpace(endpoint))
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(endpoint))
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		host = ip.String()
+	}
+
+	return net.JoinHostPort(strings.ToLower(host), port)
+}
+
+func (d *dataStoreValidator) validateIAMAuthentication(ds *DataStore) error {
+	if ds.Spec.Driver != KineMySQLDriver && ds.Spec.Driver != KinePostgreSQLDriver {
+		return fmt.Errorf("IAM authentication is supported only by the MySQL and PostgreSQL drivers")
+	}
+
+	if ds.Spec.BasicAuth != nil {
+		return fmt.Errorf("IAM authentication and basic authentication are mutually exclusive")
+	}
+
+	if source := ds.Spec.CredentialsFrom; source != nil && (len(source.KeyMapping.Username) > 0 || len(source.KeyMapping.Password) > 0) {
+		return fmt.Errorf("IAM authentication cannot be used along with the basic authentication credentials mapping")
+	}
+
+	return nil
+}
+
+func (d *dataStoreValidator) validateAzureADAuthentication(ds *DataStore) error {
+	if ds.Spec.Driver != KinePostgreSQLDriver {
+		return fmt.Errorf("the Azure AD authentication is supported only by the PostgreSQL driver")
+	}
+
+	if ds.Spec.BasicAuth != nil || ds.Spec.IAMAuthentication != nil {
+		return fmt.Errorf("the Azure AD authentication is mutually exclusive with the basic, and the IAM, authentication")
+	}
+
+	if source := ds.Spec.CredentialsFrom; source != nil && (len(source.KeyMapping.Username) > 0 || len(source.KeyMapping.Password) > 0) {
+		return fmt.Errorf("the Azure AD authentication cannot be used along with the basic authentication credentials mapping")
+	}
+
+	return nil
+}
+
+// validateConnection establishes a real connection to the DataStore, rejecting the configurations which cannot connect
+// rather than failing later in the Tenant Control Plane reconciliation: it's skipped in maintenance mode.
+func (d *dataStoreValidator) validateAllowedNamespaces(ds *DataStore) error {
+	if selector := ds.Spec.AllowedNamespaces.Selector; selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return fmt.Errorf("the allowed namespaces selector is not valid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *dataStoreValidator) validateConnection(ctx context.Context, ds *DataStore) error {
+	if d.connectionCheckFn == nil || ds.Spec.MaintenanceMode {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dataStoreConnectionCheckTimeout)
+	defer cancel()
+
+	if err := d.connectionCheckFn(ctx, *ds); err != nil {
+		return fmt.Errorf("cannot connect to the DataStore: %w", err)
+	}
+
+	return nil
+}
+
+var postgreSQLParameterRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// postgreSQLManagedParameters are the DSN parameters managed by Kamaji, which cannot be overridden.
+var postgreSQLManagedParameters = sets.NewString("user", "password", "host", "hostaddr", "port", "dbname", "sslcert", "sslkey", "sslrootcert")
+
+func (d *dataStoreValidator) validatePostgreSQL(ds *DataStore) error {
+	if ds.Spec.Driver != KinePostgreSQLDriver {
+		return fmt.Errorf("PostgreSQL parameters are supported only by the PostgreSQL driver")
+	}
+
+	for key, value := range ds.Spec.PostgreSQL.Parameters {
+		if !postgreSQLParameterRegexp.MatchString(key) {
+			return fmt.Errorf("PostgreSQL parameter %q is not valid", key)
+		}
+
+		if postgreSQLManagedParameters.Has(key) {
+			return fmt.Errorf("PostgreSQL parameter %q is managed by Kamaji and cannot be specified", key)
+		}
+
+		if err := d.validateParameterTemplate(key, value); err != nil {
+			return fmt.Errorf("PostgreSQL parameter %q is not valid: %w", key, err)
+		}
+	}
+
+	if _, ok := ds.Spec.PostgreSQL.Parameters["search_path"]; ok && len(ds.PostgreSQLSharedDatabase()) > 0 {
+		return fmt.Errorf("PostgreSQL parameter %q is managed by Kamaji when isolating the tenants by schema", "search_path")
+	}
+
+	if db := ds.Spec.PostgreSQL.SharedDatabase; len(db) > 0 && !postgreSQLParameterRegexp.MatchString(db) {
+		return fmt.Errorf("PostgreSQL shared database %q is not a valid name", db)
+	}
+
+	return nil
+}
+
+var mySQLParameterRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// mySQLManagedParameters are the DSN parameters managed by kine, which cannot be overridden.
+var mySQLManagedParameters = sets.NewString("tls")
+
+func (d *dataStoreValidator) validateMySQL(ds *DataStore) error {
+	if ds.Spec.Driver != KineMySQLDriver {
+		return fmt.Errorf("MySQL parameters are supported only by the MySQL driver")
+	}
+
+	for key, value := range ds.Spec.MySQL.Parameters {
+		if !mySQLParameterRegexp.MatchString(key) {
+			return fmt.Errorf("MySQL parameter %q is not valid", key)
+		}
+
+		if mySQLManagedParameters.Has(key) {
+			return fmt.Errorf("MySQL parameter %q is managed by kine and cannot be specified", key)
+		}
+
+		if err := d.validateParameterTemplate(key, value); err != nil {
+			return fmt.Errorf("MySQL parameter %q is not valid: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// validateParameterTemplate ensures the templated DSN parameter is rendered with the Tenant Control Plane values.
+func (d *dataStoreValidator) validateParameterTemplate(key, value string) error {
+	_, err := renderConnectionParameter(key, value, ConnectionParametersData{Name: "name", Namespace: "namespace", Schema: "schema"})
+
+	return err
+}
+
+func (d *dataStoreValidator) validateEndpointZones(ds *DataStore) error {
+	if ds.Spec.Driver != EtcdDriver {
+		return fmt.Errorf("the endpoint zones are supported only by the etcd driver")
+	}
+
+	endpoints := make(map[string]struct{}, len(ds.Spec.Endpoints))
+	for _, ep := range ds.Spec.Endpoints {
+		endpoints[ep] = struct{}{}
+	}
+
+	for ep := range ds.Spec.EndpointZones {
+		if _, ok := endpoints[ep]; !ok {
+			return fmt.Errorf("the zone is declared for the endpoint %s, which is not listed in the endpoints", ep)
+		}
+	}
+
+	return nil
+}
+
+func (d *dataStoreValidator) validateMaintenance(ds *DataStore) error {
+	if ds.Spec.Driver != EtcdDriver {
+		return fmt.Errorf("maintenance operations are supported only by the etcd driver")
+	}
+
+	for name, schedule := range map[string]*MaintenanceSchedule{"compaction": ds.Spec.Maintenance.GetCompactionSchedule(), "defragmentation": ds.Spec.Maintenance.Defragmentation} {
+		if schedule != nil && schedule.Interval.Duration < time.Minute {
+			return fmt.Errorf("the %s interval must be at least one minute", name)
+		}
+	}
+
+	return nil
+}
+
+func (d *dataStoreValidator) validateCredentialsFrom(ds *DataStore) error {
+	if len(ds.Spec.CredentialsFrom.Name) == 0 || len(ds.Spec.CredentialsFrom.Namespace) == 0 {
+		return fmt.Errorf("the credentials Secret name and namespace are mandatory")
+	}
+
+	if ds.Spec.CredentialsFrom.KeyMapping == (CredentialsKeyMapping{}) {
+		return fmt.Errorf("the credentials Secret must map at least a key")
+	}
+
 	return nil
 }
 
 func (d *dataStoreValidator) validateBasicAuth(ctx context.Context, ds *DataStore) error {
-	if err := d.validateContentReference(ctx, ds.Spec.BasicAuth.Password); err != nil {
+	if err := d.validateContentReference(ctx, ds, ds.Spec.BasicAuth.Password); err != nil {
 		return fmt.Errorf("basic-auth password is not valid, %w", err)
 	}
 
-	if err := d.validateContentReference(ctx, ds.Spec.BasicAuth.Username); err != nil {
+	if err := d.validateContentReference(ctx, ds, ds.Spec.BasicAuth.Username); err != nil {
 		return fmt.Errorf("basic-auth username is not valid, %w", err)
 	}
 
 	return nil
 }
 
+func (d *dataStoreValidator) validatePrivilegedAuth(ctx context.Context, ds *DataStore) error {
+	if ds.Spec.Driver != KineMySQLDriver && ds.Spec.Driver != KinePostgreSQLDriver {
+		return fmt.Errorf("the privileged authentication is supported only by the MySQL and PostgreSQL drivers")
+	}
+
+	if ds.Spec.IAMAuthentication != nil || ds.Spec.AzureADAuthentication != nil {
+		return fmt.Errorf("the privileged authentication is mutually exclusive with the IAM, and the Azure AD, authentication")
+	}
+
+	if err := d.validateContentReference(ctx, ds, ds.Spec.PrivilegedAuth.Password); err != nil {
+		return fmt.Errorf("privileged-auth password is not valid, %w", err)
+	}
+
+	if err := d.validateContentReference(ctx, ds, ds.Spec.PrivilegedAuth.Username); err != nil {
+		return fmt.Errorf("privileged-auth username is not valid, %w", err)
+	}
+
+	return nil
+}
+
 func (d *dataStoreValidator) validateTLSConfig(ctx context.Context, ds *DataStore) error {
-	if err := d.validateContentReference(ctx, ds.Spec.TLSConfig.CertificateAuthority.Certificate); err != nil {
+	if err := d.validateContentReference(ctx, ds, ds.Spec.TLSConfig.CertificateAuthority.Certificate); err != nil {
 		return fmt.Errorf("CA certificate is not valid, %w", err)
 	}
 
@@ -145,23 +491,23 @@ func (d *dataStoreValidator) validateTLSConfig(ctx context.Context, ds *DataStor
 	}
 
 	if ds.Spec.TLSConfig.CertificateAuthority.PrivateKey != nil {
-		if err := d.validateContentReference(ctx, *ds.Spec.TLSConfig.CertificateAuthority.PrivateKey); err != nil {
+		if err := d.validateContentReference(ctx, ds, *ds.Spec.TLSConfig.CertificateAuthority.PrivateKey); err != nil {
 			return fmt.Errorf("CA private key is not valid, %w", err)
 		}
 	}
 
-	if err := d.validateContentReference(ctx, ds.Spec.TLSConfig.ClientCertificate.Certificate); err != nil {
+	if err := d.validateContentReference(ctx, ds, ds.Spec.TLSConfig.ClientCertificate.Certificate); err != nil {
 		return fmt.Errorf("client certificate is not valid, %w", err)
 	}
 
-	if err := d.validateContentReference(ctx, ds.Spec.TLSConfig.ClientCertificate.PrivateKey); err != nil {
+	if err := d.validateContentReference(ctx, ds, ds.Spec.TLSConfig.ClientCertificate.PrivateKey); err != nil {
 		return fmt.Errorf("client private key is not valid, %w", err)
 	}
 
 	return nil
 }
 
-func (d *dataStoreValidator) validateContentReference(ctx context.Context, ref ContentRef) error {
+func (d *dataStoreValidator) validateContentReference(ctx context.Context, ds *DataStore, ref ContentRef) error {
 	switch {
 	case len(ref.Content) > 0:
 		return nil
@@ -172,6 +518,10 @@ func (d *dataStoreValidator) validateContentReference(ctx context.Context, ref C
 	case len(ref.SecretRef.SecretReference.Namespace) == 0:
 		return fmt.Errorf("the Secret reference namespace is mandatory")
 	}
+	// The Secrets provisioned by an external secret manager could be not yet available.
+	if source := ds.Spec.CredentialsFrom; source != nil && source.SecretReference == ref.SecretRef.SecretReference {
+		return nil
+	}
 
 	if err := d.client.Get(ctx, types.NamespacedName{Name: ref.SecretRef.SecretReference.Name, Namespace: ref.SecretRef.SecretReference.Namespace}, &corev1.Secret{}); err != nil {
 		if errors.IsNotFound(err) {
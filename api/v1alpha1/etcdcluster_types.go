@@ -0,0 +1,81 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EtcdClusterSpec defines the desired state of EtcdCluster.
+type EtcdClusterSpec struct {
+	// Replicas is the number of etcd members: it cannot be changed once the cluster has been provisioned.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Enum=1;3;5
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the number of etcd members is immutable"
+	Replicas int32 `json:"replicas,omitempty"`
+	// Image is the container image used to run the etcd members.
+	// +kubebuilder:default="quay.io/coreos/etcd:v3.5.6"
+	Image string `json:"image,omitempty"`
+	// Storage defines the persistent volume claimed by each etcd member.
+	Storage EtcdClusterStorage `json:"storage,omitempty"`
+	// Resources defines the compute resources of the etcd members.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type EtcdClusterStorage struct {
+	// StorageClassName is the Storage Class of the volumes: when not specified, the default one is used.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// +kubebuilder:default="10Gi"
+	Size resource.Quantity `json:"size,omitempty"`
+}
+
+// EtcdClusterStatus defines the observed state of EtcdCluster.
+type EtcdClusterStatus struct {
+	// ReadyReplicas is the number of etcd members ready to serve requests.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// AuthEnabled reports if the etcd authentication has been enabled, along with the root user.
+	AuthEnabled bool `json:"authEnabled,omitempty"`
+	// DataStore is the name of the DataStore exposing the etcd cluster, available once provisioned.
+	DataStore string `json:"dataStore,omitempty"`
+	// Conditions reports the observations of the etcd cluster provisioning.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ConditionTypeEtcdClusterReady reports if the etcd cluster has been provisioned, and exposed as a DataStore.
+const ConditionTypeEtcdClusterReady = "Ready"
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas",description="etcd members"
+//+kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="etcd members ready"
+//+kubebuilder:printcolumn:name="DataStore",type="string",JSONPath=".status.dataStore",description="DataStore exposing the etcd cluster"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// EtcdCluster is the Schema for the etcdclusters API: Kamaji provisions and operates a dedicated etcd cluster,
+// exposed as a DataStore with the same name.
+type EtcdCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdClusterSpec   `json:"spec,omitempty"`
+	Status EtcdClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EtcdClusterList contains a list of EtcdCluster.
+type EtcdClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdCluster{}, &EtcdClusterList{})
+}
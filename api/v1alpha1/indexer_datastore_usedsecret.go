@@ -43,6 +43,16 @@ func (d *DatastoreUsedSecret) ExtractValue() client.IndexerFunc {
 			}
 		}
 
+		if ds.Spec.PrivilegedAuth != nil {
+			if ds.Spec.PrivilegedAuth.Username.SecretRef != nil {
+				res = append(res, d.namespacedName(*ds.Spec.PrivilegedAuth.Username.SecretRef))
+			}
+
+			if ds.Spec.PrivilegedAuth.Password.SecretRef != nil {
+				res = append(res, d.namespacedName(*ds.Spec.PrivilegedAuth.Password.SecretRef))
+			}
+		}
+
 		if ds.Spec.TLSConfig.CertificateAuthority.Certificate.SecretRef != nil {
 			res = append(res, d.namespacedName(*ds.Spec.TLSConfig.CertificateAuthority.Certificate.SecretRef))
 		}
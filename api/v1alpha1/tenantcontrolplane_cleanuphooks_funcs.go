@@ -0,0 +1,88 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// CleanupHookWebhookMaxTimeout caps the time given to each webhook request, since it's performed by the reconciliation.
+const CleanupHookWebhookMaxTimeout = 10 * time.Second
+
+// CleanupHookJobPolicy lists the images, and the ServiceAccounts, the clean-up hook Jobs are allowed to use,
+// as configured by the operator: the Jobs are created by Kamaji, thus they're refused when not listed.
+// +kubebuilder:object:generate=false
+type CleanupHookJobPolicy struct {
+	// Images are the allowed image patterns, in the path.Match syntax, such as registry.example.com/hooks/*.
+	Images []string
+	// ServiceAccounts are the allowed ServiceAccount names, the empty one being the default ServiceAccount.
+	ServiceAccounts []string
+}
+
+// Allows ensures the given clean-up hook Job uses an allowed image, and ServiceAccount.
+func (in CleanupHookJobPolicy) Allows(job *CleanupHookJob) error {
+	imageAllowed := false
+
+	for _, pattern := range in.Images {
+		if ok, _ := path.Match(pattern, job.Image); ok {
+			imageAllowed = true
+
+			break
+		}
+	}
+
+	if !imageAllowed {
+		return fmt.Errorf("the image %s is not allowed for the clean-up hook Jobs", job.Image)
+	}
+
+	serviceAccount := job.ServiceAccountName
+	if len(serviceAccount) == 0 {
+		serviceAccount = "default"
+	}
+
+	for _, allowed := range in.ServiceAccounts {
+		if allowed == serviceAccount {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the ServiceAccount %s is not allowed for the clean-up hook Jobs", serviceAccount)
+}
+
+// ValidateCleanupHooks ensures each clean-up hook declares either a webhook, or a Job allowed by the given policy,
+// and a timeout of one second, at least.
+func (in *TenantControlPlane) ValidateCleanupHooks(jobPolicy CleanupHookJobPolicy) error {
+	for _, hook := range in.Spec.CleanupHooks {
+		if (hook.Webhook == nil) == (hook.Job == nil) {
+			return fmt.Errorf("the clean-up hook %s must declare either a webhook, or a Job", hook.Name)
+		}
+
+		if hook.Job != nil {
+			if err := jobPolicy.Allows(hook.Job); err != nil {
+				return fmt.Errorf("the clean-up hook %s is not allowed: %w", hook.Name, err)
+			}
+		}
+
+		if hook.Timeout.Duration < time.Second {
+			return fmt.Errorf("the clean-up hook %s timeout must be one second, at least", hook.Name)
+		}
+	}
+
+	return nil
+}
+
+// CleanupHookStatus returns the status of the given clean-up hook, appending it if missing.
+func (in *TenantControlPlane) CleanupHookStatus(name string) *CleanupHookStatus {
+	for i := range in.Status.CleanupHooks {
+		if in.Status.CleanupHooks[i].Name == name {
+			return &in.Status.CleanupHooks[i]
+		}
+	}
+
+	in.Status.CleanupHooks = append(in.Status.CleanupHooks, CleanupHookStatus{Name: name})
+
+	return &in.Status.CleanupHooks[len(in.Status.CleanupHooks)-1]
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCleanupHookJobPolicyAllows(t *testing.T) {
+	policy := CleanupHookJobPolicy{
+		Images:          []string{"registry.example.com/hooks/*", "alpine:3.17"},
+		ServiceAccounts: []string{"cleanup"},
+	}
+
+	tests := []struct {
+		name      string
+		job       CleanupHookJob
+		expectErr bool
+	}{
+		{name: "allowed image pattern", job: CleanupHookJob{Image: "registry.example.com/hooks/billing:v1", ServiceAccountName: "cleanup"}},
+		{name: "allowed image", job: CleanupHookJob{Image: "alpine:3.17", ServiceAccountName: "cleanup"}},
+		{name: "image in a nested repository", job: CleanupHookJob{Image: "registry.example.com/hooks/nested/billing:v1", ServiceAccountName: "cleanup"}, expectErr: true},
+		{name: "image not allowed", job: CleanupHookJob{Image: "alpine:latest", ServiceAccountName: "cleanup"}, expectErr: true},
+		{name: "ServiceAccount not allowed", job: CleanupHookJob{Image: "alpine:3.17", ServiceAccountName: "kamaji"}, expectErr: true},
+		{name: "default ServiceAccount not listed", job: CleanupHookJob{Image: "alpine:3.17"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Allows(&tt.job)
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expected the error to be %t, got %v", tt.expectErr, err)
+			}
+		})
+	}
+
+	if err := (CleanupHookJobPolicy{}).Allows(&CleanupHookJob{Image: "alpine:3.17"}); err == nil {
+		t.Error("expected the Jobs to be refused with no policy")
+	}
+}
+
+func TestTenantControlPlaneValidateCleanupHooksUpdate(t *testing.T) {
+	webhook := CleanupHook{Name: "billing", Webhook: &CleanupHookWebhook{URL: "https://billing.example.com"}, Timeout: metav1.Duration{Duration: time.Minute}}
+	job := CleanupHook{Name: "cmdb", Job: &CleanupHookJob{Image: "alpine:3.17"}, Timeout: metav1.Duration{Duration: time.Minute}}
+
+	tenant := func(deleting bool, hooks ...CleanupHook) *TenantControlPlane {
+		tcp := &TenantControlPlane{Spec: TenantControlPlaneSpec{CleanupHooks: hooks}}
+		if deleting {
+			now := metav1.Now()
+			tcp.SetDeletionTimestamp(&now)
+		}
+
+		return tcp
+	}
+
+	tests := []struct {
+		name      string
+		old       *TenantControlPlane
+		tcp       *TenantControlPlane
+		expectErr bool
+	}{
+		{name: "unchanged Job no longer allowed", old: tenant(false, job), tcp: tenant(false, job)},
+		{name: "unchanged hooks upon the deletion", old: tenant(true, webhook, job), tcp: tenant(true, webhook, job)},
+		{name: "webhook added", old: tenant(false), tcp: tenant(false, webhook)},
+		{name: "Job not allowed added", old: tenant(false, webhook), tcp: tenant(false, webhook, job), expectErr: true},
+		{name: "hooks removed upon the deletion", old: tenant(true, webhook), tcp: tenant(true), expectErr: true},
+		{name: "hooks changed upon the deletion", old: tenant(true, webhook), tcp: tenant(true, job), expectErr: true},
+	}
+
+	validator := &tenantControlPlaneValidator{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateCleanupHooksUpdate(tt.old, tt.tcp)
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expected the error to be %t, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Validate ensures the stub zones and the upstreams can be safely rendered into the CoreDNS Corefile.
+func (in *CoreDNSAddonSpec) Validate() error {
+	zones := make(map[string]struct{}, len(in.StubZones))
+
+	for _, stub := range in.StubZones {
+		zone := stub.NormalizedZone()
+
+		if errs := validation.IsDNS1123Subdomain(zone); len(errs) > 0 {
+			return fmt.Errorf("the stub zone %s is not valid: %s", stub.Zone, strings.Join(errs, ", "))
+		}
+
+		if _, ok := zones[zone]; ok {
+			return fmt.Errorf("the stub zone %s is declared multiple times", stub.Zone)
+		}
+
+		zones[zone] = struct{}{}
+
+		if len(stub.Servers) == 0 {
+			return fmt.Errorf("the stub zone %s requires at least a DNS server", stub.Zone)
+		}
+
+		for _, server := range stub.Servers {
+			if err := validateDNSServer(server); err != nil {
+				return fmt.Errorf("the stub zone %s has an invalid DNS server: %w", stub.Zone, err)
+			}
+		}
+	}
+
+	for _, upstream := range in.Upstreams {
+		if err := validateDNSServer(upstream); err != nil {
+			return fmt.Errorf("invalid upstream DNS server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// NormalizedZone returns the zone in lower case, without the trailing dot.
+func (in DNSStubZone) NormalizedZone() string {
+	return strings.TrimSuffix(strings.ToLower(in.Zone), ".")
+}
+
+func validateDNSServer(server string) error {
+	host := server
+
+	if h, _, err := net.SplitHostPort(server); err == nil {
+		host = h
+	}
+
+	if net.ParseIP(host) == nil {
+		return fmt.Errorf("%s is not an IP address, or an IP:port pair", server)
+	}
+
+	return nil
+}
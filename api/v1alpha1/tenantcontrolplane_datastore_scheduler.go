@@ -0,0 +1,100 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dataStoreScheduler selects the DataStore for a Tenant Control Plane among the ones matching its selector.
+type dataStoreScheduler struct {
+	client client.Client
+}
+
+func (d *dataStoreScheduler) Schedule(ctx context.Context, tcp *TenantControlPlane) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(tcp.Spec.DataStoreSelector)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the DataStore selector: %w", err)
+	}
+
+	dsList := &DataStoreList{}
+	if err = d.client.List(ctx, dsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("unable to list the DataStore candidates: %w", err)
+	}
+
+	usage, err := dataStoreUsage(ctx, d.client, tcp)
+	if err != nil {
+		return "", err
+	}
+
+	namespace := &corev1.Namespace{}
+	if err = d.client.Get(ctx, types.NamespacedName{Name: tcp.GetNamespace()}, namespace); err != nil {
+		return "", fmt.Errorf("unable to retrieve the Tenant Control Plane namespace: %w", err)
+	}
+
+	candidates := make([]DataStore, 0, len(dsList.Items))
+
+	for _, ds := range dsList.Items {
+		if ds.GetDeletionTimestamp() != nil || ds.Spec.MaintenanceMode {
+			continue
+		}
+
+		if ds.Spec.MaxTenants != nil && usage[ds.GetName()] >= int(*ds.Spec.MaxTenants) {
+			continue
+		}
+
+		if allowed, _ := ds.IsNamespaceAllowed(namespace); !allowed {
+			continue
+		}
+
+		candidates = append(candidates, ds)
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no DataStore out of maintenance mode, with available capacity, and allowed for the namespace, is matching the selector %s", selector.String())
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		left, right := usage[candidates[i].GetName()], usage[candidates[j].GetName()]
+
+		if left == right {
+			return candidates[i].GetName() < candidates[j].GetName()
+		}
+
+		if tcp.Spec.DataStoreSchedulingPolicy == DataStoreSchedulingBinPack {
+			return left > right
+		}
+
+		return left < right
+	})
+
+	return candidates[0].GetName(), nil
+}
+
+// dataStoreUsage returns the number of Tenant Control Planes placed on each DataStore, excluding the given one.
+func dataStoreUsage(ctx context.Context, c client.Client, tcp *TenantControlPlane) (map[string]int, error) {
+	tcpList := &TenantControlPlaneList{}
+	if err := c.List(ctx, tcpList); err != nil {
+		return nil, fmt.Errorf("unable to list the Tenant Control Planes for the DataStore usage: %w", err)
+	}
+
+	usage := make(map[string]int)
+
+	for _, i := range tcpList.Items {
+		if i.GetNamespace() == tcp.GetNamespace() && i.GetName() == tcp.GetName() {
+			continue
+		}
+
+		usage[i.Spec.DataStore]++
+	}
+
+	return usage, nil
+}
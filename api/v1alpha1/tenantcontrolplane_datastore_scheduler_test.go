@@ -0,0 +1,201 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDataStoreSchedulerSchedule(t *testing.T) {
+	pool := map[string]string{"pool": "default"}
+
+	dataStore := func(name string, mutate ...func(ds *DataStore)) *DataStore {
+		ds := &DataStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: pool},
+			Spec:       DataStoreSpec{Driver: EtcdDriver, Endpoints: []string{"etcd:2379"}},
+		}
+
+		for _, fn := range mutate {
+			fn(ds)
+		}
+
+		return ds
+	}
+
+	tenant := func(namespace, name, dataStore string) *TenantControlPlane {
+		return &TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       TenantControlPlaneSpec{DataStore: dataStore},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		policy    DataStoreSchedulingPolicy
+		selector  map[string]string
+		labels    map[string]string
+		objects   []client.Object
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "spread selects the least used DataStore",
+			objects:  []client.Object{dataStore("a"), dataStore("b"), tenant("other", "one", "a")},
+			expected: "b",
+		},
+		{
+			name:     "bin-pack selects the most used DataStore",
+			policy:   DataStoreSchedulingBinPack,
+			objects:  []client.Object{dataStore("a"), dataStore("b"), tenant("other", "one", "b")},
+			expected: "b",
+		},
+		{
+			name:     "spread ties are broken by name",
+			objects:  []client.Object{dataStore("c"), dataStore("b"), tenant("other", "one", "c"), tenant("other", "two", "b")},
+			expected: "b",
+		},
+		{
+			name:     "bin-pack ties are broken by name",
+			policy:   DataStoreSchedulingBinPack,
+			objects:  []client.Object{dataStore("c"), dataStore("b")},
+			expected: "b",
+		},
+		{
+			name:     "the Tenant Control Plane itself is not counted in the usage",
+			objects:  []client.Object{dataStore("a"), dataStore("b"), tenant("default", "tcp", "a"), tenant("other", "one", "b")},
+			expected: "a",
+		},
+		{
+			name:     "DataStores not matching the selector are skipped",
+			selector: map[string]string{"pool": "dedicated"},
+			objects: []client.Object{dataStore("a"), dataStore("b", func(ds *DataStore) {
+				ds.SetLabels(map[string]string{"pool": "dedicated"})
+			})},
+			expected: "b",
+		},
+		{
+			name: "DataStores in maintenance mode are skipped",
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.MaintenanceMode = true
+			}), dataStore("b"), tenant("other", "one", "b")},
+			expected: "b",
+		},
+		{
+			name:   "DataStores at capacity are skipped",
+			policy: DataStoreSchedulingBinPack,
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.MaxTenants = pointer.Int32(1)
+			}), dataStore("b"), tenant("other", "one", "a")},
+			expected: "b",
+		},
+		{
+			name: "all the DataStores at capacity",
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.MaxTenants = pointer.Int32(1)
+			}), dataStore("b", func(ds *DataStore) {
+				ds.Spec.MaxTenants = pointer.Int32(0)
+			}), tenant("other", "one", "a")},
+			expectErr: true,
+		},
+		{
+			name: "all the DataStores in maintenance mode",
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.MaintenanceMode = true
+			}), dataStore("b", func(ds *DataStore) {
+				ds.Spec.MaintenanceMode = true
+			})},
+			expectErr: true,
+		},
+		{
+			name:      "no DataStore matching the selector",
+			selector:  map[string]string{"pool": "missing"},
+			objects:   []client.Object{dataStore("a")},
+			expectErr: true,
+		},
+		{
+			name: "DataStores dedicated to other namespaces are skipped",
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.AllowedNamespaces = &DataStoreAllowedNamespaces{Names: []string{"other"}}
+			}), dataStore("b"), tenant("other", "one", "b")},
+			expected: "b",
+		},
+		{
+			name: "DataStores allowed by the namespace name",
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.AllowedNamespaces = &DataStoreAllowedNamespaces{Names: []string{"default"}}
+			}), dataStore("b"), tenant("other", "one", "b")},
+			expected: "a",
+		},
+		{
+			name:   "DataStores allowed by the namespace selector",
+			labels: map[string]string{"tier": "gold"},
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.AllowedNamespaces = &DataStoreAllowedNamespaces{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}}
+			}), dataStore("b"), tenant("other", "one", "b")},
+			expected: "a",
+		},
+		{
+			name: "all the DataStores dedicated to other namespaces",
+			objects: []client.Object{dataStore("a", func(ds *DataStore) {
+				ds.Spec.AllowedNamespaces = &DataStoreAllowedNamespaces{Names: []string{"other"}}
+			}), dataStore("b", func(ds *DataStore) {
+				ds.Spec.AllowedNamespaces = &DataStoreAllowedNamespaces{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}}
+			})},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := corev1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			selector := tt.selector
+			if selector == nil {
+				selector = pool
+			}
+
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: tt.labels}}
+
+			scheduler := &dataStoreScheduler{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.objects, namespace)...).Build(),
+			}
+
+			tcp := tenant("default", "tcp", "")
+			tcp.Spec.DataStoreSelector = &metav1.LabelSelector{MatchLabels: selector}
+			tcp.Spec.DataStoreSchedulingPolicy = tt.policy
+
+			name, err := scheduler.Schedule(context.Background(), tcp)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got the DataStore %q", name)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if name != tt.expected {
+				t.Errorf("expected the DataStore %q, got %q", tt.expected, name)
+			}
+		})
+	}
+}
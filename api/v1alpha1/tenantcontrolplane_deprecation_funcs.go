@@ -0,0 +1,50 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// IgnoreDeprecatedAPIsAnnotation allows the upgrade to a Kubernetes release removing deprecated APIs still in use.
+const IgnoreDeprecatedAPIsAnnotation = "kamaji.clastix.io/ignore-deprecated-apis"
+
+// String returns the API in the group/version/resource form, along with the subresource.
+func (in DeprecatedAPIUsage) String() string {
+	parts := []string{in.Version, in.Resource}
+	if len(in.Group) > 0 {
+		parts = append([]string{in.Group}, parts...)
+	}
+
+	if len(in.Subresource) > 0 {
+		parts = append(parts, in.Subresource)
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// DeprecatedAPIsRemovedBy returns the deprecated APIs in use, removed by the given Kubernetes release,
+// or by a previous one: the ones with no known removal release are not taken into account.
+func (in *TenantControlPlane) DeprecatedAPIsRemovedBy(version semver.Version) []DeprecatedAPIUsage {
+	var removed []DeprecatedAPIUsage
+
+	for _, usage := range in.Status.Kubernetes.DeprecatedAPIs {
+		if len(usage.RemovedRelease) == 0 {
+			continue
+		}
+
+		release, err := semver.ParseTolerant(usage.RemovedRelease)
+		if err != nil {
+			continue
+		}
+
+		if version.Major > release.Major || (version.Major == release.Major && version.Minor >= release.Minor) {
+			removed = append(removed, usage)
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,23 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+const (
+	// ExportedAnnotation marks a Tenant Control Plane moved to another management cluster: the reconciliation is stopped,
+	// and its deletion releases the DataStore with no cleanup, since the data is still in use by the imported one.
+	ExportedAnnotation = "kamaji.clastix.io/exported"
+	// ImportingAnnotation pauses the reconciliation of a Tenant Control Plane being imported from another management cluster,
+	// until its status, and its Secrets, have been restored.
+	ImportingAnnotation = "kamaji.clastix.io/importing"
+)
+
+// IsExported returns true when the Tenant Control Plane has been moved to another management cluster.
+func (in *TenantControlPlane) IsExported() bool {
+	return in.GetAnnotations()[ExportedAnnotation] == "true"
+}
+
+// IsImporting returns true when the Tenant Control Plane is being imported from another management cluster.
+func (in *TenantControlPlane) IsImporting() bool {
+	return in.GetAnnotations()[ImportingAnnotation] == "true"
+}
@@ -4,10 +4,14 @@
 package v1alpha1
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
+	"net/url"
 	"strconv"
+	"strings"
+	"text/template"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -17,6 +21,27 @@ import (
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
 )
 
+// MigrationDryRunAnnotation requests the validation of the migration to the given DataStore, with no data being copied:
+// the result is reported by the DataStoreMigrationValidated condition, and the annotation is removed once performed.
+const MigrationDryRunAnnotation = "kamaji.clastix.io/migration-dry-run"
+
+// ApproveRolloutAnnotation approves the rollout of the Secrets changes held by the Manual checksum strategy:
+// it's removed once the Control Plane Deployment has been updated.
+const ApproveRolloutAnnotation = "kamaji.clastix.io/approve-rollout"
+
+// APIServerLogLevelAnnotation requests to change the verbosity of the running API Server instances, with no restart:
+// the level is reset to the declared one upon the next rollout, and the annotation is removed once applied.
+const APIServerLogLevelAnnotation = "kamaji.clastix.io/apiserver-log-level"
+
+// PausedAnnotation pauses the reconciliation of a Tenant Control Plane, such as during a DataStore maintenance:
+// the running components are left untouched, and its deletion is still processed.
+const PausedAnnotation = "kamaji.clastix.io/paused"
+
+// IsPaused returns true when the reconciliation of the Tenant Control Plane has been paused.
+func (in *TenantControlPlane) IsPaused() bool {
+	return in.GetAnnotations()[PausedAnnotation] == "true"
+}
+
 // AssignedControlPlaneAddress returns the announced address and port of a Tenant Control Plane.
 // In case of non-well formed values, or missing announcement, an error is returned.
 func (in *TenantControlPlane) AssignedControlPlaneAddress() (string, int32, error) {
@@ -70,3 +95,268 @@ func (in *TenantControlPlane) DeclaredControlPlaneAddress(ctx context.Context, c
 
 	return "", kamajierrors.MissingValidIPError{}
 }
+
+// APIServerCertSANs returns the extra Subject Alternative Names of the API Server certificate, including the
+// Konnectivity proxy server host dialled by the agents, since the Konnectivity server presents the same certificate,
+// along with the load balancer addresses of its dedicated Service, if any.
+func (in *TenantControlPlane) APIServerCertSANs() []string {
+	sans := append([]string{}, in.Spec.NetworkProfile.CertSANs...)
+
+	konnectivity := in.Spec.Addons.Konnectivity
+	if konnectivity == nil {
+		return sans
+	}
+
+	var hosts []string
+
+	if len(konnectivity.KonnectivityAgentSpec.ProxyServerHost) > 0 {
+		hosts = append(hosts, konnectivity.KonnectivityAgentSpec.ProxyServerHost)
+	}
+
+	if in.IsKonnectivityDedicatedService() {
+		for _, ingress := range in.Status.Addons.Konnectivity.Service.LoadBalancer.Ingress {
+			if len(ingress.IP) > 0 {
+				hosts = append(hosts, ingress.IP)
+			}
+
+			if len(ingress.Hostname) > 0 {
+				hosts = append(hosts, ingress.Hostname)
+			}
+		}
+	}
+
+	for _, host := range hosts {
+		var found bool
+
+		for _, san := range sans {
+			if san == host {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			sans = append(sans, host)
+		}
+	}
+
+	return sans
+}
+
+// IsStandbyDataStorePromotion returns true when the desired DataStore is the standby one, already holding a snapshot
+// of the Tenant Control Plane data: the promotion doesn't require a migration, since the current DataStore could be lost.
+func (in *TenantControlPlane) IsStandbyDataStorePromotion() bool {
+	standby := in.Status.Storage.Standby
+
+	return standby != nil &&
+		standby.DataStoreName == in.Spec.DataStore &&
+		standby.DataStoreName != in.Status.Storage.DataStoreName &&
+		!standby.LastSyncTime.IsZero()
+}
+
+// MigrationDryRunTarget returns the DataStore requested with the MigrationDryRunAnnotation, if any.
+func (in *TenantControlPlane) MigrationDryRunTarget() string {
+	return in.GetAnnotations()[MigrationDryRunAnnotation]
+}
+
+// LegacyKubeconfigFormatsDisabled returns if the kubeconfig Secrets must be rewritten to the canonical format.
+func (in *TenantControlPlane) LegacyKubeconfigFormatsDisabled() bool {
+	return in.Spec.Kubeconfig != nil && in.Spec.Kubeconfig.DisableLegacyFormats
+}
+
+// UsersKubeconfigOIDC returns the OIDC issuer URL and client ID used by the users kubeconfig,
+// falling back to the ones configured in the API Server arguments.
+func (in *TenantControlPlane) UsersKubeconfigOIDC() (issuerURL string, clientID string) {
+	if in.Spec.ControlPlane.Deployment.ExtraArgs != nil {
+		for _, arg := range in.Spec.ControlPlane.Deployment.ExtraArgs.APIServer {
+			switch key, value, _ := strings.Cut(arg, "="); key {
+			case "--oidc-issuer-url":
+				issuerURL = value
+			case "--oidc-client-id":
+				clientID = value
+			}
+		}
+	}
+
+	if in.Spec.Kubeconfig != nil && in.Spec.Kubeconfig.Users != nil {
+		users := in.Spec.Kubeconfig.Users
+
+		if len(users.IssuerURL) > 0 {
+			issuerURL = users.IssuerURL
+		}
+
+		if len(users.ClientID) > 0 {
+			clientID = users.ClientID
+		}
+	}
+
+	return issuerURL, clientID
+}
+
+// ValidateReconciliationMode ensures the addon installed once is not server-side applied, since it's not reconciled anymore.
+func (in *AddonSpec) ValidateReconciliationMode() error {
+	if in.ReconciliationMode == AddonReconciliationModeInstallOnce && in.ServerSideApply {
+		return fmt.Errorf("the addon installed once cannot be server-side applied")
+	}
+
+	return nil
+}
+
+// Validate ensures the manifests addon references either a ConfigMap, or a Secret.
+func (in *ManifestsAddonSpec) Validate() error {
+	if (in.ConfigMapRef == nil) == (in.SecretRef == nil) {
+		return fmt.Errorf("the manifests addon %s must reference either a ConfigMap, or a Secret", in.Name)
+	}
+
+	return nil
+}
+
+// Validate ensures the cert-manager webhook can be reached through the Konnectivity tunnel, and its manifests URL is a valid template.
+func (in *CertManagerAddonSpec) Validate(konnectivity *KonnectivitySpec) error {
+	if konnectivity == nil {
+		return fmt.Errorf("the cert-manager addon requires the Konnectivity addon, used by the API Server to reach the cert-manager webhook")
+	}
+
+	if _, err := in.ReleaseManifestsURL(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// certManagerManifestsURL is the default location of the cert-manager release manifests.
+const certManagerManifestsURL = "https://github.com/cert-manager/cert-manager/releases/download/{{ .Version }}/cert-manager.yaml"
+
+// ReleaseManifestsURL returns the location of the release manifests of the declared cert-manager version.
+func (in *CertManagerAddonSpec) ReleaseManifestsURL() (string, error) {
+	manifestsURL := in.ManifestsURL
+	if len(manifestsURL) == 0 {
+		manifestsURL = certManagerManifestsURL
+	}
+
+	return renderManifestsURL("cert-manager", manifestsURL, in.Version)
+}
+
+// Validate ensures the CNI manifests URL is a valid template, and the encapsulation is supported by the provider.
+func (in *CNIAddonSpec) Validate() error {
+	switch {
+	case in.Provider == CNIProviderCilium && len(in.ManifestsURL) == 0:
+		return fmt.Errorf("the Cilium CNI addon requires the URL of the rendered manifests, since no release manifests are available")
+	case in.Provider == CNIProviderCilium && in.Encapsulation == CNIEncapsulationIPIP:
+		return fmt.Errorf("the IPIP encapsulation is not supported by Cilium")
+	case in.Provider == CNIProviderCalico && in.Encapsulation == CNIEncapsulationGeneve:
+		return fmt.Errorf("the Geneve encapsulation is not supported by Calico")
+	}
+
+	if len(in.PodCIDR) > 0 {
+		if _, _, err := net.ParseCIDR(in.PodCIDR); err != nil {
+			return fmt.Errorf("the CNI Pod CIDR %s is not valid: %w", in.PodCIDR, err)
+		}
+	}
+
+	if _, err := in.ReleaseManifestsURL(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate ensures the storage addon installs a template, or a StorageClass, with at most one default StorageClass.
+func (in *StorageAddonSpec) Validate() error {
+	if len(in.Template) == 0 && len(in.StorageClasses) == 0 {
+		return fmt.Errorf("the storage addon requires a template, or a StorageClass")
+	}
+
+	var defaults []string
+
+	for _, storageClass := range in.StorageClasses {
+		if storageClass.Default {
+			defaults = append(defaults, storageClass.Name)
+		}
+	}
+
+	if len(defaults) > 1 {
+		return fmt.Errorf("the storage addon allows at most one default StorageClass, found %s", strings.Join(defaults, ", "))
+	}
+
+	return nil
+}
+
+const (
+	// calicoManifestsURL is the default location of the Calico release manifests.
+	calicoManifestsURL = "https://raw.githubusercontent.com/projectcalico/calico/{{ .Version }}/manifests/calico.yaml"
+	// calicoVersion is the default Calico version.
+	calicoVersion = "v3.25.0"
+)
+
+// ReleaseVersion returns the declared version of the CNI plugin, or the default one of the provider.
+func (in *CNIAddonSpec) ReleaseVersion() string {
+	if len(in.Version) == 0 && in.Provider == CNIProviderCalico {
+		return calicoVersion
+	}
+
+	return in.Version
+}
+
+// ReleaseManifestsURL returns the location of the release manifests of the declared CNI plugin version.
+func (in *CNIAddonSpec) ReleaseManifestsURL() (string, error) {
+	manifestsURL := in.ManifestsURL
+	if len(manifestsURL) == 0 {
+		manifestsURL = calicoManifestsURL
+	}
+
+	return renderManifestsURL("CNI", manifestsURL, in.ReleaseVersion())
+}
+
+// renderManifestsURL replaces the {{ .Version }} placeholder of the given manifests URL template.
+func renderManifestsURL(addon, manifestsURL, version string) (string, error) {
+	tmpl, err := template.New("url").Parse(manifestsURL)
+	if err != nil {
+		return "", fmt.Errorf("the %s manifests URL is not a valid template: %w", addon, err)
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, map[string]string{"Version": version}); err != nil {
+		return "", fmt.Errorf("the %s manifests URL cannot be rendered: %w", addon, err)
+	}
+
+	if _, err = url.ParseRequestURI(buf.String()); err != nil {
+		return "", fmt.Errorf("the %s manifests URL %s is not valid: %w", addon, buf.String(), err)
+	}
+
+	return buf.String(), nil
+}
+
+// Validate ensures exactly one secret store is specified by the kubeconfig external target, along with a valid address.
+func (in *KubeconfigExternalTarget) Validate() error {
+	var addresses []string
+
+	if in.Vault != nil {
+		addresses = append(addresses, in.Vault.Address)
+	}
+
+	if in.AWSSecretsManager != nil {
+		if len(in.AWSSecretsManager.CredentialsSecretRef.Name) == 0 {
+			return fmt.Errorf("the kubeconfig external target %s must reference the AWS credentials Secret", in.Name)
+		}
+
+		addresses = append(addresses, "")
+	}
+
+	if in.AzureKeyVault != nil {
+		addresses = append(addresses, in.AzureKeyVault.VaultURL)
+	}
+
+	if len(addresses) != 1 {
+		return fmt.Errorf("the kubeconfig external target %s must specify exactly one secret store", in.Name)
+	}
+
+	if address := addresses[0]; len(address) > 0 {
+		if parsed, err := url.ParseRequestURI(address); err != nil || len(parsed.Host) == 0 || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+			return fmt.Errorf("the kubeconfig external target %s has an invalid store address %s", in.Name, address)
+		}
+	}
+
+	return nil
+}
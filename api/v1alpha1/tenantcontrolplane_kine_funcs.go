@@ -0,0 +1,65 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+)
+
+// DesiredKineMode returns the kine deployment mode for the given Tenant Control Plane,
+// falling back to the sidecar one if not specified.
+func (in *TenantControlPlane) DesiredKineMode() KineMode {
+	if in.Spec.ControlPlane.Kine == nil || len(in.Spec.ControlPlane.Kine.Mode) == 0 {
+		return KineModeSidecar
+	}
+
+	return in.Spec.ControlPlane.Kine.Mode
+}
+
+// KineMutualTLSEnabled returns true when the kube-apiserver must connect to kine using mutual TLS,
+// either because it has been requested, or it's running as a separate Deployment.
+func (in *TenantControlPlane) KineMutualTLSEnabled() bool {
+	if in.DesiredKineMode() == KineModeDeployment {
+		return true
+	}
+
+	return in.Spec.ControlPlane.Kine != nil && in.Spec.ControlPlane.Kine.MutualTLS
+}
+
+// KineMetricsPort returns the port of the kine metrics endpoint, and if it is enabled.
+func (in *TenantControlPlane) KineMetricsPort() (int32, bool) {
+	if in.Spec.ControlPlane.Kine == nil || in.Spec.ControlPlane.Kine.Metrics == nil {
+		return 0, false
+	}
+
+	if port := in.Spec.ControlPlane.Kine.Metrics.Port; port > 0 {
+		return port, true
+	}
+
+	return 8080, true
+}
+
+// KineCleanupInterval returns the interval between two cleanups of the kine table, and if the cleanup is enabled.
+func (in *TenantControlPlane) KineCleanupInterval() (time.Duration, bool) {
+	if in.Spec.ControlPlane.Kine == nil || in.Spec.ControlPlane.Kine.Compaction == nil || in.Spec.ControlPlane.Kine.Compaction.CleanupInterval == nil {
+		return 0, false
+	}
+
+	return in.Spec.ControlPlane.Kine.Compaction.CleanupInterval.Duration, true
+}
+
+// Validate ensures the compaction interval is positive, and the cleanups are not running more than once a minute,
+// since they're rewriting the whole table.
+func (in *KineCompactionSpec) Validate() error {
+	if in.Interval != nil && in.Interval.Duration <= 0 {
+		return fmt.Errorf("the kine compaction interval must be positive")
+	}
+
+	if in.CleanupInterval != nil && in.CleanupInterval.Duration < time.Minute {
+		return fmt.Errorf("the kine cleanup interval must be at least one minute")
+	}
+
+	return nil
+}
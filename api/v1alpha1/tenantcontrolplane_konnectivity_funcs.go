@@ -0,0 +1,348 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// KonnectivityRemovalConfirmationAnnotation confirms the removal of the Konnectivity agent resources from the Tenant Cluster.
+const KonnectivityRemovalConfirmationAnnotation = "kamaji.clastix.io/confirm-konnectivity-removal"
+
+const (
+	// KonnectivityDefaultAdminPort is the port of the Konnectivity admin endpoint, when not specified.
+	KonnectivityDefaultAdminPort int32 = 8133
+	// KonnectivityDefaultHealthPort is the port of the Konnectivity health endpoint, when not specified.
+	KonnectivityDefaultHealthPort int32 = 8134
+	// konnectivityProxyServerPort is the port the Konnectivity server is listening to in HTTP-Connect mode.
+	konnectivityProxyServerPort int32 = 8131
+)
+
+// KonnectivityRecreationAnnotation requests to tear down, and rebuild, all the Konnectivity resources:
+// it's removed once the resources have been deleted.
+const KonnectivityRecreationAnnotation = "kamaji.clastix.io/recreate-konnectivity"
+
+// IsKonnectivityRecreationRequested returns true when the Konnectivity addon is enabled,
+// and the rebuild of its resources has been requested with the KonnectivityRecreationAnnotation.
+func (in *TenantControlPlane) IsKonnectivityRecreationRequested() bool {
+	return in.Spec.Addons.Konnectivity != nil && in.GetAnnotations()[KonnectivityRecreationAnnotation] == "true"
+}
+
+// IsKonnectivityHTTPConnect returns true when the API Server reaches the Konnectivity server using the http-connect mode.
+func (in *TenantControlPlane) IsKonnectivityHTTPConnect() bool {
+	return in.Spec.Addons.Konnectivity != nil && in.Spec.Addons.Konnectivity.isHTTPConnect()
+}
+
+// IsKonnectivityStandalone returns true when the Konnectivity server runs in its own Deployment.
+func (in *TenantControlPlane) IsKonnectivityStandalone() bool {
+	return in.Spec.Addons.Konnectivity != nil && in.Spec.Addons.Konnectivity.KonnectivityServerSpec.Deployment != nil
+}
+
+// isHTTPConnect returns true when the http-connect mode is declared, or enforced by the standalone server.
+func (in *KonnectivitySpec) isHTTPConnect() bool {
+	return in.Mode == KonnectivityModeHTTPConnect || in.KonnectivityServerSpec.Deployment != nil
+}
+
+// IsKonnectivityAggregatorRouting returns true when the aggregated APIs must be reached through the Konnectivity tunnel,
+// dialling the endpoints of the extension API servers.
+func (in *TenantControlPlane) IsKonnectivityAggregatorRouting() bool {
+	konnectivity := in.Spec.Addons.Konnectivity
+
+	return konnectivity != nil && (konnectivity.AggregatorRouting == nil || *konnectivity.AggregatorRouting)
+}
+
+// IsKonnectivityDedicatedService returns true when the Konnectivity server is exposed with its own Service.
+func (in *TenantControlPlane) IsKonnectivityDedicatedService() bool {
+	return in.Spec.Addons.Konnectivity != nil && in.Spec.Addons.Konnectivity.KonnectivityServerSpec.Service != nil
+}
+
+// IsKonnectivityLeaseCounting returns true when the Konnectivity agents count the servers using their Leases.
+func (in *TenantControlPlane) IsKonnectivityLeaseCounting() bool {
+	return in.Spec.Addons.Konnectivity != nil && in.Spec.Addons.Konnectivity.LeaseCounting != nil
+}
+
+// KonnectivityServerCount returns the number of Konnectivity servers announced to the agents:
+// the explicit one, if any, otherwise the desired replicas, since a server runs in each Pod,
+// or the standalone Deployment ones.
+func (in *TenantControlPlane) KonnectivityServerCount() int32 {
+	if konnectivity := in.Spec.Addons.Konnectivity; konnectivity != nil && konnectivity.KonnectivityServerSpec.ServerCount != nil {
+		return *konnectivity.KonnectivityServerSpec.ServerCount
+	}
+
+	if in.IsKonnectivityStandalone() {
+		return in.Spec.Addons.Konnectivity.KonnectivityServerSpec.Deployment.Replicas
+	}
+
+	return in.Spec.ControlPlane.Deployment.Replicas
+}
+
+// KonnectivityFallbackActive returns if the API Server must reach the worker nodes with the direct egress,
+// since the Konnectivity agents have been unavailable for longer than the fallback threshold:
+// when not active yet, the time left for the threshold is returned.
+func (in *TenantControlPlane) KonnectivityFallbackActive() (active bool, retryAfter time.Duration) {
+	konnectivity := in.Spec.Addons.Konnectivity
+	if konnectivity == nil || konnectivity.Fallback == nil {
+		return false, 0
+	}
+
+	since := in.Status.Addons.Konnectivity.AgentsUnavailableSince
+	if since == nil {
+		return false, 0
+	}
+
+	if left := time.Until(since.Add(konnectivity.Fallback.UnavailabilityThreshold.Duration)); left > 0 {
+		return false, left
+	}
+
+	return true, 0
+}
+
+// IsKonnectivityRemovalPending returns true when the Konnectivity addon has been disabled,
+// although its resources are still deployed in the Tenant Cluster.
+func (in *TenantControlPlane) IsKonnectivityRemovalPending() bool {
+	status := in.Status.Addons.Konnectivity
+
+	return in.Spec.Addons.Konnectivity == nil &&
+		(len(status.Agent.Namespace) > 0 || len(status.ServiceAccount.Name) > 0 || len(status.ClusterRoleBinding.Name) > 0 || len(status.Leases.Name) > 0)
+}
+
+// KonnectivityRemovalAllowed returns if the Konnectivity agent resources can be removed from the Tenant Cluster
+// according to the removal policy: when not allowed, the reason and the time left for the grace period are returned.
+func (in *TenantControlPlane) KonnectivityRemovalAllowed() (allowed bool, reason string, retryAfter time.Duration) {
+	policy := in.Spec.Addons.KonnectivityRemoval
+	if policy == nil {
+		return true, "", 0
+	}
+
+	if policy.RequireConfirmation && in.GetAnnotations()[KonnectivityRemovalConfirmationAnnotation] != "true" {
+		return false, fmt.Sprintf("waiting for the %s=true annotation", KonnectivityRemovalConfirmationAnnotation), 0
+	}
+
+	if policy.GracePeriod != nil {
+		requestedAt := in.Status.Addons.Konnectivity.RemovalRequestedAt
+		if requestedAt == nil {
+			return false, "waiting for the grace period to start", policy.GracePeriod.Duration
+		}
+
+		expiration := requestedAt.Add(policy.GracePeriod.Duration)
+		if left := time.Until(expiration); left > 0 {
+			return false, fmt.Sprintf("waiting for the grace period, expiring at %s", expiration.UTC().Format(time.RFC3339)), left
+		}
+	}
+
+	return true, "", 0
+}
+
+// konnectivityTLSMinimumVersion is the first Konnectivity release supporting the TLS hardening flags.
+var konnectivityTLSMinimumVersion = semver.MustParse("0.0.32")
+
+// ValidateTLS ensures the TLS hardening flags are supported by the deployed Konnectivity server and agent versions,
+// and the cipher suites are known, and secure.
+func (in *KonnectivitySpec) ValidateTLS() error {
+	if in.TLS == nil {
+		return nil
+	}
+
+	for component, version := range map[string]string{"server": in.KonnectivityServerSpec.Version, "agent": in.KonnectivityAgentSpec.Version} {
+		ver, err := semver.ParseTolerant(version)
+		if err != nil {
+			return fmt.Errorf("unable to parse the Konnectivity %s version %s: %w", component, version, err)
+		}
+
+		if ver.LT(konnectivityTLSMinimumVersion) {
+			return fmt.Errorf("the Konnectivity TLS flags require the %s version v%s or greater, actually %s", component, konnectivityTLSMinimumVersion.String(), version)
+		}
+	}
+
+	if len(in.TLS.CipherSuites) > 0 && in.TLS.MinVersion == "VersionTLS13" {
+		return fmt.Errorf("the Konnectivity cipher suites cannot be configured along with the TLS 1.3 minimum version")
+	}
+
+	secure := make(map[string]struct{}, len(tls.CipherSuites()))
+	for _, suite := range tls.CipherSuites() {
+		secure[suite.Name] = struct{}{}
+	}
+
+	for _, name := range in.TLS.CipherSuites {
+		if _, ok := secure[name]; !ok {
+			return fmt.Errorf("the Konnectivity cipher suite %s is either unknown, or insecure", name)
+		}
+	}
+
+	return nil
+}
+
+// konnectivityLeaseCountingMinimumVersion is the first Konnectivity release supporting the lease-based server counting.
+var konnectivityLeaseCountingMinimumVersion = semver.MustParse("0.30.0")
+
+// ValidateLeaseCounting ensures the lease-based server counting is supported by the deployed Konnectivity server and agent versions,
+// it's not mixed with the static server count, and the Leases are renewed before expiring.
+func (in *KonnectivitySpec) ValidateLeaseCounting() error {
+	if in.LeaseCounting == nil {
+		return nil
+	}
+
+	for component, version := range map[string]string{"server": in.KonnectivityServerSpec.Version, "agent": in.KonnectivityAgentSpec.Version} {
+		ver, err := semver.ParseTolerant(version)
+		if err != nil {
+			return fmt.Errorf("unable to parse the Konnectivity %s version %s: %w", component, version, err)
+		}
+
+		if ver.LT(konnectivityLeaseCountingMinimumVersion) {
+			return fmt.Errorf("the Konnectivity lease counting requires the %s version v%s or greater, actually %s", component, konnectivityLeaseCountingMinimumVersion.String(), version)
+		}
+	}
+
+	if in.KonnectivityServerSpec.ServerCount != nil {
+		return fmt.Errorf("the Konnectivity server count cannot be declared along with the lease counting")
+	}
+
+	if in.LeaseCounting.RenewalInterval.Duration <= 0 || in.LeaseCounting.RenewalInterval.Duration >= in.LeaseCounting.LeaseDuration.Duration {
+		return fmt.Errorf("the Konnectivity lease renewal interval must be positive, and lower than the lease duration")
+	}
+
+	return nil
+}
+
+// ValidateLimits ensures the Konnectivity server memory limit and the keepalive interval of the API Server connections are positive.
+func (in *KonnectivitySpec) ValidateLimits() error {
+	limits := in.KonnectivityServerSpec.Limits
+	if limits == nil {
+		return nil
+	}
+
+	if limits.Memory != nil && limits.Memory.Sign() <= 0 {
+		return fmt.Errorf("the Konnectivity server memory limit must be positive")
+	}
+
+	if limits.FrontendKeepaliveTime != nil && limits.FrontendKeepaliveTime.Duration < time.Second {
+		return fmt.Errorf("the Konnectivity server frontend keepalive time must be at least 1s")
+	}
+
+	return nil
+}
+
+// ValidateProxyServer ensures the host dialled by the agents is either a hostname, or an IP address.
+func (in *KonnectivitySpec) ValidateProxyServer() error {
+	host := in.KonnectivityAgentSpec.ProxyServerHost
+	if len(host) == 0 || net.ParseIP(host) != nil {
+		return nil
+	}
+
+	if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+		return fmt.Errorf("the Konnectivity proxy server host %s is neither an IP address, nor a valid hostname: %s", host, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// ValidatePorts ensures the ports of the Konnectivity server, and the ones of the agent, are not colliding.
+func (in *KonnectivitySpec) ValidatePorts() error {
+	serverAdmin, serverHealth := in.KonnectivityServerSpec.Ports()
+
+	serverPorts := map[int32]string{in.KonnectivityServerSpec.Port: "agent"}
+	if in.isHTTPConnect() {
+		serverPorts[konnectivityProxyServerPort] = "proxy server"
+	}
+
+	for name, port := range map[string]int32{"admin": serverAdmin, "health": serverHealth} {
+		if used, ok := serverPorts[port]; ok {
+			return fmt.Errorf("the Konnectivity server %s port %d collides with the %s one", name, port, used)
+		}
+
+		serverPorts[port] = name
+	}
+
+	if agentAdmin, agentHealth := in.KonnectivityAgentSpec.Ports(); agentAdmin == agentHealth {
+		return fmt.Errorf("the Konnectivity agent admin and health ports must be different")
+	}
+
+	return nil
+}
+
+// ValidateStandalone ensures the standalone Konnectivity server can be reached by the agents, which cannot dial it
+// through the API Server Service, and it's not waited for by the readiness gate of the Tenant Control Plane Pods.
+func (in *KonnectivitySpec) ValidateStandalone() error {
+	if in.KonnectivityServerSpec.Deployment == nil {
+		return nil
+	}
+
+	if in.KonnectivityServerSpec.Service == nil {
+		return fmt.Errorf("the Konnectivity server Deployment requires the dedicated Service, exposing it to the agents")
+	}
+
+	if in.ReadinessGate {
+		return fmt.Errorf("the Konnectivity readiness gate cannot be used along with the Konnectivity server Deployment")
+	}
+
+	return nil
+}
+
+// Ports returns the admin and the health ports of the Konnectivity server, falling back to the default ones.
+func (in *KonnectivityServerSpec) Ports() (admin int32, health int32) {
+	return konnectivityPorts(in.AdminPort, in.HealthPort)
+}
+
+// Ports returns the admin and the health ports of the Konnectivity agent, falling back to the default ones.
+func (in *KonnectivityAgentSpec) Ports() (admin int32, health int32) {
+	return konnectivityPorts(in.AdminPort, in.HealthPort)
+}
+
+func konnectivityPorts(admin, health int32) (int32, int32) {
+	if admin == 0 {
+		admin = KonnectivityDefaultAdminPort
+	}
+
+	if health == 0 {
+		health = KonnectivityDefaultHealthPort
+	}
+
+	return admin, health
+}
+
+// ProxyServer returns the host and port dialled by the Konnectivity agents, defaulting to the given
+// Tenant Control Plane address and the Konnectivity server port.
+func (in *KonnectivitySpec) ProxyServer(address string) (string, int32) {
+	host, port := address, in.KonnectivityServerSpec.Port
+
+	if service := in.KonnectivityServerSpec.Service; service != nil && service.Port > 0 {
+		port = service.Port
+	}
+
+	if len(in.KonnectivityAgentSpec.ProxyServerHost) > 0 {
+		host = in.KonnectivityAgentSpec.ProxyServerHost
+	}
+
+	if in.KonnectivityAgentSpec.ProxyServerPort > 0 {
+		port = in.KonnectivityAgentSpec.ProxyServerPort
+	}
+
+	return host, port
+}
+
+// TLSArgs returns the TLS hardening flags shared by the Konnectivity server and agent.
+func (in *KonnectivitySpec) TLSArgs() map[string]string {
+	args := map[string]string{}
+
+	if in.TLS == nil {
+		return args
+	}
+
+	if len(in.TLS.CipherSuites) > 0 {
+		args["--cipher-suites"] = strings.Join(in.TLS.CipherSuites, ",")
+	}
+
+	if len(in.TLS.MinVersion) > 0 {
+		args["--tls-min-version"] = in.TLS.MinVersion
+	}
+
+	return args
+}
@@ -4,6 +4,8 @@
 package v1alpha1
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,3 +17,36 @@ func (in *KubeadmPhaseStatus) SetChecksum(checksum string) {
 	in.LastUpdate = metav1.Now()
 	in.Checksum = checksum
 }
+
+// KubeadmPhasesEnabled returns true when the kubeadm phases must be performed in the Tenant Cluster,
+// the default behaviour when not specified.
+func (in *TenantControlPlane) KubeadmPhasesEnabled() bool {
+	if in.Spec.Kubeadm == nil || in.Spec.Kubeadm.Enabled == nil {
+		return true
+	}
+
+	return *in.Spec.Kubeadm.Enabled
+}
+
+// BootstrapTokenRotation returns the bootstrap token rotation settings, if the rotation is enabled
+// along with the kubeadm phases.
+func (in *TenantControlPlane) BootstrapTokenRotation() *BootstrapTokenSpec {
+	if !in.KubeadmPhasesEnabled() {
+		return nil
+	}
+
+	return in.Spec.Kubeadm.BootstrapToken
+}
+
+// Validate ensures a bootstrap token is renewed before its expiration.
+func (in *BootstrapTokenSpec) Validate() error {
+	if in.TTL.Duration <= 0 {
+		return fmt.Errorf("the bootstrap token TTL must be positive")
+	}
+
+	if in.RenewBefore.Duration <= 0 || in.RenewBefore.Duration >= in.TTL.Duration {
+		return fmt.Errorf("the bootstrap token renewal must be positive, and less than its TTL")
+	}
+
+	return nil
+}
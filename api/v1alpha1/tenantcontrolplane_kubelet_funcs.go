@@ -0,0 +1,47 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsKubeletServingCAManaged returns true when Kamaji manages the Certificate Authority signing the kubelet serving certificates.
+func (in *TenantControlPlane) IsKubeletServingCAManaged() bool {
+	kubeletTLS := in.Spec.Kubernetes.Kubelet.TLS
+
+	return kubeletTLS != nil && kubeletTLS.ServingCertificateAuthority != nil
+}
+
+// IsKubeletServingCSRApproved returns true when the kubelet serving CertificateSigningRequests are approved by Kamaji.
+func (in *TenantControlPlane) IsKubeletServingCSRApproved() bool {
+	return in.IsKubeletServingCAManaged() && !in.Spec.Kubernetes.Kubelet.TLS.ServingCertificateAuthority.ManualApproval
+}
+
+// ValidateKubeletTLS ensures the kubelet serving Certificate Authority is either supplied, or managed by Kamaji,
+// and it's rotated ahead of its expiration.
+func (in *TenantControlPlane) ValidateKubeletTLS() error {
+	if !in.IsKubeletServingCAManaged() {
+		return nil
+	}
+
+	kubeletTLS := in.Spec.Kubernetes.Kubelet.TLS
+
+	if kubeletTLS.CertificateAuthority != nil {
+		return fmt.Errorf("the kubelet serving Certificate Authority cannot be both supplied, and managed by Kamaji")
+	}
+
+	ca := kubeletTLS.ServingCertificateAuthority
+
+	if ca.Validity.Duration < time.Hour {
+		return fmt.Errorf("the kubelet serving Certificate Authority validity must be one hour, at least")
+	}
+
+	if ca.RenewBefore.Duration <= 0 || ca.RenewBefore.Duration >= ca.Validity.Duration {
+		return fmt.Errorf("the kubelet serving Certificate Authority renewal must happen before its expiration, and after its issuing")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/blang/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// kubeProxyNFTablesMinimumVersion is the first kube-proxy release supporting the nftables mode.
+var kubeProxyNFTablesMinimumVersion = semver.MustParse("1.29.0")
+
+// Validate ensures the kube-proxy options are accepted by the kube-proxy, which would otherwise refuse to start:
+// the version is the one of the image, overridden by the tag, if any.
+func (in *KubeProxyAddonSpec) Validate(kubernetesVersion string) error {
+	if in.Mode == KubeProxyModeNFTables {
+		version := kubernetesVersion
+		if len(in.ImageTag) > 0 {
+			version = in.ImageTag
+		}
+
+		ver, err := semver.ParseTolerant(version)
+		if err != nil {
+			return fmt.Errorf("unable to parse the kube-proxy version %s: %w", version, err)
+		}
+
+		if ver.LT(kubeProxyNFTablesMinimumVersion) {
+			return fmt.Errorf("the kube-proxy nftables mode requires the version v%s or greater, actually %s", kubeProxyNFTablesMinimumVersion.String(), version)
+		}
+	}
+
+	if len(in.ClusterCIDR) > 0 {
+		if _, _, err := net.ParseCIDR(in.ClusterCIDR); err != nil {
+			return fmt.Errorf("the kube-proxy cluster CIDR %s is not valid: %w", in.ClusterCIDR, err)
+		}
+	}
+
+	if len(in.MetricsBindAddress) > 0 {
+		host, _, err := net.SplitHostPort(in.MetricsBindAddress)
+		if err != nil {
+			return fmt.Errorf("the kube-proxy metrics bind address %s must be in the IP:port form: %w", in.MetricsBindAddress, err)
+		}
+
+		if net.ParseIP(host) == nil {
+			return fmt.Errorf("the kube-proxy metrics bind address %s must be an IP address", in.MetricsBindAddress)
+		}
+	}
+
+	if conntrack := in.Conntrack; conntrack != nil {
+		for name, timeout := range map[string]*metav1.Duration{"established": conntrack.TCPEstablishedTimeout, "close wait": conntrack.TCPCloseWaitTimeout} {
+			if timeout != nil && timeout.Duration < 0 {
+				return fmt.Errorf("the kube-proxy conntrack TCP %s timeout cannot be negative", name)
+			}
+		}
+	}
+
+	return nil
+}
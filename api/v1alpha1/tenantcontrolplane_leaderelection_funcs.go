@@ -0,0 +1,57 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The leader election defaults of the controller-manager, and of the scheduler.
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// Durations returns the lease duration, the renew deadline, and the retry period, falling back to the Kubernetes defaults.
+func (in *LeaderElectionSpec) Durations() (leaseDuration, renewDeadline, retryPeriod time.Duration) {
+	leaseDuration, renewDeadline, retryPeriod = defaultLeaseDuration, defaultRenewDeadline, defaultRetryPeriod
+
+	if in == nil {
+		return leaseDuration, renewDeadline, retryPeriod
+	}
+
+	durationOr := func(duration *metav1.Duration, fallback time.Duration) time.Duration {
+		if duration == nil {
+			return fallback
+		}
+
+		return duration.Duration
+	}
+
+	return durationOr(in.LeaseDuration, leaseDuration), durationOr(in.RenewDeadline, renewDeadline), durationOr(in.RetryPeriod, retryPeriod)
+}
+
+// Validate ensures the durations are accepted by the controller-manager, and the scheduler,
+// which would otherwise refuse to start.
+func (in *LeaderElectionSpec) Validate() error {
+	leaseDuration, renewDeadline, retryPeriod := in.Durations()
+
+	if leaseDuration <= 0 || renewDeadline <= 0 || retryPeriod <= 0 {
+		return fmt.Errorf("the leader election durations must be greater than zero")
+	}
+
+	if renewDeadline >= leaseDuration {
+		return fmt.Errorf("the leader election renew deadline (%s) must be shorter than the lease duration (%s)", renewDeadline, leaseDuration)
+	}
+
+	if retryPeriod >= renewDeadline {
+		return fmt.Errorf("the leader election retry period (%s) must be shorter than the renew deadline (%s)", retryPeriod, renewDeadline)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+)
+
+// Validate ensures the rate limits are positive, and the window is lasting up to a day.
+func (in *DataStoreMigrationSpec) Validate() error {
+	if in.BytesPerSecond != nil && in.BytesPerSecond.Sign() <= 0 {
+		return fmt.Errorf("the migration bytes per second must be positive")
+	}
+
+	if in.Window == nil {
+		return nil
+	}
+
+	if _, err := time.Parse("15:04", in.Window.Start); err != nil {
+		return fmt.Errorf("the migration window start %s is not in the HH:MM format", in.Window.Start)
+	}
+
+	if in.Window.Duration.Duration <= 0 || in.Window.Duration.Duration > 24*time.Hour {
+		return fmt.Errorf("the migration window duration must be positive, and up to 24h")
+	}
+
+	return nil
+}
+
+// NextOpening returns the time the window opens at: the current one, if the window is already open.
+// The window is assumed to be validated.
+func (in *DataStoreMigrationWindow) NextOpening(now time.Time) time.Time {
+	now = now.UTC()
+
+	start, _ := time.Parse("15:04", in.Start)
+	// The window opened yesterday could be still open.
+	opening := time.Date(now.Year(), now.Month(), now.Day()-1, start.Hour(), start.Minute(), 0, 0, time.UTC)
+
+	for !now.Before(opening.Add(in.Duration.Duration)) {
+		opening = opening.AddDate(0, 0, 1)
+	}
+
+	if now.After(opening) {
+		return now
+	}
+
+	return opening
+}
@@ -7,6 +7,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -53,6 +54,17 @@ type CertificatesStatus struct {
 	FrontProxyClient       CertificatePrivateKeyPairStatus `json:"frontProxyClient,omitempty"`
 	SA                     PublicKeyPrivateKeyPairStatus   `json:"sa,omitempty"`
 	ETCD                   *ETCDCertificatesStatus         `json:"etcd,omitempty"`
+	// KubeletServingCA reports the Certificate Authority managed by Kamaji to sign the kubelet serving certificates.
+	KubeletServingCA *KubeletServingCAStatus `json:"kubeletServingCA,omitempty"`
+}
+
+// KubeletServingCAStatus defines the status of the Certificate Authority signing the kubelet serving certificates.
+type KubeletServingCAStatus struct {
+	CertificatePrivateKeyPairStatus `json:",inline"`
+	// NotAfter is the expiration of the current Certificate Authority.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// LastRotation is the time of the latest rotation: the previous Certificate Authority is still trusted until its expiration.
+	LastRotation *metav1.Time `json:"lastRotation,omitempty"`
 }
 
 type DataStoreCertificateStatus struct {
@@ -80,6 +92,91 @@ type StorageStatus struct {
 	Config        DataStoreConfigStatus      `json:"config,omitempty"`
 	Setup         DataStoreSetupStatus       `json:"setup,omitempty"`
 	Certificate   DataStoreCertificateStatus `json:"certificate,omitempty"`
+	// Kine contains the status of kine when running as a separate Deployment.
+	Kine *KineStatus `json:"kine,omitempty"`
+	// KineCleanup contains the results of the last cleanup of the kine table.
+	KineCleanup *KineCleanupStatus `json:"kineCleanup,omitempty"`
+	// Quota contains the storage usage of the Tenant Control Plane when a DataStore quota is set.
+	Quota *DataStoreQuotaStatus `json:"quota,omitempty"`
+	// Migration contains the progress of the last migration to another DataStore.
+	Migration *DataStoreMigrationStatus `json:"migration,omitempty"`
+	// Standby contains the status of the snapshots shipped to the standby DataStore.
+	Standby *StandbyDataStoreStatus `json:"standby,omitempty"`
+}
+
+// KineCleanupStatus defines the observed results of the last cleanup of the kine table.
+type KineCleanupStatus struct {
+	// LastRun is the time of the last cleanup, either successful or not.
+	LastRun metav1.Time       `json:"lastRun,omitempty"`
+	Result  MaintenanceResult `json:"result,omitempty"`
+	// SizeBefore is the size in bytes of the kine table, including its indexes, before the last cleanup.
+	SizeBefore int64 `json:"sizeBefore,omitempty"`
+	// SizeAfter is the size in bytes of the kine table, including its indexes, after the last cleanup.
+	SizeAfter int64 `json:"sizeAfter,omitempty"`
+	// Message contains the details of the last cleanup, such as the error.
+	Message string `json:"message,omitempty"`
+}
+
+// StandbyDataStoreStatus defines the observed state of the snapshots shipped to the standby DataStore.
+type StandbyDataStoreStatus struct {
+	// DataStoreName is the name of the standby DataStore the snapshots are shipped to.
+	DataStoreName string `json:"dataStoreName,omitempty"`
+	// KeysCopied is the number of keys, or rows, copied by the last successful snapshot.
+	KeysCopied int64 `json:"keysCopied,omitempty"`
+	// LastSyncTime is the time of the last successful snapshot: the standby DataStore can be promoted only once set.
+	// It's cleared while an etcd, or MySQL, snapshot overwrites the previous one, since a failure would leave it partial.
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+	// LastAttemptTime is the time of the last snapshot, either successful or not.
+	LastAttemptTime metav1.Time `json:"lastAttemptTime,omitempty"`
+	// LastError reports the error the last snapshot failed with.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Scheduled;Running;Completed;Failed
+type DataStoreMigrationPhase string
+
+const (
+	DataStoreMigrationPhaseScheduled DataStoreMigrationPhase = "Scheduled"
+	DataStoreMigrationPhaseRunning   DataStoreMigrationPhase = "Running"
+	DataStoreMigrationPhaseCompleted DataStoreMigrationPhase = "Completed"
+	DataStoreMigrationPhaseFailed    DataStoreMigrationPhase = "Failed"
+)
+
+// DataStoreMigrationStatus defines the observed progress of the data migration to another DataStore.
+type DataStoreMigrationStatus struct {
+	Phase DataStoreMigrationPhase `json:"phase,omitempty"`
+	// TargetDataStore is the name of the DataStore the data is migrated to.
+	TargetDataStore string `json:"targetDataStore,omitempty"`
+	// KeysTotal is the number of keys, or rows, to be copied to the target DataStore.
+	KeysTotal int64 `json:"keysTotal,omitempty"`
+	// KeysCopied is the number of keys, or rows, already copied to the target DataStore.
+	KeysCopied int64 `json:"keysCopied,omitempty"`
+	// Percentage is the progress of the migration, ranging from 0 to 100.
+	Percentage int32 `json:"percentage,omitempty"`
+	// EstimatedCompletionTime is the expected end of the migration, according to the pace of the copied keys.
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+	// NextWindow reports when the scheduled migration is going to start.
+	NextWindow *metav1.Time `json:"nextWindow,omitempty"`
+	// LastError reports the error the migration failed with.
+	LastError  string      `json:"lastError,omitempty"`
+	StartTime  metav1.Time `json:"startTime,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// DataStoreQuotaStatus defines the observed storage usage of the Tenant Control Plane in the DataStore.
+type DataStoreQuotaStatus struct {
+	Used resource.Quantity `json:"used,omitempty"`
+	Size resource.Quantity `json:"size,omitempty"`
+	// ReadOnly reports if the write permission has been revoked due to the quota enforcement.
+	ReadOnly   bool        `json:"readOnly,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// KineStatus defines the observed state of kine when running as a separate Deployment.
+type KineStatus struct {
+	Certificate CertificatePrivateKeyPairStatus `json:"certificate,omitempty"`
+	Deployment  ExternalKubernetesObjectStatus  `json:"deployment,omitempty"`
+	Service     KubernetesServiceStatus         `json:"service,omitempty"`
 }
 
 // KubeconfigStatus contains information about the generated kubeconfig.
@@ -87,6 +184,19 @@ type KubeconfigStatus struct {
 	SecretName string      `json:"secretName,omitempty"`
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 	Checksum   string      `json:"checksum,omitempty"`
+	// LegacyKeys lists the keys of the Secret belonging to deprecated layouts:
+	// they are removed when the legacy kubeconfig formats are disabled.
+	LegacyKeys []string `json:"legacyKeys,omitempty"`
+}
+
+// KubeconfigExternalTargetStatus reports the last push of the kubeconfig to an external secret store.
+type KubeconfigExternalTargetStatus struct {
+	Name string `json:"name"`
+	// Checksum of the pushed kubeconfig, along with the target settings.
+	Checksum   string      `json:"checksum,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+	// Target is the store the kubeconfig has been pushed to, used to delete it once the target is removed.
+	Target KubeconfigExternalTarget `json:"target"`
 }
 
 // KubeconfigsStatus stores information about all the generated kubeconfig resources.
@@ -94,6 +204,14 @@ type KubeconfigsStatus struct {
 	Admin             KubeconfigStatus `json:"admin,omitempty"`
 	ControllerManager KubeconfigStatus `json:"controllerManager,omitempty"`
 	Scheduler         KubeconfigStatus `json:"scheduler,omitempty"`
+	// AdminTargets lists the copies of the admin kubeconfig Secret, in the namespace/name format.
+	AdminTargets []string `json:"adminTargets,omitempty"`
+	// AdminExternalTargets reports the external secret stores the admin kubeconfig has been pushed to.
+	// +listType=map
+	// +listMapKey=name
+	AdminExternalTargets []KubeconfigExternalTargetStatus `json:"adminExternalTargets,omitempty"`
+	// Users is the kubeconfig for the human users, authenticating through OIDC.
+	Users KubeconfigStatus `json:"users,omitempty"`
 }
 
 // KubeadmConfigStatus contains the status of the configuration required by kubeadm.
@@ -113,6 +231,20 @@ type KubeadmPhaseStatus struct {
 // KubeadmPhasesStatus contains the status of the different kubeadm phases action.
 type KubeadmPhasesStatus struct {
 	BootstrapToken KubeadmPhaseStatus `json:"bootstrapToken"`
+	// JoinCommand reports the bootstrap token currently published for the nodes joining the Tenant Cluster.
+	JoinCommand *JoinCommandStatus `json:"joinCommand,omitempty"`
+	// Skipped lists the kubeadm phases not performed since disabled in the Tenant Control Plane specification.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// JoinCommandStatus contains the status of the bootstrap token published for the nodes joining the Tenant Cluster:
+// the token secret, the CA certificate hash, and the kubeadm join command are available in the referenced Secret.
+type JoinCommandStatus struct {
+	SecretName string `json:"secretName,omitempty"`
+	// TokenID is the public part of the current bootstrap token.
+	TokenID    string      `json:"tokenID,omitempty"`
+	Expiration metav1.Time `json:"expiration,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 type ExternalKubernetesObjectStatus struct {
@@ -122,16 +254,51 @@ type ExternalKubernetesObjectStatus struct {
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Succeeded;Failed
+type CleanupHookResult string
+
+const (
+	CleanupHookResultSucceeded CleanupHookResult = "Succeeded"
+	CleanupHookResultFailed    CleanupHookResult = "Failed"
+)
+
+type CleanupHookStatus struct {
+	Name string `json:"name"`
+	// Result is set once the hook is completed: Failed is reported only for the hooks with the Ignore failure policy.
+	Result CleanupHookResult `json:"result,omitempty"`
+	// Attempts is the number of the performed attempts.
+	Attempts int32 `json:"attempts,omitempty"`
+	// Message reports the last failure, if any.
+	Message string `json:"message,omitempty"`
+	// StartTime is the time when the last attempt has been started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time when the hook has been completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
 // KonnectivityStatus defines the status of Konnectivity as Addon.
 type KonnectivityStatus struct {
-	Enabled            bool                            `json:"enabled"`
-	ConfigMap          KonnectivityConfigMap           `json:"configMap,omitempty"`
-	Certificate        CertificatePrivateKeyPairStatus `json:"certificate,omitempty"`
+	Enabled     bool                            `json:"enabled"`
+	ConfigMap   KonnectivityConfigMap           `json:"configMap,omitempty"`
+	Certificate CertificatePrivateKeyPairStatus `json:"certificate,omitempty"`
+	// ProxyCertificate is the Secret holding the proxy server, and client, certificates used by the http-connect mode.
+	ProxyCertificate   CertificatePrivateKeyPairStatus `json:"proxyCertificate,omitempty"`
 	Kubeconfig         KubeconfigStatus                `json:"kubeconfig,omitempty"`
 	ServiceAccount     ExternalKubernetesObjectStatus  `json:"sa,omitempty"`
 	ClusterRoleBinding ExternalKubernetesObjectStatus  `json:"clusterrolebinding,omitempty"`
 	Agent              ExternalKubernetesObjectStatus  `json:"agent,omitempty"`
 	Service            KubernetesServiceStatus         `json:"service,omitempty"`
+	// Leases is the Role granting the access to the Konnectivity server Leases, when they're used to count the servers.
+	Leases ExternalKubernetesObjectStatus `json:"leases,omitempty"`
+	// ServerDeployment is the Deployment running the Konnectivity servers, when they're not sidecar containers.
+	ServerDeployment ExternalKubernetesObjectStatus `json:"serverDeployment,omitempty"`
+	// RemovalRequestedAt is the time when the addon has been disabled, while its resources are still in the Tenant Cluster.
+	RemovalRequestedAt *metav1.Time `json:"removalRequestedAt,omitempty"`
+	// AgentsUnavailableSince is the time since no Konnectivity agent is available in the Tenant Cluster,
+	// tracked only when the fallback is enabled.
+	AgentsUnavailableSince *metav1.Time `json:"agentsUnavailableSince,omitempty"`
+	// AddonHealthStatus reports the health of the Konnectivity agents.
+	AddonHealthStatus `json:",inline"`
 }
 
 type KonnectivityConfigMap struct {
@@ -141,8 +308,21 @@ type KonnectivityConfigMap struct {
 
 // AddonStatus defines the observed state of an Addon.
 type AddonStatus struct {
-	Enabled    bool        `json:"enabled"`
-	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+	Enabled           bool        `json:"enabled"`
+	LastUpdate        metav1.Time `json:"lastUpdate,omitempty"`
+	AddonHealthStatus `json:",inline"`
+}
+
+// AddonHealthStatus reports the health of an addon, periodically collected from its workloads in the Tenant Cluster.
+type AddonHealthStatus struct {
+	// Version is the version of the addon running in the Tenant Cluster, as the image tag of its first workload.
+	Version string `json:"version,omitempty"`
+	// LastSync is the time of the last health collection.
+	LastSync *metav1.Time `json:"lastSync,omitempty"`
+	// Conditions report the Healthy condition of the addon, true when all its workloads are available.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // AddonsStatus defines the observed state of the different Addons.
@@ -150,6 +330,23 @@ type AddonsStatus struct {
 	CoreDNS      AddonStatus        `json:"coreDNS,omitempty"`
 	KubeProxy    AddonStatus        `json:"kubeProxy,omitempty"`
 	Konnectivity KonnectivityStatus `json:"konnectivity,omitempty"`
+	CertManager  AddonStatus        `json:"certManager,omitempty"`
+	CNI          AddonStatus        `json:"cni,omitempty"`
+	Storage      AddonStatus        `json:"storage,omitempty"`
+	// Manifests reports the manifests addons applied to the Tenant Cluster.
+	// +listType=map
+	// +listMapKey=name
+	Manifests []ManifestsAddonStatus `json:"manifests,omitempty"`
+}
+
+// ManifestsAddonStatus defines the observed state of a manifests addon.
+type ManifestsAddonStatus struct {
+	Name string `json:"name"`
+	// Checksum of the applied manifests.
+	Checksum string `json:"checksum,omitempty"`
+	// Resources is the number of resources applied to the Tenant Cluster.
+	Resources  int32       `json:"resources,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 // TenantControlPlaneStatus defines the observed state of TenantControlPlane.
@@ -171,6 +368,70 @@ type TenantControlPlaneStatus struct {
 	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
 	// Addons contains the status of the different Addons
 	Addons AddonsStatus `json:"addons,omitempty"`
+	// ResourceFootprint reports the resources consumed by the Tenant Control Plane in the management cluster.
+	ResourceFootprint *ResourceFootprintStatus `json:"resourceFootprint,omitempty"`
+	// CleanupHooks reports the progress of the clean-up hooks performed upon the deletion.
+	CleanupHooks []CleanupHookStatus `json:"cleanupHooks,omitempty"`
+	// Conditions contains the latest observations of the Tenant Control Plane state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// ConditionTypeDataStoreQuotaExceeded reports if the Tenant Control Plane exceeded its DataStore quota.
+	ConditionTypeDataStoreQuotaExceeded = "DataStoreQuotaExceeded"
+	// ConditionTypeDataStoreMigrationValidated reports the result of the last migration dry-run, along with the
+	// estimated amount of data, and duration, of the migration.
+	ConditionTypeDataStoreMigrationValidated = "DataStoreMigrationValidated"
+	// ConditionTypeDegraded reports if the Tenant Control Plane is not fully operational,
+	// such as when its DataStore is in maintenance mode.
+	ConditionTypeDegraded = "Degraded"
+	// ConditionTypeKonnectivityRemovalPending reports if the removal of the Konnectivity agent resources is waiting
+	// for the grace period, or the confirmation.
+	ConditionTypeKonnectivityRemovalPending = "KonnectivityRemovalPending"
+	// ConditionTypeKonnectivityCapacityExceeded reports if the Konnectivity agents exceed the capacity of the servers.
+	ConditionTypeKonnectivityCapacityExceeded = "KonnectivityCapacityExceeded"
+	// ConditionTypeKonnectivityServerOverloaded reports if the Konnectivity server has been restarted for exceeding its memory limit.
+	ConditionTypeKonnectivityServerOverloaded = "KonnectivityServerOverloaded"
+	// ConditionTypeKonnectivityDegraded reports if the API Server is reaching the worker nodes with the direct egress,
+	// bypassing the unavailable Konnectivity agents.
+	ConditionTypeKonnectivityDegraded = "KonnectivityDegraded"
+	// ConditionTypeDeprecatedAPIsInUse reports if the Tenant Cluster clients are requesting deprecated APIs.
+	ConditionTypeDeprecatedAPIsInUse = "DeprecatedAPIsInUse"
+	// ConditionTypeRolloutPending reports the Secrets whose changes have not been rolled out to the Control Plane Pods,
+	// according to the checksum strategy.
+	ConditionTypeRolloutPending = "RolloutPending"
+	// ConditionTypeDriftDetected reports if the live settings of the Tenant Control Plane components differ from the declared ones.
+	ConditionTypeDriftDetected = "DriftDetected"
+	// ConditionTypeControllerManagerLeaseHealthy reports if the kube-controller-manager leader election lease is renewed
+	// by a running Pod of the Tenant Control Plane.
+	ConditionTypeControllerManagerLeaseHealthy = "ControllerManagerLeaseHealthy"
+	// ConditionTypeSchedulerLeaseHealthy reports if the kube-scheduler leader election lease is renewed
+	// by a running Pod of the Tenant Control Plane.
+	ConditionTypeSchedulerLeaseHealthy = "SchedulerLeaseHealthy"
+	// ConditionTypeAddonHealthy reports, in the addon status, if all the addon workloads are available in the Tenant Cluster.
+	ConditionTypeAddonHealthy = "Healthy"
+	// ConditionTypeAPIServerLogLevelChanged reports the result of the last API Server verbosity change,
+	// requested with the APIServerLogLevelAnnotation.
+	ConditionTypeAPIServerLogLevelChanged = "APIServerLogLevelChanged"
+)
+
+// ResourceFootprintStatus contains the aggregated resources consumed by the Tenant Control Plane in the management cluster,
+// such as the compute requests and limits of its Pods, the storage claimed by its PersistentVolumeClaims, and its Services.
+type ResourceFootprintStatus struct {
+	// Requests is the sum of the compute resources requested by all the desired Tenant Control Plane replicas.
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// Limits is the sum of the compute resources limits of all the desired Tenant Control Plane replicas.
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+	// Storage is the sum of the storage requested by the PersistentVolumeClaims owned by the Tenant Control Plane.
+	Storage resource.Quantity `json:"storage,omitempty"`
+	// PersistentVolumeClaims is the number of PersistentVolumeClaims owned by the Tenant Control Plane.
+	PersistentVolumeClaims int32 `json:"persistentVolumeClaims,omitempty"`
+	// Services is the number of Services owned by the Tenant Control Plane.
+	Services int32 `json:"services,omitempty"`
+	// Last time when the footprint was computed
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 // KubernetesStatus defines the status of the resources deployed in the management cluster,
@@ -181,6 +442,36 @@ type KubernetesStatus struct {
 	Deployment KubernetesDeploymentStatus `json:"deployment,omitempty"`
 	Service    KubernetesServiceStatus    `json:"service,omitempty"`
 	Ingress    *KubernetesIngressStatus   `json:"ingress,omitempty"`
+	// DeprecatedAPIs lists the deprecated APIs requested to the Tenant Control Plane API Server,
+	// according to the apiserver_requested_deprecated_apis metric.
+	DeprecatedAPIs []DeprecatedAPIUsage `json:"deprecatedAPIs,omitempty"`
+	// LeaderElection reports the leader election leases of the kube-controller-manager and kube-scheduler,
+	// collected from the Tenant Cluster.
+	LeaderElection []LeaderElectionLeaseStatus `json:"leaderElection,omitempty"`
+}
+
+// LeaderElectionLeaseStatus reports the leader election lease of a Tenant Control Plane component.
+type LeaderElectionLeaseStatus struct {
+	// Component is the name of the component holding the lease, such as kube-scheduler.
+	Component string `json:"component"`
+	// HolderIdentity is the identity of the current leader, made of the Pod name and of a random suffix.
+	HolderIdentity string `json:"holderIdentity,omitempty"`
+	// RenewTime is the last time the lease has been renewed by the leader.
+	RenewTime *metav1.MicroTime `json:"renewTime,omitempty"`
+	// LeaseTransitions is the number of times the leadership changed hands.
+	LeaseTransitions int32 `json:"leaseTransitions,omitempty"`
+	// LastSync is the last time the lease has been collected.
+	LastSync metav1.Time `json:"lastSync,omitempty"`
+}
+
+// DeprecatedAPIUsage reports a deprecated API requested by the Tenant Cluster clients.
+type DeprecatedAPIUsage struct {
+	Group       string `json:"group,omitempty"`
+	Version     string `json:"version"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	// RemovedRelease is the Kubernetes minor release removing the API, such as 1.25.
+	RemovedRelease string `json:"removedRelease,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Provisioning;CertificateAuthorityRotating;Upgrading;Migrating;Ready;NotReady
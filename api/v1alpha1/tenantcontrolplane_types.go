@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +32,11 @@ type NetworkProfileSpec struct {
 	PodCIDR string `json:"podCidr,omitempty"`
 	// +kubebuilder:default={"10.96.0.10"}
 	DNSServiceIPs []string `json:"dnsServiceIPs,omitempty"`
+	// ServiceNodePortRange is the port range reserved for the Services of type NodePort in the Tenant Cluster,
+	// expressed in the min-max form, such as 30000-32767.
+	// When omitted, the API Server default one is used, unless specified with the extra arguments.
+	// +kubebuilder:validation:Pattern=`^[0-9]+-[0-9]+$`
+	ServiceNodePortRange string `json:"serviceNodePortRange,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Hostname;InternalIP;ExternalIP;InternalDNS;ExternalDNS
@@ -53,6 +59,55 @@ type KubeletSpec struct {
 	// CGroupFS defines the  cgroup driver for Kubelet
 	// https://kubernetes.io/docs/tasks/administer-cluster/kubeadm/configure-cgroup-driver/
 	CGroupFS CGroupDriver `json:"cgroupfs,omitempty"`
+	// TLS allows to supply the trust and the credentials used by the kube-apiserver to connect to the kubelets,
+	// required when the tenant nodes have serving certificates issued by an external Certificate Authority.
+	TLS *KubeletTLSSpec `json:"tls,omitempty"`
+	// NodePools declares the kubelet configurations of the tenant node pools diverging from the default one,
+	// such as the nodes running an operating system without systemd: each of them is uploaded to the
+	// kubelet-config-<name> ConfigMap in the kube-system namespace of the Tenant Cluster, readable by the joining nodes.
+	// +listType=map
+	// +listMapKey=name
+	NodePools []KubeletNodePoolSpec `json:"nodePools,omitempty"`
+}
+
+// KubeletNodePoolSpec defines the kubelet configuration overrides of a tenant node pool.
+type KubeletNodePoolSpec struct {
+	// Name of the node pool, used as suffix of the kubelet configuration ConfigMap.
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// CGroupFS defines the cgroup driver of the kubelets of the node pool, overriding the Tenant Control Plane one.
+	CGroupFS CGroupDriver `json:"cgroupfs"`
+}
+
+// KubeletTLSSpec defines the TLS configuration used by the kube-apiserver to connect to the kubelets.
+type KubeletTLSSpec struct {
+	// CertificateAuthority is the bundle used to verify the kubelet serving certificates,
+	// such as bare content of the file, or a SecretReference.
+	// When not specified, the kubelet serving certificates are not verified.
+	CertificateAuthority *ContentRef `json:"certificateAuthority,omitempty"`
+	// ClientCertificate is the certificate and private key pair presented by the kube-apiserver to the kubelets,
+	// replacing the one signed by the Tenant Control Plane Certificate Authority.
+	ClientCertificate *ClientCertificate `json:"clientCertificate,omitempty"`
+	// ServingCertificateAuthority lets Kamaji generate and rotate the Certificate Authority signing the kubelet serving certificates,
+	// requested by the tenant nodes with the TLS bootstrap: the kube-apiserver verifies them against it.
+	// It's mutually exclusive with CertificateAuthority.
+	ServingCertificateAuthority *KubeletServingCertificateAuthoritySpec `json:"servingCertificateAuthority,omitempty"`
+}
+
+// KubeletServingCertificateAuthoritySpec defines the lifecycle of the Certificate Authority managed by Kamaji
+// to sign the kubelet serving certificates.
+type KubeletServingCertificateAuthoritySpec struct {
+	// Validity of the generated Certificate Authority.
+	// +kubebuilder:default="8760h"
+	Validity metav1.Duration `json:"validity,omitempty"`
+	// RenewBefore is the time ahead of the expiration the Certificate Authority is rotated:
+	// the previous one is still trusted until its expiration, letting the kubelets renew their serving certificates.
+	// +kubebuilder:default="720h"
+	RenewBefore metav1.Duration `json:"renewBefore,omitempty"`
+	// ManualApproval disables the approval of the kubelet serving CertificateSigningRequests by Kamaji,
+	// leaving it to an external approver running in the Tenant Cluster.
+	ManualApproval bool `json:"manualApproval,omitempty"`
 }
 
 // KubernetesSpec defines the desired state of Kubernetes.
@@ -65,6 +120,22 @@ type KubernetesSpec struct {
 	// Full reference available here: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers
 	// +kubebuilder:default=CertificateApproval;CertificateSigning;CertificateSubjectRestriction;DefaultIngressClass;DefaultStorageClass;DefaultTolerationSeconds;LimitRanger;MutatingAdmissionWebhook;NamespaceLifecycle;PersistentVolumeClaimResize;Priority;ResourceQuota;RuntimeClass;ServiceAccount;StorageObjectInUseProtection;TaintNodesByCondition;ValidatingAdmissionWebhook
 	AdmissionControllers AdmissionControllers `json:"admissionControllers,omitempty"`
+	// Streaming allows tuning the streaming requests, such as exec, attach, and port-forward,
+	// especially when proxied through the Konnectivity tunnel.
+	Streaming *StreamingSpec `json:"streaming,omitempty"`
+}
+
+// StreamingSpec defines the options for the streaming requests, such as exec, attach, and port-forward.
+type StreamingSpec struct {
+	// WebSockets enables the WebSocket streaming protocol for exec, attach, and port-forward requests
+	// by turning on the related kube-apiserver feature gates, available starting from Kubernetes v1.30.
+	WebSockets bool `json:"webSockets,omitempty"`
+	// IdleTimeout is the maximum time a streaming connection can be idle before being closed by the kubelet.
+	// When not specified, the kubelet default value is used.
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+	// KeepaliveTime defines the interval of the keepalive pings between the Konnectivity server and agents,
+	// preventing the idle tunnels, and the streams going through them, from being dropped by the network devices in the middle.
+	KeepaliveTime *metav1.Duration `json:"keepaliveTime,omitempty"`
 }
 
 // AdditionalMetadata defines which additional metadata, such as labels and annotations, must be attached to the created resource.
@@ -82,6 +153,74 @@ type ControlPlane struct {
 	Service ServiceSpec `json:"service"`
 	// Defining the options for an Optional Ingress which will expose API Server of the Tenant Control Plane
 	Ingress *IngressSpec `json:"ingress,omitempty"`
+	// Defining the options for kine, the etcd shim used when the DataStore driver is MySQL or PostgreSQL.
+	Kine *KineSpec `json:"kine,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Sidecar;Deployment
+
+type KineMode string
+
+var (
+	KineModeSidecar    KineMode = "Sidecar"
+	KineModeDeployment KineMode = "Deployment"
+)
+
+type KineSpec struct {
+	// Mode defines how kine is deployed: as a sidecar container in each Tenant Control Plane Pod,
+	// or as a separate Deployment shared by all the kube-apiserver replicas, reducing the connections to the DataStore.
+	// When running as a Deployment, the kube-apiserver connects to kine using mutual TLS.
+	// +kubebuilder:default=Sidecar
+	Mode KineMode `json:"mode,omitempty"`
+	// The number of kine replicas, taken in consideration only when running in Deployment mode.
+	// +kubebuilder:default=1
+	Replicas int32 `json:"replicas,omitempty"`
+	// MutualTLS secures the communication between the kube-apiserver and the kine sidecar over localhost,
+	// using a dedicated serving certificate for kine: the Deployment mode always enforces it.
+	MutualTLS bool `json:"mutualTLS,omitempty"`
+	// Container image used by kine, overriding the default one of the Kamaji Operator.
+	Image string `json:"image,omitempty"`
+	// Container image version of kine, used along with the image, or the repository of the default one.
+	Version string `json:"version,omitempty"`
+	// Resources define the amount of CPU and memory to allocate to kine.
+	Resources *ComponentResourceRequirements `json:"resources,omitempty"`
+	// ExtraArgs are the additional arguments of kine, such as --slow-sql-threshold,
+	// taking precedence over the ones specified in the Deployment extra arguments.
+	ExtraArgs ExtraArgs `json:"extraArgs,omitempty"`
+	// Metrics enables the Prometheus metrics endpoint of kine, along with a PodMonitor scraping it,
+	// labelled with the Tenant Control Plane name and namespace: the Prometheus Operator CRDs are required.
+	Metrics *KineMetricsSpec `json:"metrics,omitempty"`
+	// Compaction configures the compaction of the superseded revisions performed by kine, requiring kine v0.9.9 at least,
+	// along with the periodic cleanup of the kine table, reclaiming the space left by the compacted rows.
+	Compaction *KineCompactionSpec `json:"compaction,omitempty"`
+}
+
+type KineCompactionSpec struct {
+	// Interval between two compactions performed by kine: the kine default one is used when not specified.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Retention is the minimum number of the latest revisions retained by the compaction:
+	// the kine default one is used when not specified.
+	// +kubebuilder:validation:Minimum=0
+	Retention *int64 `json:"retention,omitempty"`
+	// BatchSize is the number of revisions compacted in a single transaction: the kine default one is used when not specified.
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int64 `json:"batchSize,omitempty"`
+	// CleanupInterval between two cleanups of the kine table, such as VACUUM for PostgreSQL, or OPTIMIZE TABLE for MySQL,
+	// performed by Kamaji reporting the table size before and after it: the cleanup is disabled when not specified.
+	CleanupInterval *metav1.Duration `json:"cleanupInterval,omitempty"`
+}
+
+type KineMetricsSpec struct {
+	// Port of the kine metrics endpoint.
+	// +kubebuilder:default=8080
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+	// Interval at which the metrics are scraped: the Prometheus default one is used when not specified.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	Interval string `json:"interval,omitempty"`
+	// Labels are added to the PodMonitor, such as the ones selected by the Prometheus instance.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // IngressSpec defines the options for the ingress which will expose API Server of the Tenant Control Plane.
@@ -146,6 +285,64 @@ type DeploymentSpec struct {
 	// such as kube-apiserver, controller-manager, and scheduler.
 	ExtraArgs          *ControlPlaneExtraArgs `json:"extraArgs,omitempty"`
 	AdditionalMetadata AdditionalMetadata     `json:"additionalMetadata,omitempty"`
+	// ChecksumPolicy defines how the changes of the certificates, and kubeconfig, Secrets mounted by the Control Plane
+	// components, along with the DataStore certificates and configuration, are detected, and applied:
+	// by default, any change of their content is rolling out the Pods.
+	ChecksumPolicy *ChecksumPolicy `json:"checksumPolicy,omitempty"`
+	// LeaderElection tunes the leader election of the controller-manager, and of the scheduler, running in each replica:
+	// the shorter the durations, the faster the failover to a standby replica, at the cost of more requests to the API Server.
+	LeaderElection *LeaderElectionSpec `json:"leaderElection,omitempty"`
+}
+
+// LeaderElectionSpec defines the leader election durations shared by the controller-manager, and the scheduler:
+// when not specified, the Kubernetes defaults are used.
+type LeaderElectionSpec struct {
+	// LeaseDuration is the time the standby replicas wait, since the last renewal, before taking over the leadership.
+	// Defaults to 15s.
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	// RenewDeadline is the time the leader retries to renew the leadership before giving it up,
+	// and it must be shorter than the lease duration. Defaults to 10s.
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	// RetryPeriod is the time between the attempts to acquire, or renew, the leadership,
+	// and it must be shorter than the renew deadline. Defaults to 2s.
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=MD5;SHA256
+
+type ChecksumAlgorithm string
+
+const (
+	ChecksumAlgorithmMD5    ChecksumAlgorithm = "MD5"
+	ChecksumAlgorithmSHA256 ChecksumAlgorithm = "SHA256"
+)
+
+// +kubebuilder:validation:Enum=Rollout;StatusOnly;Manual
+
+type ChecksumStrategy string
+
+const (
+	// ChecksumStrategyRollout rolls out the Control Plane Pods upon a change of the Secrets.
+	ChecksumStrategyRollout ChecksumStrategy = "Rollout"
+	// ChecksumStrategyStatusOnly reports the changed Secrets with the RolloutPending condition only:
+	// the mounted files are refreshed by the kubelet, and picked up by the components reloading them.
+	ChecksumStrategyStatusOnly ChecksumStrategy = "StatusOnly"
+	// ChecksumStrategyManual reports the changed Secrets with the RolloutPending condition,
+	// rolling out the Pods once approved with the ApproveRolloutAnnotation.
+	ChecksumStrategyManual ChecksumStrategy = "Manual"
+)
+
+type ChecksumPolicy struct {
+	// Algorithm used to compute the checksum of the Secrets: changing it is rolling out the Pods,
+	// unless a different strategy is selected. The SHA256 checksum is truncated to 63 characters, as a label value.
+	// +kubebuilder:default=MD5
+	Algorithm ChecksumAlgorithm `json:"algorithm,omitempty"`
+	// Keys restricts the checksum of each Secret to the given keys, ignoring the changes of the other ones:
+	// the Secrets containing none of them are still computed on all their keys.
+	Keys []string `json:"keys,omitempty"`
+	// Strategy defines the action taken upon a change of the Secrets.
+	// +kubebuilder:default=Rollout
+	Strategy ChecksumStrategy `json:"strategy,omitempty"`
 }
 
 // ControlPlaneExtraArgs allows specifying additional arguments to the Control Plane components.
@@ -161,11 +358,232 @@ type ServiceSpec struct {
 	AdditionalMetadata AdditionalMetadata `json:"additionalMetadata,omitempty"`
 	// ServiceType allows specifying how to expose the Tenant Control Plane.
 	ServiceType ServiceType `json:"serviceType"`
+	// ExternalTrafficPolicy of the LoadBalancer, or NodePort, Service: Local preserves the client source IP,
+	// reported by the API Server audit logs, when the load balancer is passing through the TLS connections.
+	// The PROXY protocol must be disabled on the load balancer, since it's not supported by the API Server.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+	ServiceTopologySpec   `json:",inline"`
+}
+
+// ServiceTopologySpec defines the routing of the traffic originated by the clients running in the management cluster,
+// keeping it in their zone, or node, rather than spreading it across all the endpoints.
+type ServiceTopologySpec struct {
+	// TopologyMode enables the topology aware routing of the Service, preferring the endpoints in the zone of the client
+	// when they're enough to handle its traffic: it's set as service.kubernetes.io/topology-mode annotation, along with
+	// the service.kubernetes.io/topology-aware-hints one read by the management clusters older than v1.27.
+	// +kubebuilder:validation:Enum=Auto
+	TopologyMode string `json:"topologyMode,omitempty"`
+	// InternalTrafficPolicy of the Service: Local routes the in-cluster traffic to the endpoints on the node of the client only.
+	// +kubebuilder:validation:Enum=Cluster;Local
+	InternalTrafficPolicy *corev1.ServiceInternalTrafficPolicyType `json:"internalTrafficPolicy,omitempty"`
 }
 
 // AddonSpec defines the spec for every addon.
 type AddonSpec struct {
 	ImageOverrideTrait `json:",inline"`
+	// ServerSideApply reconciles the addon resources with the server-side apply, using the kamaji field manager:
+	// the fields declared by Kamaji are enforced, while the ones set by other managers, such as the GitOps tools
+	// of the tenant, are preserved, allowing the co-management of the addon.
+	ServerSideApply bool `json:"serverSideApply,omitempty"`
+	// ReconciliationMode defines whether the addon resources are enforced, reverting any change, or installed once,
+	// then handed over to the tenant administrators. It defaults to Enforce.
+	ReconciliationMode AddonReconciliationMode `json:"reconciliationMode,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Enforce;InstallOnce
+type AddonReconciliationMode string
+
+const (
+	// AddonReconciliationModeEnforce reconciles the addon resources continuously, reverting the drifts.
+	AddonReconciliationModeEnforce AddonReconciliationMode = "Enforce"
+	// AddonReconciliationModeInstallOnce creates the missing addon resources, leaving the existing ones untouched.
+	AddonReconciliationModeInstallOnce AddonReconciliationMode = "InstallOnce"
+)
+
+// +kubebuilder:validation:Enum=iptables;ipvs;nftables
+type KubeProxyMode string
+
+const (
+	KubeProxyModeIPTables KubeProxyMode = "iptables"
+	KubeProxyModeIPVS     KubeProxyMode = "ipvs"
+	KubeProxyModeNFTables KubeProxyMode = "nftables"
+)
+
+// KubeProxyAddonSpec defines the spec for the kube-proxy addon.
+type KubeProxyAddonSpec struct {
+	AddonSpec `json:",inline"`
+	// Mode of the kube-proxy, defaulting to iptables: nftables requires the kube-proxy v1.29, at least.
+	Mode KubeProxyMode `json:"mode,omitempty"`
+	// ClusterCIDR is the range of the Pods, used to tell the traffic internal to the cluster apart.
+	// When not specified, the Pod CIDR of the network profile is used.
+	ClusterCIDR string `json:"clusterCIDR,omitempty"`
+	// MetricsBindAddress is the address of the metrics server, in the IP:port form, defaulting to 127.0.0.1:10249.
+	// The 0.0.0.0:10249 value allows scraping it from the other nodes.
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+	// Conntrack tunes the connection tracking table of the tenant nodes.
+	Conntrack *KubeProxyConntrackSpec `json:"conntrack,omitempty"`
+
+	// Tolerations of the kube-proxy Pods, replacing the default one tolerating any taint:
+	// declare them to keep the kube-proxy off the tainted nodes, such as the externally managed appliances.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector of the kube-proxy Pods, replacing the default kubernetes.io/os=linux one.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity of the kube-proxy Pods.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// KubeProxyConntrackSpec defines the connection tracking options of the kube-proxy.
+type KubeProxyConntrackSpec struct {
+	// MaxPerCore is the maximum number of NAT connections to track per CPU core, 0 leaves the limit as-is.
+	// +kubebuilder:validation:Minimum=0
+	MaxPerCore *int32 `json:"maxPerCore,omitempty"`
+	// Min is the minimum number of connection tracking entries to allocate, regardless of MaxPerCore.
+	// +kubebuilder:validation:Minimum=0
+	Min *int32 `json:"min,omitempty"`
+	// TCPEstablishedTimeout is the idle timeout of the established TCP connections.
+	TCPEstablishedTimeout *metav1.Duration `json:"tcpEstablishedTimeout,omitempty"`
+	// TCPCloseWaitTimeout is the time the TCP connections in the CLOSE_WAIT state are tracked.
+	TCPCloseWaitTimeout *metav1.Duration `json:"tcpCloseWaitTimeout,omitempty"`
+}
+
+// CoreDNSAddonSpec defines the spec for the CoreDNS addon.
+type CoreDNSAddonSpec struct {
+	AddonSpec `json:",inline"`
+	// StubZones delegates the resolution of the given zones to the specified DNS servers,
+	// such as corp.internal resolved by the 10.0.0.53 DNS server.
+	StubZones []DNSStubZone `json:"stubZones,omitempty"`
+	// Upstreams overrides the DNS servers used to forward the queries not belonging to the cluster domain, or to a stub zone.
+	// When not specified, the nameservers of the node are used.
+	Upstreams []string `json:"upstreams,omitempty"`
+	// Replicas of the CoreDNS Deployment, overriding the kubeadm default of two.
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources of the CoreDNS container, replacing the kubeadm defaults.
+	Resources *ComponentResourceRequirements `json:"resources,omitempty"`
+	// Tolerations of the CoreDNS Pods, replacing the default CriticalAddonsOnly and control plane ones.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector of the CoreDNS Pods, replacing the default kubernetes.io/os=linux one.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity of the CoreDNS Pods, replacing the default anti-affinity spreading them across the nodes.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+type CertManagerAddonSpec struct {
+	// Version of cert-manager.
+	// +kubebuilder:default=v1.11.0
+	Version string `json:"version,omitempty"`
+	// ManifestsURL is the location of the release manifests, where the {{ .Version }} placeholder is replaced with the version,
+	// such as an internal mirror for the air-gapped environments. It defaults to the GitHub release of cert-manager.
+	ManifestsURL string `json:"manifestsURL,omitempty"`
+	// ImageRepository replaces the quay.io/jetstack registry of the cert-manager images.
+	ImageRepository string `json:"imageRepository,omitempty"`
+	// Values override the defaults of the release manifests.
+	Values *CertManagerValues `json:"values,omitempty"`
+	// ReconciliationMode defines whether the cert-manager resources are enforced, reverting any change,
+	// or installed once, then handed over to the tenant administrators. It defaults to Enforce.
+	ReconciliationMode AddonReconciliationMode `json:"reconciliationMode,omitempty"`
+}
+
+type CertManagerValues struct {
+	// Replicas of the cert-manager controller, webhook, and cainjector Deployments.
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+	// ExtraArgs are appended to the arguments of the cert-manager controller, such as --dns01-recursive-nameservers.
+	ExtraArgs ExtraArgs `json:"extraArgs,omitempty"`
+	// Tolerations of the cert-manager Pods.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector of the cert-manager Pods, replacing the default kubernetes.io/os=linux one.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Calico;Cilium
+type CNIProvider string
+
+const (
+	CNIProviderCalico CNIProvider = "Calico"
+	CNIProviderCilium CNIProvider = "Cilium"
+)
+
+// +kubebuilder:validation:Enum=VXLAN;IPIP;Geneve;None
+type CNIEncapsulation string
+
+const (
+	CNIEncapsulationVXLAN  CNIEncapsulation = "VXLAN"
+	CNIEncapsulationIPIP   CNIEncapsulation = "IPIP"
+	CNIEncapsulationGeneve CNIEncapsulation = "Geneve"
+	CNIEncapsulationNone   CNIEncapsulation = "None"
+)
+
+type CNIAddonSpec struct {
+	// Provider of the Container Network Interface plugin.
+	Provider CNIProvider `json:"provider"`
+	// Version of the CNI plugin, defaulting to v3.25.0 for Calico.
+	Version string `json:"version,omitempty"`
+	// ManifestsURL is the location of the release manifests, where the {{ .Version }} placeholder is replaced with the version.
+	// It defaults to the GitHub repository of Calico, while it's required for Cilium, which only provides the Helm chart:
+	// the rendered manifests must be published, such as in an internal mirror.
+	ManifestsURL string `json:"manifestsURL,omitempty"`
+	// PodCIDR assigned to the Pods by the CNI plugin, defaulting to the Pod CIDR of the network profile.
+	PodCIDR string `json:"podCIDR,omitempty"`
+	// Encapsulation of the traffic between the nodes: IPIP is supported by Calico only, and Geneve by Cilium only,
+	// while None requires the Pod CIDR to be routed by the nodes network.
+	// +kubebuilder:default=VXLAN
+	Encapsulation CNIEncapsulation `json:"encapsulation,omitempty"`
+	// ReconciliationMode defines whether the CNI resources are enforced, reverting any change,
+	// or installed once, then handed over to the tenant administrators. It defaults to Enforce.
+	ReconciliationMode AddonReconciliationMode `json:"reconciliationMode,omitempty"`
+}
+
+// StorageAddonSpec defines the storage resources installed in the Tenant Cluster: at least a template, or a StorageClass,
+// must be specified.
+type StorageAddonSpec struct {
+	// Template is the name of a storage template provided by the operator: a ConfigMap of the Kamaji namespace, labelled with
+	// addons.kamaji.clastix.io/storage-template, whose keys hold the multi-document YAML manifests, such as a CSI driver.
+	// The manifests are rendered as Go templates, with the {{ .Name }}, {{ .Namespace }}, and {{ .Version }} of the
+	// Tenant Control Plane, and the {{ .Parameters }} of the addon.
+	Template string `json:"template,omitempty"`
+	// Parameters are passed to the storage template, such as the credentials Secret name, or the cloud region.
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// StorageClasses created in the Tenant Cluster.
+	// +listType=map
+	// +listMapKey=name
+	StorageClasses []StorageClassSpec `json:"storageClasses,omitempty"`
+	// ReconciliationMode defines whether the storage resources are enforced, reverting any change,
+	// or installed once, then handed over to the tenant administrators. It defaults to Enforce.
+	ReconciliationMode AddonReconciliationMode `json:"reconciliationMode,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Immediate;WaitForFirstConsumer
+type VolumeBindingMode string
+
+// StorageClassSpec defines a StorageClass created in the Tenant Cluster.
+type StorageClassSpec struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Default marks the StorageClass as the default one of the Tenant Cluster, used by the claims with no class:
+	// at most one StorageClass can be the default.
+	Default bool `json:"default,omitempty"`
+	// Provisioner is the name of the CSI driver provisioning the volumes.
+	// +kubebuilder:validation:MinLength=1
+	Provisioner string `json:"provisioner"`
+	// Parameters of the provisioner.
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// ReclaimPolicy of the provisioned volumes, defaulting to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+	// VolumeBindingMode defines when the volumes are provisioned, defaulting to Immediate.
+	VolumeBindingMode    *VolumeBindingMode `json:"volumeBindingMode,omitempty"`
+	AllowVolumeExpansion *bool              `json:"allowVolumeExpansion,omitempty"`
+	MountOptions         []string           `json:"mountOptions,omitempty"`
+}
+
+type DNSStubZone struct {
+	// Zone is the DNS domain that must be delegated, such as corp.internal.
+	Zone string `json:"zone"`
+	// Servers is the list of DNS servers resolving the zone, such as IP or IP:port pairs.
+	// +kubebuilder:validation:MinItems=1
+	Servers []string `json:"servers"`
 }
 
 type ImageOverrideTrait struct {
@@ -183,6 +601,15 @@ type ExtraArgs []string
 type KonnectivityServerSpec struct {
 	// The port which Konnectivity server is listening to.
 	Port int32 `json:"port"`
+	// AdminPort is the port of the Konnectivity server admin endpoint, 8133 when not specified:
+	// change it to avoid collisions with the other containers of the Tenant Control Plane Pods, or with the host ones.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	AdminPort int32 `json:"adminPort,omitempty"`
+	// HealthPort is the port of the Konnectivity server liveness and readiness endpoints, 8134 when not specified.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	HealthPort int32 `json:"healthPort,omitempty"`
 	// Container image version of the Konnectivity server.
 	// +kubebuilder:default=v0.0.32
 	Version string `json:"version,omitempty"`
@@ -192,6 +619,94 @@ type KonnectivityServerSpec struct {
 	// Resources define the amount of CPU and memory to allocate to the Konnectivity server.
 	Resources *ComponentResourceRequirements `json:"resources,omitempty"`
 	ExtraArgs ExtraArgs                      `json:"extraArgs,omitempty"`
+	// AgentsPerServer is the number of agents a single Konnectivity server is expected to serve:
+	// a server runs for each Tenant Control Plane replica, and each agent connects to all of them.
+	// When the Tenant Cluster nodes exceed the overall capacity, the KonnectivityCapacityExceeded condition
+	// reports the number of replicas required to serve them.
+	// +kubebuilder:validation:Minimum=1
+	AgentsPerServer *int32 `json:"agentsPerServer,omitempty"`
+	// ServerCount is the number of Konnectivity servers announced to the agents, which keep connecting until reaching it:
+	// when not specified, it matches the desired Tenant Control Plane replicas.
+	// Set it when the replicas are managed otherwise, such as by an autoscaler, to the number of servers
+	// the agents are expected to connect to: changing it rolls out the Tenant Control Plane Pods.
+	// +kubebuilder:validation:Minimum=1
+	ServerCount *int32 `json:"serverCount,omitempty"`
+	// Service exposes the Konnectivity server to the agents with a dedicated Service, named after the Tenant Control Plane
+	// with the konnectivity suffix, rather than with an additional port of the API Server one:
+	// the agents dial its load balancer address, when available, unless the proxy server host is specified.
+	Service *KonnectivityServiceSpec `json:"service,omitempty"`
+	// Limits protect the Tenant Control Plane Pods from a Konnectivity server overloaded by the tunnels of the Tenant Cluster,
+	// such as thousands of concurrent port-forwards.
+	Limits *KonnectivityServerLimitsSpec `json:"limits,omitempty"`
+
+	// ImagePullPolicy of the Konnectivity server container, Always when not specified:
+	// IfNotPresent avoids pulling the image upon each restart of the Tenant Control Plane Pods.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Probes tunes the liveness probe of the Konnectivity server container, and enables its readiness probe.
+	Probes *KonnectivityServerProbesSpec `json:"probes,omitempty"`
+	// Deployment runs the Konnectivity server in its own Deployment, named after the Tenant Control Plane with the
+	// konnectivity-server suffix, rather than as a sidecar container of the Tenant Control Plane Pods: the servers are
+	// scaled, and restarted, independently of the API Server, which reaches them through the Service with the same name,
+	// using the http-connect mode over mutual TLS regardless of the declared one.
+	// The agents connect to the servers through the dedicated Service, which is required.
+	Deployment *KonnectivityServerDeploymentSpec `json:"deployment,omitempty"`
+}
+
+type KonnectivityServerDeploymentSpec struct {
+	// Replicas is the number of the Konnectivity servers, announced to the agents unless the server count is specified.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas,omitempty"`
+}
+
+type KonnectivityServerProbesSpec struct {
+	// Liveness overrides the timings of the liveness probe, checking the health endpoint of the server:
+	// the unspecified ones keep their defaults, that is an initial delay of 30 seconds, a timeout of 60 seconds,
+	// a period of 10 seconds, and a failure threshold of 3.
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+	// Readiness enables the readiness probe of the server container, checking its health endpoint:
+	// the server readiness one is not used, since it fails until an agent is connected, use the readiness gate instead.
+	// The unspecified timings keep the Kubernetes defaults.
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+}
+
+// ProbeSpec defines the timings of a container probe.
+type ProbeSpec struct {
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+type KonnectivityServerLimitsSpec struct {
+	// Memory is the limit of the Konnectivity server container, bounding the buffers of the concurrent tunnels:
+	// once exceeded, only the server is restarted, rather than the whole Tenant Control Plane Pod.
+	// It's ignored when the server resources already declare a memory limit.
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// FrontendKeepaliveTime is the interval of the keepalive pings sent by the server to the API Server connections:
+	// the idle connections not answering them are reaped, along with their tunnels.
+	FrontendKeepaliveTime *metav1.Duration `json:"frontendKeepaliveTime,omitempty"`
+	// RestartThreshold is the number of restarts of the Konnectivity server container, due to exceeding its memory,
+	// reported by the KonnectivityServerOverloaded condition.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	RestartThreshold int32 `json:"restartThreshold,omitempty"`
+}
+
+type KonnectivityServiceSpec struct {
+	AdditionalMetadata AdditionalMetadata `json:"additionalMetadata,omitempty"`
+	// ServiceType allows specifying how to expose the Konnectivity server.
+	ServiceType ServiceType `json:"serviceType"`
+	// Port of the Service, defaulting to the Konnectivity server one: it's used as node port too, with the NodePort type.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port                int32 `json:"port,omitempty"`
+	ServiceTopologySpec `json:",inline"`
 }
 
 type KonnectivityAgentSpec struct {
@@ -202,6 +717,37 @@ type KonnectivityAgentSpec struct {
 	// +kubebuilder:default=v0.0.32
 	Version   string    `json:"version,omitempty"`
 	ExtraArgs ExtraArgs `json:"extraArgs,omitempty"`
+	// ProxyServerHost is the hostname, or the IP address, dialled by the agents to reach the Konnectivity server,
+	// overriding the Tenant Control Plane address in split-horizon DNS setups.
+	// Since the Konnectivity server presents the API Server certificate, it's added to its Subject Alternative Names.
+	ProxyServerHost string `json:"proxyServerHost,omitempty"`
+	// ProxyServerPort is the port dialled by the agents to reach the Konnectivity server,
+	// overriding the server one, such as when it's translated by a load balancer.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ProxyServerPort int32 `json:"proxyServerPort,omitempty"`
+	// Tolerations of the Konnectivity agent Pods, replacing the default CriticalAddonsOnly one:
+	// declare them to schedule the agents on the tainted nodes, such as the edge ones.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector of the Konnectivity agent Pods, replacing the default kubernetes.io/os=linux one.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity of the Konnectivity agent Pods.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PriorityClassName of the Konnectivity agent Pods.
+	// +kubebuilder:default=system-cluster-critical
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// ImagePullSecrets are the Secrets used to pull the agent image from a private registry:
+	// they must be available in the kube-system namespace of the Tenant Cluster.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// AdminPort is the port of the Konnectivity agent admin endpoint, 8133 when not specified.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	AdminPort int32 `json:"adminPort,omitempty"`
+	// HealthPort is the port of the Konnectivity agent liveness endpoint, 8134 when not specified.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	HealthPort int32 `json:"healthPort,omitempty"`
 }
 
 // KonnectivitySpec defines the spec for Konnectivity.
@@ -210,18 +756,288 @@ type KonnectivitySpec struct {
 	KonnectivityServerSpec KonnectivityServerSpec `json:"server,omitempty"`
 	// +kubebuilder:default={version:"v0.0.32",image:"registry.k8s.io/kas-network-proxy/proxy-agent"}
 	KonnectivityAgentSpec KonnectivityAgentSpec `json:"agent,omitempty"`
+	// TLS hardens the connections between the Konnectivity server and the agents,
+	// requiring the version 0.0.32, or greater, for both of them.
+	TLS *KonnectivityTLSSpec `json:"tls,omitempty"`
+	// Mode is the protocol used by the API Server to reach the Konnectivity server: grpc, over a Unix Domain Socket shared by the containers,
+	// or http-connect, over a TCP connection secured with mutual TLS, using dedicated proxy certificates.
+	// The agents connect to the server using gRPC regardless of the mode.
+	// +kubebuilder:default=grpc
+	Mode KonnectivityMode `json:"mode,omitempty"`
+	// AggregatorRouting routes the requests to the aggregated APIs, such as the metrics-server, to the endpoints
+	// of the extension API servers, rather than to their Service IP, through the Konnectivity tunnel:
+	// the extension API servers running on the tenant worker nodes are otherwise unreachable from the API Server.
+	// Changing it rolls out the Tenant Control Plane Pods.
+	// +kubebuilder:default=true
+	AggregatorRouting *bool `json:"aggregatorRouting,omitempty"`
+	// Fallback switches the API Server to the direct egress when no Konnectivity agent is available for longer than the threshold,
+	// restoring the tunnel once the agents are back: enable it only if the API Server can reach the worker nodes directly.
+	// Each switch rolls out the Tenant Control Plane Pods.
+	Fallback *KonnectivityFallbackSpec `json:"fallback,omitempty"`
+	// LeaseCounting lets the agents discover the number of the running Konnectivity servers from their Leases,
+	// rather than the static server count: each server holds a Lease in the kube-system namespace of the Tenant Cluster,
+	// renewed until it's running, keeping the agents connected to all of them during the scale events.
+	// It requires the version 0.30.0, or greater, for both the server and the agent, and cannot be used along with the server count.
+	LeaseCounting *KonnectivityLeaseCountingSpec `json:"leaseCounting,omitempty"`
+	// ReadinessGate adds the kamaji.clastix.io/konnectivity-agents-connected readiness gate to the Tenant Control Plane Pods:
+	// a Pod is Ready, thus added to the Service endpoints, only once its Konnectivity server is connected to the agents,
+	// preventing the exec, attach, and logs requests from failing right after a rollout.
+	// The gate is satisfied with no agents to wait for, such as when the Tenant Cluster has no nodes yet.
+	ReadinessGate bool `json:"readinessGate,omitempty"`
+	// ReconciliationMode defines whether the agent resources in the Tenant Cluster are enforced, reverting any change,
+	// or installed once, then handed over to the tenant administrators: the changes to the Tenant Control Plane,
+	// such as the server address, or the agent version, are not propagated to them anymore. It defaults to Enforce.
+	ReconciliationMode AddonReconciliationMode `json:"reconciliationMode,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=grpc;http-connect
+type KonnectivityMode string
+
+const (
+	KonnectivityModeGRPC        KonnectivityMode = "grpc"
+	KonnectivityModeHTTPConnect KonnectivityMode = "http-connect"
+)
+
+type KonnectivityFallbackSpec struct {
+	// UnavailabilityThreshold is the time the agents must be unavailable before switching to the direct egress.
+	// +kubebuilder:default="5m"
+	UnavailabilityThreshold metav1.Duration `json:"unavailabilityThreshold,omitempty"`
+}
+
+type KonnectivityLeaseCountingSpec struct {
+	// LeaseDuration is the time a Konnectivity server Lease is valid since its last renewal:
+	// the servers not renewing it are no longer counted by the agents.
+	// +kubebuilder:default="30s"
+	LeaseDuration metav1.Duration `json:"leaseDuration,omitempty"`
+	// RenewalInterval is the interval between the renewals of the Konnectivity server Lease, lower than its duration.
+	// +kubebuilder:default="15s"
+	RenewalInterval metav1.Duration `json:"renewalInterval,omitempty"`
+}
+
+type KonnectivityTLSSpec struct {
+	// CipherSuites is the list of the allowed TLS 1.2 cipher suites, using the Go names, such as TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:
+	// the insecure ones are refused, the TLS 1.3 ones are not configurable.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// MinVersion is the minimum TLS version accepted by the server and the agents.
+	// +kubebuilder:validation:Enum=VersionTLS12;VersionTLS13
+	MinVersion string `json:"minVersion,omitempty"`
 }
 
 // AddonsSpec defines the enabled addons and their features.
 type AddonsSpec struct {
 	// Enables the DNS addon in the Tenant Cluster.
 	// The registry and the tag are configurable, the image is hard-coded to `coredns`.
-	CoreDNS *AddonSpec `json:"coreDNS,omitempty"`
+	CoreDNS *CoreDNSAddonSpec `json:"coreDNS,omitempty"`
 	// Enables the Konnectivity addon in the Tenant Cluster, required if the worker nodes are in a different network.
 	Konnectivity *KonnectivitySpec `json:"konnectivity,omitempty"`
 	// Enables the kube-proxy addon in the Tenant Cluster.
 	// The registry and the tag are configurable, the image is hard-coded to `kube-proxy`.
-	KubeProxy *AddonSpec `json:"kubeProxy,omitempty"`
+	KubeProxy *KubeProxyAddonSpec `json:"kubeProxy,omitempty"`
+	// KonnectivityRemoval defines how the Konnectivity agent resources are removed from the Tenant Cluster once the addon is disabled:
+	// removing them breaks the exec, attach, and logs requests until the API Server can reach the worker nodes directly.
+	// When not specified, the resources are removed immediately.
+	KonnectivityRemoval *AddonRemovalPolicy `json:"konnectivityRemoval,omitempty"`
+	// Enables the cert-manager addon in the Tenant Cluster, installed from its release manifests:
+	// the API Server reaches the cert-manager webhook through the Konnectivity tunnel, which must be enabled.
+	CertManager *CertManagerAddonSpec `json:"certManager,omitempty"`
+	// Enables the CNI addon in the Tenant Cluster, installing the chosen Container Network Interface plugin
+	// from its release manifests: the nodes joining the Tenant Cluster get Ready with no further step.
+	CNI *CNIAddonSpec `json:"cni,omitempty"`
+	// Enables the storage addon in the Tenant Cluster, installing a CSI driver from the templates provided by the operator,
+	// and the StorageClass objects: the stateful workloads can claim volumes right after the provisioning.
+	Storage *StorageAddonSpec `json:"storage,omitempty"`
+	// Manifests applies the user-provided manifests, stored in ConfigMaps or Secrets of the Tenant Control Plane namespace,
+	// to the Tenant Cluster, such as the CNI configurations, the RBAC rules, or the policies.
+	// +listType=map
+	// +listMapKey=name
+	Manifests []ManifestsAddonSpec `json:"manifests,omitempty"`
+}
+
+// ManifestsAddonSpec references the manifests applied to the Tenant Cluster: each key of the ConfigMap, or of the Secret,
+// holds a multi-document YAML, applied in the order of the keys.
+type ManifestsAddonSpec struct {
+	// Name of the addon, labelling the applied resources.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// ConfigMapRef is the ConfigMap storing the manifests.
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+	// SecretRef is the Secret storing the manifests, such as when they contain credentials.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// ReconciliationMode defines whether the resources are server-side applied continuously, reverting any change
+	// and pruning the ones removed from the manifests, or created once, then handed over to the tenant administrators.
+	// It defaults to Enforce.
+	ReconciliationMode AddonReconciliationMode `json:"reconciliationMode,omitempty"`
+}
+
+// AddonRemovalPolicy defines the safeguards applied before removing the addon resources from the Tenant Cluster.
+type AddonRemovalPolicy struct {
+	// GracePeriod is the time to wait since the addon has been disabled before removing its resources.
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	// RequireConfirmation defers the removal until the Tenant Control Plane is annotated with
+	// kamaji.clastix.io/confirm-konnectivity-removal=true.
+	RequireConfirmation bool `json:"requireConfirmation,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Spread;BinPack
+
+type DataStoreSchedulingPolicy string
+
+var (
+	DataStoreSchedulingSpread  DataStoreSchedulingPolicy = "Spread"
+	DataStoreSchedulingBinPack DataStoreSchedulingPolicy = "BinPack"
+)
+
+// +kubebuilder:validation:Enum=Warn;ReadOnly
+
+type DataStoreQuotaEnforcement string
+
+var (
+	DataStoreQuotaEnforcementWarn     DataStoreQuotaEnforcement = "Warn"
+	DataStoreQuotaEnforcementReadOnly DataStoreQuotaEnforcement = "ReadOnly"
+)
+
+// StandbyDataStoreSpec defines the secondary DataStore receiving the snapshots of the Tenant Control Plane data.
+type StandbyDataStoreSpec struct {
+	// DataStore is the name of the standby DataStore, which must use the same driver of the Tenant Control Plane one.
+	DataStore string `json:"dataStore"`
+	// +kubebuilder:default="1h"
+	// Interval between two snapshots shipped to the standby DataStore.
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// DataStoreQuotaSpec defines the storage quota of the Tenant Control Plane on a shared etcd DataStore.
+type DataStoreQuotaSpec struct {
+	// Size is the maximum amount of data the Tenant Control Plane can store in the DataStore,
+	// computed as the sum of the keys and values size under its prefix.
+	Size resource.Quantity `json:"size"`
+	// +kubebuilder:default=Warn
+	// Enforcement defines the action taken when the quota is exceeded:
+	// Warn reports the DataStoreQuotaExceeded condition only,
+	// ReadOnly revokes the write permission of the Tenant Control Plane until the quota is raised.
+	Enforcement DataStoreQuotaEnforcement `json:"enforcement,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Retain;Delete
+
+type DataStoreRetentionPolicy string
+
+var (
+	DataStoreRetentionPolicyRetain DataStoreRetentionPolicy = "Retain"
+	DataStoreRetentionPolicyDelete DataStoreRetentionPolicy = "Delete"
+)
+
+// KubeconfigSpec defines the options for the kubeconfig Secrets generated for the Tenant Control Plane.
+type KubeconfigSpec struct {
+	// DisableLegacyFormats rewrites the kubeconfig Secrets to the canonical format, removing the keys of the deprecated layouts
+	// left over by the previous versions: the removed keys are reported in the kubeconfig status until the migration is completed.
+	DisableLegacyFormats bool `json:"disableLegacyFormats,omitempty"`
+	// AdminSecretTargets defines the additional Secrets the admin kubeconfig is copied to, even in a different namespace.
+	// The target namespace must opt-in by listing the Tenant Control Plane namespace, or the wildcard "*",
+	// in the comma separated annotation kamaji.clastix.io/kubeconfig-source-namespaces.
+	// Since owner references cannot cross namespaces, the copies are tracked by labels and deleted along with the Tenant Control Plane.
+	AdminSecretTargets []KubeconfigSecretTarget `json:"adminSecretTargets,omitempty"`
+	// AdminExternalTargets defines the external secret stores the admin kubeconfig is pushed to, such as a Vault KV path,
+	// for the consumers outside the management cluster: the kubeconfig is pushed again upon its rotation, and deleted
+	// from the store once the target is removed, or along with the Tenant Control Plane.
+	// +listType=map
+	// +listMapKey=name
+	AdminExternalTargets []KubeconfigExternalTarget `json:"adminExternalTargets,omitempty"`
+	// Users enables the generation of a kubeconfig for the human users, stored in its own Secret: it has no embedded
+	// client certificate, and authenticates against the OIDC issuer of the API Server using the kubelogin plugin.
+	Users *UsersKubeconfigSpec `json:"users,omitempty"`
+}
+
+// UsersKubeconfigSpec defines the OIDC settings of the kubeconfig handed out to the human users.
+type UsersKubeconfigSpec struct {
+	// IssuerURL of the OIDC provider, defaulting to the --oidc-issuer-url argument of the API Server.
+	IssuerURL string `json:"issuerURL,omitempty"`
+	// ClientID of the OIDC provider, defaulting to the --oidc-client-id argument of the API Server.
+	ClientID string `json:"clientID,omitempty"`
+	// ExtraScopes are the additional scopes requested to the OIDC provider, such as email, or groups.
+	ExtraScopes []string `json:"extraScopes,omitempty"`
+}
+
+// KubeconfigSecretTarget defines the placement of a copy of a kubeconfig Secret.
+type KubeconfigSecretTarget struct {
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Name of the Secret, if empty the name of the source kubeconfig Secret is used.
+	Name string `json:"name,omitempty"`
+}
+
+// KubeconfigExternalTarget defines an external secret store the kubeconfig is pushed to: exactly one store must be specified.
+type KubeconfigExternalTarget struct {
+	// Name identifies the target.
+	// +kubebuilder:validation:MinLength=1
+	Name              string                             `json:"name"`
+	Vault             *VaultKubeconfigTarget             `json:"vault,omitempty"`
+	AWSSecretsManager *AWSSecretsManagerKubeconfigTarget `json:"awsSecretsManager,omitempty"`
+	AzureKeyVault     *AzureKeyVaultKubeconfigTarget     `json:"azureKeyVault,omitempty"`
+}
+
+// VaultKubeconfigTarget defines the path of a HashiCorp Vault KV version 2 secrets engine the kubeconfig is written to,
+// with the same keys of the kubeconfig Secret, such as admin.conf.
+type VaultKubeconfigTarget struct {
+	// Address of the Vault server, such as https://vault.example.com:8200.
+	Address string `json:"address"`
+	// Namespace of the Vault Enterprise server.
+	Namespace string `json:"namespace,omitempty"`
+	// Mount path of the KV version 2 secrets engine.
+	// +kubebuilder:default=secret
+	Mount string `json:"mount,omitempty"`
+	// Path of the secret, relative to the mount path.
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+	// TokenSecretRef references the Secret, in the Tenant Control Plane namespace, holding the Vault token in the token key.
+	TokenSecretRef corev1.LocalObjectReference `json:"tokenSecretRef"`
+}
+
+// AWSSecretsManagerKubeconfigTarget defines the AWS Secrets Manager secret the kubeconfig is written to, created if missing:
+// the secret value is a JSON object with the same keys of the kubeconfig Secret, such as admin.conf.
+type AWSSecretsManagerKubeconfigTarget struct {
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+	// SecretName is the name, or the ARN, of the secret.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+	// CredentialsSecretRef references the Secret, in the Tenant Control Plane namespace, holding the access-key-id,
+	// secret-access-key, and the optional session-token keys: the credentials of the operator are never used.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// AzureKeyVaultKubeconfigTarget defines the Azure Key Vault secret the admin.conf kubeconfig is written to.
+type AzureKeyVaultKubeconfigTarget struct {
+	// VaultURL of the Key Vault, such as https://example.vault.azure.net.
+	VaultURL string `json:"vaultURL"`
+	// SecretName is the name of the Key Vault secret.
+	// +kubebuilder:validation:Pattern=`^[0-9a-zA-Z-]{1,127}$`
+	SecretName string `json:"secretName"`
+	// CredentialsSecretRef references the Secret, in the Tenant Control Plane namespace, holding the tenant-id, client-id,
+	// and client-secret keys of the service principal allowed to set the Key Vault secrets.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// DataStoreMigrationSpec defines the rate limits, and the scheduling, of the migration to another DataStore.
+type DataStoreMigrationSpec struct {
+	// KeysPerSecond limits the number of keys, or rows, copied per second to the target DataStore.
+	// +kubebuilder:validation:Minimum=1
+	KeysPerSecond *int32 `json:"keysPerSecond,omitempty"`
+	// BytesPerSecond limits the amount of data copied per second to the target DataStore, such as 10Mi.
+	BytesPerSecond *resource.Quantity `json:"bytesPerSecond,omitempty"`
+	// Window restricts the start of the migration to a daily time window: a migration already started is not interrupted
+	// once the window is closed.
+	Window *DataStoreMigrationWindow `json:"window,omitempty"`
+}
+
+// DataStoreMigrationWindow defines a daily time window, in UTC.
+type DataStoreMigrationWindow struct {
+	// Start is the time of the day the window opens at, in the HH:MM format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration of the window, up to 24 hours.
+	Duration metav1.Duration `json:"duration"`
 }
 
 // TenantControlPlaneSpec defines the desired state of TenantControlPlane.
@@ -229,14 +1045,162 @@ type TenantControlPlaneSpec struct {
 	// DataStore allows to specify a DataStore that should be used to store the Kubernetes data for the given Tenant Control Plane.
 	// This parameter is optional and acts as an override over the default one which is used by the Kamaji Operator.
 	// Migration from a different DataStore to another one is not yet supported and the reconciliation will be blocked.
-	DataStore    string       `json:"dataStore,omitempty"`
-	ControlPlane ControlPlane `json:"controlPlane"`
+	DataStore string `json:"dataStore,omitempty"`
+	// DataStoreSelector allows to constrain the DataStore candidates when no DataStore has been specified:
+	// the DataStore is automatically selected among the matching ones according to the scheduling policy.
+	// When no selector is specified, the default DataStore used by the Kamaji Operator is selected.
+	DataStoreSelector *metav1.LabelSelector `json:"dataStoreSelector,omitempty"`
+	// +kubebuilder:default=Spread
+	// DataStoreSchedulingPolicy defines how the DataStore is selected among the candidates matching the selector:
+	// Spread selects the DataStore with the lowest number of Tenant Control Planes, BinPack the one with the highest.
+	DataStoreSchedulingPolicy DataStoreSchedulingPolicy `json:"dataStoreSchedulingPolicy,omitempty"`
+	// DataStoreQuota limits the amount of data the Tenant Control Plane can store in a shared etcd DataStore,
+	// preventing a noisy tenant from filling it up.
+	DataStoreQuota *DataStoreQuotaSpec `json:"dataStoreQuota,omitempty"`
+	// DataStoreZoneAffinity makes the API Server prefer the etcd endpoints in the same zone of the Tenant Control Plane,
+	// according to the DataStore endpoint zones, minimizing the cross-zone latency and egress cost:
+	// pin the Tenant Control Plane Pods to the zone with the affinity. Changing it rolls out the Tenant Control Plane Pods.
+	DataStoreZoneAffinity *DataStoreZoneAffinity `json:"dataStoreZoneAffinity,omitempty"`
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`
+	// DataStoreSchema overrides the name of the schema, or of the etcd prefix, used to store the Tenant Control Plane data,
+	// otherwise generated from its namespace and name: it allows adopting a pre-existing kine database.
+	// The value cannot be changed once the Tenant Control Plane has been created, and it must be unique across the
+	// Tenant Control Planes of the same DataStore.
+	DataStoreSchema string `json:"dataStoreSchema,omitempty"`
+	// DataStoreCredentials references a Secret, in the Tenant Control Plane namespace, providing the DataStore user
+	// and password with the DB_USER and DB_PASSWORD keys, rather than generating them: the user is expected to be provisioned,
+	// and removed, by an external process, thus Kamaji only creates the schema and grants the privileges.
+	// Supported by the MySQL and PostgreSQL drivers, it cannot be changed once the Tenant Control Plane has been created.
+	DataStoreCredentials *corev1.LocalObjectReference `json:"dataStoreCredentials,omitempty"`
+	// +kubebuilder:default=Delete
+	// DataStoreRetentionPolicy defines what happens to the Tenant Control Plane data upon its deletion:
+	// Delete drops the schema, or the etcd prefix, along with the users, Retain removes the users and privileges
+	// only, leaving the data intact for a later adoption by a Tenant Control Plane using the same DataStore schema.
+	DataStoreRetentionPolicy DataStoreRetentionPolicy `json:"dataStoreRetentionPolicy,omitempty"`
+	// StandbyDataStore declares a secondary DataStore, kept in sync with periodic snapshots of the Tenant Control Plane data,
+	// for disaster recovery purposes: setting the DataStore field to the standby one promotes it, with no data copy.
+	StandbyDataStore *StandbyDataStoreSpec `json:"standbyDataStore,omitempty"`
+	// DataStoreMigration throttles the migration of the data to another DataStore, and restricts its start to the off-peak hours,
+	// preventing the large Tenant Control Planes from saturating the shared databases.
+	DataStoreMigration *DataStoreMigrationSpec `json:"dataStoreMigration,omitempty"`
+	ControlPlane       ControlPlane            `json:"controlPlane"`
 	// Kubernetes specification for tenant control plane
 	Kubernetes KubernetesSpec `json:"kubernetes"`
 	// NetworkProfile specifies how the network is
 	NetworkProfile NetworkProfileSpec `json:"networkProfile,omitempty"`
 	// Addons contain which addons are enabled
 	Addons AddonsSpec `json:"addons,omitempty"`
+	// Kubeconfig defines the options for the generated kubeconfig Secrets.
+	Kubeconfig *KubeconfigSpec `json:"kubeconfig,omitempty"`
+	// Kubeadm defines the kubeadm phases performed in the Tenant Cluster.
+	Kubeadm *KubeadmSpec `json:"kubeadm,omitempty"`
+	// CleanupHooks are the external clean-up actions performed, in order, upon the Tenant Control Plane deletion,
+	// such as deregistering the tenant from the billing systems: the DataStore is released, and the finalizer removed,
+	// only once all of them are completed, or failed with the Ignore policy. They cannot be changed once the deletion started.
+	// +listType=map
+	// +listMapKey=name
+	CleanupHooks []CleanupHook `json:"cleanupHooks,omitempty"`
+	// ReadOnly rejects the changes requested to the Tenant Cluster, keeping the reads available, such as during incident freezes,
+	// or migrations: the requests performed by the Kubernetes components, such as the kubelets and the kube-system controllers,
+	// are still allowed, keeping the workloads running. The changes applied by Kamaji to the Tenant Cluster are rejected too.
+	ReadOnly bool `json:"readonly,omitempty"`
+}
+
+// CleanupHook is an external clean-up action, either a webhook call or a Job, performed upon the Tenant Control Plane deletion.
+type CleanupHook struct {
+	// Name identifies the hook in the status, and in the name of its Job.
+	// +kubebuilder:validation:MaxLength=24
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Webhook is called with a POST request, carrying the Tenant Control Plane namespace, name, and UID as JSON:
+	// any 2xx response completes the hook.
+	Webhook *CleanupHookWebhook `json:"webhook,omitempty"`
+	// Job is run in the Tenant Control Plane namespace, with the TENANT_CONTROL_PLANE_NAME and TENANT_CONTROL_PLANE_NAMESPACE
+	// environment variables: its completion completes the hook, and it's deleted afterwards. The image, and the ServiceAccount,
+	// must be allowed by the operator.
+	Job *CleanupHookJob `json:"job,omitempty"`
+	// Timeout is the time given to each attempt of the hook: the Job active deadline, or the webhook request,
+	// capped at 10 seconds since it's performed by the reconciliation.
+	// +kubebuilder:default="5m"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy defines what happens when an attempt fails, or times out: Fail retries the hook, blocking the deletion
+	// until it succeeds, Ignore reports the failure and proceeds with the deletion.
+	// +kubebuilder:default=Fail
+	FailurePolicy CleanupHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Fail;Ignore
+type CleanupHookFailurePolicy string
+
+const (
+	CleanupHookFailurePolicyFail   CleanupHookFailurePolicy = "Fail"
+	CleanupHookFailurePolicyIgnore CleanupHookFailurePolicy = "Ignore"
+)
+
+type CleanupHookWebhook struct {
+	// URL of the webhook, using the HTTPS scheme.
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+	// CABundle is the PEM encoded CA bundle used to verify the webhook certificate:
+	// when not specified, the system trust roots are used.
+	CABundle []byte `json:"caBundle,omitempty"`
+	// AuthorizationSecretRef references the key of a Secret, in the Tenant Control Plane namespace,
+	// whose value is sent as the Authorization header.
+	AuthorizationSecretRef *corev1.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
+}
+
+type CleanupHookJob struct {
+	// Image of the Job container.
+	Image string `json:"image"`
+	// Command of the Job container, overriding the image entrypoint.
+	Command []string `json:"command,omitempty"`
+	// Args of the Job container.
+	Args []string `json:"args,omitempty"`
+	// Env of the Job container.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// ServiceAccountName used by the Job Pod, in the Tenant Control Plane namespace.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+type DataStoreZoneAffinity struct {
+	// Zone of the Tenant Control Plane, matching the DataStore endpoint zones.
+	Zone string `json:"zone"`
+	// Policy defines how the endpoints are selected: Preferred lists the ones in the zone first, followed by the others
+	// for the failover, Required lists only the ones in the zone. When no endpoint belongs to the zone, all of them are used.
+	// +kubebuilder:default=Preferred
+	Policy DataStoreZoneAffinityPolicy `json:"policy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Preferred;Required
+type DataStoreZoneAffinityPolicy string
+
+const (
+	DataStoreZoneAffinityPreferred DataStoreZoneAffinityPolicy = "Preferred"
+	DataStoreZoneAffinityRequired  DataStoreZoneAffinityPolicy = "Required"
+)
+
+// KubeadmSpec defines the kubeadm phases performed in the Tenant Cluster.
+type KubeadmSpec struct {
+	// Enabled performs the kubeadm phases, such as the upload of the kubeadm and kubelet configurations, and the bootstrap token:
+	// when disabled, the Tenant Cluster is expected to be bootstrapped externally, such as with a GitOps tool from day zero.
+	// The control plane and its PKI are created anyway.
+	// +kubebuilder:default=true
+	Enabled *bool `json:"enabled,omitempty"`
+	// BootstrapToken manages the lifecycle of the bootstrap tokens used by the nodes joining the Tenant Cluster:
+	// the current one is published, along with the kubeadm join command, in the <name>-join-command Secret.
+	BootstrapToken *BootstrapTokenSpec `json:"bootstrapToken,omitempty"`
+}
+
+// BootstrapTokenSpec defines the rotation of the bootstrap tokens generated in the Tenant Cluster.
+type BootstrapTokenSpec struct {
+	// TTL of the generated bootstrap tokens: the expired ones are deleted by the token cleaner of the kube-controller-manager.
+	// +kubebuilder:default="24h0m0s"
+	TTL metav1.Duration `json:"ttl,omitempty"`
+	// RenewBefore is the time left before the expiration of the current bootstrap token to generate a new one:
+	// the previous token is still valid until its expiration, letting the in-flight provisioning complete.
+	// +kubebuilder:default="1h0m0s"
+	RenewBefore metav1.Duration `json:"renewBefore,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -6,13 +6,16 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/blang/semver"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,10 +26,12 @@ import (
 //+kubebuilder:webhook:path=/mutate-kamaji-clastix-io-v1alpha1-tenantcontrolplane,mutating=true,failurePolicy=fail,sideEffects=None,groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=create;update,versions=v1alpha1,name=mtenantcontrolplane.kb.io,admissionReviewVersions=v1
 //+kubebuilder:webhook:path=/validate-kamaji-clastix-io-v1alpha1-tenantcontrolplane,mutating=false,failurePolicy=fail,sideEffects=None,groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=create;update,versions=v1alpha1,name=vtenantcontrolplane.kb.io,admissionReviewVersions=v1
 
-func (in *TenantControlPlane) SetupWebhookWithManager(mgr ctrl.Manager, datastore string) error {
+func (in *TenantControlPlane) SetupWebhookWithManager(mgr ctrl.Manager, datastore string, ingressExposure bool, cleanupHookJobs CleanupHookJobPolicy) error {
 	validator := &tenantControlPlaneValidator{
 		client:           mgr.GetClient(),
 		defaultDatastore: datastore,
+		ingressExposure:  ingressExposure,
+		cleanupHookJobs:  cleanupHookJobs,
 		log:              mgr.GetLogger().WithName("tenantcontrolplane-webhook"),
 	}
 
@@ -37,26 +42,68 @@ func (in *TenantControlPlane) SetupWebhookWithManager(mgr ctrl.Manager, datastor
 		Complete()
 }
 
+// kubeletPort is the port the kubelet of the worker nodes listens to, which must be kept out of the NodePort range.
+const kubeletPort = 10250
+
 type tenantControlPlaneValidator struct {
 	client           client.Client
 	defaultDatastore string
-	log              logr.Logger
+	// ingressExposure reports whether the operator manages the Ingress objects.
+	ingressExposure bool
+	// cleanupHookJobs lists the images, and the ServiceAccounts, allowed for the clean-up hook Jobs.
+	cleanupHookJobs CleanupHookJobPolicy
+	log             logr.Logger
 }
 
-func (t *tenantControlPlaneValidator) Default(_ context.Context, obj runtime.Object) error {
+func (t *tenantControlPlaneValidator) Default(ctx context.Context, obj runtime.Object) error {
 	tcp, ok := obj.(*TenantControlPlane)
 	if !ok {
 		return fmt.Errorf("expected *kamajiv1alpha1.TenantControlPlane")
 	}
 
-	if len(tcp.Spec.DataStore) == 0 {
-		tcp.Spec.DataStore = t.defaultDatastore
+	if len(tcp.Spec.DataStore) > 0 {
+		return nil
+	}
+
+	if tcp.Spec.DataStoreSelector == nil {
+		dataStore, err := t.namespaceDefaultDataStore(ctx, tcp)
+		if err != nil {
+			return err
+		}
+
+		tcp.Spec.DataStore = dataStore
+
+		return nil
+	}
+
+	scheduler := &dataStoreScheduler{client: t.client}
+
+	dataStore, err := scheduler.Schedule(ctx, tcp)
+	if err != nil {
+		return errors.Wrap(err, "unable to schedule a DataStore")
 	}
 
+	tcp.Spec.DataStore = dataStore
+
 	return nil
 }
 
-func (t *tenantControlPlaneValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+// namespaceDefaultDataStore returns the DataStore the Tenant Control Plane namespace is annotated with,
+// letting the platform teams segment the tenants by namespace: otherwise, the Kamaji default one.
+func (t *tenantControlPlaneValidator) namespaceDefaultDataStore(ctx context.Context, tcp *TenantControlPlane) (string, error) {
+	namespace := &corev1.Namespace{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.GetNamespace()}, namespace); err != nil {
+		return "", fmt.Errorf("unable to retrieve the namespace for the default DataStore: %w", err)
+	}
+
+	if name := namespace.GetAnnotations()[DefaultDataStoreAnnotation]; len(name) > 0 {
+		return name, nil
+	}
+
+	return t.defaultDatastore, nil
+}
+
+func (t *tenantControlPlaneValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
 	tcp, ok := obj.(*TenantControlPlane)
 	if !ok {
 		return fmt.Errorf("expected *kamajiv1alpha1.TenantControlPlane")
@@ -82,6 +129,138 @@ func (t *tenantControlPlaneValidator) ValidateCreate(_ context.Context, obj runt
 		return err
 	}
 
+	if err = t.validateStreaming(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKubeletTLS(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateCoreDNS(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateUsersKubeconfig(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKubeconfigExternalTargets(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateLeaderElection(tcp); err != nil {
+		return err
+	}
+
+	if err = tcp.ValidateCleanupHooks(t.cleanupHookJobs); err != nil {
+		return err
+	}
+
+	if err = tcp.ValidateKubeletTLS(); err != nil {
+		return err
+	}
+
+	if err = t.validateKubeProxy(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateAddonsReconciliationMode(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateManifestsAddons(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateCertManager(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateCNI(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateStorageAddon(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateIngressExposure(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateBootstrapToken(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateDataStoreMigration(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKineCompaction(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKonnectivityTLS(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKonnectivityProxyServer(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKonnectivityLeaseCounting(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKonnectivityLimits(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKonnectivityPorts(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateKonnectivityStandalone(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateServiceNodePortRange(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateExternalTrafficPolicy(tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateDataStoreQuota(ctx, tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateDataStoreCredentials(ctx, nil, tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateDataStoreSchemaUniqueness(ctx, tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateStandbyDataStore(ctx, tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateDataStoreMaintenanceMode(ctx, nil, tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateDataStoreAllowedNamespaces(ctx, nil, tcp); err != nil {
+		return err
+	}
+
+	if err = t.validateDataStoreCapacity(ctx, nil, tcp); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -101,12 +280,173 @@ func (t *tenantControlPlaneValidator) ValidateUpdate(ctx context.Context, oldObj
 	if err := t.validateVersionUpdate(old, tcp); err != nil {
 		return err
 	}
+	if err := t.validateDeprecatedAPIs(old, tcp); err != nil {
+		return err
+	}
 	if err := t.validateDataStore(ctx, old, tcp); err != nil {
 		return err
 	}
+	if err := t.validateDataStoreSchema(old, tcp); err != nil {
+		return err
+	}
+	if err := t.validateDataStoreCredentials(ctx, old, tcp); err != nil {
+		return err
+	}
 	if err := t.validatePreferredKubeletAddressTypes(tcp.Spec.Kubernetes.Kubelet.PreferredAddressTypes); err != nil {
 		return err
 	}
+	if err := t.validateStreaming(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKubeletTLS(tcp); err != nil {
+		return err
+	}
+	if err := t.validateCoreDNS(tcp); err != nil {
+		return err
+	}
+	if err := t.validateUsersKubeconfig(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKubeconfigExternalTargets(tcp); err != nil {
+		return err
+	}
+	if err := t.validateLeaderElection(tcp); err != nil {
+		return err
+	}
+	if err := t.validateCleanupHooksUpdate(old, tcp); err != nil {
+		return err
+	}
+	if err := tcp.ValidateKubeletTLS(); err != nil {
+		return err
+	}
+	if err := t.validateKubeProxy(tcp); err != nil {
+		return err
+	}
+	if err := t.validateAddonsReconciliationMode(tcp); err != nil {
+		return err
+	}
+	if err := t.validateManifestsAddons(tcp); err != nil {
+		return err
+	}
+	if err := t.validateCertManager(tcp); err != nil {
+		return err
+	}
+	if err := t.validateCNI(tcp); err != nil {
+		return err
+	}
+	if err := t.validateStorageAddon(tcp); err != nil {
+		return err
+	}
+	if err := t.validateIngressExposure(tcp); err != nil {
+		return err
+	}
+	if err := t.validateBootstrapToken(tcp); err != nil {
+		return err
+	}
+	if err := t.validateDataStoreMigration(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKineCompaction(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKonnectivityTLS(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKonnectivityProxyServer(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKonnectivityLeaseCounting(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKonnectivityLimits(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKonnectivityPorts(tcp); err != nil {
+		return err
+	}
+	if err := t.validateKonnectivityStandalone(tcp); err != nil {
+		return err
+	}
+	if err := t.validateServiceNodePortRange(tcp); err != nil {
+		return err
+	}
+	if err := t.validateExternalTrafficPolicy(tcp); err != nil {
+		return err
+	}
+	if err := t.validateDataStoreQuota(ctx, tcp); err != nil {
+		return err
+	}
+	if err := t.validateStandbyDataStore(ctx, tcp); err != nil {
+		return err
+	}
+	if err := t.validateDataStoreMaintenanceMode(ctx, old, tcp); err != nil {
+		return err
+	}
+	if err := t.validateDataStoreAllowedNamespaces(ctx, old, tcp); err != nil {
+		return err
+	}
+	if err := t.validateDataStoreCapacity(ctx, old, tcp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateDataStoreAllowedNamespaces prevents using a DataStore, or a standby one, dedicated to other namespaces:
+// the Tenant Control Planes already placed are not affected by the changes of the allowed namespaces.
+func (t *tenantControlPlaneValidator) validateDataStoreAllowedNamespaces(ctx context.Context, old, tcp *TenantControlPlane) error {
+	var names []string
+
+	if len(tcp.Spec.DataStore) > 0 && (old == nil || old.Spec.DataStore != tcp.Spec.DataStore) {
+		names = append(names, tcp.Spec.DataStore)
+	}
+
+	if standby := tcp.Spec.StandbyDataStore; standby != nil && (old == nil || old.Spec.StandbyDataStore == nil || old.Spec.StandbyDataStore.DataStore != standby.DataStore) {
+		names = append(names, standby.DataStore)
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.GetNamespace()}, namespace); err != nil {
+		return fmt.Errorf("unable to retrieve the namespace for the DataStore allowed namespaces validation: %w", err)
+	}
+
+	for _, name := range names {
+		ds := &DataStore{}
+		if err := t.client.Get(ctx, types.NamespacedName{Name: name}, ds); err != nil {
+			return fmt.Errorf("unable to retrieve the DataStore for the allowed namespaces validation: %w", err)
+		}
+
+		allowed, err := ds.IsNamespaceAllowed(namespace)
+		if err != nil {
+			return err
+		}
+
+		if !allowed {
+			return fmt.Errorf("the DataStore %s is not allowed for the Tenant Control Planes of the namespace %s", name, tcp.GetNamespace())
+		}
+	}
+
+	return nil
+}
+
+// validateDataStoreMaintenanceMode prevents scheduling, or migrating, a Tenant Control Plane onto a DataStore in maintenance mode.
+func (t *tenantControlPlaneValidator) validateDataStoreMaintenanceMode(ctx context.Context, old, tcp *TenantControlPlane) error {
+	if len(tcp.Spec.DataStore) == 0 || (old != nil && old.Spec.DataStore == tcp.Spec.DataStore) {
+		return nil
+	}
+
+	ds := &DataStore{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, ds); err != nil {
+		return fmt.Errorf("unable to retrieve the DataStore for the maintenance mode validation: %w", err)
+	}
+
+	if ds.Spec.MaintenanceMode {
+		return fmt.Errorf("the DataStore %s is in maintenance mode and cannot accept new Tenant Control Planes", ds.GetName())
+	}
 
 	return nil
 }
@@ -129,6 +469,356 @@ func (t *tenantControlPlaneValidator) validatePreferredKubeletAddressTypes(addre
 	return nil
 }
 
+func (t *tenantControlPlaneValidator) validateStreaming(tcp *TenantControlPlane) error {
+	streaming := tcp.Spec.Kubernetes.Streaming
+	if streaming == nil {
+		return nil
+	}
+
+	if streaming.IdleTimeout != nil && streaming.IdleTimeout.Duration < 0 {
+		return fmt.Errorf("the streaming idle timeout cannot be negative")
+	}
+
+	if streaming.KeepaliveTime != nil && streaming.KeepaliveTime.Duration <= 0 {
+		return fmt.Errorf("the streaming keepalive time must be greater than zero")
+	}
+
+	if !streaming.WebSockets {
+		return nil
+	}
+
+	ver, err := semver.Make(t.normalizeKubernetesVersion(tcp.Spec.Kubernetes.Version))
+	if err != nil {
+		return errors.Wrap(err, "unable to parse the desired Kubernetes version")
+	}
+
+	if ver.LT(semver.MustParse("1.30.0")) {
+		return fmt.Errorf("the WebSocket streaming protocol requires Kubernetes v1.30 or greater, actually %s", ver.String())
+	}
+
+	return nil
+}
+
+func (t *tenantControlPlaneValidator) validateKubeletTLS(tcp *TenantControlPlane) error {
+	kubeletTLS := tcp.Spec.Kubernetes.Kubelet.TLS
+	if kubeletTLS == nil {
+		return nil
+	}
+
+	isEmpty := func(ref ContentRef) bool {
+		return len(ref.Content) == 0 && ref.SecretRef == nil
+	}
+
+	if ca := kubeletTLS.CertificateAuthority; ca != nil && isEmpty(*ca) {
+		return fmt.Errorf("the kubelet Certificate Authority requires either the bare content or a Secret reference")
+	}
+
+	if cc := kubeletTLS.ClientCertificate; cc != nil && (isEmpty(cc.Certificate) || isEmpty(cc.PrivateKey)) {
+		return fmt.Errorf("the kubelet client certificate requires both the certificate and the private key")
+	}
+
+	return nil
+}
+
+func (t *tenantControlPlaneValidator) validateCoreDNS(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.CoreDNS == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.CoreDNS.Validate()
+}
+
+func (t *tenantControlPlaneValidator) validateLeaderElection(tcp *TenantControlPlane) error {
+	if tcp.Spec.ControlPlane.Deployment.LeaderElection == nil {
+		return nil
+	}
+
+	return tcp.Spec.ControlPlane.Deployment.LeaderElection.Validate()
+}
+
+func (t *tenantControlPlaneValidator) validateKonnectivityTLS(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.Konnectivity.ValidateTLS()
+}
+
+func (t *tenantControlPlaneValidator) validateKonnectivityProxyServer(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.Konnectivity.ValidateProxyServer()
+}
+
+func (t *tenantControlPlaneValidator) validateKubeProxy(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.KubeProxy == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.KubeProxy.Validate(tcp.Spec.Kubernetes.Version)
+}
+
+func (t *tenantControlPlaneValidator) validateAddonsReconciliationMode(tcp *TenantControlPlane) error {
+	if coreDNS := tcp.Spec.Addons.CoreDNS; coreDNS != nil {
+		if err := coreDNS.ValidateReconciliationMode(); err != nil {
+			return fmt.Errorf("CoreDNS: %w", err)
+		}
+	}
+
+	if kubeProxy := tcp.Spec.Addons.KubeProxy; kubeProxy != nil {
+		if err := kubeProxy.ValidateReconciliationMode(); err != nil {
+			return fmt.Errorf("kube-proxy: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (t *tenantControlPlaneValidator) validateDataStoreMigration(tcp *TenantControlPlane) error {
+	if tcp.Spec.DataStoreMigration == nil {
+		return nil
+	}
+
+	return tcp.Spec.DataStoreMigration.Validate()
+}
+
+func (t *tenantControlPlaneValidator) validateKineCompaction(tcp *TenantControlPlane) error {
+	if tcp.Spec.ControlPlane.Kine == nil || tcp.Spec.ControlPlane.Kine.Compaction == nil {
+		return nil
+	}
+
+	return tcp.Spec.ControlPlane.Kine.Compaction.Validate()
+}
+
+func (t *tenantControlPlaneValidator) validateCertManager(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.CertManager == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.CertManager.Validate(tcp.Spec.Addons.Konnectivity)
+}
+
+func (t *tenantControlPlaneValidator) validateIngressExposure(tcp *TenantControlPlane) error {
+	if tcp.Spec.ControlPlane.Ingress == nil || t.ingressExposure {
+		return nil
+	}
+
+	return fmt.Errorf("the Ingress exposure is disabled by the operator, the Tenant Control Plane cannot declare an Ingress")
+}
+
+func (t *tenantControlPlaneValidator) validateBootstrapToken(tcp *TenantControlPlane) error {
+	if tcp.Spec.Kubeadm == nil || tcp.Spec.Kubeadm.BootstrapToken == nil {
+		return nil
+	}
+
+	return tcp.Spec.Kubeadm.BootstrapToken.Validate()
+}
+
+func (t *tenantControlPlaneValidator) validateCNI(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.CNI == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.CNI.Validate()
+}
+
+func (t *tenantControlPlaneValidator) validateStorageAddon(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.Storage == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.Storage.Validate()
+}
+
+func (t *tenantControlPlaneValidator) validateManifestsAddons(tcp *TenantControlPlane) error {
+	for i := range tcp.Spec.Addons.Manifests {
+		if err := tcp.Spec.Addons.Manifests[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *tenantControlPlaneValidator) validateKonnectivityLeaseCounting(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.Konnectivity.ValidateLeaseCounting()
+}
+
+func (t *tenantControlPlaneValidator) validateKonnectivityLimits(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.Konnectivity.ValidateLimits()
+}
+
+func (t *tenantControlPlaneValidator) validateKonnectivityPorts(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.Konnectivity.ValidatePorts()
+}
+
+func (t *tenantControlPlaneValidator) validateKonnectivityStandalone(tcp *TenantControlPlane) error {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return nil
+	}
+
+	return tcp.Spec.Addons.Konnectivity.ValidateStandalone()
+}
+
+// validateExternalTrafficPolicy ensures the policy is set only when the Service is reachable from outside the cluster.
+func (t *tenantControlPlaneValidator) validateExternalTrafficPolicy(tcp *TenantControlPlane) error {
+	service := tcp.Spec.ControlPlane.Service
+	if len(service.ExternalTrafficPolicy) == 0 || service.ServiceType != ServiceTypeClusterIP {
+		return nil
+	}
+
+	if tcp.Spec.NetworkProfile.AllowAddressAsExternalIP && len(tcp.Spec.NetworkProfile.Address) > 0 {
+		return nil
+	}
+
+	return fmt.Errorf("the external traffic policy requires either a LoadBalancer, or a NodePort, Service, or an external IP")
+}
+
+// validateServiceNodePortRange ensures the NodePort range is well-formed, and it doesn't overlap the kubelet port
+// of the worker nodes: the range is specified either with the structured field, or the extra arguments.
+func (t *tenantControlPlaneValidator) validateServiceNodePortRange(tcp *TenantControlPlane) error {
+	nodePortRange := tcp.Spec.NetworkProfile.ServiceNodePortRange
+	if len(nodePortRange) == 0 {
+		return nil
+	}
+
+	if extraArgs := tcp.Spec.ControlPlane.Deployment.ExtraArgs; extraArgs != nil {
+		for _, arg := range extraArgs.APIServer {
+			if strings.HasPrefix(arg, "--service-node-port-range") {
+				return fmt.Errorf("the service node port range cannot be specified both with the network profile and the API Server extra arguments")
+			}
+		}
+	}
+
+	portRange, err := utilnet.ParsePortRange(nodePortRange)
+	if err != nil {
+		return fmt.Errorf("the service node port range is not valid: %w", err)
+	}
+
+	if portRange.Base == 0 || portRange.Size < 2 {
+		return fmt.Errorf("the service node port range %s must not include the port 0, and its minimum must be lower than the maximum", nodePortRange)
+	}
+
+	if portRange.Contains(kubeletPort) {
+		return fmt.Errorf("the service node port range %s cannot include the kubelet port %d", nodePortRange, kubeletPort)
+	}
+
+	return nil
+}
+
+func (t *tenantControlPlaneValidator) validateUsersKubeconfig(tcp *TenantControlPlane) error {
+	if tcp.Spec.Kubeconfig == nil || tcp.Spec.Kubeconfig.Users == nil {
+		return nil
+	}
+
+	if issuerURL, clientID := tcp.UsersKubeconfigOIDC(); len(issuerURL) == 0 || len(clientID) == 0 {
+		return fmt.Errorf("the users kubeconfig requires the OIDC issuer URL and client ID, either specified or configured in the API Server arguments")
+	}
+
+	return nil
+}
+
+func (t *tenantControlPlaneValidator) validateKubeconfigExternalTargets(tcp *TenantControlPlane) error {
+	if tcp.Spec.Kubeconfig == nil {
+		return nil
+	}
+
+	for i := range tcp.Spec.Kubeconfig.AdminExternalTargets {
+		if err := tcp.Spec.Kubeconfig.AdminExternalTargets[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *tenantControlPlaneValidator) validateDataStoreQuota(ctx context.Context, tcp *TenantControlPlane) error {
+	if tcp.Spec.DataStoreQuota == nil {
+		return nil
+	}
+
+	if tcp.Spec.DataStoreQuota.Size.Sign() <= 0 {
+		return fmt.Errorf("the DataStore quota size must be greater than zero")
+	}
+
+	ds := &DataStore{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, ds); err != nil {
+		return fmt.Errorf("unable to retrieve the DataStore for the quota validation: %w", err)
+	}
+
+	if ds.Spec.Driver != EtcdDriver {
+		return fmt.Errorf("the DataStore quota is supported only by the etcd driver")
+	}
+
+	return nil
+}
+
+// validateDataStoreCapacity prevents placing, or migrating, a Tenant Control Plane onto a DataStore which reached its maximum number of tenants.
+func (t *tenantControlPlaneValidator) validateDataStoreCapacity(ctx context.Context, old, tcp *TenantControlPlane) error {
+	if len(tcp.Spec.DataStore) == 0 || (old != nil && old.Spec.DataStore == tcp.Spec.DataStore) {
+		return nil
+	}
+
+	ds := &DataStore{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, ds); err != nil {
+		return fmt.Errorf("unable to retrieve the DataStore for the capacity validation: %w", err)
+	}
+
+	if ds.Spec.MaxTenants == nil {
+		return nil
+	}
+
+	usage, err := dataStoreUsage(ctx, t.client, tcp)
+	if err != nil {
+		return err
+	}
+
+	if usage[ds.GetName()] >= int(*ds.Spec.MaxTenants) {
+		return fmt.Errorf("the DataStore %s reached its maximum number of Tenant Control Planes (%d)", ds.GetName(), *ds.Spec.MaxTenants)
+	}
+
+	return nil
+}
+
+// validateDeprecatedAPIs refuses the upgrade to a Kubernetes release removing the deprecated APIs still requested
+// by the Tenant Cluster clients, unless explicitly ignored.
+func (t *tenantControlPlaneValidator) validateDeprecatedAPIs(oldObj, newObj *TenantControlPlane) error {
+	if oldObj.Spec.Kubernetes.Version == newObj.Spec.Kubernetes.Version || newObj.GetAnnotations()[IgnoreDeprecatedAPIsAnnotation] == "true" {
+		return nil
+	}
+
+	ver, err := semver.Make(t.normalizeKubernetesVersion(newObj.Spec.Kubernetes.Version))
+	if err != nil {
+		return errors.Wrap(err, "unable to parse the desired Kubernetes version")
+	}
+
+	removed := newObj.DeprecatedAPIsRemovedBy(ver)
+	if len(removed) == 0 {
+		return nil
+	}
+
+	apis := make([]string, 0, len(removed))
+	for _, usage := range removed {
+		apis = append(apis, fmt.Sprintf("%s (removed in %s)", usage.String(), usage.RemovedRelease))
+	}
+
+	return fmt.Errorf("the Kubernetes version %s removes the deprecated APIs still in use: %s; annotate with %s=true to upgrade anyway", newObj.Spec.Kubernetes.Version, strings.Join(apis, ", "), IgnoreDeprecatedAPIsAnnotation)
+}
+
 func (t *tenantControlPlaneValidator) validateVersionUpdate(oldObj, newObj *TenantControlPlane) error {
 	oldVer, oldErr := semver.Make(t.normalizeKubernetesVersion(oldObj.Spec.Kubernetes.Version))
 	if oldErr != nil {
@@ -157,6 +847,127 @@ func (t *tenantControlPlaneValidator) validateVersionUpdate(oldObj, newObj *Tena
 	return nil
 }
 
+func (t *tenantControlPlaneValidator) validateDataStoreSchema(oldObj, tcp *TenantControlPlane) error {
+	if oldObj.Spec.DataStoreSchema != tcp.Spec.DataStoreSchema {
+		return fmt.Errorf("the DataStore schema is immutable, actually %q", oldObj.Spec.DataStoreSchema)
+	}
+
+	return nil
+}
+
+// validateCleanupHooksUpdate validates the changed clean-up hooks only, allowing the updates of the Tenant Control Planes
+// declaring Jobs no longer allowed by the operator, such as the finalizers removal: they're immutable upon the deletion,
+// since removing them would skip them.
+func (t *tenantControlPlaneValidator) validateCleanupHooksUpdate(old, tcp *TenantControlPlane) error {
+	if reflect.DeepEqual(old.Spec.CleanupHooks, tcp.Spec.CleanupHooks) {
+		return nil
+	}
+
+	if old.GetDeletionTimestamp() != nil {
+		return fmt.Errorf("the clean-up hooks are immutable once the TenantControlPlane is being deleted")
+	}
+
+	return tcp.ValidateCleanupHooks(t.cleanupHookJobs)
+}
+
+// validateDataStoreSchemaUniqueness prevents a Tenant Control Plane from claiming the schema, or the etcd prefix,
+// of another one on the same DataStore, either overridden, generated, or already provisioned: it would grant access
+// to its data, and destroy it upon the deletion.
+func (t *tenantControlPlaneValidator) validateDataStoreSchemaUniqueness(ctx context.Context, tcp *TenantControlPlane) error {
+	schema := tcp.Spec.DataStoreSchema
+	if len(schema) == 0 {
+		schema = fmt.Sprintf("%s_%s", tcp.GetNamespace(), tcp.GetName())
+	}
+
+	tcpList := &TenantControlPlaneList{}
+	if err := t.client.List(ctx, tcpList); err != nil {
+		return errors.Wrap(err, "unable to list the TenantControlPlane objects")
+	}
+
+	for _, other := range tcpList.Items {
+		if other.GetNamespace() == tcp.GetNamespace() && other.GetName() == tcp.GetName() {
+			continue
+		}
+
+		if other.Spec.DataStore != tcp.Spec.DataStore && other.Status.Storage.DataStoreName != tcp.Spec.DataStore {
+			continue
+		}
+
+		used := other.Spec.DataStoreSchema
+		if len(used) == 0 {
+			used = fmt.Sprintf("%s_%s", other.GetNamespace(), other.GetName())
+		}
+
+		if schema == used || schema == other.Status.Storage.Setup.Schema {
+			return fmt.Errorf("the DataStore schema %q is already used by the TenantControlPlane %s/%s", schema, other.GetNamespace(), other.GetName())
+		}
+	}
+
+	return nil
+}
+
+// validateDataStoreCredentials ensures the user-supplied credentials are immutable, and supported by the DataStore driver:
+// the etcd one authenticates with the client certificates.
+func (t *tenantControlPlaneValidator) validateDataStoreCredentials(ctx context.Context, old, tcp *TenantControlPlane) error {
+	secretName := func(ref *corev1.LocalObjectReference) string {
+		if ref == nil {
+			return ""
+		}
+
+		return ref.Name
+	}
+
+	if old != nil && secretName(old.Spec.DataStoreCredentials) != secretName(tcp.Spec.DataStoreCredentials) {
+		return fmt.Errorf("the DataStore credentials are immutable, actually %q", secretName(old.Spec.DataStoreCredentials))
+	}
+
+	if tcp.Spec.DataStoreCredentials == nil || len(tcp.Spec.DataStore) == 0 {
+		return nil
+	}
+
+	if len(tcp.Spec.DataStoreCredentials.Name) == 0 {
+		return fmt.Errorf("the DataStore credentials Secret name cannot be empty")
+	}
+
+	ds := &DataStore{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, ds); err != nil {
+		return fmt.Errorf("unable to retrieve the DataStore for the credentials validation: %w", err)
+	}
+
+	if ds.Spec.Driver == EtcdDriver {
+		return fmt.Errorf("the DataStore credentials are not supported by the etcd driver")
+	}
+
+	return nil
+}
+
+// validateStandbyDataStore ensures the standby DataStore exists, and it shares the driver of the Tenant Control Plane one.
+func (t *tenantControlPlaneValidator) validateStandbyDataStore(ctx context.Context, tcp *TenantControlPlane) error {
+	if tcp.Spec.StandbyDataStore == nil {
+		return nil
+	}
+
+	standby, current := &DataStore{}, &DataStore{}
+
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.Spec.StandbyDataStore.DataStore}, standby); err != nil {
+		return fmt.Errorf("unable to retrieve the standby DataStore for validation: %w", err)
+	}
+
+	if len(tcp.Spec.DataStore) == 0 {
+		return nil
+	}
+
+	if err := t.client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, current); err != nil {
+		return fmt.Errorf("unable to retrieve the DataStore for the standby validation: %w", err)
+	}
+
+	if standby.Spec.Driver != current.Spec.Driver {
+		return fmt.Errorf("the standby DataStore must use the %s driver of the Tenant Control Plane DataStore", current.Spec.Driver)
+	}
+
+	return nil
+}
+
 func (t *tenantControlPlaneValidator) validateDataStore(ctx context.Context, oldObj, tcp *TenantControlPlane) error {
 	if oldObj.Spec.DataStore == tcp.Spec.DataStore {
 		return nil
@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTenantControlPlaneValidateDataStoreSchemaUniqueness(t *testing.T) {
+	tenant := func(namespace, name, dataStore, schema string) *TenantControlPlane {
+		return &TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       TenantControlPlaneSpec{DataStore: dataStore, DataStoreSchema: schema},
+		}
+	}
+
+	provisioned := tenant("other", "provisioned", "", "")
+	provisioned.Status.Storage.DataStoreName = "default"
+	provisioned.Status.Storage.Setup.Schema = "adopted"
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	validator := &tenantControlPlaneValidator{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			tenant("other", "generated", "default", ""),
+			tenant("other", "overridden", "default", "custom"),
+			tenant("other", "claimer", "default", "default_claimed"),
+			tenant("other", "elsewhere", "dedicated", "dedicated"),
+			provisioned,
+		).Build(),
+	}
+
+	tests := []struct {
+		name      string
+		tcp       *TenantControlPlane
+		expectErr bool
+	}{
+		{name: "unique generated schema", tcp: tenant("default", "tcp", "default", "")},
+		{name: "unique overridden schema", tcp: tenant("default", "tcp", "default", "unique")},
+		{name: "schema used on another DataStore", tcp: tenant("default", "tcp", "default", "dedicated")},
+		{name: "generated schema of another tenant", tcp: tenant("default", "tcp", "default", "other_generated"), expectErr: true},
+		{name: "overridden schema of another tenant", tcp: tenant("default", "tcp", "default", "custom"), expectErr: true},
+		{name: "provisioned schema of another tenant", tcp: tenant("default", "tcp", "default", "adopted"), expectErr: true},
+		{name: "generated schema overridden by another tenant", tcp: tenant("default", "claimed", "default", ""), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateDataStoreSchemaUniqueness(context.Background(), tt.tcp)
+			if tt.expectErr != (err != nil) {
+				t.Errorf("expected the error to be %t, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -87,10 +87,10 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&TenantControlPlane{}).SetupWebhookWithManager(mgr, "")
+	err = (&TenantControlPlane{}).SetupWebhookWithManager(mgr, "", true, CleanupHookJobPolicy{})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = (&DataStore{}).SetupWebhookWithManager(mgr)
+	err = (&DataStore{}).SetupWebhookWithManager(mgr, nil)
 	Expect(err).NotTo(HaveOccurred())
 
 	//+kubebuilder:scaffold:webhook
@@ -9,7 +9,8 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,6 +30,22 @@ func (in *APIServerCertificatesStatus) DeepCopy() *APIServerCertificatesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecretsManagerKubeconfigTarget) DeepCopyInto(out *AWSSecretsManagerKubeconfigTarget) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecretsManagerKubeconfigTarget.
+func (in *AWSSecretsManagerKubeconfigTarget) DeepCopy() *AWSSecretsManagerKubeconfigTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecretsManagerKubeconfigTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalMetadata) DeepCopyInto(out *AdditionalMetadata) {
 	*out = *in
@@ -58,6 +75,52 @@ func (in *AdditionalMetadata) DeepCopy() *AdditionalMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonHealthStatus) DeepCopyInto(out *AddonHealthStatus) {
+	*out = *in
+	if in.LastSync != nil {
+		in, out := &in.LastSync, &out.LastSync
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonHealthStatus.
+func (in *AddonHealthStatus) DeepCopy() *AddonHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRemovalPolicy) DeepCopyInto(out *AddonRemovalPolicy) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRemovalPolicy.
+func (in *AddonRemovalPolicy) DeepCopy() *AddonRemovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRemovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 	*out = *in
@@ -78,6 +141,7 @@ func (in *AddonSpec) DeepCopy() *AddonSpec {
 func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
 	*out = *in
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.AddonHealthStatus.DeepCopyInto(&out.AddonHealthStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
@@ -95,8 +159,8 @@ func (in *AddonsSpec) DeepCopyInto(out *AddonsSpec) {
 	*out = *in
 	if in.CoreDNS != nil {
 		in, out := &in.CoreDNS, &out.CoreDNS
-		*out = new(AddonSpec)
-		**out = **in
+		*out = new(CoreDNSAddonSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Konnectivity != nil {
 		in, out := &in.Konnectivity, &out.Konnectivity
@@ -105,9 +169,36 @@ func (in *AddonsSpec) DeepCopyInto(out *AddonsSpec) {
 	}
 	if in.KubeProxy != nil {
 		in, out := &in.KubeProxy, &out.KubeProxy
-		*out = new(AddonSpec)
+		*out = new(KubeProxyAddonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KonnectivityRemoval != nil {
+		in, out := &in.KonnectivityRemoval, &out.KonnectivityRemoval
+		*out = new(AddonRemovalPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerAddonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CNI != nil {
+		in, out := &in.CNI, &out.CNI
+		*out = new(CNIAddonSpec)
 		**out = **in
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageAddonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]ManifestsAddonSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsSpec.
@@ -126,6 +217,16 @@ func (in *AddonsStatus) DeepCopyInto(out *AddonsStatus) {
 	in.CoreDNS.DeepCopyInto(&out.CoreDNS)
 	in.KubeProxy.DeepCopyInto(&out.KubeProxy)
 	in.Konnectivity.DeepCopyInto(&out.Konnectivity)
+	in.CertManager.DeepCopyInto(&out.CertManager)
+	in.CNI.DeepCopyInto(&out.CNI)
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]ManifestsAddonStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsStatus.
@@ -158,961 +259,3044 @@ func (in AdmissionControllers) DeepCopy() AdmissionControllers {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+func (in *AzureADAuthentication) DeepCopyInto(out *AzureADAuthentication) {
 	*out = *in
-	in.Username.DeepCopyInto(&out.Username)
-	in.Password.DeepCopyInto(&out.Password)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
-func (in *BasicAuth) DeepCopy() *BasicAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureADAuthentication.
+func (in *AzureADAuthentication) DeepCopy() *AzureADAuthentication {
 	if in == nil {
 		return nil
 	}
-	out := new(BasicAuth)
+	out := new(AzureADAuthentication)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertKeyPair) DeepCopyInto(out *CertKeyPair) {
+func (in *AzureKeyVaultKubeconfigTarget) DeepCopyInto(out *AzureKeyVaultKubeconfigTarget) {
 	*out = *in
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	if in.PrivateKey != nil {
-		in, out := &in.PrivateKey, &out.PrivateKey
-		*out = new(ContentRef)
-		(*in).DeepCopyInto(*out)
-	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertKeyPair.
-func (in *CertKeyPair) DeepCopy() *CertKeyPair {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultKubeconfigTarget.
+func (in *AzureKeyVaultKubeconfigTarget) DeepCopy() *AzureKeyVaultKubeconfigTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(CertKeyPair)
+	out := new(AzureKeyVaultKubeconfigTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatePrivateKeyPairStatus) DeepCopyInto(out *CertificatePrivateKeyPairStatus) {
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePrivateKeyPairStatus.
-func (in *CertificatePrivateKeyPairStatus) DeepCopy() *CertificatePrivateKeyPairStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificatePrivateKeyPairStatus)
+	out := new(BasicAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+func (in *BootstrapTokenSpec) DeepCopyInto(out *BootstrapTokenSpec) {
 	*out = *in
-	in.CA.DeepCopyInto(&out.CA)
-	in.APIServer.DeepCopyInto(&out.APIServer)
-	in.APIServerKubeletClient.DeepCopyInto(&out.APIServerKubeletClient)
-	in.FrontProxyCA.DeepCopyInto(&out.FrontProxyCA)
-	in.FrontProxyClient.DeepCopyInto(&out.FrontProxyClient)
-	in.SA.DeepCopyInto(&out.SA)
-	if in.ETCD != nil {
-		in, out := &in.ETCD, &out.ETCD
-		*out = new(ETCDCertificatesStatus)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TTL = in.TTL
+	out.RenewBefore = in.RenewBefore
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
-func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapTokenSpec.
+func (in *BootstrapTokenSpec) DeepCopy() *BootstrapTokenSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificatesStatus)
+	out := new(BootstrapTokenSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientCertificate) DeepCopyInto(out *ClientCertificate) {
+func (in *BulkAction) DeepCopyInto(out *BulkAction) {
 	*out = *in
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificate.
-func (in *ClientCertificate) DeepCopy() *ClientCertificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkAction.
+func (in *BulkAction) DeepCopy() *BulkAction {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientCertificate)
+	out := new(BulkAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentResourceRequirements) DeepCopyInto(out *ComponentResourceRequirements) {
+func (in *BulkActionList) DeepCopyInto(out *BulkActionList) {
 	*out = *in
-	if in.Limits != nil {
-		in, out := &in.Limits, &out.Limits
-		*out = make(v1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	if in.Requests != nil {
-		in, out := &in.Requests, &out.Requests
-		*out = make(v1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BulkAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentResourceRequirements.
-func (in *ComponentResourceRequirements) DeepCopy() *ComponentResourceRequirements {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkActionList.
+func (in *BulkActionList) DeepCopy() *BulkActionList {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentResourceRequirements)
+	out := new(BulkActionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContentRef) DeepCopyInto(out *ContentRef) {
-	*out = *in
-	if in.Content != nil {
-		in, out := &in.Content, &out.Content
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(SecretReference)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentRef.
-func (in *ContentRef) DeepCopy() *ContentRef {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ContentRef)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
+func (in *BulkActionSpec) DeepCopyInto(out *BulkActionSpec) {
 	*out = *in
-	in.Deployment.DeepCopyInto(&out.Deployment)
-	in.Service.DeepCopyInto(&out.Service)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(IngressSpec)
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlane.
-func (in *ControlPlane) DeepCopy() *ControlPlane {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkActionSpec.
+func (in *BulkActionSpec) DeepCopy() *BulkActionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlane)
+	out := new(BulkActionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneComponentsResources) DeepCopyInto(out *ControlPlaneComponentsResources) {
+func (in *BulkActionStatus) DeepCopyInto(out *BulkActionStatus) {
 	*out = *in
-	if in.APIServer != nil {
-		in, out := &in.APIServer, &out.APIServer
-		*out = new(ComponentResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ControllerManager != nil {
-		in, out := &in.ControllerManager, &out.ControllerManager
-		*out = new(ComponentResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]BulkActionTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Scheduler != nil {
-		in, out := &in.Scheduler, &out.Scheduler
-		*out = new(ComponentResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentsResources.
-func (in *ControlPlaneComponentsResources) DeepCopy() *ControlPlaneComponentsResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkActionStatus.
+func (in *BulkActionStatus) DeepCopy() *BulkActionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneComponentsResources)
+	out := new(BulkActionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneExtraArgs) DeepCopyInto(out *ControlPlaneExtraArgs) {
+func (in *BulkActionTarget) DeepCopyInto(out *BulkActionTarget) {
 	*out = *in
-	if in.APIServer != nil {
-		in, out := &in.APIServer, &out.APIServer
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ControllerManager != nil {
-		in, out := &in.ControllerManager, &out.ControllerManager
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Scheduler != nil {
-		in, out := &in.Scheduler, &out.Scheduler
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Kine != nil {
-		in, out := &in.Kine, &out.Kine
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneExtraArgs.
-func (in *ControlPlaneExtraArgs) DeepCopy() *ControlPlaneExtraArgs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkActionTarget.
+func (in *BulkActionTarget) DeepCopy() *BulkActionTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneExtraArgs)
+	out := new(BulkActionTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStore) DeepCopyInto(out *DataStore) {
+func (in *CNIAddonSpec) DeepCopyInto(out *CNIAddonSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStore.
-func (in *DataStore) DeepCopy() *DataStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIAddonSpec.
+func (in *CNIAddonSpec) DeepCopy() *CNIAddonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStore)
+	out := new(CNIAddonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataStore) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreCertificateStatus) DeepCopyInto(out *DataStoreCertificateStatus) {
+func (in *CertKeyPair) DeepCopyInto(out *CertKeyPair) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = new(ContentRef)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreCertificateStatus.
-func (in *DataStoreCertificateStatus) DeepCopy() *DataStoreCertificateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertKeyPair.
+func (in *CertKeyPair) DeepCopy() *CertKeyPair {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreCertificateStatus)
+	out := new(CertKeyPair)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreConfigStatus) DeepCopyInto(out *DataStoreConfigStatus) {
+func (in *CertManagerAddonSpec) DeepCopyInto(out *CertManagerAddonSpec) {
 	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(CertManagerValues)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreConfigStatus.
-func (in *DataStoreConfigStatus) DeepCopy() *DataStoreConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerAddonSpec.
+func (in *CertManagerAddonSpec) DeepCopy() *CertManagerAddonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreConfigStatus)
+	out := new(CertManagerAddonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreList) DeepCopyInto(out *DataStoreList) {
+func (in *CertManagerValues) DeepCopyInto(out *CertManagerValues) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerValues.
+func (in *CertManagerValues) DeepCopy() *CertManagerValues {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatePrivateKeyPairStatus) DeepCopyInto(out *CertificatePrivateKeyPairStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePrivateKeyPairStatus.
+func (in *CertificatePrivateKeyPairStatus) DeepCopy() *CertificatePrivateKeyPairStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatePrivateKeyPairStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+	*out = *in
+	in.CA.DeepCopyInto(&out.CA)
+	in.APIServer.DeepCopyInto(&out.APIServer)
+	in.APIServerKubeletClient.DeepCopyInto(&out.APIServerKubeletClient)
+	in.FrontProxyCA.DeepCopyInto(&out.FrontProxyCA)
+	in.FrontProxyClient.DeepCopyInto(&out.FrontProxyClient)
+	in.SA.DeepCopyInto(&out.SA)
+	if in.ETCD != nil {
+		in, out := &in.ETCD, &out.ETCD
+		*out = new(ETCDCertificatesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletServingCA != nil {
+		in, out := &in.KubeletServingCA, &out.KubeletServingCA
+		*out = new(KubeletServingCAStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
+func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecksumPolicy) DeepCopyInto(out *ChecksumPolicy) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecksumPolicy.
+func (in *ChecksumPolicy) DeepCopy() *ChecksumPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecksumPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupHook) DeepCopyInto(out *CleanupHook) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(CleanupHookWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(CleanupHookJob)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupHook.
+func (in *CleanupHook) DeepCopy() *CleanupHook {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupHookJob) DeepCopyInto(out *CleanupHookJob) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupHookJob.
+func (in *CleanupHookJob) DeepCopy() *CleanupHookJob {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupHookJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupHookStatus) DeepCopyInto(out *CleanupHookStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupHookStatus.
+func (in *CleanupHookStatus) DeepCopy() *CleanupHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupHookWebhook) DeepCopyInto(out *CleanupHookWebhook) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupHookWebhook.
+func (in *CleanupHookWebhook) DeepCopy() *CleanupHookWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupHookWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificate) DeepCopyInto(out *ClientCertificate) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificate.
+func (in *ClientCertificate) DeepCopy() *ClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompactionSchedule) DeepCopyInto(out *CompactionSchedule) {
+	*out = *in
+	out.MaintenanceSchedule = in.MaintenanceSchedule
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompactionSchedule.
+func (in *CompactionSchedule) DeepCopy() *CompactionSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(CompactionSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentResourceRequirements) DeepCopyInto(out *ComponentResourceRequirements) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentResourceRequirements.
+func (in *ComponentResourceRequirements) DeepCopy() *ComponentResourceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentResourceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentRef) DeepCopyInto(out *ContentRef) {
+	*out = *in
+	if in.Content != nil {
+		in, out := &in.Content, &out.Content
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentRef.
+func (in *ContentRef) DeepCopy() *ContentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
+	*out = *in
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = new(KineSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlane.
+func (in *ControlPlane) DeepCopy() *ControlPlane {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponentsResources) DeepCopyInto(out *ControlPlaneComponentsResources) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(ComponentResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ComponentResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(ComponentResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentsResources.
+func (in *ControlPlaneComponentsResources) DeepCopy() *ControlPlaneComponentsResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponentsResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneExtraArgs) DeepCopyInto(out *ControlPlaneExtraArgs) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneExtraArgs.
+func (in *ControlPlaneExtraArgs) DeepCopy() *ControlPlaneExtraArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneExtraArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSAddonSpec) DeepCopyInto(out *CoreDNSAddonSpec) {
+	*out = *in
+	out.AddonSpec = in.AddonSpec
+	if in.StubZones != nil {
+		in, out := &in.StubZones, &out.StubZones
+		*out = make([]DNSStubZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upstreams != nil {
+		in, out := &in.Upstreams, &out.Upstreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ComponentResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSAddonSpec.
+func (in *CoreDNSAddonSpec) DeepCopy() *CoreDNSAddonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSAddonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsKeyMapping) DeepCopyInto(out *CredentialsKeyMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsKeyMapping.
+func (in *CredentialsKeyMapping) DeepCopy() *CredentialsKeyMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsKeyMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSource) DeepCopyInto(out *CredentialsSource) {
+	*out = *in
+	out.SecretReference = in.SecretReference
+	out.KeyMapping = in.KeyMapping
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSource.
+func (in *CredentialsSource) DeepCopy() *CredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSStubZone) DeepCopyInto(out *DNSStubZone) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSStubZone.
+func (in *DNSStubZone) DeepCopy() *DNSStubZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSStubZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStore) DeepCopyInto(out *DataStore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStore.
+func (in *DataStore) DeepCopy() *DataStore {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataStore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreAllowedNamespaces) DeepCopyInto(out *DataStoreAllowedNamespaces) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreAllowedNamespaces.
+func (in *DataStoreAllowedNamespaces) DeepCopy() *DataStoreAllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreAllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreCertificateStatus) DeepCopyInto(out *DataStoreCertificateStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreCertificateStatus.
+func (in *DataStoreCertificateStatus) DeepCopy() *DataStoreCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreConfigStatus) DeepCopyInto(out *DataStoreConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreConfigStatus.
+func (in *DataStoreConfigStatus) DeepCopy() *DataStoreConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreList) DeepCopyInto(out *DataStoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreList.
+func (in *DataStoreList) DeepCopy() *DataStoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreMaintenance) DeepCopyInto(out *DataStoreMaintenance) {
+	*out = *in
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(CompactionSchedule)
+		**out = **in
+	}
+	if in.Defragmentation != nil {
+		in, out := &in.Defragmentation, &out.Defragmentation
+		*out = new(MaintenanceSchedule)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreMaintenance.
+func (in *DataStoreMaintenance) DeepCopy() *DataStoreMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreMaintenanceStatus) DeepCopyInto(out *DataStoreMaintenanceStatus) {
+	*out = *in
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(MaintenanceRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defragmentation != nil {
+		in, out := &in.Defragmentation, &out.Defragmentation
+		*out = new(MaintenanceRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreMaintenanceStatus.
+func (in *DataStoreMaintenanceStatus) DeepCopy() *DataStoreMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreMigrationSpec) DeepCopyInto(out *DataStoreMigrationSpec) {
+	*out = *in
+	if in.KeysPerSecond != nil {
+		in, out := &in.KeysPerSecond, &out.KeysPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BytesPerSecond != nil {
+		in, out := &in.BytesPerSecond, &out.BytesPerSecond
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(DataStoreMigrationWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreMigrationSpec.
+func (in *DataStoreMigrationSpec) DeepCopy() *DataStoreMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreMigrationStatus) DeepCopyInto(out *DataStoreMigrationStatus) {
+	*out = *in
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextWindow != nil {
+		in, out := &in.NextWindow, &out.NextWindow
+		*out = (*in).DeepCopy()
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreMigrationStatus.
+func (in *DataStoreMigrationStatus) DeepCopy() *DataStoreMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreMigrationWindow) DeepCopyInto(out *DataStoreMigrationWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreMigrationWindow.
+func (in *DataStoreMigrationWindow) DeepCopy() *DataStoreMigrationWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreMigrationWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreProvisionedResource) DeepCopyInto(out *DataStoreProvisionedResource) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreProvisionedResource.
+func (in *DataStoreProvisionedResource) DeepCopy() *DataStoreProvisionedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreProvisionedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreQuotaSpec) DeepCopyInto(out *DataStoreQuotaSpec) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreQuotaSpec.
+func (in *DataStoreQuotaSpec) DeepCopy() *DataStoreQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreQuotaStatus) DeepCopyInto(out *DataStoreQuotaStatus) {
+	*out = *in
+	out.Used = in.Used.DeepCopy()
+	out.Size = in.Size.DeepCopy()
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreQuotaStatus.
+func (in *DataStoreQuotaStatus) DeepCopy() *DataStoreQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSetupStatus) DeepCopyInto(out *DataStoreSetupStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSetupStatus.
+func (in *DataStoreSetupStatus) DeepCopy() *DataStoreSetupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSetupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSpec) DeepCopyInto(out *DataStoreSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(Endpoints, len(*in))
+		copy(*out, *in)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivilegedAuth != nil {
+		in, out := &in.PrivilegedAuth, &out.PrivilegedAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TLSConfig.DeepCopyInto(&out.TLSConfig)
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(DataStoreMaintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTenants != nil {
+		in, out := &in.MaxTenants, &out.MaxTenants
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(PostgreSQLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MySQL != nil {
+		in, out := &in.MySQL, &out.MySQL
+		*out = new(MySQLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsFrom != nil {
+		in, out := &in.CredentialsFrom, &out.CredentialsFrom
+		*out = new(CredentialsSource)
+		**out = **in
+	}
+	if in.IAMAuthentication != nil {
+		in, out := &in.IAMAuthentication, &out.IAMAuthentication
+		*out = new(IAMAuthentication)
+		**out = **in
+	}
+	if in.AzureADAuthentication != nil {
+		in, out := &in.AzureADAuthentication, &out.AzureADAuthentication
+		*out = new(AzureADAuthentication)
+		**out = **in
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(DataStoreAllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointZones != nil {
+		in, out := &in.EndpointZones, &out.EndpointZones
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSpec.
+func (in *DataStoreSpec) DeepCopy() *DataStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreStatus) DeepCopyInto(out *DataStoreStatus) {
+	*out = *in
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(DataStoreMaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = make([]DataStoreProvisionedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreStatus.
+func (in *DataStoreStatus) DeepCopy() *DataStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreZoneAffinity) DeepCopyInto(out *DataStoreZoneAffinity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreZoneAffinity.
+func (in *DataStoreZoneAffinity) DeepCopy() *DataStoreZoneAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreZoneAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreUsedSecret) DeepCopyInto(out *DatastoreUsedSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreUsedSecret.
+func (in *DatastoreUsedSecret) DeepCopy() *DatastoreUsedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(DatastoreUsedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ControlPlaneComponentsResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = new(ControlPlaneExtraArgs)
+		(*in).DeepCopyInto(*out)
+	}
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	if in.ChecksumPolicy != nil {
+		in, out := &in.ChecksumPolicy, &out.ChecksumPolicy
+		*out = new(ChecksumPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LeaderElection != nil {
+		in, out := &in.LeaderElection, &out.LeaderElection
+		*out = new(LeaderElectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSpec.
+func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprecatedAPIUsage) DeepCopyInto(out *DeprecatedAPIUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecatedAPIUsage.
+func (in *DeprecatedAPIUsage) DeepCopy() *DeprecatedAPIUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprecatedAPIUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCDCertificateStatus) DeepCopyInto(out *ETCDCertificateStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificateStatus.
+func (in *ETCDCertificateStatus) DeepCopy() *ETCDCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCDCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCDCertificatesStatus) DeepCopyInto(out *ETCDCertificatesStatus) {
+	*out = *in
+	in.APIServer.DeepCopyInto(&out.APIServer)
+	in.CA.DeepCopyInto(&out.CA)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificatesStatus.
+func (in *ETCDCertificatesStatus) DeepCopy() *ETCDCertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCDCertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Endpoints) DeepCopyInto(out *Endpoints) {
+	{
+		in := &in
+		*out = make(Endpoints, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoints.
+func (in Endpoints) DeepCopy() Endpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoints)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdCluster) DeepCopyInto(out *EtcdCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdCluster.
+func (in *EtcdCluster) DeepCopy() *EtcdCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdClusterList) DeepCopyInto(out *EtcdClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DataStore, len(*in))
+		*out = make([]EtcdCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdClusterList.
+func (in *EtcdClusterList) DeepCopy() *EtcdClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdClusterSpec) DeepCopyInto(out *EtcdClusterSpec) {
+	*out = *in
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdClusterSpec.
+func (in *EtcdClusterSpec) DeepCopy() *EtcdClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdClusterStatus) DeepCopyInto(out *EtcdClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdClusterStatus.
+func (in *EtcdClusterStatus) DeepCopy() *EtcdClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdClusterStorage) DeepCopyInto(out *EtcdClusterStorage) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdClusterStorage.
+func (in *EtcdClusterStorage) DeepCopy() *EtcdClusterStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdClusterStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalKubernetesObjectStatus) DeepCopyInto(out *ExternalKubernetesObjectStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKubernetesObjectStatus.
+func (in *ExternalKubernetesObjectStatus) DeepCopy() *ExternalKubernetesObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalKubernetesObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ExtraArgs) DeepCopyInto(out *ExtraArgs) {
+	{
+		in := &in
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraArgs.
+func (in ExtraArgs) DeepCopy() ExtraArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraArgs)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMAuthentication) DeepCopyInto(out *IAMAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMAuthentication.
+func (in *IAMAuthentication) DeepCopy() *IAMAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
+func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverrideTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinCommandStatus) DeepCopyInto(out *JoinCommandStatus) {
+	*out = *in
+	in.Expiration.DeepCopyInto(&out.Expiration)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinCommandStatus.
+func (in *JoinCommandStatus) DeepCopy() *JoinCommandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinCommandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KineCleanupStatus) DeepCopyInto(out *KineCleanupStatus) {
+	*out = *in
+	in.LastRun.DeepCopyInto(&out.LastRun)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineCleanupStatus.
+func (in *KineCleanupStatus) DeepCopy() *KineCleanupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KineCleanupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KineCompactionSpec) DeepCopyInto(out *KineCompactionSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CleanupInterval != nil {
+		in, out := &in.CleanupInterval, &out.CleanupInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineCompactionSpec.
+func (in *KineCompactionSpec) DeepCopy() *KineCompactionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KineCompactionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KineMetricsSpec) DeepCopyInto(out *KineMetricsSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineMetricsSpec.
+func (in *KineMetricsSpec) DeepCopy() *KineMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KineMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KineSpec) DeepCopyInto(out *KineSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ComponentResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(KineMetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(KineCompactionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineSpec.
+func (in *KineSpec) DeepCopy() *KineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KineStatus) DeepCopyInto(out *KineStatus) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	in.Service.DeepCopyInto(&out.Service)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KineStatus.
+func (in *KineStatus) DeepCopy() *KineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityAgentSpec) DeepCopyInto(out *KonnectivityAgentSpec) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentSpec.
+func (in *KonnectivityAgentSpec) DeepCopy() *KonnectivityAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityConfigMap) DeepCopyInto(out *KonnectivityConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityConfigMap.
+func (in *KonnectivityConfigMap) DeepCopy() *KonnectivityConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityFallbackSpec) DeepCopyInto(out *KonnectivityFallbackSpec) {
+	*out = *in
+	out.UnavailabilityThreshold = in.UnavailabilityThreshold
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityFallbackSpec.
+func (in *KonnectivityFallbackSpec) DeepCopy() *KonnectivityFallbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityFallbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityLeaseCountingSpec) DeepCopyInto(out *KonnectivityLeaseCountingSpec) {
+	*out = *in
+	out.LeaseDuration = in.LeaseDuration
+	out.RenewalInterval = in.RenewalInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityLeaseCountingSpec.
+func (in *KonnectivityLeaseCountingSpec) DeepCopy() *KonnectivityLeaseCountingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityLeaseCountingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServerDeploymentSpec) DeepCopyInto(out *KonnectivityServerDeploymentSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerDeploymentSpec.
+func (in *KonnectivityServerDeploymentSpec) DeepCopy() *KonnectivityServerDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServerDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServerLimitsSpec) DeepCopyInto(out *KonnectivityServerLimitsSpec) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.FrontendKeepaliveTime != nil {
+		in, out := &in.FrontendKeepaliveTime, &out.FrontendKeepaliveTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerLimitsSpec.
+func (in *KonnectivityServerLimitsSpec) DeepCopy() *KonnectivityServerLimitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServerLimitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServerProbesSpec) DeepCopyInto(out *KonnectivityServerProbesSpec) {
+	*out = *in
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerProbesSpec.
+func (in *KonnectivityServerProbesSpec) DeepCopy() *KonnectivityServerProbesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServerProbesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServerSpec) DeepCopyInto(out *KonnectivityServerSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ComponentResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.AgentsPerServer != nil {
+		in, out := &in.AgentsPerServer, &out.AgentsPerServer
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServerCount != nil {
+		in, out := &in.ServerCount, &out.ServerCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(KonnectivityServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(KonnectivityServerLimitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(KonnectivityServerProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(KonnectivityServerDeploymentSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerSpec.
+func (in *KonnectivityServerSpec) DeepCopy() *KonnectivityServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServiceSpec) DeepCopyInto(out *KonnectivityServiceSpec) {
+	*out = *in
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	in.ServiceTopologySpec.DeepCopyInto(&out.ServiceTopologySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServiceSpec.
+func (in *KonnectivityServiceSpec) DeepCopy() *KonnectivityServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivitySpec) DeepCopyInto(out *KonnectivitySpec) {
+	*out = *in
+	in.KonnectivityServerSpec.DeepCopyInto(&out.KonnectivityServerSpec)
+	in.KonnectivityAgentSpec.DeepCopyInto(&out.KonnectivityAgentSpec)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KonnectivityTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AggregatorRouting != nil {
+		in, out := &in.AggregatorRouting, &out.AggregatorRouting
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(KonnectivityFallbackSpec)
+		**out = **in
+	}
+	if in.LeaseCounting != nil {
+		in, out := &in.LeaseCounting, &out.LeaseCounting
+		*out = new(KonnectivityLeaseCountingSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivitySpec.
+func (in *KonnectivitySpec) DeepCopy() *KonnectivitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityStatus) DeepCopyInto(out *KonnectivityStatus) {
+	*out = *in
+	out.ConfigMap = in.ConfigMap
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.ProxyCertificate.DeepCopyInto(&out.ProxyCertificate)
+	in.Kubeconfig.DeepCopyInto(&out.Kubeconfig)
+	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
+	in.ClusterRoleBinding.DeepCopyInto(&out.ClusterRoleBinding)
+	in.Agent.DeepCopyInto(&out.Agent)
+	in.Service.DeepCopyInto(&out.Service)
+	in.Leases.DeepCopyInto(&out.Leases)
+	in.ServerDeployment.DeepCopyInto(&out.ServerDeployment)
+	if in.RemovalRequestedAt != nil {
+		in, out := &in.RemovalRequestedAt, &out.RemovalRequestedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.AgentsUnavailableSince != nil {
+		in, out := &in.AgentsUnavailableSince, &out.AgentsUnavailableSince
+		*out = (*in).DeepCopy()
+	}
+	in.AddonHealthStatus.DeepCopyInto(&out.AddonHealthStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityStatus.
+func (in *KonnectivityStatus) DeepCopy() *KonnectivityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityTLSSpec) DeepCopyInto(out *KonnectivityTLSSpec) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityTLSSpec.
+func (in *KonnectivityTLSSpec) DeepCopy() *KonnectivityTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeProxyAddonSpec) DeepCopyInto(out *KubeProxyAddonSpec) {
+	*out = *in
+	out.AddonSpec = in.AddonSpec
+	if in.Conntrack != nil {
+		in, out := &in.Conntrack, &out.Conntrack
+		*out = new(KubeProxyConntrackSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreList.
-func (in *DataStoreList) DeepCopy() *DataStoreList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxyAddonSpec.
+func (in *KubeProxyAddonSpec) DeepCopy() *KubeProxyAddonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreList)
+	out := new(KubeProxyAddonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataStoreList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeProxyConntrackSpec) DeepCopyInto(out *KubeProxyConntrackSpec) {
+	*out = *in
+	if in.MaxPerCore != nil {
+		in, out := &in.MaxPerCore, &out.MaxPerCore
+		*out = new(int32)
+		**out = **in
 	}
-	return nil
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TCPEstablishedTimeout != nil {
+		in, out := &in.TCPEstablishedTimeout, &out.TCPEstablishedTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TCPCloseWaitTimeout != nil {
+		in, out := &in.TCPCloseWaitTimeout, &out.TCPCloseWaitTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxyConntrackSpec.
+func (in *KubeProxyConntrackSpec) DeepCopy() *KubeProxyConntrackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeProxyConntrackSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreSetupStatus) DeepCopyInto(out *DataStoreSetupStatus) {
+func (in *KubeadmConfigStatus) DeepCopyInto(out *KubeadmConfigStatus) {
 	*out = *in
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSetupStatus.
-func (in *DataStoreSetupStatus) DeepCopy() *DataStoreSetupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigStatus.
+func (in *KubeadmConfigStatus) DeepCopy() *KubeadmConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmPhaseStatus) DeepCopyInto(out *KubeadmPhaseStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhaseStatus.
+func (in *KubeadmPhaseStatus) DeepCopy() *KubeadmPhaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmPhaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmPhasesStatus) DeepCopyInto(out *KubeadmPhasesStatus) {
+	*out = *in
+	in.BootstrapToken.DeepCopyInto(&out.BootstrapToken)
+	if in.JoinCommand != nil {
+		in, out := &in.JoinCommand, &out.JoinCommand
+		*out = new(JoinCommandStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Skipped != nil {
+		in, out := &in.Skipped, &out.Skipped
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhasesStatus.
+func (in *KubeadmPhasesStatus) DeepCopy() *KubeadmPhasesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmPhasesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmSpec) DeepCopyInto(out *KubeadmSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BootstrapToken != nil {
+		in, out := &in.BootstrapToken, &out.BootstrapToken
+		*out = new(BootstrapTokenSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmSpec.
+func (in *KubeadmSpec) DeepCopy() *KubeadmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigExternalTarget) DeepCopyInto(out *KubeconfigExternalTarget) {
+	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultKubeconfigTarget)
+		**out = **in
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(AWSSecretsManagerKubeconfigTarget)
+		**out = **in
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(AzureKeyVaultKubeconfigTarget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigExternalTarget.
+func (in *KubeconfigExternalTarget) DeepCopy() *KubeconfigExternalTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigExternalTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigExternalTargetStatus) DeepCopyInto(out *KubeconfigExternalTargetStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigExternalTargetStatus.
+func (in *KubeconfigExternalTargetStatus) DeepCopy() *KubeconfigExternalTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigExternalTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSecretTarget) DeepCopyInto(out *KubeconfigSecretTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretTarget.
+func (in *KubeconfigSecretTarget) DeepCopy() *KubeconfigSecretTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigSecretTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSpec) DeepCopyInto(out *KubeconfigSpec) {
+	*out = *in
+	if in.AdminSecretTargets != nil {
+		in, out := &in.AdminSecretTargets, &out.AdminSecretTargets
+		*out = make([]KubeconfigSecretTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdminExternalTargets != nil {
+		in, out := &in.AdminExternalTargets, &out.AdminExternalTargets
+		*out = make([]KubeconfigExternalTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = new(UsersKubeconfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSpec.
+func (in *KubeconfigSpec) DeepCopy() *KubeconfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigStatus) DeepCopyInto(out *KubeconfigStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.LegacyKeys != nil {
+		in, out := &in.LegacyKeys, &out.LegacyKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigStatus.
+func (in *KubeconfigStatus) DeepCopy() *KubeconfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigsStatus) DeepCopyInto(out *KubeconfigsStatus) {
+	*out = *in
+	in.Admin.DeepCopyInto(&out.Admin)
+	in.ControllerManager.DeepCopyInto(&out.ControllerManager)
+	in.Scheduler.DeepCopyInto(&out.Scheduler)
+	if in.AdminTargets != nil {
+		in, out := &in.AdminTargets, &out.AdminTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdminExternalTargets != nil {
+		in, out := &in.AdminExternalTargets, &out.AdminExternalTargets
+		*out = make([]KubeconfigExternalTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Users.DeepCopyInto(&out.Users)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigsStatus.
+func (in *KubeconfigsStatus) DeepCopy() *KubeconfigsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletNodePoolSpec) DeepCopyInto(out *KubeletNodePoolSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletNodePoolSpec.
+func (in *KubeletNodePoolSpec) DeepCopy() *KubeletNodePoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreSetupStatus)
+	out := new(KubeletNodePoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreSpec) DeepCopyInto(out *DataStoreSpec) {
+func (in *KubeletServingCAStatus) DeepCopyInto(out *KubeletServingCAStatus) {
 	*out = *in
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
-		*out = make(Endpoints, len(*in))
-		copy(*out, *in)
+	in.CertificatePrivateKeyPairStatus.DeepCopyInto(&out.CertificatePrivateKeyPairStatus)
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
 	}
-	if in.BasicAuth != nil {
-		in, out := &in.BasicAuth, &out.BasicAuth
-		*out = new(BasicAuth)
-		(*in).DeepCopyInto(*out)
+	if in.LastRotation != nil {
+		in, out := &in.LastRotation, &out.LastRotation
+		*out = (*in).DeepCopy()
 	}
-	in.TLSConfig.DeepCopyInto(&out.TLSConfig)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSpec.
-func (in *DataStoreSpec) DeepCopy() *DataStoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletServingCAStatus.
+func (in *KubeletServingCAStatus) DeepCopy() *KubeletServingCAStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreSpec)
+	out := new(KubeletServingCAStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreStatus) DeepCopyInto(out *DataStoreStatus) {
+func (in *KubeletServingCertificateAuthoritySpec) DeepCopyInto(out *KubeletServingCertificateAuthoritySpec) {
 	*out = *in
-	if in.UsedBy != nil {
-		in, out := &in.UsedBy, &out.UsedBy
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.Validity = in.Validity
+	out.RenewBefore = in.RenewBefore
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreStatus.
-func (in *DataStoreStatus) DeepCopy() *DataStoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletServingCertificateAuthoritySpec.
+func (in *KubeletServingCertificateAuthoritySpec) DeepCopy() *KubeletServingCertificateAuthoritySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreStatus)
+	out := new(KubeletServingCertificateAuthoritySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
+func (in *KubeletSpec) DeepCopyInto(out *KubeletSpec) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.PreferredAddressTypes != nil {
+		in, out := &in.PreferredAddressTypes, &out.PreferredAddressTypes
+		*out = make([]KubeletPreferredAddressType, len(*in))
+		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ControlPlaneComponentsResources)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KubeletTLSSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = new(ControlPlaneExtraArgs)
-		(*in).DeepCopyInto(*out)
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]KubeletNodePoolSpec, len(*in))
+		copy(*out, *in)
 	}
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSpec.
-func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletSpec.
+func (in *KubeletSpec) DeepCopy() *KubeletSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentSpec)
+	out := new(KubeletSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ETCDCertificateStatus) DeepCopyInto(out *ETCDCertificateStatus) {
+func (in *KubeletTLSSpec) DeepCopyInto(out *KubeletTLSSpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.CertificateAuthority != nil {
+		in, out := &in.CertificateAuthority, &out.CertificateAuthority
+		*out = new(ContentRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(ClientCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServingCertificateAuthority != nil {
+		in, out := &in.ServingCertificateAuthority, &out.ServingCertificateAuthority
+		*out = new(KubeletServingCertificateAuthoritySpec)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificateStatus.
-func (in *ETCDCertificateStatus) DeepCopy() *ETCDCertificateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletTLSSpec.
+func (in *KubeletTLSSpec) DeepCopy() *KubeletTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ETCDCertificateStatus)
+	out := new(KubeletTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ETCDCertificatesStatus) DeepCopyInto(out *ETCDCertificatesStatus) {
+func (in *KubernetesDeploymentStatus) DeepCopyInto(out *KubernetesDeploymentStatus) {
 	*out = *in
-	in.APIServer.DeepCopyInto(&out.APIServer)
-	in.CA.DeepCopyInto(&out.CA)
+	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificatesStatus.
-func (in *ETCDCertificatesStatus) DeepCopy() *ETCDCertificatesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeploymentStatus.
+func (in *KubernetesDeploymentStatus) DeepCopy() *KubernetesDeploymentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ETCDCertificatesStatus)
+	out := new(KubernetesDeploymentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Endpoints) DeepCopyInto(out *Endpoints) {
-	{
-		in := &in
-		*out = make(Endpoints, len(*in))
-		copy(*out, *in)
-	}
+func (in *KubernetesIngressStatus) DeepCopyInto(out *KubernetesIngressStatus) {
+	*out = *in
+	in.IngressStatus.DeepCopyInto(&out.IngressStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoints.
-func (in Endpoints) DeepCopy() Endpoints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesIngressStatus.
+func (in *KubernetesIngressStatus) DeepCopy() *KubernetesIngressStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Endpoints)
+	out := new(KubernetesIngressStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalKubernetesObjectStatus) DeepCopyInto(out *ExternalKubernetesObjectStatus) {
+func (in *KubernetesServiceStatus) DeepCopyInto(out *KubernetesServiceStatus) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.ServiceStatus.DeepCopyInto(&out.ServiceStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKubernetesObjectStatus.
-func (in *ExternalKubernetesObjectStatus) DeepCopy() *ExternalKubernetesObjectStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceStatus.
+func (in *KubernetesServiceStatus) DeepCopy() *KubernetesServiceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalKubernetesObjectStatus)
+	out := new(KubernetesServiceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ExtraArgs) DeepCopyInto(out *ExtraArgs) {
-	{
-		in := &in
-		*out = make(ExtraArgs, len(*in))
+func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
+	*out = *in
+	in.Kubelet.DeepCopyInto(&out.Kubelet)
+	if in.AdmissionControllers != nil {
+		in, out := &in.AdmissionControllers, &out.AdmissionControllers
+		*out = make(AdmissionControllers, len(*in))
 		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraArgs.
-func (in ExtraArgs) DeepCopy() ExtraArgs {
-	if in == nil {
-		return nil
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(StreamingSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(ExtraArgs)
-	in.DeepCopyInto(out)
-	return *out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
-func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
+func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageOverrideTrait)
+	out := new(KubernetesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+func (in *KubernetesStatus) DeepCopyInto(out *KubernetesStatus) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	in.Version.DeepCopyInto(&out.Version)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(KubernetesIngressStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeprecatedAPIs != nil {
+		in, out := &in.DeprecatedAPIs, &out.DeprecatedAPIs
+		*out = make([]DeprecatedAPIUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.LeaderElection != nil {
+		in, out := &in.LeaderElection, &out.LeaderElection
+		*out = make([]LeaderElectionLeaseStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
-func (in *IngressSpec) DeepCopy() *IngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesStatus.
+func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressSpec)
+	out := new(KubernetesStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityAgentSpec) DeepCopyInto(out *KonnectivityAgentSpec) {
+func (in *KubernetesVersion) DeepCopyInto(out *KubernetesVersion) {
 	*out = *in
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = make(ExtraArgs, len(*in))
-		copy(*out, *in)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(KubernetesVersionStatus)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentSpec.
-func (in *KonnectivityAgentSpec) DeepCopy() *KonnectivityAgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersion.
+func (in *KubernetesVersion) DeepCopy() *KubernetesVersion {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityAgentSpec)
+	out := new(KubernetesVersion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityConfigMap) DeepCopyInto(out *KonnectivityConfigMap) {
+func (in *LeaderElectionLeaseStatus) DeepCopyInto(out *LeaderElectionLeaseStatus) {
 	*out = *in
+	if in.RenewTime != nil {
+		in, out := &in.RenewTime, &out.RenewTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastSync.DeepCopyInto(&out.LastSync)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityConfigMap.
-func (in *KonnectivityConfigMap) DeepCopy() *KonnectivityConfigMap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionLeaseStatus.
+func (in *LeaderElectionLeaseStatus) DeepCopy() *LeaderElectionLeaseStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityConfigMap)
+	out := new(LeaderElectionLeaseStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityServerSpec) DeepCopyInto(out *KonnectivityServerSpec) {
+func (in *LeaderElectionSpec) DeepCopyInto(out *LeaderElectionSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ComponentResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.LeaseDuration != nil {
+		in, out := &in.LeaseDuration, &out.LeaseDuration
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = make(ExtraArgs, len(*in))
-		copy(*out, *in)
+	if in.RenewDeadline != nil {
+		in, out := &in.RenewDeadline, &out.RenewDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryPeriod != nil {
+		in, out := &in.RetryPeriod, &out.RetryPeriod
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerSpec.
-func (in *KonnectivityServerSpec) DeepCopy() *KonnectivityServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionSpec.
+func (in *LeaderElectionSpec) DeepCopy() *LeaderElectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityServerSpec)
+	out := new(LeaderElectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivitySpec) DeepCopyInto(out *KonnectivitySpec) {
+func (in *MaintenanceRunStatus) DeepCopyInto(out *MaintenanceRunStatus) {
 	*out = *in
-	in.KonnectivityServerSpec.DeepCopyInto(&out.KonnectivityServerSpec)
-	in.KonnectivityAgentSpec.DeepCopyInto(&out.KonnectivityAgentSpec)
+	in.LastRun.DeepCopyInto(&out.LastRun)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivitySpec.
-func (in *KonnectivitySpec) DeepCopy() *KonnectivitySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceRunStatus.
+func (in *MaintenanceRunStatus) DeepCopy() *MaintenanceRunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivitySpec)
+	out := new(MaintenanceRunStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityStatus) DeepCopyInto(out *KonnectivityStatus) {
+func (in *MaintenanceSchedule) DeepCopyInto(out *MaintenanceSchedule) {
 	*out = *in
-	out.ConfigMap = in.ConfigMap
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	in.Kubeconfig.DeepCopyInto(&out.Kubeconfig)
-	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
-	in.ClusterRoleBinding.DeepCopyInto(&out.ClusterRoleBinding)
-	in.Agent.DeepCopyInto(&out.Agent)
-	in.Service.DeepCopyInto(&out.Service)
+	out.Interval = in.Interval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityStatus.
-func (in *KonnectivityStatus) DeepCopy() *KonnectivityStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSchedule.
+func (in *MaintenanceSchedule) DeepCopy() *MaintenanceSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityStatus)
+	out := new(MaintenanceSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmConfigStatus) DeepCopyInto(out *KubeadmConfigStatus) {
+func (in *ManifestsAddonSpec) DeepCopyInto(out *ManifestsAddonSpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigStatus.
-func (in *KubeadmConfigStatus) DeepCopy() *KubeadmConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsAddonSpec.
+func (in *ManifestsAddonSpec) DeepCopy() *ManifestsAddonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmConfigStatus)
+	out := new(ManifestsAddonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmPhaseStatus) DeepCopyInto(out *KubeadmPhaseStatus) {
+func (in *ManifestsAddonStatus) DeepCopyInto(out *ManifestsAddonStatus) {
 	*out = *in
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhaseStatus.
-func (in *KubeadmPhaseStatus) DeepCopy() *KubeadmPhaseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsAddonStatus.
+func (in *ManifestsAddonStatus) DeepCopy() *ManifestsAddonStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmPhaseStatus)
+	out := new(ManifestsAddonStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmPhasesStatus) DeepCopyInto(out *KubeadmPhasesStatus) {
+func (in *MySQLSpec) DeepCopyInto(out *MySQLSpec) {
 	*out = *in
-	in.BootstrapToken.DeepCopyInto(&out.BootstrapToken)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhasesStatus.
-func (in *KubeadmPhasesStatus) DeepCopy() *KubeadmPhasesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLSpec.
+func (in *MySQLSpec) DeepCopy() *MySQLSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmPhasesStatus)
+	out := new(MySQLSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigStatus) DeepCopyInto(out *KubeconfigStatus) {
+func (in *NetworkProfileSpec) DeepCopyInto(out *NetworkProfileSpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.CertSANs != nil {
+		in, out := &in.CertSANs, &out.CertSANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServiceIPs != nil {
+		in, out := &in.DNSServiceIPs, &out.DNSServiceIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigStatus.
-func (in *KubeconfigStatus) DeepCopy() *KubeconfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkProfileSpec.
+func (in *NetworkProfileSpec) DeepCopy() *NetworkProfileSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigStatus)
+	out := new(NetworkProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigsStatus) DeepCopyInto(out *KubeconfigsStatus) {
+func (in *PostgreSQLSpec) DeepCopyInto(out *PostgreSQLSpec) {
 	*out = *in
-	in.Admin.DeepCopyInto(&out.Admin)
-	in.ControllerManager.DeepCopyInto(&out.ControllerManager)
-	in.Scheduler.DeepCopyInto(&out.Scheduler)
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigsStatus.
-func (in *KubeconfigsStatus) DeepCopy() *KubeconfigsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLSpec.
+func (in *PostgreSQLSpec) DeepCopy() *PostgreSQLSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigsStatus)
+	out := new(PostgreSQLSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeletSpec) DeepCopyInto(out *KubeletSpec) {
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
 	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletSpec.
-func (in *KubeletSpec) DeepCopy() *KubeletSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeletSpec)
+	out := new(ProbeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesDeploymentStatus) DeepCopyInto(out *KubernetesDeploymentStatus) {
+func (in *PublicKeyPrivateKeyPairStatus) DeepCopyInto(out *PublicKeyPrivateKeyPairStatus) {
 	*out = *in
-	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeploymentStatus.
-func (in *KubernetesDeploymentStatus) DeepCopy() *KubernetesDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyPrivateKeyPairStatus.
+func (in *PublicKeyPrivateKeyPairStatus) DeepCopy() *PublicKeyPrivateKeyPairStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesDeploymentStatus)
+	out := new(PublicKeyPrivateKeyPairStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesIngressStatus) DeepCopyInto(out *KubernetesIngressStatus) {
+func (in *ResourceFootprintStatus) DeepCopyInto(out *ResourceFootprintStatus) {
 	*out = *in
-	in.IngressStatus.DeepCopyInto(&out.IngressStatus)
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	out.Storage = in.Storage.DeepCopy()
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesIngressStatus.
-func (in *KubernetesIngressStatus) DeepCopy() *KubernetesIngressStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFootprintStatus.
+func (in *ResourceFootprintStatus) DeepCopy() *ResourceFootprintStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesIngressStatus)
+	out := new(ResourceFootprintStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesServiceStatus) DeepCopyInto(out *KubernetesServiceStatus) {
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
-	in.ServiceStatus.DeepCopyInto(&out.ServiceStatus)
+	out.SecretReference = in.SecretReference
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceStatus.
-func (in *KubernetesServiceStatus) DeepCopy() *KubernetesServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesServiceStatus)
+	out := new(SecretReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
-	out.Kubelet = in.Kubelet
-	if in.AdmissionControllers != nil {
-		in, out := &in.AdmissionControllers, &out.AdmissionControllers
-		*out = make(AdmissionControllers, len(*in))
-		copy(*out, *in)
-	}
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	in.ServiceTopologySpec.DeepCopyInto(&out.ServiceTopologySpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
-func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
+func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesSpec)
+	out := new(ServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesStatus) DeepCopyInto(out *KubernetesStatus) {
+func (in *ServiceTopologySpec) DeepCopyInto(out *ServiceTopologySpec) {
 	*out = *in
-	in.Version.DeepCopyInto(&out.Version)
-	in.Deployment.DeepCopyInto(&out.Deployment)
-	in.Service.DeepCopyInto(&out.Service)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(KubernetesIngressStatus)
-		(*in).DeepCopyInto(*out)
+	if in.InternalTrafficPolicy != nil {
+		in, out := &in.InternalTrafficPolicy, &out.InternalTrafficPolicy
+		*out = new(corev1.ServiceInternalTrafficPolicyType)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesStatus.
-func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTopologySpec.
+func (in *ServiceTopologySpec) DeepCopy() *ServiceTopologySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesStatus)
+	out := new(ServiceTopologySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesVersion) DeepCopyInto(out *KubernetesVersion) {
+func (in *StandbyDataStoreSpec) DeepCopyInto(out *StandbyDataStoreSpec) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(KubernetesVersionStatus)
-		**out = **in
-	}
+	out.Interval = in.Interval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersion.
-func (in *KubernetesVersion) DeepCopy() *KubernetesVersion {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbyDataStoreSpec.
+func (in *StandbyDataStoreSpec) DeepCopy() *StandbyDataStoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesVersion)
+	out := new(StandbyDataStoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkProfileSpec) DeepCopyInto(out *NetworkProfileSpec) {
+func (in *StandbyDataStoreStatus) DeepCopyInto(out *StandbyDataStoreStatus) {
 	*out = *in
-	if in.CertSANs != nil {
-		in, out := &in.CertSANs, &out.CertSANs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.DNSServiceIPs != nil {
-		in, out := &in.DNSServiceIPs, &out.DNSServiceIPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkProfileSpec.
-func (in *NetworkProfileSpec) DeepCopy() *NetworkProfileSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbyDataStoreStatus.
+func (in *StandbyDataStoreStatus) DeepCopy() *StandbyDataStoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkProfileSpec)
+	out := new(StandbyDataStoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicKeyPrivateKeyPairStatus) DeepCopyInto(out *PublicKeyPrivateKeyPairStatus) {
+func (in *StorageAddonSpec) DeepCopyInto(out *StorageAddonSpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]StorageClassSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyPrivateKeyPairStatus.
-func (in *PublicKeyPrivateKeyPairStatus) DeepCopy() *PublicKeyPrivateKeyPairStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAddonSpec.
+func (in *StorageAddonSpec) DeepCopy() *StorageAddonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicKeyPrivateKeyPairStatus)
+	out := new(StorageAddonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+func (in *StorageClassSpec) DeepCopyInto(out *StorageClassSpec) {
 	*out = *in
-	out.SecretReference = in.SecretReference
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReclaimPolicy != nil {
+		in, out := &in.ReclaimPolicy, &out.ReclaimPolicy
+		*out = new(corev1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.VolumeBindingMode != nil {
+		in, out := &in.VolumeBindingMode, &out.VolumeBindingMode
+		*out = new(VolumeBindingMode)
+		**out = **in
+	}
+	if in.AllowVolumeExpansion != nil {
+		in, out := &in.AllowVolumeExpansion, &out.AllowVolumeExpansion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MountOptions != nil {
+		in, out := &in.MountOptions, &out.MountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
-func (in *SecretReference) DeepCopy() *SecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassSpec.
+func (in *StorageClassSpec) DeepCopy() *StorageClassSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretReference)
+	out := new(StorageClassSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	out.Config = in.Config
+	in.Setup.DeepCopyInto(&out.Setup)
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = new(KineStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KineCleanup != nil {
+		in, out := &in.KineCleanup, &out.KineCleanup
+		*out = new(KineCleanupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(DataStoreQuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(DataStoreMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(StandbyDataStoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
-func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
+func (in *StorageStatus) DeepCopy() *StorageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpec)
+	out := new(StorageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
+func (in *StreamingSpec) DeepCopyInto(out *StreamingSpec) {
 	*out = *in
-	out.Config = in.Config
-	in.Setup.DeepCopyInto(&out.Setup)
-	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepaliveTime != nil {
+		in, out := &in.KeepaliveTime, &out.KeepaliveTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
-func (in *StorageStatus) DeepCopy() *StorageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamingSpec.
+func (in *StreamingSpec) DeepCopy() *StreamingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageStatus)
+	out := new(StreamingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1196,10 +3380,57 @@ func (in *TenantControlPlaneList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantControlPlaneSpec) DeepCopyInto(out *TenantControlPlaneSpec) {
 	*out = *in
+	if in.DataStoreSelector != nil {
+		in, out := &in.DataStoreSelector, &out.DataStoreSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataStoreQuota != nil {
+		in, out := &in.DataStoreQuota, &out.DataStoreQuota
+		*out = new(DataStoreQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataStoreZoneAffinity != nil {
+		in, out := &in.DataStoreZoneAffinity, &out.DataStoreZoneAffinity
+		*out = new(DataStoreZoneAffinity)
+		**out = **in
+	}
+	if in.DataStoreCredentials != nil {
+		in, out := &in.DataStoreCredentials, &out.DataStoreCredentials
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.StandbyDataStore != nil {
+		in, out := &in.StandbyDataStore, &out.StandbyDataStore
+		*out = new(StandbyDataStoreSpec)
+		**out = **in
+	}
+	if in.DataStoreMigration != nil {
+		in, out := &in.DataStoreMigration, &out.DataStoreMigration
+		*out = new(DataStoreMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
 	in.Kubernetes.DeepCopyInto(&out.Kubernetes)
 	in.NetworkProfile.DeepCopyInto(&out.NetworkProfile)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.Kubeconfig != nil {
+		in, out := &in.Kubeconfig, &out.Kubeconfig
+		*out = new(KubeconfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kubeadm != nil {
+		in, out := &in.Kubeadm, &out.Kubeadm
+		*out = new(KubeadmSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CleanupHooks != nil {
+		in, out := &in.CleanupHooks, &out.CleanupHooks
+		*out = make([]CleanupHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneSpec.
@@ -1222,6 +3453,25 @@ func (in *TenantControlPlaneStatus) DeepCopyInto(out *TenantControlPlaneStatus)
 	in.KubeadmConfig.DeepCopyInto(&out.KubeadmConfig)
 	in.KubeadmPhase.DeepCopyInto(&out.KubeadmPhase)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.ResourceFootprint != nil {
+		in, out := &in.ResourceFootprint, &out.ResourceFootprint
+		*out = new(ResourceFootprintStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CleanupHooks != nil {
+		in, out := &in.CleanupHooks, &out.CleanupHooks
+		*out = make([]CleanupHookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneStatus.
@@ -1233,3 +3483,54 @@ func (in *TenantControlPlaneStatus) DeepCopy() *TenantControlPlaneStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneStatusDataStore) DeepCopyInto(out *TenantControlPlaneStatusDataStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneStatusDataStore.
+func (in *TenantControlPlaneStatusDataStore) DeepCopy() *TenantControlPlaneStatusDataStore {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlaneStatusDataStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsersKubeconfigSpec) DeepCopyInto(out *UsersKubeconfigSpec) {
+	*out = *in
+	if in.ExtraScopes != nil {
+		in, out := &in.ExtraScopes, &out.ExtraScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsersKubeconfigSpec.
+func (in *UsersKubeconfigSpec) DeepCopy() *UsersKubeconfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UsersKubeconfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubeconfigTarget) DeepCopyInto(out *VaultKubeconfigTarget) {
+	*out = *in
+	out.TokenSecretRef = in.TokenSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubeconfigTarget.
+func (in *VaultKubeconfigTarget) DeepCopy() *VaultKubeconfigTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubeconfigTarget)
+	in.DeepCopyInto(out)
+	return out
+}
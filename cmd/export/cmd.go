@@ -0,0 +1,238 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		tenantControlPlane string
+		output             string
+		includeDataStore   bool
+		markExported       bool
+		timeout            time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:          "export",
+		Short:        "Export a TenantControlPlane to be imported in another management cluster",
+		Long:         "Export a TenantControlPlane to YAML, along with its status, and the Secrets and ConfigMaps it owns, such as the PKI: the data is not exported, the destination management cluster must reach the same DataStore.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+			defer cancelFn()
+
+			client, err := ctrlclient.New(ctrl.GetConfigOrDie(), ctrlclient.Options{
+				Scheme: scheme,
+			})
+			if err != nil {
+				return err
+			}
+
+			parts := strings.Split(tenantControlPlane, string(types.Separator))
+			if len(parts) != 2 {
+				return fmt.Errorf("non well-formed namespaced name for the tenant control plane, expected <NAMESPACE>/NAME, got %s", tenantControlPlane)
+			}
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, tcp); err != nil {
+				return err
+			}
+			// The status must be complete, otherwise the destination would generate again the missing resources.
+			if status := tcp.Status.Kubernetes.Version.Status; status == nil || *status != kamajiv1alpha1.VersionReady {
+				return fmt.Errorf("the TenantControlPlane must be ready to be exported")
+			}
+
+			objects, err := ownedObjects(ctx, client, tcp)
+			if err != nil {
+				return err
+			}
+
+			if ref := tcp.Spec.DataStoreCredentials; ref != nil {
+				secret := &corev1.Secret{}
+				if err = client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: ref.Name}, secret); err != nil {
+					return fmt.Errorf("cannot retrieve the DataStore credentials Secret: %w", err)
+				}
+
+				objects = append(objects, secret)
+			}
+
+			if includeDataStore {
+				dsObjects, dsErr := dataStoreObjects(ctx, client, tcp.Status.Storage.DataStoreName)
+				if dsErr != nil {
+					return dsErr
+				}
+				// The DataStore must be created before the TenantControlPlane, being validated by the admission webhook.
+				objects = append(dsObjects, objects...)
+			}
+
+			var out io.Writer = cmd.OutOrStdout()
+
+			if output != "-" {
+				f, fErr := os.Create(output)
+				if fErr != nil {
+					return fErr
+				}
+				defer f.Close()
+
+				out = f
+			}
+
+			for _, object := range append(objects, tcp.DeepCopy()) {
+				if err = write(out, scheme, object); err != nil {
+					return err
+				}
+			}
+
+			if !markExported {
+				return nil
+			}
+			// Stopping the reconciliation in the origin management cluster: its deletion releases the DataStore.
+			patch := ctrlclient.MergeFrom(tcp.DeepCopy())
+
+			annotations := tcp.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			annotations[kamajiv1alpha1.ExportedAnnotation] = "true"
+			tcp.SetAnnotations(annotations)
+
+			return client.Patch(ctx, tcp, patch)
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantControlPlane, "tenant-control-plane", "", "Namespaced-name of the TenantControlPlane that must be exported (e.g.: default/test)")
+	cmd.Flags().StringVarP(&output, "output", "o", "-", "The file the TenantControlPlane is exported to: use - to write to the standard output.")
+	cmd.Flags().BoolVar(&includeDataStore, "include-datastore", false, "Export the DataStore used by the TenantControlPlane, along with its Secrets, when not available in the destination management cluster.")
+	cmd.Flags().BoolVar(&markExported, "mark-exported", false, "Stop the reconciliation of the exported TenantControlPlane, which can be then deleted with no DataStore clean-up.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Amount of time for the context timeout")
+
+	_ = cmd.MarkFlagRequired("tenant-control-plane")
+
+	return cmd
+}
+
+// ownedObjects returns the Secrets, and the ConfigMaps, owned by the TenantControlPlane: the other owned objects,
+// such as the Deployment and the Service, are generated again by the destination management cluster.
+func ownedObjects(ctx context.Context, client ctrlclient.Client, tcp *kamajiv1alpha1.TenantControlPlane) ([]ctrlclient.Object, error) {
+	var objects []ctrlclient.Object
+
+	secrets := &corev1.SecretList{}
+	if err := client.List(ctx, secrets, ctrlclient.InNamespace(tcp.GetNamespace())); err != nil {
+		return nil, err
+	}
+
+	for i := range secrets.Items {
+		if metav1.IsControlledBy(&secrets.Items[i], tcp) {
+			objects = append(objects, &secrets.Items[i])
+		}
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := client.List(ctx, configMaps, ctrlclient.InNamespace(tcp.GetNamespace())); err != nil {
+		return nil, err
+	}
+
+	for i := range configMaps.Items {
+		if metav1.IsControlledBy(&configMaps.Items[i], tcp) {
+			objects = append(objects, &configMaps.Items[i])
+		}
+	}
+
+	return objects, nil
+}
+
+// dataStoreObjects returns the given DataStore, along with the Secrets it references.
+func dataStoreObjects(ctx context.Context, client ctrlclient.Client, name string) ([]ctrlclient.Object, error) {
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := client.Get(ctx, types.NamespacedName{Name: name}, ds); err != nil {
+		return nil, err
+	}
+
+	refs := (&kamajiv1alpha1.DatastoreUsedSecret{}).ExtractValue()(ds)
+	if source := ds.Spec.CredentialsFrom; source != nil {
+		refs = append(refs, fmt.Sprintf("%s/%s", source.Namespace, source.Name))
+	}
+
+	var objects []ctrlclient.Object
+
+	seen := map[string]struct{}{}
+
+	for _, ref := range refs {
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+
+		seen[ref] = struct{}{}
+
+		namespace, secretName, _ := strings.Cut(ref, string(types.Separator))
+
+		secret := &corev1.Secret{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+			return nil, fmt.Errorf("cannot retrieve the DataStore Secret %s: %w", ref, err)
+		}
+
+		objects = append(objects, secret)
+	}
+
+	return append(objects, ds), nil
+}
+
+// write serializes the given object, removing the metadata bound to the origin management cluster:
+// the status is kept, being restored by the import, as the TenantControlPlane owner references, set again upon the import.
+func write(out io.Writer, scheme *runtime.Scheme, object ctrlclient.Object) error {
+	gvk, err := apiutil.GVKForObject(object, scheme)
+	if err != nil {
+		return err
+	}
+
+	object.GetObjectKind().SetGroupVersionKind(gvk)
+	object.SetUID("")
+	object.SetResourceVersion("")
+	object.SetGeneration(0)
+	object.SetCreationTimestamp(metav1.Time{})
+	object.SetManagedFields(nil)
+
+	var owners []metav1.OwnerReference
+
+	for _, owner := range object.GetOwnerReferences() {
+		if owner.Kind == "TenantControlPlane" {
+			owner.UID = ""
+			owners = append(owners, owner)
+		}
+	}
+
+	object.SetOwnerReferences(owners)
+	object.SetFinalizers(nil)
+
+	manifest, err := sigsyaml.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "---\n%s", manifest)
+
+	return err
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/cmd/utils"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		files   []string
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:          "import",
+		Short:        "Import a TenantControlPlane exported from another management cluster",
+		Long:         "Import a TenantControlPlane exported with the export command: its reconciliation is paused until the status, and the owned Secrets and ConfigMaps, have been restored, preserving the PKI and the DataStore user and schema.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+			defer cancelFn()
+
+			log := ctrl.Log
+
+			decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+			var objects []ctrlclient.Object
+
+			for _, file := range files {
+				decoded, err := utils.DecodeFile(file, decoder)
+				if err != nil {
+					return err
+				}
+
+				objects = append(objects, decoded...)
+			}
+
+			var tcp *kamajiv1alpha1.TenantControlPlane
+
+			for _, object := range objects {
+				if t, ok := object.(*kamajiv1alpha1.TenantControlPlane); ok {
+					if tcp != nil {
+						return fmt.Errorf("a single TenantControlPlane can be imported at a time")
+					}
+
+					tcp = t
+				}
+			}
+
+			if tcp == nil {
+				return fmt.Errorf("no TenantControlPlane to import")
+			}
+
+			client, err := ctrlclient.New(ctrl.GetConfigOrDie(), ctrlclient.Options{
+				Scheme: scheme,
+			})
+			if err != nil {
+				return err
+			}
+			// The DataStore, along with its Secrets, must be available before the TenantControlPlane validation:
+			// the existing ones are left untouched.
+			for _, object := range objects {
+				if _, ok := object.(*kamajiv1alpha1.TenantControlPlane); ok || isOwnedBy(object, tcp) {
+					continue
+				}
+
+				if err = client.Create(ctx, object); err != nil {
+					if !k8serrors.IsAlreadyExists(err) {
+						return fmt.Errorf("cannot create %s %s: %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetName(), err)
+					}
+
+					log.Info("already existing, skipping", "kind", object.GetObjectKind().GroupVersionKind().Kind, "name", object.GetName())
+				}
+			}
+
+			status := tcp.Status.DeepCopy()
+
+			annotations := tcp.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			delete(annotations, kamajiv1alpha1.ExportedAnnotation)
+			annotations[kamajiv1alpha1.ImportingAnnotation] = "true"
+			tcp.SetAnnotations(annotations)
+
+			log.Info("creating the TenantControlPlane, with the reconciliation paused")
+
+			if err = client.Create(ctx, tcp); err != nil {
+				return err
+			}
+
+			tcp.Status = *status
+			if err = client.Status().Update(ctx, tcp); err != nil {
+				return fmt.Errorf("cannot restore the TenantControlPlane status: %w", err)
+			}
+
+			log.Info("restoring the TenantControlPlane Secrets and ConfigMaps")
+
+			for _, object := range objects {
+				if !isOwnedBy(object, tcp) {
+					continue
+				}
+
+				if err = controllerutil.SetControllerReference(tcp, object, scheme); err != nil {
+					return err
+				}
+
+				if err = client.Create(ctx, object); err != nil {
+					return fmt.Errorf("cannot create %s %s: %w", object.GetObjectKind().GroupVersionKind().Kind, object.GetName(), err)
+				}
+			}
+
+			log.Info("resuming the TenantControlPlane reconciliation")
+
+			patch := ctrlclient.MergeFrom(tcp.DeepCopy())
+
+			annotations = tcp.GetAnnotations()
+			delete(annotations, kamajiv1alpha1.ImportingAnnotation)
+			tcp.SetAnnotations(annotations)
+
+			return client.Patch(ctx, tcp, patch)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "The YAML files generated by the export command: use - to read from the standard input.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Amount of time for the context timeout")
+
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// isOwnedBy returns true for the Secrets, and the ConfigMaps, owned by the given TenantControlPlane:
+// the owner reference is exported with no UID, set again upon the import.
+func isOwnedBy(object ctrlclient.Object, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	owner := metav1.GetControllerOf(object)
+
+	return owner != nil && owner.Kind == "TenantControlPlane" && owner.Name == tcp.GetName() && object.GetNamespace() == tcp.GetNamespace()
+}
@@ -4,15 +4,18 @@
 package manager
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"os"
 	goRuntime "runtime"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -23,7 +26,11 @@ import (
 	"github.com/clastix/kamaji/controllers"
 	"github.com/clastix/kamaji/controllers/soot"
 	"github.com/clastix/kamaji/internal"
+	"github.com/clastix/kamaji/internal/adminapi"
+	kamajidatastore "github.com/clastix/kamaji/internal/datastore"
 	datastoreutils "github.com/clastix/kamaji/internal/datastore/utils"
+	"github.com/clastix/kamaji/internal/notifications"
+	"github.com/clastix/kamaji/internal/resources/addons"
 	"github.com/clastix/kamaji/internal/webhook"
 )
 
@@ -42,8 +49,41 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		webhookCABundle           []byte
 		migrateJobImage           string
 		maxConcurrentReconciles   int
+		healthyStartupDelay       time.Duration
+		ingressExposure           bool
+		etcdClusterController     bool
+		clusterDomain             string
+		cleanupHookJobImages      []string
+		cleanupHookJobSAs         []string
+		addonManifestsHosts       []string
 
 		webhookCAPath string
+
+		notificationSink                  string
+		notificationEndpoint              string
+		notificationEvents                string
+		notificationCertificateExpiration time.Duration
+
+		dataStoreCanaryInterval  time.Duration
+		dataStoreConnectionCheck bool
+		dataStoreFakeDriver      bool
+		auditInterval            time.Duration
+		driftInterval            time.Duration
+		deprecatedAPIsInterval   time.Duration
+		addonsHealthInterval     time.Duration
+		leasesHealthInterval     time.Duration
+		dataStoreGCInterval      time.Duration
+		dataStoreGCGracePeriod   time.Duration
+		dataStoreGCDryRun        bool
+		dataStoreGCPruneSchemas  bool
+
+		adminAPIBindAddress string
+		adminAPITokenFile   string
+		adminAPICertFile    string
+		adminAPIKeyFile     string
+
+		sink       notifications.Sink
+		sinkEvents sets.String
 	)
 
 	ctx := ctrl.SetupSignalHandler()
@@ -70,6 +110,22 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if len(adminAPIBindAddress) > 0 {
+				if err = cmdutils.CheckFlags(cmd.Flags(), []string{"admin-api-tls-cert-file", "admin-api-tls-key-file"}...); err != nil {
+					return err
+				}
+			}
+
+			if len(notificationSink) > 0 {
+				if sink, err = notifications.NewSink(notifications.SinkType(notificationSink), notificationEndpoint); err != nil {
+					return err
+				}
+
+				if sinkEvents, err = notifications.ParseEventTypes(notificationEvents); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -81,6 +137,14 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			setupLog.Info(fmt.Sprintf("Go Version: %s", goRuntime.Version()))
 			setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", goRuntime.GOOS, goRuntime.GOARCH))
 
+			if dataStoreFakeDriver {
+				setupLog.Info("the in-memory DataStore driver is enabled, it must not be used in production")
+
+				kamajidatastore.EnableFakeDriver()
+			}
+
+			addons.SetReleaseManifestsAllowedHosts(addonManifestsHosts)
+
 			mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 				Scheme:                  scheme,
 				MetricsBindAddress:      metricsBindAddress,
@@ -104,6 +168,111 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&controllers.DataStoreMaintenance{}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "DataStoreMaintenance")
+
+				return err
+			}
+
+			if etcdClusterController {
+				if err = (&controllers.EtcdCluster{Namespace: managerNamespace, ClusterDomain: clusterDomain}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "EtcdCluster")
+
+					return err
+				}
+			}
+
+			if err = (&controllers.BulkAction{}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "BulkAction")
+
+				return err
+			}
+
+			if err = (&controllers.TenantControlPlaneStandby{}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneStandby")
+
+				return err
+			}
+
+			if err = (&controllers.TenantControlPlaneKineCleanup{}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneKineCleanup")
+
+				return err
+			}
+
+			// With no Sink, the controller is releasing the finalizers of the previously notified Tenant Control Planes.
+			if err = (&controllers.TenantControlPlaneNotification{
+				Sink:                           sink,
+				Events:                         sinkEvents,
+				CertificateExpirationThreshold: notificationCertificateExpiration,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneNotification")
+
+				return err
+			}
+
+			if dataStoreCanaryInterval > 0 {
+				if err = (&controllers.TenantControlPlaneCanary{Interval: dataStoreCanaryInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneCanary")
+
+					return err
+				}
+			}
+
+			if dataStoreGCInterval > 0 {
+				if err = (&controllers.DataStoreGarbageCollector{APIReader: mgr.GetAPIReader(), Interval: dataStoreGCInterval, GracePeriod: dataStoreGCGracePeriod, DryRun: dataStoreGCDryRun, PruneSchemas: dataStoreGCPruneSchemas}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "DataStoreGarbageCollector")
+
+					return err
+				}
+			}
+
+			if err = (&controllers.KonnectivityReadinessGate{}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "KonnectivityReadinessGate")
+
+				return err
+			}
+
+			if driftInterval > 0 {
+				if err = (&controllers.TenantControlPlaneDrift{Interval: driftInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneDrift")
+
+					return err
+				}
+			}
+
+			if auditInterval > 0 {
+				if err = (&controllers.TenantControlPlaneAudit{Interval: auditInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneAudit")
+
+					return err
+				}
+			}
+
+			if addonsHealthInterval > 0 {
+				if err = (&controllers.TenantControlPlaneAddonsHealth{Interval: addonsHealthInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneAddonsHealth")
+
+					return err
+				}
+			}
+
+			if leasesHealthInterval > 0 {
+				if err = (&controllers.TenantControlPlaneLeasesHealth{Interval: leasesHealthInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneLeasesHealth")
+
+					return err
+				}
+			}
+
+			if deprecatedAPIsInterval > 0 {
+				if err = (&controllers.TenantControlPlaneDeprecatedAPIs{Interval: deprecatedAPIsInterval}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneDeprecatedAPIs")
+
+					return err
+				}
+			}
+
 			reconciler := &controllers.TenantControlPlaneReconciler{
 				Client:    mgr.GetClient(),
 				APIReader: mgr.GetAPIReader(),
@@ -111,6 +280,8 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 					DefaultDataStoreName: datastore,
 					KineContainerImage:   kineImage,
 					TmpBaseDirectory:     tmpDirectory,
+					IngressExposure:      ingressExposure,
+					CleanupHookJobs:      kamajiv1alpha1.CleanupHookJobPolicy{Images: cleanupHookJobImages, ServiceAccounts: cleanupHookJobSAs},
 				},
 				TriggerChan:             tcpChannel,
 				KamajiNamespace:         managerNamespace,
@@ -118,6 +289,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				KamajiService:           managerServiceName,
 				KamajiMigrateImage:      migrateJobImage,
 				MaxConcurrentReconciles: maxConcurrentReconciles,
+				HealthyStartupDelay:     healthyStartupDelay,
 			}
 
 			if err = reconciler.SetupWithManager(mgr); err != nil {
@@ -132,6 +304,12 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&webhook.ReadOnly{}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to register webhook", "webhook", "ReadOnly")
+
+				return err
+			}
+
 			if err = (&kamajiv1alpha1.DatastoreUsedSecret{}).SetupWithManager(ctx, mgr); err != nil {
 				setupLog.Error(err, "unable to create indexer", "indexer", "DatastoreUsedSecret")
 
@@ -144,28 +322,49 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
-			if err = (&kamajiv1alpha1.TenantControlPlane{}).SetupWebhookWithManager(mgr, datastore); err != nil {
+			if err = (&kamajiv1alpha1.TenantControlPlane{}).SetupWebhookWithManager(mgr, datastore, ingressExposure, kamajiv1alpha1.CleanupHookJobPolicy{Images: cleanupHookJobImages, ServiceAccounts: cleanupHookJobSAs}); err != nil {
 				setupLog.Error(err, "unable to create webhook", "webhook", "TenantControlPlane")
 
 				return err
 			}
-			if err = (&kamajiv1alpha1.DataStore{}).SetupWebhookWithManager(mgr); err != nil {
+			var dataStoreConnectionCheckFn kamajiv1alpha1.DataStoreConnectionCheckFn
+			if dataStoreConnectionCheck {
+				dataStoreConnectionCheckFn = func(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+					return kamajidatastore.CheckConnection(ctx, mgr.GetClient(), ds)
+				}
+			}
+
+			if err = (&kamajiv1alpha1.DataStore{}).SetupWebhookWithManager(mgr, dataStoreConnectionCheckFn); err != nil {
 				setupLog.Error(err, "unable to create webhook", "webhook", "DataStore")
 
 				return err
 			}
 
 			if err = (&soot.Manager{
-				MigrateCABundle:         webhookCABundle,
-				MigrateServiceName:      managerServiceName,
-				MigrateServiceNamespace: managerNamespace,
-				AdminClient:             mgr.GetClient(),
+				MigrateCABundle:           webhookCABundle,
+				MigrateServiceName:        managerServiceName,
+				MigrateServiceNamespace:   managerNamespace,
+				StorageTemplatesNamespace: managerNamespace,
+				AdminClient:               mgr.GetClient(),
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to set up soot manager")
 
 				return err
 			}
 
+			if len(adminAPIBindAddress) > 0 {
+				if err = (&adminapi.Server{
+					BindAddress: adminAPIBindAddress,
+					TokenFile:   adminAPITokenFile,
+					CertFile:    adminAPICertFile,
+					KeyFile:     adminAPIKeyFile,
+				}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to set up the admin API")
+
+					return err
+				}
+			}
+
 			if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 				setupLog.Error(err, "unable to set up health check")
 
@@ -204,9 +403,36 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().StringVar(&datastore, "datastore", "etcd", "The default DataStore that should be used by Kamaji to setup the required storage.")
 	cmd.Flags().StringVar(&migrateJobImage, "migrate-image", fmt.Sprintf("clastix/kamaji:v%s", internal.GitTag), "Specify the container image to launch when a TenantControlPlane is migrated to a new datastore.")
 	cmd.Flags().IntVar(&maxConcurrentReconciles, "max-concurrent-tcp-reconciles", 1, "Specify the number of workers for the Tenant Control Plane controller (beware of CPU consumption)")
+	cmd.Flags().DurationVar(&healthyStartupDelay, "healthy-tcp-startup-delay", 10*time.Second, "Fixed delay of the healthy Tenant Control Planes reconciliation upon start-up and resync, giving a head start to the not ready ones: it doesn't reorder the queue, thus it should be tuned on the number of tenants, and it's disabled when zero.")
 	cmd.Flags().StringVar(&managerNamespace, "pod-namespace", os.Getenv("POD_NAMESPACE"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&managerServiceName, "webhook-service-name", "kamaji-webhook-service", "The Kamaji webhook server Service name which is used to get validation webhooks, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&managerServiceAccountName, "serviceaccount-name", os.Getenv("SERVICE_ACCOUNT"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
+	cmd.Flags().StringVar(&notificationSink, "notification-sink", "", "The sink used to notify the Tenant Control Plane lifecycle events, one of webhook, slack, or cloudevents: notifications are disabled when empty.")
+	cmd.Flags().StringVar(&notificationEndpoint, "notification-endpoint", "", "The URL of the notification sink receiving the Tenant Control Plane lifecycle events.")
+	cmd.Flags().StringVar(&notificationEvents, "notification-events", "", "Comma separated list of the Tenant Control Plane lifecycle events to notify, among created, ready, upgraded, degraded, deleted, and certificate-expiring: all of them when empty.")
+	cmd.Flags().DurationVar(&notificationCertificateExpiration, "notification-certificate-expiration-threshold", 30*24*time.Hour, "The time left before the expiration of a Tenant Control Plane certificate to send the certificate-expiring notification.")
+	cmd.Flags().DurationVar(&dataStoreCanaryInterval, "datastore-canary-interval", 0, "The interval used to probe the write and read latency of each Tenant Control Plane through its DataStore data path, published as metrics: the canary is disabled when zero.")
+	cmd.Flags().BoolVar(&dataStoreFakeDriver, "datastore-fake-driver", false, "Serve the DataStore objects annotated with kamaji.clastix.io/fake-driver=true with the in-memory driver, exercising the reconciliation with no data store to provision: for testing purposes only.")
+	cmd.Flags().BoolVar(&dataStoreConnectionCheck, "datastore-connection-check", false, "Establish a connection to the DataStore upon its creation and update, rejecting the configurations which cannot connect.")
+	cmd.Flags().DurationVar(&auditInterval, "audit-interval", 0, "The interval used to generate the credentials audit report ConfigMap of each Tenant Control Plane: the report is disabled when zero.")
+	cmd.Flags().DurationVar(&driftInterval, "drift-detection-interval", 0, "The interval used to compare the live settings of the Tenant Control Plane components with the declared ones, reporting the DriftDetected condition: the detection is disabled when zero.")
+	cmd.Flags().DurationVar(&addonsHealthInterval, "addons-health-interval", 0, "The interval used to collect the health of the addons from their workloads in each Tenant Cluster, reporting it in the addons status along with the running version: the collection is disabled when zero.")
+	cmd.Flags().DurationVar(&leasesHealthInterval, "leases-health-interval", 0, "The interval used to collect the leader election leases of the kube-controller-manager and kube-scheduler from each Tenant Cluster, reporting the ControllerManagerLeaseHealthy and SchedulerLeaseHealthy conditions: the collection is disabled when zero.")
+	cmd.Flags().DurationVar(&deprecatedAPIsInterval, "deprecated-apis-interval", 0, "The interval used to collect the deprecated APIs requested to each Tenant Control Plane, reporting the DeprecatedAPIsInUse condition and refusing the upgrades removing them: the collection is disabled when zero.")
+	cmd.Flags().DurationVar(&dataStoreGCInterval, "datastore-gc-interval", 0, "The interval used to remove from each DataStore the users, and etcd roles, of the Tenant Control Planes which no longer exist: the garbage collection is disabled when zero.")
+	cmd.Flags().DurationVar(&dataStoreGCGracePeriod, "datastore-gc-grace-period", time.Hour, "The minimum age of the users and schemas, since their creation by Kamaji, before being considered by the garbage collection.")
+	cmd.Flags().BoolVar(&dataStoreGCDryRun, "datastore-gc-dry-run", false, "Report the orphaned users and schemas of the DataStore objects, with logs and metrics, without deleting them.")
+	cmd.Flags().BoolVar(&dataStoreGCPruneSchemas, "datastore-gc-prune-schemas", false, "Delete the orphaned schemas, or etcd prefixes, along with their data: they could have been retained on purpose by the DataStore retention policy.")
+	cmd.Flags().StringVar(&adminAPIBindAddress, "admin-api-bind-address", "", "The address the admin API, used to automate the Tenant Control Planes lifecycle, binds to: the API is disabled when empty.")
+	cmd.Flags().StringVar(&adminAPITokenFile, "admin-api-token-file", "", "Path to the file containing the optional static bearer token of the admin API, allowed to use all the routes: the other tokens are authenticated, and authorized, with the Kubernetes API.")
+	cmd.Flags().StringVar(&adminAPICertFile, "admin-api-tls-cert-file", "", "Path to the TLS certificate served by the admin API.")
+	cmd.Flags().StringVar(&adminAPIKeyFile, "admin-api-tls-key-file", "", "Path to the TLS private key of the admin API.")
+	cmd.Flags().BoolVar(&ingressExposure, "ingress-exposure", true, "Allow the Tenant Control Planes to be exposed with an Ingress: when disabled, the Ingress objects are not watched, requiring no permission on them, and the Tenant Control Planes declaring one are refused.")
+	cmd.Flags().BoolVar(&etcdClusterController, "etcd-cluster-controller", true, "Run the controller of the EtcdCluster objects: when disabled, no permission on the EtcdCluster objects and the StatefulSets is required.")
+	cmd.Flags().StringVar(&clusterDomain, "cluster-domain", controllers.DefaultClusterDomain, "The DNS domain of the management cluster, used to address the members of the etcd clusters provisioned by the EtcdCluster controller.")
+	cmd.Flags().StringSliceVar(&cleanupHookJobImages, "cleanup-hook-job-images", nil, "The image patterns, in the Go path.Match syntax, allowed for the clean-up hook Jobs of the Tenant Control Planes: the Jobs are refused when empty.")
+	cmd.Flags().StringSliceVar(&cleanupHookJobSAs, "cleanup-hook-job-service-accounts", nil, "The ServiceAccount names allowed for the clean-up hook Jobs of the Tenant Control Planes, the default one included only when listed.")
+	cmd.Flags().StringSliceVar(&addonManifestsHosts, "addon-manifests-allowed-hosts", addons.DefaultReleaseManifestsHosts, "The hosts the release manifests of the cert-manager and CNI addons can be downloaded from, including the redirections: the manifests URL declared by the Tenant Control Planes is refused for any other host.")
 	cmd.Flags().StringVar(&webhookCAPath, "webhook-ca-path", "/tmp/k8s-webhook-server/serving-certs/ca.crt", "Path to the Manager webhook server CA, required for the TenantControlPlane migration jobs.")
 
 	cobra.OnInitialize(func() {
@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -25,6 +27,9 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		tenantControlPlane string
 		targetDataStore    string
 		timeout            time.Duration
+		fakeDriver         bool
+		keysPerSecond      int64
+		bytesPerSecond     int64
 	)
 
 	cmd := &cobra.Command{
@@ -37,6 +42,10 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 			log := ctrl.Log
 
+			if fakeDriver {
+				datastore.EnableFakeDriver()
+			}
+
 			log.Info("generating the controller-runtime client")
 
 			client, err := ctrlclient.New(ctrl.GetConfigOrDie(), ctrlclient.Options{
@@ -82,7 +91,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 			log.Info("generating the origin storage connection")
 
-			originConnection, err := datastore.NewStorageConnection(ctx, client, *originDs)
+			originConnection, err := datastore.NewPrivilegedStorageConnection(ctx, client, *originDs)
 			if err != nil {
 				return err
 			}
@@ -90,7 +99,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 			log.Info("generating the target storage connection")
 
-			targetConnection, err := datastore.NewStorageConnection(ctx, client, *targetDs)
+			targetConnection, err := datastore.NewPrivilegedStorageConnection(ctx, client, *targetDs)
 			if err != nil {
 				return err
 			}
@@ -98,8 +107,70 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			// Start migrating from the old Datastore to the new one
 			log.Info("migration from origin to target started")
 
-			if err = originConnection.Migrate(ctx, *tcp, targetConnection); err != nil {
-				return fmt.Errorf("unable to migrate data from %s to %s: %w", originDs.GetName(), targetDs.GetName(), err)
+			if err = updateMigrationStatus(ctx, client, tcp, func(status *kamajiv1alpha1.DataStoreMigrationStatus) {
+				*status = kamajiv1alpha1.DataStoreMigrationStatus{
+					Phase:           kamajiv1alpha1.DataStoreMigrationPhaseRunning,
+					TargetDataStore: targetDs.GetName(),
+					StartTime:       metav1.Now(),
+				}
+			}); err != nil {
+				return err
+			}
+
+			var lastReport time.Time
+
+			started := time.Now()
+
+			progress := func(copied, total int64) {
+				// Throttling the status updates, unless the migration is starting, or completed
+				if copied > 0 && copied < total && time.Since(lastReport) < migrationProgressInterval {
+					return
+				}
+
+				lastReport = time.Now()
+
+				if updateErr := updateMigrationStatus(ctx, client, tcp, func(status *kamajiv1alpha1.DataStoreMigrationStatus) {
+					status.KeysCopied, status.KeysTotal = copied, total
+					status.Percentage = 100
+					if total > 0 {
+						status.Percentage = int32(copied * 100 / total)
+					}
+					// The remaining keys are expected to be copied at the same pace of the ones copied so far.
+					status.EstimatedCompletionTime = nil
+					if copied > 0 && copied < total {
+						elapsed := time.Since(started)
+						eta := metav1.NewTime(time.Now().Add(time.Duration(float64(elapsed) * float64(total-copied) / float64(copied))))
+						status.EstimatedCompletionTime = &eta
+					}
+				}); updateErr != nil {
+					log.Error(updateErr, "cannot report the migration progress")
+				}
+			}
+
+			throttle := datastore.NewMigrationThrottle(keysPerSecond, bytesPerSecond)
+
+			if err = originConnection.Migrate(ctx, *tcp, targetConnection, progress, throttle); err != nil {
+				err = fmt.Errorf("unable to migrate data from %s to %s: %w", originDs.GetName(), targetDs.GetName(), err)
+				// The context could be expired, the failure must be reported anyway
+				statusCtx, statusCancelFn := context.WithTimeout(context.Background(), 30*time.Second)
+				defer statusCancelFn()
+
+				if updateErr := updateMigrationStatus(statusCtx, client, tcp, func(status *kamajiv1alpha1.DataStoreMigrationStatus) {
+					status.Phase = kamajiv1alpha1.DataStoreMigrationPhaseFailed
+					status.LastError = err.Error()
+				}); updateErr != nil {
+					log.Error(updateErr, "cannot report the migration failure")
+				}
+
+				return err
+			}
+
+			if err = updateMigrationStatus(ctx, client, tcp, func(status *kamajiv1alpha1.DataStoreMigrationStatus) {
+				status.Phase = kamajiv1alpha1.DataStoreMigrationPhaseCompleted
+				status.Percentage = 100
+				status.EstimatedCompletionTime = nil
+			}); err != nil {
+				return err
 			}
 
 			log.Info("migration completed")
@@ -111,9 +182,35 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().StringVar(&tenantControlPlane, "tenant-control-plane", "", "Namespaced-name of the TenantControlPlane that must be migrated (e.g.: default/test)")
 	cmd.Flags().StringVar(&targetDataStore, "target-datastore", "", "Name of the Datastore to which the TenantControlPlane will be migrated")
 	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Amount of time for the context timeout")
+	cmd.Flags().Int64Var(&keysPerSecond, "keys-per-second", 0, "Maximum number of keys, or rows, copied per second to the target DataStore, zero means unlimited")
+	cmd.Flags().Int64Var(&bytesPerSecond, "bytes-per-second", 0, "Maximum amount of bytes copied per second to the target DataStore, zero means unlimited")
+	cmd.Flags().BoolVar(&fakeDriver, "datastore-fake-driver", false, "Serve the DataStore objects annotated with kamaji.clastix.io/fake-driver=true with the in-memory driver: for testing purposes only.")
 
 	_ = cmd.MarkFlagRequired("tenant-control-plane")
 	_ = cmd.MarkFlagRequired("target-datastore")
 
 	return cmd
 }
+
+// migrationProgressInterval is the minimum interval between two updates of the migration progress.
+const migrationProgressInterval = 5 * time.Second
+
+// updateMigrationStatus applies the given change to the migration status of the TenantControlPlane,
+// retrying on conflicts since the controller is updating the status concurrently.
+func updateMigrationStatus(ctx context.Context, client ctrlclient.Client, tcp *kamajiv1alpha1.TenantControlPlane, fn func(status *kamajiv1alpha1.DataStoreMigrationStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kamajiv1alpha1.TenantControlPlane{}
+		if err := client.Get(ctx, ctrlclient.ObjectKeyFromObject(tcp), latest); err != nil {
+			return err
+		}
+
+		if latest.Status.Storage.Migration == nil {
+			latest.Status.Storage.Migration = &kamajiv1alpha1.DataStoreMigrationStatus{}
+		}
+
+		fn(latest.Status.Storage.Migration)
+		latest.Status.Storage.Migration.LastUpdate = metav1.Now()
+
+		return client.Status().Update(ctx, latest)
+	})
+}
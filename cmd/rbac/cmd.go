@@ -0,0 +1,54 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/clastix/kamaji/internal/rbac"
+)
+
+func NewCmd() *cobra.Command {
+	// CLI flags
+	var (
+		prefix         string
+		namespace      string
+		serviceAccount string
+		features       rbac.Features
+	)
+
+	cmd := &cobra.Command{
+		Use:          "rbac",
+		Short:        "Generate the minimal RBAC required by the operator with the enabled features",
+		Long:         "Generate the ClusterRole, and the leader election Role, required by the operator, along with their bindings to its ServiceAccount: the permissions of the optional features are granted only when the corresponding flags are set, which must match the ones of the manager.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, object := range rbac.Manifests(prefix, namespace, serviceAccount, features) {
+				manifest, err := sigsyaml.Marshal(object)
+				if err != nil {
+					return err
+				}
+
+				if _, err = fmt.Fprintf(cmd.OutOrStdout(), "---\n%s", manifest); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+	// Setting up CLI flags
+	cmd.Flags().StringVar(&prefix, "name-prefix", "kamaji", "The prefix of the names of the generated roles and bindings.")
+	cmd.Flags().StringVar(&namespace, "namespace", "kamaji-system", "The Kubernetes Namespace on which the Operator is running in.")
+	cmd.Flags().StringVar(&serviceAccount, "serviceaccount-name", "kamaji-controller-manager", "The ServiceAccount used by the Operator.")
+	cmd.Flags().BoolVar(&features.IngressExposure, "ingress-exposure", true, "Grant the permissions to expose the Tenant Control Planes with an Ingress, not required when the manager runs with --ingress-exposure=false.")
+	cmd.Flags().BoolVar(&features.EtcdClusterController, "etcd-cluster-controller", true, "Grant the permissions to manage the EtcdCluster objects, not required when the manager runs with --etcd-cluster-controller=false.")
+	cmd.Flags().BoolVar(&features.PodMonitors, "pod-monitors", false, "Grant the permissions to manage the PodMonitor objects scraping the kine metrics.")
+	cmd.Flags().BoolVar(&features.AdminAPI, "admin-api", false, "Grant the permissions to review the tokens, and the access, of the admin API clients, required when the manager runs with --admin-api-bind-address.")
+
+	return cmd
+}
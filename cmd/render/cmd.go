@@ -0,0 +1,81 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package render
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"github.com/clastix/kamaji/cmd/utils"
+	"github.com/clastix/kamaji/controllers"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		files        []string
+		datastore    string
+		kineImage    string
+		tmpDirectory string
+	)
+
+	cmd := &cobra.Command{
+		Use:          "render",
+		Short:        "Render the Kubernetes objects created for a TenantControlPlane, with no API Server involved",
+		Long:         "Render the Kubernetes objects Kamaji would create for a TenantControlPlane to YAML, without applying them: the files must contain the TenantControlPlane, its DataStore, and the DataStore Secrets, if any.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+			var objects []client.Object
+
+			for _, file := range files {
+				decoded, err := utils.DecodeFile(file, decoder)
+				if err != nil {
+					return err
+				}
+
+				objects = append(objects, decoded...)
+			}
+
+			rendered, err := controllers.Render(context.Background(), scheme, controllers.TenantControlPlaneReconcilerConfig{
+				DefaultDataStoreName: datastore,
+				KineContainerImage:   kineImage,
+				TmpBaseDirectory:     tmpDirectory,
+				IngressExposure:      true,
+			}, objects...)
+			if err != nil {
+				return err
+			}
+
+			for _, object := range rendered {
+				manifest, err := sigsyaml.Marshal(object)
+				if err != nil {
+					return err
+				}
+
+				if _, err = fmt.Fprintf(cmd.OutOrStdout(), "---\n%s", manifest); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+	// Setting up CLI flags
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "The YAML files containing the TenantControlPlane, its DataStore, and the DataStore Secrets: use - to read from the standard input.")
+	cmd.Flags().StringVar(&datastore, "datastore", "etcd", "The default DataStore that should be used by Kamaji to setup the required storage.")
+	cmd.Flags().StringVar(&kineImage, "kine-image", "rancher/kine:v0.9.2-amd64", "Container image along with tag to use for the Kine sidecar container (used only if etcd-storage-type is set to one of kine strategies).")
+	cmd.Flags().StringVar(&tmpDirectory, "tmp-directory", "/tmp/kamaji", "Directory which will be used to work with temporary files.")
+
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DecodeFile decodes the Kubernetes objects of the given multi-document YAML file: use - to read from the standard input.
+func DecodeFile(file string, decoder runtime.Decoder) ([]client.Object, error) {
+	var reader io.Reader = os.Stdin
+
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		reader = f
+	}
+
+	var objects []client.Object
+
+	documents := yaml.NewYAMLReader(bufio.NewReader(reader))
+
+	for {
+		document, err := documents.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		decoded, _, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode an object of %s: %w", file, err)
+		}
+
+		object, ok := decoded.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object of %s", file)
+		}
+
+		objects = append(objects, object)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources/addons"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// TenantControlPlaneAddonsHealth periodically collects the health of the addons from their workloads in the Tenant Cluster,
+// such as the available CoreDNS replicas, or the available Konnectivity agents, reporting it in the addons status along with
+// the running version: the degraded addons are visible from the management cluster.
+type TenantControlPlaneAddonsHealth struct {
+	client client.Client
+
+	Interval time.Duration
+}
+
+func (r *TenantControlPlaneAddonsHealth) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if status := tcp.Status.Kubernetes.Version.Status; status == nil || *status != kamajiv1alpha1.VersionReady {
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.client, tcp)
+	if err != nil {
+		log.Error(err, "cannot generate Tenant client")
+
+		return reconcile.Result{}, err
+	}
+
+	workloads, err := addons.Workloads(ctx, tenantClient, tcp)
+	if err != nil {
+		log.Error(err, "cannot retrieve the addons workloads")
+
+		return reconcile.Result{}, err
+	}
+	// The health of the disabled addons is not relevant anymore.
+	for _, status := range []*kamajiv1alpha1.AddonHealthStatus{
+		&tcp.Status.Addons.CoreDNS.AddonHealthStatus,
+		&tcp.Status.Addons.KubeProxy.AddonHealthStatus,
+		&tcp.Status.Addons.Konnectivity.AddonHealthStatus,
+		&tcp.Status.Addons.CertManager.AddonHealthStatus,
+		&tcp.Status.Addons.CNI.AddonHealthStatus,
+		&tcp.Status.Addons.Storage.AddonHealthStatus,
+	} {
+		references, ok := workloads[status]
+		if !ok {
+			*status = kamajiv1alpha1.AddonHealthStatus{}
+
+			continue
+		}
+
+		health, healthErr := addons.CollectHealth(ctx, tenantClient, references)
+		if healthErr != nil {
+			log.Error(healthErr, "cannot collect the addon health")
+
+			return reconcile.Result{}, healthErr
+		}
+
+		addons.SetHealth(status, health, tcp.GetGeneration())
+	}
+
+	if err = r.client.Status().Update(ctx, tcp); err != nil {
+		log.Error(err, "cannot update the addons health")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+func (r *TenantControlPlaneAddonsHealth) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneAddonsHealth) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-addons-health").
+		// The collections are scheduled by the requeue interval: updates are ignored to keep the rate steady.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
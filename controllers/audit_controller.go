@@ -0,0 +1,121 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/audit"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// AuditReportKey is the ConfigMap key storing the credentials audit report, in JSON format.
+const AuditReportKey = "report.json"
+
+// TenantControlPlaneAudit periodically generates a ConfigMap for each Tenant Control Plane listing all the credentials
+// managed by Kamaji, along with their age, algorithm, expiration, and last rotation, as compliance evidence.
+type TenantControlPlaneAudit struct {
+	client client.Client
+
+	Interval time.Duration
+}
+
+func (r *TenantControlPlaneAudit) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := r.client.List(ctx, secretList, client.InNamespace(tcp.GetNamespace())); err != nil {
+		log.Error(err, "cannot list the Tenant Control Plane Secrets")
+
+		return reconcile.Result{}, err
+	}
+
+	secrets := make([]corev1.Secret, 0, len(secretList.Items))
+
+	for i := range secretList.Items {
+		if metav1.IsControlledBy(&secretList.Items[i], tcp) {
+			secrets = append(secrets, secretList.Items[i])
+		}
+	}
+
+	report, err := json.MarshalIndent(audit.NewReport(fmt.Sprintf("%s/%s", tcp.GetNamespace(), tcp.GetName()), secrets, time.Now().UTC()), "", "  ")
+	if err != nil {
+		log.Error(err, "cannot marshal the credentials audit report")
+
+		return reconcile.Result{}, err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix("credentials-audit", tcp),
+			Namespace: tcp.GetNamespace(),
+		},
+	}
+
+	if _, err = utilities.CreateOrUpdateWithConflict(ctx, r.client, configMap, func() error {
+		configMap.SetLabels(utilities.MergeMaps(configMap.GetLabels(), utilities.KamajiLabels(), map[string]string{
+			"kamaji.clastix.io/name":      tcp.GetName(),
+			"kamaji.clastix.io/component": "credentials-audit",
+		}))
+		configMap.Data = map[string]string{
+			AuditReportKey: string(report),
+		}
+
+		return controllerruntime.SetControllerReference(tcp, configMap, r.client.Scheme())
+	}); err != nil {
+		log.Error(err, "cannot store the credentials audit report")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+func (r *TenantControlPlaneAudit) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneAudit) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-audit").
+		// The reports are scheduled by the requeue interval: updates are ignored to keep the generation rate steady.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
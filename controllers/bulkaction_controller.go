@@ -0,0 +1,222 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// bulkActionStatusBatchSize is the number of target results persisted with a single status update:
+// upon a failed update only the results of the current batch are lost, and their targets processed again.
+const bulkActionStatusBatchSize = 10
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=bulkactions,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=bulkactions/status,verbs=get;update;patch
+
+// BulkAction performs the action declared by each BulkAction object on the Tenant Control Planes matching its selectors,
+// reporting the result for each of them: the action is performed once, and it's completed even if failed for some targets.
+type BulkAction struct {
+	client client.Client
+	// statusBatchSize overrides the bulkActionStatusBatchSize, if set.
+	statusBatchSize int
+}
+
+func (r *BulkAction) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	action := &kamajiv1alpha1.BulkAction{}
+	if err := r.client.Get(ctx, request.NamespacedName, action); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if action.Status.CompletionTime != nil || action.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	targets, err := r.targets(ctx, action)
+	if err != nil {
+		log.Error(err, "unable to retrieve the targeted Tenant Control Planes")
+
+		return reconcile.Result{}, err
+	}
+	// Skipping the Tenant Control Planes whose result has been persisted by a previous, interrupted, reconciliation.
+	processed := make(map[string]struct{}, len(action.Status.Targets))
+	for _, target := range action.Status.Targets {
+		processed[fmt.Sprintf("%s/%s", target.Namespace, target.Name)] = struct{}{}
+	}
+
+	batchSize := r.statusBatchSize
+	if batchSize <= 0 {
+		batchSize = bulkActionStatusBatchSize
+	}
+
+	pending := 0
+
+	for i := range targets {
+		tcp := &targets[i]
+
+		if _, ok := processed[fmt.Sprintf("%s/%s", tcp.GetNamespace(), tcp.GetName())]; ok {
+			continue
+		}
+
+		result := kamajiv1alpha1.BulkActionTarget{
+			Namespace: tcp.GetNamespace(),
+			Name:      tcp.GetName(),
+			Result:    kamajiv1alpha1.BulkActionResultSucceeded,
+			Time:      metav1.Now(),
+		}
+
+		if actionErr := r.perform(ctx, action.Spec.Action, tcp); actionErr != nil {
+			log.Error(actionErr, "action failed", "action", action.Spec.Action, "namespace", tcp.GetNamespace(), "name", tcp.GetName())
+
+			result.Result = kamajiv1alpha1.BulkActionResultFailed
+			result.Message = actionErr.Error()
+			action.Status.Failed++
+		} else {
+			action.Status.Succeeded++
+		}
+
+		action.Status.Targets = append(action.Status.Targets, result)
+
+		if pending++; pending < batchSize {
+			continue
+		}
+
+		if err = r.client.Status().Update(ctx, action); err != nil {
+			log.Error(err, "unable to persist the results of the processed targets")
+
+			return reconcile.Result{}, err
+		}
+
+		pending = 0
+	}
+
+	now := metav1.Now()
+	action.Status.CompletionTime = &now
+
+	if err = r.client.Status().Update(ctx, action); err != nil {
+		log.Error(err, "unable to update the status")
+
+		return reconcile.Result{}, err
+	}
+
+	log.Info("bulk action completed", "action", action.Spec.Action, "succeeded", action.Status.Succeeded, "failed", action.Status.Failed)
+
+	return reconcile.Result{}, nil
+}
+
+// targets returns the Tenant Control Planes matching the selectors, skipping the ones being deleted.
+func (r *BulkAction) targets(ctx context.Context, action *kamajiv1alpha1.BulkAction) ([]kamajiv1alpha1.TenantControlPlane, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&action.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if len(action.Spec.DataStore) > 0 {
+		options = append(options, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, action.Spec.DataStore)})
+	}
+
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err = r.client.List(ctx, tcpList, options...); err != nil {
+		return nil, err
+	}
+
+	var namespaces map[string]struct{}
+
+	if action.Spec.NamespaceSelector != nil {
+		namespaceSelector, nsErr := metav1.LabelSelectorAsSelector(action.Spec.NamespaceSelector)
+		if nsErr != nil {
+			return nil, nsErr
+		}
+
+		nsList := &corev1.NamespaceList{}
+		if err = r.client.List(ctx, nsList, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+			return nil, err
+		}
+
+		namespaces = make(map[string]struct{}, len(nsList.Items))
+		for _, ns := range nsList.Items {
+			namespaces[ns.GetName()] = struct{}{}
+		}
+	}
+
+	var targets []kamajiv1alpha1.TenantControlPlane
+
+	for _, tcp := range tcpList.Items {
+		if tcp.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		if namespaces != nil {
+			if _, ok := namespaces[tcp.GetNamespace()]; !ok {
+				continue
+			}
+		}
+
+		targets = append(targets, tcp)
+	}
+
+	return targets, nil
+}
+
+func (r *BulkAction) perform(ctx context.Context, action kamajiv1alpha1.BulkActionType, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	switch action {
+	case kamajiv1alpha1.BulkActionRotateCertificates:
+		return utilities.RequestCertificatesRotation(ctx, r.client, tcp)
+	case kamajiv1alpha1.BulkActionPause, kamajiv1alpha1.BulkActionResume:
+		patch := client.MergeFrom(tcp.DeepCopy())
+
+		annotations := tcp.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		if action == kamajiv1alpha1.BulkActionPause {
+			annotations[kamajiv1alpha1.PausedAnnotation] = "true"
+		} else {
+			delete(annotations, kamajiv1alpha1.PausedAnnotation)
+		}
+
+		tcp.SetAnnotations(annotations)
+
+		return r.client.Patch(ctx, tcp, patch)
+	default:
+		return fmt.Errorf("unsupported action %s", action)
+	}
+}
+
+func (r *BulkAction) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *BulkAction) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		// The actions are performed once: the status updates are not taken into account.
+		For(&kamajiv1alpha1.BulkAction{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// failingStatusClient counts the patches of the Tenant Control Planes, failing the given status update.
+type failingStatusClient struct {
+	client.Client
+	patches      map[string]int
+	updates      int
+	failedUpdate int
+}
+
+func (c *failingStatusClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patches[obj.GetName()]++
+
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *failingStatusClient) Status() client.StatusWriter {
+	return &failingStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type failingStatusWriter struct {
+	client.StatusWriter
+	client *failingStatusClient
+}
+
+func (w *failingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if w.client.updates++; w.client.updates == w.client.failedUpdate {
+		return fmt.Errorf("status update failed")
+	}
+
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func TestBulkActionReconcileRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		batchSize    int
+		failedUpdate int
+		patches      map[string]int
+	}{
+		{
+			name:         "failed final update",
+			batchSize:    1,
+			failedUpdate: 4,
+			patches:      map[string]int{"tcp-1": 1, "tcp-2": 1, "tcp-3": 1},
+		},
+		{
+			name:         "failed batch update",
+			batchSize:    1,
+			failedUpdate: 2,
+			patches:      map[string]int{"tcp-1": 1, "tcp-2": 2, "tcp-3": 1},
+		},
+		{
+			name:         "failed update of a batch with multiple targets",
+			batchSize:    2,
+			failedUpdate: 1,
+			patches:      map[string]int{"tcp-1": 2, "tcp-2": 2, "tcp-3": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			action := &kamajiv1alpha1.BulkAction{
+				ObjectMeta: metav1.ObjectMeta{Name: "pause"},
+				Spec: kamajiv1alpha1.BulkActionSpec{
+					Action:   kamajiv1alpha1.BulkActionPause,
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				},
+			}
+
+			objects := []client.Object{action}
+			for _, name := range []string{"tcp-1", "tcp-2", "tcp-3"} {
+				objects = append(objects, &kamajiv1alpha1.TenantControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"team": "payments"}},
+				})
+			}
+
+			c := &failingStatusClient{
+				Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+				patches:      map[string]int{},
+				failedUpdate: tt.failedUpdate,
+			}
+
+			r := &BulkAction{statusBatchSize: tt.batchSize}
+			if err := r.InjectClient(c); err != nil {
+				t.Fatal(err)
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: action.GetName()}}
+
+			if _, err := r.Reconcile(context.Background(), request); err == nil {
+				t.Fatal("expected the failed status update to be returned")
+			}
+
+			if _, err := r.Reconcile(context.Background(), request); err != nil {
+				t.Fatal(err)
+			}
+
+			for name, expected := range tt.patches {
+				if c.patches[name] != expected {
+					t.Errorf("expected %s to be patched %d times, got %d", name, expected, c.patches[name])
+				}
+			}
+
+			if err := c.Get(context.Background(), request.NamespacedName, action); err != nil {
+				t.Fatal(err)
+			}
+
+			if action.Status.CompletionTime == nil {
+				t.Error("expected the action to be completed")
+			}
+
+			if action.Status.Succeeded != 3 || action.Status.Failed != 0 || len(action.Status.Targets) != 3 {
+				t.Errorf("expected 3 succeeded targets, got %d succeeded, %d failed, and %d results", action.Status.Succeeded, action.Status.Failed, len(action.Status.Targets))
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+// TenantControlPlaneCanary periodically writes, and reads back, a sentinel key through the data path of each
+// Tenant Control Plane, publishing the latencies as metrics to detect the noisy neighbours of shared DataStores.
+type TenantControlPlaneCanary struct {
+	client client.Client
+
+	Interval time.Duration
+}
+
+func (r *TenantControlPlaneCanary) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			metrics.DeleteCanary(request.Namespace, request.Name)
+
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		metrics.DeleteCanary(request.Namespace, request.Name)
+
+		return reconcile.Result{}, nil
+	}
+	// The Tenant Control Plane storage is not yet ready, nothing to probe.
+	if len(tcp.Status.Storage.DataStoreName) == 0 || len(tcp.Status.Storage.Setup.Schema) == 0 {
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Name: tcp.Status.Storage.DataStoreName}, ds); err != nil {
+		log.Error(err, "cannot retrieve the DataStore of the Tenant Control Plane")
+
+		return reconcile.Result{}, err
+	}
+
+	conn, err := datastore.NewStorageConnection(ctx, r.client, *ds)
+	if err != nil {
+		log.Error(err, "cannot create the connection to the DataStore")
+
+		return reconcile.Result{}, err
+	}
+	defer conn.Close()
+
+	prober, ok := conn.(datastore.Prober)
+	if !ok {
+		log.Info("the DataStore driver doesn't support the canary", "driver", conn.Driver())
+
+		return reconcile.Result{}, nil
+	}
+
+	write, read, err := prober.Probe(ctx, tcp.Status.Storage.Setup.Schema)
+	if err != nil {
+		log.Error(err, "DataStore canary failed", "datastore", ds.GetName())
+
+		metrics.RecordCanaryFailure(tcp.GetNamespace(), tcp.GetName(), ds.GetName())
+
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	metrics.RecordCanaryLatency(tcp.GetNamespace(), tcp.GetName(), ds.GetName(), write, read)
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+func (r *TenantControlPlaneCanary) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneCanary) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-canary").
+		// The probes are scheduled by the requeue interval: updates are ignored to keep the probing rate steady.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
@@ -5,11 +5,16 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -22,10 +27,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
 )
 
 type DataStore struct {
-	client client.Client
+	client   client.Client
+	recorder record.EventRecorder
 	// TenantControlPlaneTrigger is the channel used to communicate across the controllers:
 	// if a Data Source is updated we have to be sure that the reconciliation of the certificates content
 	// for each Tenant Control Plane is put in place properly.
@@ -34,6 +41,7 @@ type DataStore struct {
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log := log.FromContext(ctx)
@@ -65,6 +73,14 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 	}
 
 	ds.Status.UsedBy = tcpSets.List()
+	// Verifying the credentials upon each change, such as the rotation of the root ones:
+	// the Tenant Control Planes are triggered anyway, re-granting the privileges of the per-tenant users.
+	// The check is skipped in maintenance mode, since the database could be unreachable on purpose.
+	if !ds.Spec.MaintenanceMode {
+		r.setCredentialsCondition(ctx, ds)
+	}
+
+	r.setSaturatedCondition(ds)
 
 	if err := r.client.Status().Update(ctx, ds); err != nil {
 		log.Error(err, "cannot update the status for the given instance")
@@ -81,6 +97,77 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 	return reconcile.Result{}, nil
 }
 
+func (r *DataStore) setCredentialsCondition(ctx context.Context, ds *kamajiv1alpha1.DataStore) {
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeDataStoreCredentialsReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ds.GetGeneration(),
+		Reason:             "Verified",
+		Message:            "the credentials allow managing the Tenant Control Plane users and schemas",
+	}
+
+	if err := r.checkCredentials(ctx, ds); err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InsufficientPrivileges"
+		condition.Message = err.Error()
+	}
+
+	previous := meta.FindStatusCondition(ds.Status.Conditions, condition.Type)
+	if condition.Status == metav1.ConditionFalse && (previous == nil || previous.Status != condition.Status) {
+		r.recorder.Event(ds, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, condition)
+}
+
+func (r *DataStore) setSaturatedCondition(ds *kamajiv1alpha1.DataStore) {
+	if ds.Spec.MaxTenants == nil {
+		meta.RemoveStatusCondition(&ds.Status.Conditions, kamajiv1alpha1.ConditionTypeDataStoreSaturated)
+
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeDataStoreSaturated,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: ds.GetGeneration(),
+		Reason:             "CapacityAvailable",
+		Message:            fmt.Sprintf("%d out of %d Tenant Control Planes", len(ds.Status.UsedBy), *ds.Spec.MaxTenants),
+	}
+
+	if len(ds.Status.UsedBy) >= int(*ds.Spec.MaxTenants) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "MaxTenantsReached"
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, condition)
+}
+
+func (r *DataStore) checkCredentials(ctx context.Context, ds *kamajiv1alpha1.DataStore) error {
+	conn, err := datastore.NewStorageConnection(ctx, r.client, *ds)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err = conn.Check(ctx); err != nil {
+		return err
+	}
+	// The privileges are required by the privileged credentials only, when declared.
+	if ds.Spec.PrivilegedAuth != nil {
+		if conn, err = datastore.NewPrivilegedStorageConnection(ctx, r.client, *ds); err != nil {
+			return err
+		}
+		defer conn.Close()
+	}
+
+	if checker, ok := conn.(datastore.PrivilegesChecker); ok {
+		return checker.CheckPrivileges(ctx)
+	}
+
+	return nil
+}
+
 func (r *DataStore) InjectClient(client client.Client) error {
 	r.client = client
 
@@ -88,6 +175,8 @@ func (r *DataStore) InjectClient(client client.Client) error {
 }
 
 func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("datastore-controller")
+
 	enqueueFn := func(tcp *kamajiv1alpha1.TenantControlPlane, limitingInterface workqueue.RateLimitingInterface) {
 		if dataStoreName := tcp.Status.Storage.DataStoreName; len(dataStoreName) > 0 {
 			limitingInterface.AddRateLimited(reconcile.Request{
@@ -114,5 +203,30 @@ func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
 				enqueueFn(deleteEvent.Object.(*kamajiv1alpha1.TenantControlPlane), limitingInterface)
 			},
 		}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.dataStoresUsingSecret)).
 		Complete(r)
 }
+
+// dataStoresUsingSecret maps a Secret to the DataStore objects referencing it:
+// a rotation of the TLS material is propagated to the Tenant Control Planes using them.
+func (r *DataStore) dataStoresUsingSecret(object client.Object) []reconcile.Request {
+	dsList := &kamajiv1alpha1.DataStoreList{}
+
+	if err := r.client.List(context.Background(), dsList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.DatastoreUsedSecretNamespacedNameKey, fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName())),
+	}); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(dsList.Items))
+
+	for _, ds := range dsList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: k8stypes.NamespacedName{
+				Name: ds.GetName(),
+			},
+		})
+	}
+
+	return requests
+}
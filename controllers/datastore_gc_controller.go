@@ -0,0 +1,241 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+// DataStoreGarbageCollector periodically removes from each DataStore the users, and the etcd roles, created for
+// Tenant Control Planes which no longer exist, such as the ones left behind by a failed cleanup.
+// Only the ones recorded as created by this Kamaji installation are considered, at least GracePeriod after their
+// creation: the ones of other installations sharing the DataStore, or moved to another management cluster, are kept.
+// The schemas, or the etcd prefixes, are removed only when PruneSchemas is enabled, since they could have been
+// intentionally retained according to the DataStore retention policy.
+type DataStoreGarbageCollector struct {
+	client client.Client
+	// APIReader lists the Tenant Control Planes bypassing the cache, which could miss the ones just created.
+	APIReader client.Reader
+
+	Interval     time.Duration
+	GracePeriod  time.Duration
+	DryRun       bool
+	PruneSchemas bool
+}
+
+func (r *DataStoreGarbageCollector) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := r.client.Get(ctx, request.NamespacedName, ds); err != nil {
+		if k8serrors.IsNotFound(err) {
+			metrics.DeleteGarbageCollection(request.Name)
+
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if ds.GetDeletionTimestamp() != nil {
+		metrics.DeleteGarbageCollection(request.Name)
+
+		return reconcile.Result{}, nil
+	}
+
+	conn, err := datastore.NewPrivilegedStorageConnection(ctx, r.client, *ds)
+	if err != nil {
+		log.Error(err, "cannot create the connection to the DataStore")
+
+		return reconcile.Result{}, err
+	}
+	defer conn.Close()
+
+	enumerator, ok := conn.(datastore.Enumerator)
+	if !ok {
+		log.Info("the DataStore driver doesn't support the garbage collection", "driver", conn.Driver())
+
+		return reconcile.Result{}, nil
+	}
+
+	users, err := enumerator.ListUsers(ctx)
+	if err != nil {
+		log.Error(err, "cannot list the DataStore users")
+
+		return reconcile.Result{}, err
+	}
+
+	schemas, err := enumerator.ListSchemas(ctx)
+	if err != nil {
+		log.Error(err, "cannot list the DataStore schemas")
+
+		return reconcile.Result{}, err
+	}
+	// Listing the Tenant Control Planes after the users and the schemas: the ones created in between are included.
+	inUse, err := r.tenantResourceNames(ctx)
+	if err != nil {
+		log.Error(err, "cannot list the Tenant Control Planes")
+
+		return reconcile.Result{}, err
+	}
+	// The credentials used by Kamaji could follow the same naming of the tenants.
+	if config, configErr := datastore.NewConnectionConfig(ctx, r.client, *ds); configErr == nil {
+		inUse.Insert(config.User)
+	}
+
+	orphanedUsers := r.orphaned(ds, kamajiv1alpha1.DataStoreResourceUser, users, inUse)
+	orphanedSchemas := r.orphaned(ds, kamajiv1alpha1.DataStoreResourceSchema, schemas, inUse)
+
+	metrics.RecordGarbageCollectionFindings(ds.GetName(), len(orphanedUsers), len(orphanedSchemas))
+
+	if r.DryRun {
+		if len(orphanedUsers) > 0 || len(orphanedSchemas) > 0 {
+			log.Info("orphaned resources found in the DataStore", "users", orphanedUsers, "schemas", orphanedSchemas)
+		}
+
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	for _, user := range orphanedUsers {
+		if err = conn.DeleteUser(ctx, user); err != nil {
+			log.Error(err, "cannot delete the orphaned user", "user", user)
+
+			continue
+		}
+
+		log.Info("orphaned user deleted", "user", user)
+		metrics.RecordGarbageCollectionDeletion(ds.GetName(), "user")
+
+		if err = datastore.ForgetProvisioned(ctx, r.client, ds.GetName(), kamajiv1alpha1.DataStoreResourceUser, user); err != nil {
+			log.Error(err, "cannot forget the deleted user", "user", user)
+		}
+	}
+
+	for _, schema := range orphanedSchemas {
+		kind, deleteErr := r.deleteSchema(ctx, conn, schema)
+		if deleteErr != nil {
+			log.Error(deleteErr, "cannot delete the orphaned schema", "schema", schema)
+
+			continue
+		}
+
+		if len(kind) == 0 {
+			continue
+		}
+
+		log.Info("orphaned "+kind+" deleted", "schema", schema)
+		metrics.RecordGarbageCollectionDeletion(ds.GetName(), kind)
+
+		if err = datastore.ForgetProvisioned(ctx, r.client, ds.GetName(), kamajiv1alpha1.DataStoreResourceSchema, schema); err != nil {
+			log.Error(err, "cannot forget the deleted schema", "schema", schema)
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+// deleteSchema removes the etcd role granting access to the prefix, as the Retain policy does, and the data,
+// either the prefix or the database, only when the pruning is enabled: the kind of the deleted resource is returned,
+// empty when nothing has been deleted.
+func (r *DataStoreGarbageCollector) deleteSchema(ctx context.Context, conn datastore.Connection, schema string) (string, error) {
+	var kind string
+
+	if conn.Driver() == string(kamajiv1alpha1.EtcdDriver) {
+		if err := conn.RevokePrivileges(ctx, "", schema); err != nil {
+			return "", err
+		}
+
+		kind = "role"
+	}
+
+	if !r.PruneSchemas {
+		return kind, nil
+	}
+
+	if err := conn.DeleteDB(ctx, schema); err != nil {
+		return "", err
+	}
+
+	return "schema", nil
+}
+
+// tenantResourceNames returns the users, and the schemas, of all the existing Tenant Control Planes, regardless of
+// their DataStore, taking into account the ones being migrated, or not yet provisioned.
+func (r *DataStoreGarbageCollector) tenantResourceNames(ctx context.Context) (sets.String, error) {
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := r.APIReader.List(ctx, tcpList); err != nil {
+		return nil, err
+	}
+
+	names := sets.NewString()
+
+	for _, tcp := range tcpList.Items {
+		names.Insert(fmt.Sprintf("%s_%s", tcp.GetNamespace(), tcp.GetName()))
+
+		for _, name := range []string{tcp.Spec.DataStoreSchema, tcp.Status.Storage.Setup.User, tcp.Status.Storage.Setup.Schema} {
+			if len(name) > 0 {
+				names.Insert(name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// orphaned returns the given users, or schemas, recorded as created by Kamaji at least GracePeriod ago,
+// which are no longer in use.
+func (r *DataStoreGarbageCollector) orphaned(ds *kamajiv1alpha1.DataStore, kind kamajiv1alpha1.DataStoreResourceKind, names []string, inUse sets.String) []string {
+	var orphaned []string
+
+	for _, name := range names {
+		if inUse.Has(name) {
+			continue
+		}
+
+		provisioned := ds.Status.GetProvisioned(kind, name)
+		if provisioned == nil || time.Since(provisioned.CreationTimestamp.Time) < r.GracePeriod {
+			continue
+		}
+
+		orphaned = append(orphaned, name)
+	}
+
+	return orphaned
+}
+
+func (r *DataStoreGarbageCollector) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *DataStoreGarbageCollector) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("datastore-gc").
+		// The collections are scheduled by the requeue interval: updates are ignored to keep the rate steady.
+		For(&kamajiv1alpha1.DataStore{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
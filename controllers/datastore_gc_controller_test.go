@@ -0,0 +1,98 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+func TestDataStoreGarbageCollectorReconcile(t *testing.T) {
+	datastore.EnableFakeDriver()
+
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	old, recent := metav1.NewTime(time.Now().Add(-2*time.Hour)), metav1.Now()
+
+	ds := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "gc", Annotations: map[string]string{datastore.FakeDriverAnnotation: "true"}},
+		Spec:       kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KineMySQLDriver, Endpoints: []string{"mysql:3306"}},
+	}
+	for name, creationTimestamp := range map[string]metav1.Time{"default_deleted": old, "default_existing": old, "default_recent": recent} {
+		ds.Status.AddProvisioned(kamajiv1alpha1.DataStoreResourceUser, name, creationTimestamp)
+		ds.Status.AddProvisioned(kamajiv1alpha1.DataStoreResourceSchema, name, creationTimestamp)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ds, tcp).Build()
+
+	conn := datastore.NewFakeConnection(*ds)
+	// The users of other Kamaji installations, or imported, are not recorded.
+	for _, name := range []string{"default_deleted", "default_existing", "default_recent", "default_unrecorded"} {
+		if err := conn.CreateUser(context.Background(), name, "password"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := conn.CreateDB(context.Background(), name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gc := &DataStoreGarbageCollector{APIReader: c, Interval: time.Minute, GracePeriod: time.Hour}
+	if err := gc.InjectClient(c); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gc.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: ds.GetName()}}); err != nil {
+		t.Fatal(err)
+	}
+
+	enumerator := conn.(datastore.Enumerator)
+
+	users, err := enumerator.ListUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"default_existing", "default_recent", "default_unrecorded"}; !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected the users %v, got %v", expected, users)
+	}
+	// The schemas are pruned only on demand.
+	schemas, err := enumerator.ListSchemas(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"default_deleted", "default_existing", "default_recent", "default_unrecorded"}; !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected the schemas %v, got %v", expected, schemas)
+	}
+
+	latest := &kamajiv1alpha1.DataStore{}
+	if err = c.Get(context.Background(), types.NamespacedName{Name: ds.GetName()}, latest); err != nil {
+		t.Fatal(err)
+	}
+
+	if latest.Status.GetProvisioned(kamajiv1alpha1.DataStoreResourceUser, "default_deleted") != nil {
+		t.Error("expected the deleted user to be forgotten")
+	}
+
+	if latest.Status.GetProvisioned(kamajiv1alpha1.DataStoreResourceSchema, "default_deleted") == nil {
+		t.Error("expected the retained schema to be recorded")
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// DataStoreMaintenance performs the periodic compaction and defragmentation of the etcd DataStore objects.
+type DataStoreMaintenance struct {
+	client client.Client
+}
+
+func (r *DataStoreMaintenance) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := r.client.Get(ctx, request.NamespacedName, ds); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if ds.Spec.Driver != kamajiv1alpha1.EtcdDriver || ds.Spec.Maintenance == nil || ds.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if ds.Status.Maintenance == nil {
+		ds.Status.Maintenance = &kamajiv1alpha1.DataStoreMaintenanceStatus{}
+	}
+
+	compactionDue, compactionNext := r.isDue(ds.Spec.Maintenance.GetCompactionSchedule(), ds.Status.Maintenance.Compaction)
+	defragmentationDue, defragmentationNext := r.isDue(ds.Spec.Maintenance.Defragmentation, ds.Status.Maintenance.Defragmentation)
+
+	if compactionDue || defragmentationDue {
+		conn, err := datastore.NewStorageConnection(ctx, r.client, *ds)
+		if err != nil {
+			log.Error(err, "cannot create the connection to the DataStore")
+
+			return reconcile.Result{}, err
+		}
+		defer conn.Close()
+
+		maintainer, ok := conn.(datastore.Maintainer)
+		if !ok {
+			return reconcile.Result{}, fmt.Errorf("the %s driver doesn't support maintenance operations", conn.Driver())
+		}
+		// Compaction must precede the defragmentation, since the latter is releasing the space freed up by the former.
+		if compactionDue {
+			ds.Status.Maintenance.Compaction = r.compact(ctx, maintainer, ds.Spec.Maintenance.Compaction.Retention, ds.Status.Maintenance.Compaction)
+
+			if ds.Status.Maintenance.Compaction.Result == kamajiv1alpha1.MaintenanceResultFailed {
+				log.Error(fmt.Errorf("%s", ds.Status.Maintenance.Compaction.Message), "DataStore compaction failed")
+			}
+		}
+
+		if defragmentationDue {
+			endpoints, defragmentationErr := maintainer.Defragment(ctx)
+			ds.Status.Maintenance.Defragmentation = r.runStatus(fmt.Sprintf("defragmented members: %s", strings.Join(endpoints, ",")), defragmentationErr)
+
+			if defragmentationErr != nil {
+				log.Error(defragmentationErr, "DataStore defragmentation failed")
+			}
+		}
+
+		if err = r.client.Status().Update(ctx, ds); err != nil {
+			log.Error(err, "cannot update the status for the given instance")
+
+			return reconcile.Result{}, err
+		}
+
+		_, compactionNext = r.isDue(ds.Spec.Maintenance.GetCompactionSchedule(), ds.Status.Maintenance.Compaction)
+		_, defragmentationNext = r.isDue(ds.Spec.Maintenance.Defragmentation, ds.Status.Maintenance.Defragmentation)
+	}
+
+	return reconcile.Result{RequeueAfter: r.requeueAfter(compactionNext, defragmentationNext)}, nil
+}
+
+// compact discards the revisions up to the compactionTarget one, recording the current head revision
+// as the target of the next run.
+func (r *DataStoreMaintenance) compact(ctx context.Context, maintainer datastore.Maintainer, retention int64, previous *kamajiv1alpha1.MaintenanceRunStatus) *kamajiv1alpha1.MaintenanceRunStatus {
+	head, err := maintainer.Revision(ctx)
+	if err != nil {
+		return r.runStatus("", err)
+	}
+
+	var last int64
+	if previous != nil && previous.Result == kamajiv1alpha1.MaintenanceResultSucceeded {
+		last = previous.Revision
+	}
+
+	status := r.runStatus(fmt.Sprintf("recorded revision %d, compacted by the next run", head), nil)
+
+	if target := compactionTarget(last, head, retention); target > 0 {
+		status = r.runStatus(fmt.Sprintf("compacted revision %d", target), maintainer.Compact(ctx, target))
+	}
+
+	if status.Result == kamajiv1alpha1.MaintenanceResultSucceeded {
+		status.Revision = head
+	}
+
+	return status
+}
+
+// compactionTarget returns the revision to compact to, or zero when none: the head revision is never compacted,
+// since the watchers would fail resuming from any older revision, thus the one observed by the previous run is used,
+// retaining the revisions written since then, along with the given number of the latest revisions, if any.
+func compactionTarget(previous, head, retention int64) int64 {
+	target := previous
+
+	if retention > 0 && (target <= 0 || head-retention < target) {
+		target = head - retention
+	}
+
+	if target <= 0 || target >= head {
+		return 0
+	}
+
+	return target
+}
+
+// isDue returns if the given maintenance operation must be executed, along with the time left before the next run.
+func (r *DataStoreMaintenance) isDue(schedule *kamajiv1alpha1.MaintenanceSchedule, status *kamajiv1alpha1.MaintenanceRunStatus) (bool, time.Duration) {
+	if schedule == nil {
+		return false, 0
+	}
+
+	if status == nil || status.LastRun.IsZero() {
+		return true, schedule.Interval.Duration
+	}
+
+	next := time.Until(status.LastRun.Add(schedule.Interval.Duration))
+	if next <= 0 {
+		return true, schedule.Interval.Duration
+	}
+
+	return false, next
+}
+
+func (r *DataStoreMaintenance) runStatus(message string, err error) *kamajiv1alpha1.MaintenanceRunStatus {
+	status := &kamajiv1alpha1.MaintenanceRunStatus{
+		LastRun: metav1.Now(),
+		Result:  kamajiv1alpha1.MaintenanceResultSucceeded,
+		Message: message,
+	}
+
+	if err != nil {
+		status.Result = kamajiv1alpha1.MaintenanceResultFailed
+		status.Message = err.Error()
+	}
+
+	return status
+}
+
+func (r *DataStoreMaintenance) requeueAfter(durations ...time.Duration) (requeue time.Duration) {
+	for _, d := range durations {
+		if d > 0 && (requeue == 0 || d < requeue) {
+			requeue = d
+		}
+	}
+
+	return requeue
+}
+
+func (r *DataStoreMaintenance) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *DataStoreMaintenance) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("datastore-maintenance").
+		For(&kamajiv1alpha1.DataStore{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+		)).
+		Complete(r)
+}
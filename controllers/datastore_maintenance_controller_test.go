@@ -0,0 +1,32 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import "testing"
+
+func TestCompactionTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		previous  int64
+		head      int64
+		retention int64
+		expected  int64
+	}{
+		{name: "first run without retention", previous: 0, head: 100, retention: 0, expected: 0},
+		{name: "previous revision", previous: 80, head: 100, retention: 0, expected: 80},
+		{name: "first run with retention", previous: 0, head: 100, retention: 30, expected: 70},
+		{name: "retention older than the previous revision", previous: 80, head: 100, retention: 30, expected: 70},
+		{name: "previous revision older than the retention", previous: 50, head: 100, retention: 30, expected: 50},
+		{name: "retention exceeding the head", previous: 0, head: 20, retention: 30, expected: 0},
+		{name: "no writes since the previous run", previous: 100, head: 100, retention: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compactionTarget(tt.previous, tt.head, tt.retention); got != tt.expected {
+				t.Errorf("expected revision %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
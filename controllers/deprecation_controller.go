@@ -0,0 +1,187 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// deprecatedAPIsMetric is set by the API Server for each deprecated API requested since its start.
+const deprecatedAPIsMetric = "apiserver_requested_deprecated_apis"
+
+// TenantControlPlaneDeprecatedAPIs periodically collects the deprecated APIs requested to the Tenant Control Plane
+// API Server, reporting them in the status along with the DeprecatedAPIsInUse condition: the upgrades to a release
+// removing them are refused by the admission webhook.
+// The metric is reset upon the API Server restart, and it's collected from a single replica.
+type TenantControlPlaneDeprecatedAPIs struct {
+	client client.Client
+
+	Interval time.Duration
+}
+
+func (r *TenantControlPlaneDeprecatedAPIs) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if status := tcp.Status.Kubernetes.Version.Status; status == nil || *status != kamajiv1alpha1.VersionReady {
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	usages, err := r.deprecatedAPIs(ctx, tcp)
+	if err != nil {
+		log.Error(err, "cannot collect the deprecated APIs")
+
+		return reconcile.Result{}, err
+	}
+
+	if r.setDeprecatedAPIs(tcp, usages) {
+		if err = r.client.Status().Update(ctx, tcp); err != nil {
+			log.Error(err, "cannot update the deprecated APIs status")
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+func (r *TenantControlPlaneDeprecatedAPIs) deprecatedAPIs(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) ([]kamajiv1alpha1.DeprecatedAPIUsage, error) {
+	clientSet, err := utilities.GetTenantClientSet(ctx, r.client, tcp)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := clientSet.CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	families, err := (&expfmt.TextParser{}).TextToMetricFamilies(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the API Server metrics: %w", err)
+	}
+
+	family, ok := families[deprecatedAPIsMetric]
+	if !ok {
+		return nil, nil
+	}
+
+	var usages []kamajiv1alpha1.DeprecatedAPIUsage
+
+	for _, metric := range family.GetMetric() {
+		if metric.GetGauge().GetValue() == 0 {
+			continue
+		}
+
+		labels := map[string]string{}
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+
+		usages = append(usages, kamajiv1alpha1.DeprecatedAPIUsage{
+			Group:          labels["group"],
+			Version:        labels["version"],
+			Resource:       labels["resource"],
+			Subresource:    labels["subresource"],
+			RemovedRelease: labels["removed_release"],
+		})
+	}
+	// The metrics order is not guaranteed: sorting keeps the status stable across the runs.
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].String() < usages[j].String()
+	})
+
+	return usages, nil
+}
+
+// setDeprecatedAPIs reports the deprecated APIs in use, returning true when changed.
+func (r *TenantControlPlaneDeprecatedAPIs) setDeprecatedAPIs(tcp *kamajiv1alpha1.TenantControlPlane, usages []kamajiv1alpha1.DeprecatedAPIUsage) bool {
+	changed := !equality.Semantic.DeepEqual(tcp.Status.Kubernetes.DeprecatedAPIs, usages)
+
+	tcp.Status.Kubernetes.DeprecatedAPIs = usages
+
+	if len(usages) == 0 {
+		if meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeDeprecatedAPIsInUse) != nil {
+			meta.RemoveStatusCondition(&tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeDeprecatedAPIsInUse)
+
+			changed = true
+		}
+
+		return changed
+	}
+
+	apis := make([]string, 0, len(usages))
+	for _, usage := range usages {
+		apis = append(apis, usage.String())
+	}
+
+	message := strings.Join(apis, ", ")
+
+	if condition := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeDeprecatedAPIsInUse); condition != nil && condition.Message == message {
+		return changed
+	}
+
+	meta.SetStatusCondition(&tcp.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeDeprecatedAPIsInUse,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tcp.GetGeneration(),
+		Reason:             "DeprecatedAPIsRequested",
+		Message:            message,
+	})
+
+	return true
+}
+
+func (r *TenantControlPlaneDeprecatedAPIs) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneDeprecatedAPIs) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-deprecated-apis").
+		// The collections are scheduled by the requeue interval: updates are ignored to keep the rate steady.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
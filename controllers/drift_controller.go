@@ -0,0 +1,252 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/drift"
+	"github.com/clastix/kamaji/internal/resources/konnectivity"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// TenantControlPlaneDrift periodically compares the live settings of the Tenant Control Plane components, such as the
+// flags of the running control plane containers and the addons versions in the Tenant Cluster, with the declared ones:
+// the discrepancies, such as manual hotfixes never declared, are reported with the DriftDetected condition.
+type TenantControlPlaneDrift struct {
+	client client.Client
+
+	Interval time.Duration
+}
+
+func (r *TenantControlPlaneDrift) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+	// Upon provisioning, or upgrades, the live settings are expected to differ.
+	if status := tcp.Status.Kubernetes.Version.Status; status == nil || *status != kamajiv1alpha1.VersionReady {
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	discrepancies, err := r.controlPlaneDrift(ctx, tcp)
+	if err != nil {
+		log.Error(err, "cannot compare the control plane settings")
+
+		return reconcile.Result{}, err
+	}
+
+	addonsDiscrepancies, err := r.addonsDrift(ctx, tcp)
+	if err != nil {
+		log.Error(err, "cannot compare the addons settings")
+
+		return reconcile.Result{}, err
+	}
+
+	discrepancies = append(discrepancies, addonsDiscrepancies...)
+
+	if r.setDriftCondition(tcp, discrepancies) {
+		if err = r.client.Status().Update(ctx, tcp); err != nil {
+			log.Error(err, "cannot update the drift condition")
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+// controlPlaneDrift compares the image tag, and the declared extra arguments, of the running control plane containers.
+func (r *TenantControlPlaneDrift) controlPlaneDrift(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) ([]drift.Discrepancy, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}, deployment); err != nil {
+		return nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	if err = r.client.List(ctx, pods, client.InNamespace(tcp.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	declared := map[string][]string{}
+	if extraArgs := tcp.Spec.ControlPlane.Deployment.ExtraArgs; extraArgs != nil {
+		declared["kube-apiserver"] = extraArgs.APIServer
+		declared["kube-controller-manager"] = extraArgs.ControllerManager
+		declared["kube-scheduler"] = extraArgs.Scheduler
+	}
+
+	var discrepancies []drift.Discrepancy
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		for _, container := range pod.Spec.Containers {
+			if container.Name != "kube-apiserver" && container.Name != "kube-controller-manager" && container.Name != "kube-scheduler" {
+				continue
+			}
+
+			component := fmt.Sprintf("%s/%s", pod.GetName(), container.Name)
+
+			discrepancies = append(discrepancies, drift.CompareImageTag(component, tcp.Spec.Kubernetes.Version, container.Image)...)
+			discrepancies = append(discrepancies, drift.CompareArgs(component, declared[container.Name], container.Args)...)
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// addonsDrift compares the image tag of the addons deployed in the Tenant Cluster with the declared one.
+func (r *TenantControlPlaneDrift) addonsDrift(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) ([]drift.Discrepancy, error) {
+	addons := tcp.Spec.Addons
+
+	declared := map[client.Object]string{}
+
+	// The addons installed once are handed over to the tenant: their changes are not drifts.
+	if addons.KubeProxy != nil && addons.KubeProxy.ReconciliationMode != kamajiv1alpha1.AddonReconciliationModeInstallOnce {
+		tag := addons.KubeProxy.ImageTag
+		if len(tag) == 0 {
+			tag = tcp.Spec.Kubernetes.Version
+		}
+
+		declared[&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "kube-proxy"}}] = tag
+	}
+	// The CoreDNS version is bound to the kubeadm one, unless overridden.
+	if addons.CoreDNS != nil && addons.CoreDNS.ReconciliationMode != kamajiv1alpha1.AddonReconciliationModeInstallOnce && len(addons.CoreDNS.ImageTag) > 0 {
+		declared[&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns"}}] = addons.CoreDNS.ImageTag
+	}
+
+	if addons.Konnectivity != nil && addons.Konnectivity.ReconciliationMode != kamajiv1alpha1.AddonReconciliationModeInstallOnce {
+		declared[&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: konnectivity.AgentNamespace, Name: konnectivity.AgentName}}] = addons.Konnectivity.KonnectivityAgentSpec.Version
+	}
+
+	if len(declared) == 0 {
+		return nil, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.client, tcp)
+	if err != nil {
+		return nil, err
+	}
+
+	var discrepancies []drift.Discrepancy
+
+	for object, tag := range declared {
+		if err = tenantClient.Get(ctx, client.ObjectKeyFromObject(object), object); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		var containers []corev1.Container
+
+		switch o := object.(type) {
+		case *appsv1.DaemonSet:
+			containers = o.Spec.Template.Spec.Containers
+		case *appsv1.Deployment:
+			containers = o.Spec.Template.Spec.Containers
+		}
+
+		for _, container := range containers {
+			discrepancies = append(discrepancies, drift.CompareImageTag(fmt.Sprintf("%s/%s", object.GetName(), container.Name), tag, container.Image)...)
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// setDriftCondition reports the discrepancies with the DriftDetected condition, returning true when changed.
+func (r *TenantControlPlaneDrift) setDriftCondition(tcp *kamajiv1alpha1.TenantControlPlane, discrepancies []drift.Discrepancy) bool {
+	if len(discrepancies) == 0 {
+		if meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeDriftDetected) == nil {
+			return false
+		}
+
+		meta.RemoveStatusCondition(&tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeDriftDetected)
+
+		return true
+	}
+
+	details := make([]string, 0, len(discrepancies))
+	for _, discrepancy := range discrepancies {
+		details = append(details, discrepancy.String())
+	}
+	// The map iteration order is random: sorting keeps the message stable across the runs.
+	sort.Strings(details)
+
+	message := strings.Join(details, "; ")
+
+	if condition := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeDriftDetected); condition != nil && condition.Message == message {
+		return false
+	}
+
+	meta.SetStatusCondition(&tcp.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeDriftDetected,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tcp.GetGeneration(),
+		Reason:             "LiveSettingsDiffer",
+		Message:            message,
+	})
+
+	return true
+}
+
+func (r *TenantControlPlaneDrift) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneDrift) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-drift").
+		// The comparisons are scheduled by the requeue interval: updates are ignored to keep the verification rate steady.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
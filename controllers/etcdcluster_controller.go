@@ -0,0 +1,503 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	etcdClientPort = 2379
+	etcdPeerPort   = 2380
+	etcdMetricPort = 2381
+
+	etcdCACertName     = "ca.crt"
+	etcdCAKeyName      = "ca.key"
+	etcdServerCertName = "server.crt"
+	etcdServerKeyName  = "server.key"
+	etcdRootCertName   = "root.crt"
+	etcdRootKeyName    = "root.key"
+
+	etcdCertificatesPath = "/etc/etcd/pki"
+	etcdDataPath         = "/var/run/etcd"
+	// etcdRootCommonName is the common name of the client certificate used by Kamaji, mapped to the etcd root user.
+	etcdRootCommonName = "root"
+	// etcdNotReadyRequeue is the interval used to check again the etcd members, until all of them are ready.
+	etcdNotReadyRequeue = 10 * time.Second
+	// DefaultClusterDomain is the DNS domain of the management cluster, unless overridden.
+	DefaultClusterDomain = "cluster.local"
+)
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=etcdclusters,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=etcdclusters/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+
+// EtcdCluster provisions a dedicated etcd cluster for each EtcdCluster object: the certificates, the members,
+// and their storage, are created in the Kamaji namespace, and the cluster is exposed as a DataStore once ready.
+type EtcdCluster struct {
+	client client.Client
+
+	// Namespace is the Kubernetes Namespace on which the etcd members are deployed.
+	Namespace string
+	// ClusterDomain is the DNS domain of the management cluster, used to address the etcd members:
+	// the DefaultClusterDomain is used when not specified.
+	ClusterDomain string
+}
+
+func (r *EtcdCluster) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	etcd := &kamajiv1alpha1.EtcdCluster{}
+	if err := r.client.Get(ctx, request.NamespacedName, etcd); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+	// The owned objects are garbage collected upon deletion.
+	if etcd.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	secret, err := r.reconcileCertificates(ctx, etcd)
+	if err != nil {
+		log.Error(err, "cannot reconcile the etcd certificates")
+
+		return reconcile.Result{}, err
+	}
+
+	if err = r.reconcileService(ctx, etcd); err != nil {
+		log.Error(err, "cannot reconcile the etcd Service")
+
+		return reconcile.Result{}, err
+	}
+
+	sts, err := r.reconcileStatefulSet(ctx, etcd, secret)
+	if err != nil {
+		log.Error(err, "cannot reconcile the etcd StatefulSet")
+
+		return reconcile.Result{}, err
+	}
+
+	etcd.Status.ReadyReplicas = sts.Status.ReadyReplicas
+
+	if sts.Status.ReadyReplicas < etcd.Spec.Replicas {
+		r.setReadyCondition(etcd, metav1.ConditionFalse, "MembersNotReady", fmt.Sprintf("%d out of %d etcd members are ready", sts.Status.ReadyReplicas, etcd.Spec.Replicas))
+
+		if err = r.client.Status().Update(ctx, etcd); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{RequeueAfter: etcdNotReadyRequeue}, nil
+	}
+
+	ds := r.dataStore(etcd, secret)
+
+	if !etcd.Status.AuthEnabled {
+		if err = r.enableAuthentication(ctx, *ds); err != nil {
+			log.Error(err, "cannot enable the etcd authentication")
+
+			r.setReadyCondition(etcd, metav1.ConditionFalse, "AuthenticationFailed", err.Error())
+
+			if statusErr := r.client.Status().Update(ctx, etcd); statusErr != nil {
+				log.Error(statusErr, "cannot update the EtcdCluster status")
+			}
+
+			return reconcile.Result{}, err
+		}
+
+		etcd.Status.AuthEnabled = true
+	}
+
+	if err = r.reconcileDataStore(ctx, etcd, ds); err != nil {
+		log.Error(err, "cannot reconcile the DataStore")
+
+		return reconcile.Result{}, err
+	}
+
+	etcd.Status.DataStore = ds.GetName()
+	r.setReadyCondition(etcd, metav1.ConditionTrue, "Provisioned", "the etcd cluster is exposed as a DataStore")
+
+	return reconcile.Result{}, r.client.Status().Update(ctx, etcd)
+}
+
+func (r *EtcdCluster) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *EtcdCluster) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&kamajiv1alpha1.EtcdCluster{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&kamajiv1alpha1.DataStore{}).
+		Complete(r)
+}
+
+func (r *EtcdCluster) name(etcd *kamajiv1alpha1.EtcdCluster) string {
+	return fmt.Sprintf("%s-etcd", etcd.GetName())
+}
+
+func (r *EtcdCluster) labels(etcd *kamajiv1alpha1.EtcdCluster) map[string]string {
+	return map[string]string{
+		"kamaji.clastix.io/name":      etcd.GetName(),
+		"kamaji.clastix.io/component": "etcd",
+	}
+}
+
+func (r *EtcdCluster) clusterDomain() string {
+	if len(r.ClusterDomain) == 0 {
+		return DefaultClusterDomain
+	}
+
+	return r.ClusterDomain
+}
+
+// members returns the fully qualified domain name of each etcd member.
+func (r *EtcdCluster) members(etcd *kamajiv1alpha1.EtcdCluster) []string {
+	members := make([]string, 0, etcd.Spec.Replicas)
+
+	for i := int32(0); i < etcd.Spec.Replicas; i++ {
+		members = append(members, fmt.Sprintf("%s-%d.%s.%s.svc.%s", r.name(etcd), i, r.name(etcd), r.Namespace, r.clusterDomain()))
+	}
+
+	return members
+}
+
+// reconcileCertificates generates the Certificate Authority, the certificate shared by the members for both the client
+// and peer communication, and the root client one: they're generated once, the Certificate Authority signing the
+// certificates of the Tenant Control Planes using the DataStore.
+func (r *EtcdCluster) reconcileCertificates(ctx context.Context, etcd *kamajiv1alpha1.EtcdCluster) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-certs", r.name(etcd)),
+			Namespace: r.Namespace,
+		},
+	}
+
+	_, err := utilities.CreateOrUpdateWithConflict(ctx, r.client, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(), r.labels(etcd)))
+
+		if len(secret.Data[etcdCACertName]) == 0 {
+			caCert, caKey, err := crypto.GenerateCertificateAuthorityPrivateKeyPair(fmt.Sprintf("%s-ca", r.name(etcd)))
+			if err != nil {
+				return err
+			}
+
+			serverTemplate := crypto.NewCertificateTemplate(r.name(etcd))
+			serverTemplate.DNSNames = []string{
+				"localhost",
+				fmt.Sprintf("*.%s.%s.svc", r.name(etcd), r.Namespace),
+				fmt.Sprintf("*.%s.%s.svc.%s", r.name(etcd), r.Namespace, r.clusterDomain()),
+			}
+			serverTemplate.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+
+			serverCert, serverKey, err := crypto.GenerateCertificatePrivateKeyPair(serverTemplate, caCert.Bytes(), caKey.Bytes())
+			if err != nil {
+				return err
+			}
+
+			rootCert, rootKey, err := crypto.GenerateCertificatePrivateKeyPair(crypto.NewCertificateTemplate(etcdRootCommonName), caCert.Bytes(), caKey.Bytes())
+			if err != nil {
+				return err
+			}
+
+			secret.Data = map[string][]byte{
+				etcdCACertName:     caCert.Bytes(),
+				etcdCAKeyName:      caKey.Bytes(),
+				etcdServerCertName: serverCert.Bytes(),
+				etcdServerKeyName:  serverKey.Bytes(),
+				etcdRootCertName:   rootCert.Bytes(),
+				etcdRootKeyName:    rootKey.Bytes(),
+			}
+		}
+
+		return controllerruntime.SetControllerReference(etcd, secret, r.client.Scheme())
+	})
+
+	return secret, err
+}
+
+func (r *EtcdCluster) reconcileService(ctx context.Context, etcd *kamajiv1alpha1.EtcdCluster) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.name(etcd),
+			Namespace: r.Namespace,
+		},
+	}
+
+	_, err := utilities.CreateOrUpdateWithConflict(ctx, r.client, svc, func() error {
+		svc.SetLabels(utilities.MergeMaps(svc.GetLabels(), utilities.KamajiLabels(), r.labels(etcd)))
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+		// The members must be resolvable before being ready, allowing the initial cluster bootstrap.
+		svc.Spec.PublishNotReadyAddresses = true
+		svc.Spec.Selector = r.labels(etcd)
+		svc.Spec.Ports = []corev1.ServicePort{
+			{
+				Name:       "client",
+				Port:       etcdClientPort,
+				TargetPort: intstr.FromInt(etcdClientPort),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			{
+				Name:       "peer",
+				Port:       etcdPeerPort,
+				TargetPort: intstr.FromInt(etcdPeerPort),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		}
+
+		return controllerruntime.SetControllerReference(etcd, svc, r.client.Scheme())
+	})
+
+	return err
+}
+
+func (r *EtcdCluster) reconcileStatefulSet(ctx context.Context, etcd *kamajiv1alpha1.EtcdCluster, secret *corev1.Secret) (*appsv1.StatefulSet, error) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.name(etcd),
+			Namespace: r.Namespace,
+		},
+	}
+
+	initialCluster := make([]string, 0, etcd.Spec.Replicas)
+	for i, member := range r.members(etcd) {
+		initialCluster = append(initialCluster, fmt.Sprintf("%s-%d=https://%s:%d", r.name(etcd), i, member, etcdPeerPort))
+	}
+
+	_, err := utilities.CreateOrUpdateWithConflict(ctx, r.client, sts, func() error {
+		sts.SetLabels(utilities.MergeMaps(sts.GetLabels(), utilities.KamajiLabels(), r.labels(etcd)))
+
+		// The immutable fields are set upon creation only.
+		if sts.GetResourceVersion() == "" {
+			sts.Spec.ServiceName = r.name(etcd)
+			// All the members must be started at once, since each of them waits for the others to form the initial cluster.
+			sts.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+			sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: r.labels(etcd)}
+			sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "data",
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						StorageClassName: etcd.Spec.Storage.StorageClassName,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: etcd.Spec.Storage.Size,
+							},
+						},
+					},
+				},
+			}
+		}
+
+		sts.Spec.Replicas = pointer.Int32(etcd.Spec.Replicas)
+		sts.Spec.Template.SetLabels(r.labels(etcd))
+		sts.Spec.Template.Spec.Volumes = []corev1.Volume{
+			{
+				Name: "certs",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: secret.GetName(),
+					},
+				},
+			},
+		}
+
+		container := corev1.Container{
+			Name:  "etcd",
+			Image: etcd.Spec.Image,
+			Command: []string{
+				"etcd",
+				"--data-dir=" + etcdDataPath,
+				"--name=$(POD_NAME)",
+				"--initial-cluster-state=new",
+				"--initial-cluster=" + strings.Join(initialCluster, ","),
+				"--initial-cluster-token=" + r.name(etcd),
+				fmt.Sprintf("--initial-advertise-peer-urls=https://$(POD_NAME).%s.$(POD_NAMESPACE).svc.%s:%d", r.name(etcd), r.clusterDomain(), etcdPeerPort),
+				fmt.Sprintf("--advertise-client-urls=https://$(POD_NAME).%s.$(POD_NAMESPACE).svc.%s:%d", r.name(etcd), r.clusterDomain(), etcdClientPort),
+				fmt.Sprintf("--listen-client-urls=https://0.0.0.0:%d", etcdClientPort),
+				fmt.Sprintf("--listen-peer-urls=https://0.0.0.0:%d", etcdPeerPort),
+				fmt.Sprintf("--listen-metrics-urls=http://0.0.0.0:%d", etcdMetricPort),
+				"--client-cert-auth=true",
+				"--peer-client-cert-auth=true",
+				fmt.Sprintf("--trusted-ca-file=%s/%s", etcdCertificatesPath, etcdCACertName),
+				fmt.Sprintf("--cert-file=%s/%s", etcdCertificatesPath, etcdServerCertName),
+				fmt.Sprintf("--key-file=%s/%s", etcdCertificatesPath, etcdServerKeyName),
+				fmt.Sprintf("--peer-trusted-ca-file=%s/%s", etcdCertificatesPath, etcdCACertName),
+				fmt.Sprintf("--peer-cert-file=%s/%s", etcdCertificatesPath, etcdServerCertName),
+				fmt.Sprintf("--peer-key-file=%s/%s", etcdCertificatesPath, etcdServerKeyName),
+				"--auto-compaction-mode=periodic",
+				"--auto-compaction-retention=5m",
+				"--snapshot-count=10000",
+				"--quota-backend-bytes=8589934592",
+			},
+			Env: []corev1.EnvVar{
+				{
+					Name: "POD_NAME",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+					},
+				},
+				{
+					Name: "POD_NAMESPACE",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+					},
+				},
+			},
+			Ports: []corev1.ContainerPort{
+				{Name: "client", ContainerPort: etcdClientPort, Protocol: corev1.ProtocolTCP},
+				{Name: "peer", ContainerPort: etcdPeerPort, Protocol: corev1.ProtocolTCP},
+				{Name: "metrics", ContainerPort: etcdMetricPort, Protocol: corev1.ProtocolTCP},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "data", MountPath: etcdDataPath},
+				{Name: "certs", MountPath: etcdCertificatesPath, ReadOnly: true},
+			},
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path:   "/health",
+						Port:   intstr.FromInt(etcdMetricPort),
+						Scheme: corev1.URISchemeHTTP,
+					},
+				},
+				PeriodSeconds:  10,
+				TimeoutSeconds: 15,
+			},
+			LivenessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path:   "/health?serializable=true",
+						Port:   intstr.FromInt(etcdMetricPort),
+						Scheme: corev1.URISchemeHTTP,
+					},
+				},
+				InitialDelaySeconds: 10,
+				PeriodSeconds:       10,
+				TimeoutSeconds:      15,
+				FailureThreshold:    8,
+			},
+		}
+
+		if etcd.Spec.Resources != nil {
+			container.Resources = *etcd.Spec.Resources
+		}
+
+		sts.Spec.Template.Spec.Containers = []corev1.Container{container}
+
+		return controllerruntime.SetControllerReference(etcd, sts, r.client.Scheme())
+	})
+
+	return sts, err
+}
+
+// dataStore returns the DataStore exposing the etcd cluster, authenticated as root.
+func (r *EtcdCluster) dataStore(etcd *kamajiv1alpha1.EtcdCluster, secret *corev1.Secret) *kamajiv1alpha1.DataStore {
+	ref := corev1.SecretReference{
+		Name:      secret.GetName(),
+		Namespace: secret.GetNamespace(),
+	}
+
+	endpoints := make([]string, 0, etcd.Spec.Replicas)
+	for _, member := range r.members(etcd) {
+		endpoints = append(endpoints, fmt.Sprintf("%s:%d", member, etcdClientPort))
+	}
+
+	return &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: etcd.GetName(),
+		},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver:    kamajiv1alpha1.EtcdDriver,
+			Endpoints: endpoints,
+			TLSConfig: kamajiv1alpha1.TLSConfig{
+				CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+					Certificate: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: ref, KeyPath: etcdCACertName}},
+					PrivateKey:  &kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: ref, KeyPath: etcdCAKeyName}},
+				},
+				ClientCertificate: kamajiv1alpha1.ClientCertificate{
+					Certificate: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: ref, KeyPath: etcdRootCertName}},
+					PrivateKey:  kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: ref, KeyPath: etcdRootKeyName}},
+				},
+			},
+		},
+	}
+}
+
+// enableAuthentication creates the etcd root user, and enables the authentication: until then, any client presenting
+// a certificate signed by the Certificate Authority would be granted with all the privileges.
+func (r *EtcdCluster) enableAuthentication(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+	conn, err := datastore.NewStorageConnection(ctx, r.client, ds)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	enabler, ok := conn.(datastore.AuthenticationEnabler)
+	if !ok {
+		return fmt.Errorf("the %s driver doesn't support the authentication bootstrap", conn.Driver())
+	}
+
+	return enabler.EnableAuthentication(ctx)
+}
+
+// reconcileDataStore creates the DataStore exposing the etcd cluster, preserving the changes to the fields
+// not managed by Kamaji, such as the maintenance ones.
+func (r *EtcdCluster) reconcileDataStore(ctx context.Context, etcd *kamajiv1alpha1.EtcdCluster, desired *kamajiv1alpha1.DataStore) error {
+	ds := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: desired.GetName(),
+		},
+	}
+
+	_, err := utilities.CreateOrUpdateWithConflict(ctx, r.client, ds, func() error {
+		ds.SetLabels(utilities.MergeMaps(ds.GetLabels(), utilities.KamajiLabels(), r.labels(etcd)))
+		ds.Spec.Driver = desired.Spec.Driver
+		ds.Spec.Endpoints = desired.Spec.Endpoints
+		ds.Spec.TLSConfig = desired.Spec.TLSConfig
+
+		return controllerruntime.SetControllerReference(etcd, ds, r.client.Scheme())
+	})
+
+	return err
+}
+
+func (r *EtcdCluster) setReadyCondition(etcd *kamajiv1alpha1.EtcdCluster, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&etcd.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeEtcdClusterReady,
+		Status:             status,
+		ObservedGeneration: etcd.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func newEtcdClusterTestClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestEtcdClusterReconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		clusterDomain string
+		expected      string
+	}{
+		{name: "default cluster domain", clusterDomain: "", expected: "svc.cluster.local"},
+		{name: "custom cluster domain", clusterDomain: "example.org", expected: "svc.example.org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			etcd := &kamajiv1alpha1.EtcdCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+				Spec:       kamajiv1alpha1.EtcdClusterSpec{Replicas: 3, Image: "quay.io/coreos/etcd:v3.5.6"},
+			}
+
+			c := newEtcdClusterTestClient(t, etcd)
+
+			r := &EtcdCluster{Namespace: "kamaji-system", ClusterDomain: tt.clusterDomain}
+			if err := r.InjectClient(c); err != nil {
+				t.Fatal(err)
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: etcd.GetName()}}
+
+			result, err := r.Reconcile(context.Background(), request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// No member is ready, thus the DataStore is not exposed yet.
+			if result.RequeueAfter != etcdNotReadyRequeue {
+				t.Errorf("expected to be enqueued back after %s, got %s", etcdNotReadyRequeue, result.RequeueAfter)
+			}
+
+			if err = c.Get(context.Background(), request.NamespacedName, etcd); err != nil {
+				t.Fatal(err)
+			}
+
+			if condition := meta.FindStatusCondition(etcd.Status.Conditions, kamajiv1alpha1.ConditionTypeEtcdClusterReady); condition == nil || condition.Reason != "MembersNotReady" {
+				t.Errorf("expected the MembersNotReady condition, got %v", condition)
+			}
+
+			sts := &appsv1.StatefulSet{}
+			if err = c.Get(context.Background(), types.NamespacedName{Namespace: "kamaji-system", Name: "shared-etcd"}, sts); err != nil {
+				t.Fatal(err)
+			}
+
+			command := strings.Join(sts.Spec.Template.Spec.Containers[0].Command, " ")
+			for _, arg := range []string{
+				"--initial-cluster=shared-etcd-0=https://shared-etcd-0.shared-etcd.kamaji-system." + tt.expected + ":2380",
+				"--initial-advertise-peer-urls=https://$(POD_NAME).shared-etcd.$(POD_NAMESPACE)." + tt.expected + ":2380",
+				"--advertise-client-urls=https://$(POD_NAME).shared-etcd.$(POD_NAMESPACE)." + tt.expected + ":2379",
+			} {
+				if !strings.Contains(command, arg) {
+					t.Errorf("expected the etcd command to contain %s, got %s", arg, command)
+				}
+			}
+
+			secret := &corev1.Secret{}
+			if err = c.Get(context.Background(), types.NamespacedName{Namespace: "kamaji-system", Name: "shared-etcd-certs"}, secret); err != nil {
+				t.Fatal(err)
+			}
+
+			block, _ := pem.Decode(secret.Data[etcdServerCertName])
+			if block == nil {
+				t.Fatal("cannot decode the etcd server certificate")
+			}
+
+			certificate, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = certificate.VerifyHostname("shared-etcd-2.shared-etcd.kamaji-system." + tt.expected); err != nil {
+				t.Errorf("expected the etcd server certificate to be valid for the members: %s", err)
+			}
+		})
+	}
+}
+
+func TestEtcdClusterReconcileDataStore(t *testing.T) {
+	etcd := &kamajiv1alpha1.EtcdCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec:       kamajiv1alpha1.EtcdClusterSpec{Replicas: 3, Image: "quay.io/coreos/etcd:v3.5.6"},
+		// The authentication has been already enabled, requiring no connection to the members.
+		Status: kamajiv1alpha1.EtcdClusterStatus{AuthEnabled: true},
+	}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kamaji-system", Name: "shared-etcd"},
+		Status:     appsv1.StatefulSetStatus{ReadyReplicas: 3},
+	}
+
+	c := newEtcdClusterTestClient(t, etcd, sts)
+
+	r := &EtcdCluster{Namespace: "kamaji-system", ClusterDomain: "example.org"}
+	if err := r.InjectClient(c); err != nil {
+		t.Fatal(err)
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: etcd.GetName()}}
+
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "shared"}, ds); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := kamajiv1alpha1.Endpoints{
+		"shared-etcd-0.shared-etcd.kamaji-system.svc.example.org:2379",
+		"shared-etcd-1.shared-etcd.kamaji-system.svc.example.org:2379",
+		"shared-etcd-2.shared-etcd.kamaji-system.svc.example.org:2379",
+	}
+	if !reflect.DeepEqual(ds.Spec.Endpoints, expected) {
+		t.Errorf("expected the DataStore endpoints %v, got %v", expected, ds.Spec.Endpoints)
+	}
+
+	if err := c.Get(context.Background(), request.NamespacedName, etcd); err != nil {
+		t.Fatal(err)
+	}
+
+	if etcd.Status.DataStore != "shared" || !meta.IsStatusConditionTrue(etcd.Status.Conditions, kamajiv1alpha1.ConditionTypeEtcdClusterReady) {
+		t.Errorf("expected the EtcdCluster to be exposed by the shared DataStore, got %v", etcd.Status)
+	}
+}
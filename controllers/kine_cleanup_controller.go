@@ -0,0 +1,152 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// TenantControlPlaneKineCleanup periodically reclaims the space left in the kine table by the rows compacted by kine,
+// reporting the table size before and after the cleanup: the SQL DataStores are not reclaiming it on their own
+// with some configurations, letting the tables grow unbounded.
+type TenantControlPlaneKineCleanup struct {
+	client client.Client
+}
+
+func (r *TenantControlPlaneKineCleanup) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	interval, enabled := tcp.KineCleanupInterval()
+	if !enabled || tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+	// The cleanup is paused while the storage is not ready, or it's being migrated.
+	if len(tcp.Status.Storage.DataStoreName) == 0 || len(tcp.Status.Storage.Setup.Schema) == 0 ||
+		(tcp.Status.Kubernetes.Version.Status != nil && *tcp.Status.Kubernetes.Version.Status == kamajiv1alpha1.VersionMigrating) {
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	if status := tcp.Status.Storage.KineCleanup; status != nil {
+		if wait := interval - time.Since(status.LastRun.Time); wait > 0 {
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	before, after, err := r.cleanUp(ctx, tcp)
+	if err != nil {
+		log.Error(err, "cannot clean up the kine table", "datastore", tcp.Status.Storage.DataStoreName)
+	}
+
+	if updateErr := r.updateStatus(ctx, tcp, before, after, err); updateErr != nil {
+		log.Error(updateErr, "cannot update the kine cleanup status")
+
+		return reconcile.Result{}, updateErr
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// cleanUp reclaims the space of the kine table, returning its size before and after the cleanup.
+func (r *TenantControlPlaneKineCleanup) cleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (int64, int64, error) {
+	ds := &kamajiv1alpha1.DataStore{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Name: tcp.Status.Storage.DataStoreName}, ds); err != nil {
+		return 0, 0, err
+	}
+
+	conn, err := datastore.NewPrivilegedStorageConnection(ctx, r.client, *ds)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	cleaner, ok := conn.(datastore.TableCleaner)
+	if !ok {
+		return 0, 0, fmt.Errorf("the %s driver doesn't support the kine table cleanup", conn.Driver())
+	}
+
+	schema := tcp.Status.Storage.Setup.Schema
+
+	before, err := cleaner.TableSize(ctx, schema)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err = cleaner.CleanUpTable(ctx, schema); err != nil {
+		return before, 0, err
+	}
+
+	after, err := cleaner.TableSize(ctx, schema)
+	if err != nil {
+		return before, 0, err
+	}
+
+	return before, after, nil
+}
+
+func (r *TenantControlPlaneKineCleanup) updateStatus(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, before, after int64, cleanupErr error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kamajiv1alpha1.TenantControlPlane{}
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(tcp), latest); err != nil {
+			return err
+		}
+
+		status := &kamajiv1alpha1.KineCleanupStatus{
+			LastRun:    metav1.Now(),
+			Result:     kamajiv1alpha1.MaintenanceResultSucceeded,
+			SizeBefore: before,
+			SizeAfter:  after,
+			Message:    fmt.Sprintf("reclaimed %d bytes", before-after),
+		}
+
+		if cleanupErr != nil {
+			status.Result = kamajiv1alpha1.MaintenanceResultFailed
+			status.Message = cleanupErr.Error()
+		}
+
+		latest.Status.Storage.KineCleanup = status
+
+		return r.client.Status().Update(ctx, latest)
+	})
+}
+
+func (r *TenantControlPlaneKineCleanup) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneKineCleanup) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-kine-cleanup").
+		// The cleanups are scheduled by the requeue interval: only the specification changes are taken into account.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
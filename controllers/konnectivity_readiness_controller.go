@@ -0,0 +1,249 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources/konnectivity"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+//+kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update;patch
+
+const (
+	// konnectivityReadinessInterval is the interval used to verify again the Pods waiting for the Konnectivity agents.
+	konnectivityReadinessInterval = 10 * time.Second
+	// konnectivityReadinessTimeout bounds the requests to the Pod, which is not serving traffic yet.
+	konnectivityReadinessTimeout = 5 * time.Second
+)
+
+// KonnectivityReadinessGate satisfies the Konnectivity readiness gate of the Tenant Control Plane Pods once their server
+// is connected to the agents, as reported by its readiness endpoint, or when there are no agents to wait for: since the Pod
+// is not serving the Service traffic yet, the Tenant Cluster is reached through the Pod IP.
+// Once satisfied, the gate is never reverted, preventing a Tenant Cluster losing its nodes from losing its API Server too.
+type KonnectivityReadinessGate struct {
+	client client.Client
+}
+
+func (r *KonnectivityReadinessGate) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, request.NamespacedName, pod); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if pod.GetDeletionTimestamp() != nil || r.isGateSatisfied(pod) {
+		return reconcile.Result{}, nil
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Namespace: pod.GetNamespace(), Name: pod.GetLabels()["kamaji.clastix.io/soot"]}, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the Tenant Control Plane")
+
+		return reconcile.Result{}, err
+	}
+
+	if len(pod.Status.PodIP) == 0 {
+		return reconcile.Result{RequeueAfter: konnectivityReadinessInterval}, nil
+	}
+
+	connected, message := r.isConnected(ctx, tcp, pod)
+
+	status := corev1.ConditionFalse
+	if connected {
+		status = corev1.ConditionTrue
+	}
+
+	if err := r.setGateCondition(ctx, pod, status, message); err != nil {
+		log.Error(err, "cannot update the Konnectivity readiness gate")
+
+		return reconcile.Result{}, err
+	}
+
+	if !connected {
+		return reconcile.Result{RequeueAfter: konnectivityReadinessInterval}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// isConnected checks if the Konnectivity server of the given Pod is connected to the agents, or if there are none.
+func (r *KonnectivityReadinessGate) isConnected(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, pod *corev1.Pod) (bool, string) {
+	// The gate of the Pods created before the addon has been disabled must not hold them.
+	if konnectivitySpec := tcp.Spec.Addons.Konnectivity; konnectivitySpec == nil || !konnectivitySpec.ReadinessGate {
+		return true, "The Konnectivity readiness gate is not enabled"
+	}
+
+	_, healthPort := tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Ports()
+
+	if ready, err := r.isServerReady(ctx, pod, healthPort); err == nil && ready {
+		return true, "The Konnectivity server is connected to the agents"
+	}
+
+	desired, err := r.desiredAgents(ctx, tcp, pod)
+	if err != nil {
+		return false, fmt.Sprintf("Cannot retrieve the Konnectivity agents: %s", err.Error())
+	}
+
+	if desired == 0 {
+		return true, "No Konnectivity agent to wait for"
+	}
+
+	return false, fmt.Sprintf("Waiting for the Konnectivity server to be connected to the %d agents", desired)
+}
+
+// isServerReady checks the readiness endpoint of the Konnectivity server, failing until an agent is connected.
+func (r *KonnectivityReadinessGate) isServerReady(ctx context.Context, pod *corev1.Pod, healthPort int32) (bool, error) {
+	ctx, cancelFn := context.WithTimeout(ctx, konnectivityReadinessTimeout)
+	defer cancelFn()
+
+	endpoint := fmt.Sprintf("http://%s/readyz", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(healthPort))))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	return response.StatusCode == http.StatusOK, nil
+}
+
+// desiredAgents returns the number of the Konnectivity agents scheduled in the Tenant Cluster, reached through the given Pod.
+func (r *KonnectivityReadinessGate) desiredAgents(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, pod *corev1.Pod) (int32, error) {
+	config, err := utilities.GetRESTClientConfig(ctx, r.client, tcp)
+	if err != nil {
+		return 0, err
+	}
+	// The certificate of the API Server is verified against the Service name, rather than the Pod IP.
+	serviceURL, err := url.Parse(config.Host)
+	if err != nil {
+		return 0, err
+	}
+
+	config.Host = fmt.Sprintf("https://%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(tcp.Spec.NetworkProfile.Port))))
+	config.TLSClientConfig.ServerName = serviceURL.Hostname()
+	config.Timeout = konnectivityReadinessTimeout
+
+	clientSet, err := clientset.NewForConfig(config)
+	if err != nil {
+		return 0, err
+	}
+
+	daemonSet, err := clientSet.AppsV1().DaemonSets(konnectivity.AgentNamespace).Get(ctx, konnectivity.AgentName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return daemonSet.Status.DesiredNumberScheduled, nil
+}
+
+func (r *KonnectivityReadinessGate) isGateSatisfied(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == konnectivity.AgentsConnectedReadinessGate {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// setGateCondition sets the readiness gate condition of the Pod, updating it only upon a change.
+func (r *KonnectivityReadinessGate) setGateCondition(ctx context.Context, pod *corev1.Pod, status corev1.ConditionStatus, message string) error {
+	patch := client.StrategicMergeFrom(pod.DeepCopy())
+
+	condition := corev1.PodCondition{
+		Type:               konnectivity.AgentsConnectedReadinessGate,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Message:            message,
+	}
+
+	for i, current := range pod.Status.Conditions {
+		if current.Type != condition.Type {
+			continue
+		}
+
+		if current.Status == condition.Status && current.Message == condition.Message {
+			return nil
+		}
+
+		if current.Status == condition.Status {
+			condition.LastTransitionTime = current.LastTransitionTime
+		}
+
+		pod.Status.Conditions[i] = condition
+
+		return r.client.Status().Patch(ctx, pod, patch)
+	}
+
+	pod.Status.Conditions = append(pod.Status.Conditions, condition)
+
+	return r.client.Status().Patch(ctx, pod, patch)
+}
+
+func (r *KonnectivityReadinessGate) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *KonnectivityReadinessGate) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("konnectivity-readiness-gate").
+		For(&corev1.Pod{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			pod, ok := object.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+
+			for _, gate := range pod.Spec.ReadinessGates {
+				if gate.ConditionType == konnectivity.AgentsConnectedReadinessGate {
+					return true
+				}
+			}
+
+			return false
+		}))).
+		Complete(r)
+}
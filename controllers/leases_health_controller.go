@@ -0,0 +1,212 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	LeaseRenewedReason        = "LeaseRenewed"
+	LeaseNotFoundReason       = "LeaseNotFound"
+	LeaseExpiredReason        = "LeaseExpired"
+	LeaseHolderNotFoundReason = "LeaseHolderNotFound"
+	LeaderChangedReason       = "LeaderChanged"
+)
+
+// leaseComponents maps the leader election leases of the Tenant Cluster to the condition reporting their health.
+var leaseComponents = []struct {
+	name          string
+	conditionType string
+}{
+	{name: "kube-controller-manager", conditionType: kamajiv1alpha1.ConditionTypeControllerManagerLeaseHealthy},
+	{name: "kube-scheduler", conditionType: kamajiv1alpha1.ConditionTypeSchedulerLeaseHealthy},
+}
+
+// TenantControlPlaneLeasesHealth periodically collects the leader election leases of the kube-controller-manager and
+// kube-scheduler from the Tenant Cluster, reporting their health as conditions: the Deployment readiness is hiding
+// the failures of a single container, such as a crash loop, or a leader which is not a running Pod anymore.
+type TenantControlPlaneLeasesHealth struct {
+	client client.Client
+
+	Interval time.Duration
+}
+
+func (r *TenantControlPlaneLeasesHealth) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if status := tcp.Status.Kubernetes.Version.Status; status == nil || *status != kamajiv1alpha1.VersionReady {
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	clientSet, err := utilities.GetTenantClientSet(ctx, r.client, tcp)
+	if err != nil {
+		log.Error(err, "cannot generate Tenant client")
+
+		return reconcile.Result{}, err
+	}
+
+	pods, err := r.runningPods(ctx, tcp)
+	if err != nil {
+		log.Error(err, "cannot retrieve the Tenant Control Plane pods")
+
+		return reconcile.Result{}, err
+	}
+
+	leases := make([]kamajiv1alpha1.LeaderElectionLeaseStatus, 0, len(leaseComponents))
+
+	for _, component := range leaseComponents {
+		status, condition, leaseErr := r.collectLease(ctx, clientSet, tcp, pods, component.name)
+		if leaseErr != nil {
+			log.Error(leaseErr, "cannot collect the leader election lease", "component", component.name)
+
+			return reconcile.Result{}, leaseErr
+		}
+
+		condition.Type = component.conditionType
+		condition.ObservedGeneration = tcp.GetGeneration()
+
+		meta.SetStatusCondition(&tcp.Status.Conditions, condition)
+
+		if status != nil {
+			leases = append(leases, *status)
+		}
+	}
+
+	tcp.Status.Kubernetes.LeaderElection = leases
+
+	if err = r.client.Status().Update(ctx, tcp); err != nil {
+		log.Error(err, "cannot update the leader election leases health")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+// collectLease returns the status of the lease of the given component, along with the condition reporting its health.
+func (r *TenantControlPlaneLeasesHealth) collectLease(ctx context.Context, clientSet *clientset.Clientset, tcp *kamajiv1alpha1.TenantControlPlane, pods map[string]bool, component string) (*kamajiv1alpha1.LeaderElectionLeaseStatus, metav1.Condition, error) {
+	lease, err := clientSet.CoordinationV1().Leases(kubeadm.KubeSystemNamespace).Get(ctx, component, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, metav1.Condition{Status: metav1.ConditionFalse, Reason: LeaseNotFoundReason, Message: fmt.Sprintf("The %s lease has not been acquired yet", component)}, nil
+		}
+
+		return nil, metav1.Condition{}, err
+	}
+
+	status := &kamajiv1alpha1.LeaderElectionLeaseStatus{
+		Component: component,
+		RenewTime: lease.Spec.RenewTime,
+		LastSync:  metav1.Now(),
+	}
+
+	if lease.Spec.HolderIdentity != nil {
+		status.HolderIdentity = *lease.Spec.HolderIdentity
+	}
+
+	if lease.Spec.LeaseTransitions != nil {
+		status.LeaseTransitions = *lease.Spec.LeaseTransitions
+	}
+
+	var duration time.Duration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	// The holder identity is made of the leader hostname, thus the Pod name, and of a random suffix.
+	holder := strings.SplitN(status.HolderIdentity, "_", 2)[0]
+
+	switch {
+	case status.RenewTime == nil || time.Since(status.RenewTime.Time) > duration:
+		return status, metav1.Condition{Status: metav1.ConditionFalse, Reason: LeaseExpiredReason, Message: fmt.Sprintf("The %s lease held by %s is not renewed", component, holder)}, nil
+	case !pods[holder]:
+		return status, metav1.Condition{Status: metav1.ConditionFalse, Reason: LeaseHolderNotFoundReason, Message: fmt.Sprintf("The %s lease is renewed by %s, which is not a running Pod of the Tenant Control Plane", component, holder)}, nil
+	case r.hasLeaderChanged(tcp, status):
+		return status, metav1.Condition{Status: metav1.ConditionFalse, Reason: LeaderChangedReason, Message: fmt.Sprintf("The %s leadership changed hands since the last collection, now held by %s", component, holder)}, nil
+	default:
+		return status, metav1.Condition{Status: metav1.ConditionTrue, Reason: LeaseRenewedReason, Message: fmt.Sprintf("The %s lease is renewed by %s", component, holder)}, nil
+	}
+}
+
+// hasLeaderChanged checks if the lease transitions increased since the last collection, as upon a restart of the leader.
+func (r *TenantControlPlaneLeasesHealth) hasLeaderChanged(tcp *kamajiv1alpha1.TenantControlPlane, status *kamajiv1alpha1.LeaderElectionLeaseStatus) bool {
+	for _, previous := range tcp.Status.Kubernetes.LeaderElection {
+		if previous.Component == status.Component {
+			return status.LeaseTransitions > previous.LeaseTransitions
+		}
+	}
+
+	return false
+}
+
+// runningPods returns the names of the running Pods of the Tenant Control Plane.
+func (r *TenantControlPlaneLeasesHealth) runningPods(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (map[string]bool, error) {
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList, client.InNamespace(tcp.GetNamespace()), client.MatchingLabels{"kamaji.clastix.io/soot": tcp.GetName()}); err != nil {
+		return nil, err
+	}
+
+	pods := make(map[string]bool, len(podList.Items))
+
+	for _, pod := range podList.Items {
+		if pod.GetDeletionTimestamp() == nil && pod.Status.Phase == corev1.PodRunning {
+			pods[pod.GetName()] = true
+		}
+	}
+
+	return pods, nil
+}
+
+func (r *TenantControlPlaneLeasesHealth) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneLeasesHealth) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-leases-health").
+		// The collections are scheduled by the requeue interval: updates are ignored to keep the rate steady.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(event.UpdateEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
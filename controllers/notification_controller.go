@@ -0,0 +1,289 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/notifications"
+)
+
+const (
+	// certificateCheckInterval is the interval used to check the expiration of the Tenant Control Plane certificates.
+	certificateCheckInterval = time.Hour
+	// notificationStateAnnotation stores the last notified state of the Tenant Control Plane,
+	// detecting the transitions across the restarts of the operator.
+	notificationStateAnnotation = "kamaji.clastix.io/notification-state"
+	// notificationFinalizer holds the deletion of the Tenant Control Plane until it has been notified.
+	notificationFinalizer = "kamaji.clastix.io/notification"
+	// deletionNotificationTimeout is the time after which an undelivered deletion is not holding the Tenant Control Plane anymore.
+	deletionNotificationTimeout = 10 * time.Minute
+)
+
+// TenantControlPlaneNotification sends a notification to the configured Sink upon the significant
+// lifecycle transitions of the Tenant Control Planes, filtered according to the selected events.
+// The deliveries failing are retried with a backoff: with no Sink, the notification finalizer is released only.
+type TenantControlPlaneNotification struct {
+	client client.Client
+
+	Sink                           notifications.Sink
+	Events                         sets.String
+	CertificateExpirationThreshold time.Duration
+}
+
+// notificationState is the last notified state of a Tenant Control Plane, used to detect the transitions.
+type notificationState struct {
+	Status  kamajiv1alpha1.KubernetesVersionStatus `json:"status,omitempty"`
+	Version string                                 `json:"version,omitempty"`
+	// Certificates are the expiring certificates already notified.
+	Certificates []string `json:"certificates,omitempty"`
+}
+
+func (r *TenantControlPlaneNotification) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.GetDeletionTimestamp() != nil {
+		return r.reconcileDeletion(ctx, tcp)
+	}
+
+	if r.Sink == nil {
+		return reconcile.Result{}, r.releaseFinalizer(ctx, tcp)
+	}
+
+	previous, known := notificationState{}, false
+	if value, ok := tcp.GetAnnotations()[notificationStateAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &previous); err != nil {
+			log.Error(err, "cannot decode the last notified state, notifying the current one")
+		} else {
+			known = true
+		}
+	}
+
+	current := notificationState{Version: tcp.Status.Kubernetes.Version.Version, Certificates: previous.Certificates}
+	if tcp.Status.Kubernetes.Version.Status != nil {
+		current.Status = *tcp.Status.Kubernetes.Version.Status
+	}
+	// Upon a failed delivery, the state is not persisted: the transition is notified again by the next attempt.
+	if err := r.notifyTransition(ctx, tcp, previous, current, known); err != nil {
+		log.Error(err, "cannot deliver the notification")
+
+		return reconcile.Result{}, err
+	}
+
+	certificatesErr := r.checkCertificates(ctx, tcp, &current)
+
+	if err := r.persist(ctx, tcp, current); err != nil {
+		log.Error(err, "cannot store the last notified state")
+
+		return reconcile.Result{}, err
+	}
+
+	if certificatesErr != nil {
+		log.Error(certificatesErr, "cannot deliver the notification")
+
+		return reconcile.Result{}, certificatesErr
+	}
+
+	return reconcile.Result{RequeueAfter: certificateCheckInterval}, nil
+}
+
+// notifyTransition notifies the transition between the last notified state and the current one.
+func (r *TenantControlPlaneNotification) notifyTransition(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, previous, current notificationState, known bool) error {
+	switch {
+	case !known && (len(current.Status) == 0 || current.Status == kamajiv1alpha1.VersionProvisioning):
+		return r.notify(ctx, tcp, notifications.EventCreated, "the Tenant Control Plane has been created")
+	case !known || previous.Status == current.Status:
+		return nil
+	case current.Status == kamajiv1alpha1.VersionReady && previous.Status == kamajiv1alpha1.VersionUpgrading:
+		return r.notify(ctx, tcp, notifications.EventUpgraded, fmt.Sprintf("the Tenant Control Plane has been upgraded from %s to %s", previous.Version, current.Version))
+	case current.Status == kamajiv1alpha1.VersionReady:
+		return r.notify(ctx, tcp, notifications.EventReady, fmt.Sprintf("the Tenant Control Plane is ready, running version %s", current.Version))
+	case current.Status == kamajiv1alpha1.VersionNotReady && previous.Status == kamajiv1alpha1.VersionReady:
+		return r.notify(ctx, tcp, notifications.EventDegraded, "the Tenant Control Plane is not ready anymore")
+	default:
+		return nil
+	}
+}
+
+// checkCertificates notifies the Tenant Control Plane certificates expiring within the configured threshold,
+// once per certificate, tracking the notified ones in the given state.
+func (r *TenantControlPlaneNotification) checkCertificates(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, state *notificationState) error {
+	log := log.FromContext(ctx)
+
+	certificates := map[string]string{
+		tcp.Status.Certificates.CA.SecretName:                     kubeadmconstants.CACertName,
+		tcp.Status.Certificates.APIServer.SecretName:              kubeadmconstants.APIServerCertName,
+		tcp.Status.Certificates.APIServerKubeletClient.SecretName: kubeadmconstants.APIServerKubeletClientCertName,
+		tcp.Status.Certificates.FrontProxyCA.SecretName:           kubeadmconstants.FrontProxyCACertName,
+		tcp.Status.Certificates.FrontProxyClient.SecretName:       kubeadmconstants.FrontProxyClientCertName,
+	}
+
+	notified, expiring := sets.NewString(state.Certificates...), sets.NewString()
+
+	for secretName, key := range certificates {
+		if len(secretName) == 0 {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: secretName}, secret); err != nil {
+			log.Error(err, "cannot retrieve the certificate for the expiration check", "secret", secretName)
+
+			continue
+		}
+
+		crt, err := crypto.ParseCertificateBytes(secret.Data[key])
+		if err != nil {
+			continue
+		}
+
+		if time.Until(crt.NotAfter) > r.CertificateExpirationThreshold {
+			continue
+		}
+
+		id := fmt.Sprintf("%s/%d", secretName, crt.NotAfter.Unix())
+
+		if !notified.Has(id) {
+			if err = r.notify(ctx, tcp, notifications.EventCertificateExpiring, fmt.Sprintf("the certificate %s stored in the Secret %s expires on %s", crt.Subject.CommonName, secretName, crt.NotAfter.UTC().Format(time.RFC3339))); err != nil {
+				state.Certificates = notified.Union(expiring).List()
+
+				return err
+			}
+		}
+
+		expiring.Insert(id)
+	}
+	// The renewed certificates are not tracked anymore.
+	state.Certificates = expiring.List()
+
+	return nil
+}
+
+// persist stores the last notified state, adding the finalizer when the deletions are notified.
+func (r *TenantControlPlaneNotification) persist(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, state notificationState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	finalize := r.Events.Has(string(notifications.EventDeleted))
+
+	if tcp.GetAnnotations()[notificationStateAnnotation] == string(value) && controllerutil.ContainsFinalizer(tcp, notificationFinalizer) == finalize {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(tcp.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	annotations := tcp.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[notificationStateAnnotation] = string(value)
+	tcp.SetAnnotations(annotations)
+
+	if finalize {
+		controllerutil.AddFinalizer(tcp, notificationFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(tcp, notificationFinalizer)
+	}
+
+	return r.client.Patch(ctx, tcp, patch)
+}
+
+// reconcileDeletion notifies the deletion of the Tenant Control Plane, before releasing the finalizer:
+// the undelivered notification is retried until the timeout, preventing a broken Sink from holding the deletion.
+func (r *TenantControlPlaneNotification) reconcileDeletion(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(tcp, notificationFinalizer) {
+		return reconcile.Result{}, nil
+	}
+
+	if r.Sink != nil {
+		if err := r.notify(ctx, tcp, notifications.EventDeleted, "the Tenant Control Plane has been deleted"); err != nil {
+			if time.Since(tcp.GetDeletionTimestamp().Time) < deletionNotificationTimeout {
+				log.Error(err, "cannot deliver the notification")
+
+				return reconcile.Result{}, err
+			}
+
+			log.Error(err, "cannot deliver the notification, giving up", "timeout", deletionNotificationTimeout)
+		}
+	}
+
+	return reconcile.Result{}, r.releaseFinalizer(ctx, tcp)
+}
+
+func (r *TenantControlPlaneNotification) releaseFinalizer(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if !controllerutil.ContainsFinalizer(tcp, notificationFinalizer) {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(tcp.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(tcp, notificationFinalizer)
+
+	return r.client.Patch(ctx, tcp, patch)
+}
+
+// notify delivers the event if selected, returning the delivery error.
+func (r *TenantControlPlaneNotification) notify(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, eventType notifications.EventType, message string) error {
+	if !r.Events.Has(string(eventType)) {
+		return nil
+	}
+
+	event := notifications.Event{
+		Type:      eventType,
+		Namespace: tcp.GetNamespace(),
+		Name:      tcp.GetName(),
+		Message:   message,
+		Time:      time.Now(),
+	}
+
+	if err := r.Sink.Send(ctx, event); err != nil {
+		return fmt.Errorf("cannot deliver the %s notification: %w", eventType, err)
+	}
+
+	return nil
+}
+
+func (r *TenantControlPlaneNotification) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneNotification) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-notification").
+		For(&kamajiv1alpha1.TenantControlPlane{}).
+		Complete(r)
+}
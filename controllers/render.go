@@ -0,0 +1,227 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/datastore"
+	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// renderMaxPasses is the maximum number of reconciliations performed while rendering: the resources depend
+// on the status reported by the previous ones, thus a few passes are required to converge.
+const renderMaxPasses = 10
+
+// Render returns the objects Kamaji would create for the given Tenant Control Plane, running the reconciliation
+// against an in-memory client rather than applying them: the objects are the Tenant Control Plane, its DataStore,
+// and the ones it depends on, such as the DataStore Secrets. No connection to the DataStore is established,
+// the per-tenant users and schemas are not provisioned.
+func Render(ctx context.Context, scheme *runtime.Scheme, config TenantControlPlaneReconcilerConfig, objects ...client.Object) ([]client.Object, error) {
+	var tcp *kamajiv1alpha1.TenantControlPlane
+
+	dataStores := map[string]*kamajiv1alpha1.DataStore{}
+
+	for _, object := range objects {
+		switch o := object.(type) {
+		case *kamajiv1alpha1.TenantControlPlane:
+			if tcp != nil {
+				return nil, fmt.Errorf("a single TenantControlPlane can be rendered at once")
+			}
+
+			tcp = o
+		case *kamajiv1alpha1.DataStore:
+			dataStores[o.GetName()] = o
+		}
+	}
+
+	if tcp == nil {
+		return nil, fmt.Errorf("the TenantControlPlane to render is missing")
+	}
+
+	// The Service addresses are assigned by the cluster: the advertised one must be declared.
+	if len(tcp.Spec.NetworkProfile.Address) == 0 {
+		return nil, fmt.Errorf("the TenantControlPlane to render must declare the network profile address")
+	}
+
+	// Mirroring the defaulting webhook, the DataStore selector is not taken into account.
+	if len(tcp.Spec.DataStore) == 0 {
+		tcp.Spec.DataStore = config.DefaultDataStoreName
+	}
+
+	ds, ok := dataStores[tcp.Spec.DataStore]
+	if !ok {
+		return nil, fmt.Errorf("the DataStore %s used by the TenantControlPlane is missing", tcp.Spec.DataStore)
+	}
+
+	if len(tcp.GetNamespace()) == 0 {
+		tcp.SetNamespace(corev1.NamespaceDefault)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	rendered := &kamajiv1alpha1.TenantControlPlane{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(tcp), rendered); err != nil {
+		return nil, err
+	}
+
+	builderConfiguration := GroupResourceBuilderConfiguration{
+		client:              c,
+		log:                 logr.Discard(),
+		tcpReconcilerConfig: config,
+		tenantControlPlane:  *rendered,
+		Connection:          &renderConnection{driver: string(ds.Spec.Driver)},
+		DataStore:           *ds,
+	}
+
+	for pass := 0; pass < renderMaxPasses; pass++ {
+		changed := false
+
+		for _, resource := range GetResources(builderConfiguration) {
+			result, err := resources.Handle(ctx, resource, rendered)
+			if err != nil {
+				// The sentinel errors are waiting for the cluster state, such as the LoadBalancer address,
+				// that won't be ever available while rendering.
+				if kamajierrors.ShouldReconcileErrorBeIgnored(err) {
+					return nil, fmt.Errorf("the %s resource depends on the cluster state, such as the LoadBalancer address: %w", resource.GetName(), err)
+				}
+
+				return nil, fmt.Errorf("cannot render the %s resource: %w", resource.GetName(), err)
+			}
+
+			if result == controllerutil.OperationResultNone {
+				continue
+			}
+
+			if err = utils.UpdateStatus(ctx, c, rendered, resource); err != nil {
+				return nil, err
+			}
+
+			changed = true
+		}
+
+		if !changed {
+			return renderedObjects(ctx, c, scheme, objects)
+		}
+	}
+
+	return nil, fmt.Errorf("the rendering didn't converge after %d reconciliations", renderMaxPasses)
+}
+
+// renderedObjects lists the objects created by the reconciliation, sorted by kind, namespace, and name.
+func renderedObjects(ctx context.Context, c client.Client, scheme *runtime.Scheme, inputs []client.Object) ([]client.Object, error) {
+	provided := map[string]struct{}{}
+
+	key := func(object client.Object) string {
+		gvk, _ := apiutil.GVKForObject(object, scheme)
+
+		return fmt.Sprintf("%s/%s/%s", gvk.Kind, object.GetNamespace(), object.GetName())
+	}
+
+	for _, input := range inputs {
+		provided[key(input)] = struct{}{}
+	}
+
+	var output []client.Object
+
+	for _, list := range []client.ObjectList{
+		&corev1.SecretList{},
+		&corev1.ConfigMapList{},
+		&corev1.ServiceList{},
+		&appsv1.DeploymentList{},
+		&networkingv1.IngressList{},
+		&batchv1.JobList{},
+	} {
+		if err := c.List(ctx, list); err != nil {
+			return nil, err
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			object, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			gvk, err := apiutil.GVKForObject(object, scheme)
+			if err != nil {
+				return nil, err
+			}
+
+			object.GetObjectKind().SetGroupVersionKind(gvk)
+			object.SetResourceVersion("")
+
+			if _, ok = provided[key(object)]; ok {
+				continue
+			}
+
+			output = append(output, object)
+		}
+	}
+
+	sort.SliceStable(output, func(i, j int) bool {
+		return key(output[i]) < key(output[j])
+	})
+
+	return output, nil
+}
+
+// renderConnection is the DataStore connection used while rendering: the per-tenant users and schemas
+// are reported as already provisioned, with no actual connection.
+type renderConnection struct {
+	driver string
+}
+
+func (r *renderConnection) CreateUser(context.Context, string, string) error { return nil }
+
+func (r *renderConnection) CreateDB(context.Context, string) error { return nil }
+
+func (r *renderConnection) GrantPrivileges(context.Context, string, string) error { return nil }
+
+func (r *renderConnection) UserExists(context.Context, string) (bool, error) { return true, nil }
+
+func (r *renderConnection) DBExists(context.Context, string) (bool, error) { return true, nil }
+
+func (r *renderConnection) GrantPrivilegesExists(context.Context, string, string) (bool, error) {
+	return true, nil
+}
+
+func (r *renderConnection) DeleteUser(context.Context, string) error { return nil }
+
+func (r *renderConnection) DeleteDB(context.Context, string) error { return nil }
+
+func (r *renderConnection) RevokePrivileges(context.Context, string, string) error { return nil }
+
+func (r *renderConnection) GetConnectionString() string { return "" }
+
+func (r *renderConnection) Close() error { return nil }
+
+func (r *renderConnection) Check(context.Context) error { return nil }
+
+func (r *renderConnection) Driver() string { return r.driver }
+
+func (r *renderConnection) Migrate(context.Context, kamajiv1alpha1.TenantControlPlane, datastore.Connection, datastore.MigrationProgressFn, datastore.MigrationThrottleFn) error {
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/resources"
 	ds "github.com/clastix/kamaji/internal/resources/datastore"
+	"github.com/clastix/kamaji/internal/resources/kine"
 	"github.com/clastix/kamaji/internal/resources/konnectivity"
 )
 
@@ -26,11 +28,13 @@ type GroupResourceBuilderConfiguration struct {
 	tcpReconcilerConfig  TenantControlPlaneReconcilerConfig
 	tenantControlPlane   kamajiv1alpha1.TenantControlPlane
 	Connection           datastore.Connection
+	PrivilegedConnection *datastore.PrivilegedConnection
 	DataStore            kamajiv1alpha1.DataStore
 	KamajiNamespace      string
 	KamajiServiceAccount string
 	KamajiService        string
 	KamajiMigrateImage   string
+	recorder             record.EventRecorder
 }
 
 type GroupDeletableResourceBuilderConfiguration struct {
@@ -39,6 +43,7 @@ type GroupDeletableResourceBuilderConfiguration struct {
 	tcpReconcilerConfig TenantControlPlaneReconcilerConfig
 	tenantControlPlane  kamajiv1alpha1.TenantControlPlane
 	connection          datastore.Connection
+	privileged          *datastore.PrivilegedConnection
 }
 
 // GetResources returns a list of resources that will be used to provide tenant control planes
@@ -55,9 +60,20 @@ func GetDeletableResources(tcp *kamajiv1alpha1.TenantControlPlane, config GroupD
 	var res []resources.DeletableResource
 
 	if controllerutil.ContainsFinalizer(tcp, finalizers.DatastoreFinalizer) {
+		res = append(res, &resources.CleanupHooksResource{
+			Client:    config.client,
+			JobPolicy: config.tcpReconcilerConfig.CleanupHookJobs,
+		})
+		res = append(res, &resources.KubeconfigTargetsResource{
+			Client: config.client,
+		})
+		res = append(res, &resources.KubeconfigExternalTargetsResource{
+			Client: config.client,
+		})
 		res = append(res, &ds.Setup{
 			Client:     config.client,
 			Connection: config.connection,
+			Privileged: config.privileged,
 		})
 	}
 
@@ -65,18 +81,24 @@ func GetDeletableResources(tcp *kamajiv1alpha1.TenantControlPlane, config GroupD
 }
 
 func getDefaultResources(config GroupResourceBuilderConfiguration) []resources.Resource {
-	resources := getDataStoreMigratingResources(config.client, config.KamajiNamespace, config.KamajiMigrateImage, config.KamajiServiceAccount, config.KamajiService)
+	resources := getDataStoreMigrationDryRunResources(config.client, config.Connection)
+	resources = append(resources, getDataStoreMigratingResources(config.client, config.KamajiNamespace, config.KamajiMigrateImage, config.KamajiServiceAccount, config.KamajiService)...)
 	resources = append(resources, getUpgradeResources(config.client)...)
 	resources = append(resources, getKubernetesServiceResources(config.client)...)
 	resources = append(resources, getKubeadmConfigResources(config.client, getTmpDirectory(config.tcpReconcilerConfig.TmpBaseDirectory, config.tenantControlPlane), config.DataStore)...)
-	resources = append(resources, getKubernetesCertificatesResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
+	resources = append(resources, getKubernetesCertificatesResources(config.client, config.recorder, config.tcpReconcilerConfig, config.tenantControlPlane)...)
 	resources = append(resources, getKubeconfigResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
-	resources = append(resources, getKubernetesStorageResources(config.client, config.Connection, config.DataStore)...)
+	resources = append(resources, getKubernetesStorageResources(config.client, config.Connection, config.PrivilegedConnection, config.DataStore)...)
+	resources = append(resources, getKineResources(config.client, config.tcpReconcilerConfig, config.DataStore)...)
 	resources = append(resources, getKonnectivityServerRequirementsResources(config.client)...)
 	resources = append(resources, getKubernetesDeploymentResources(config.client, config.tcpReconcilerConfig, config.DataStore)...)
 	resources = append(resources, getKonnectivityServerPatchResources(config.client)...)
+	resources = append(resources, getAPIServerLogLevelResources(config.client)...)
 	resources = append(resources, getDataStoreMigratingCleanup(config.client, config.KamajiNamespace)...)
-	resources = append(resources, getKubernetesIngressResources(config.client)...)
+	if config.tcpReconcilerConfig.IngressExposure {
+		resources = append(resources, getKubernetesIngressResources(config.client)...)
+	}
+	resources = append(resources, getKubernetesFootprintResources(config.client)...)
 
 	return resources
 }
@@ -91,6 +113,15 @@ func getDataStoreMigratingCleanup(c client.Client, kamajiNamespace string) []res
 	}
 }
 
+func getDataStoreMigrationDryRunResources(c client.Client, connection datastore.Connection) []resources.Resource {
+	return []resources.Resource{
+		&ds.MigrationDryRun{
+			Client:     c,
+			Connection: connection,
+		},
+	}
+}
+
 func getDataStoreMigratingResources(c client.Client, kamajiNamespace, migrateImage string, kamajiServiceAccount, kamajiService string) []resources.Resource {
 	return []resources.Resource{
 		&ds.Migrate{
@@ -138,7 +169,7 @@ func getKubeadmConfigResources(c client.Client, tmpDirectory string, dataStore k
 	}
 }
 
-func getKubernetesCertificatesResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane) []resources.Resource {
+func getKubernetesCertificatesResources(c client.Client, recorder record.EventRecorder, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane) []resources.Resource {
 	return []resources.Resource{
 		&resources.CACertificate{
 			Client:       c,
@@ -154,12 +185,16 @@ func getKubernetesCertificatesResources(c client.Client, tcpReconcilerConfig Ten
 		},
 		&resources.APIServerCertificate{
 			Client:       c,
+			Recorder:     recorder,
 			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
 		},
 		&resources.APIServerKubeletClientCertificate{
 			Client:       c,
 			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
 		},
+		&resources.KubeletServingCACertificate{
+			Client: c,
+		},
 		&resources.FrontProxyClientCertificate{
 			Client:       c,
 			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
@@ -187,10 +222,19 @@ func getKubeconfigResources(c client.Client, tcpReconcilerConfig TenantControlPl
 			KubeConfigFileName: resources.SchedulerKubeConfigFileName,
 			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
 		},
+		&resources.KubeconfigTargetsResource{
+			Client: c,
+		},
+		&resources.KubeconfigExternalTargetsResource{
+			Client: c,
+		},
+		&resources.UsersKubeconfigResource{
+			Client: c,
+		},
 	}
 }
 
-func getKubernetesStorageResources(c client.Client, dbConnection datastore.Connection, datastore kamajiv1alpha1.DataStore) []resources.Resource {
+func getKubernetesStorageResources(c client.Client, dbConnection datastore.Connection, privileged *datastore.PrivilegedConnection, datastore kamajiv1alpha1.DataStore) []resources.Resource {
 	return []resources.Resource{
 		&ds.Config{
 			Client:     c,
@@ -200,12 +244,40 @@ func getKubernetesStorageResources(c client.Client, dbConnection datastore.Conne
 		&ds.Setup{
 			Client:     c,
 			Connection: dbConnection,
+			Privileged: privileged,
 			DataStore:  datastore,
 		},
 		&ds.Certificate{
 			Client:    c,
 			DataStore: datastore,
 		},
+		&ds.Quota{
+			Client:     c,
+			Connection: dbConnection,
+			DataStore:  datastore,
+		},
+	}
+}
+
+func getKineResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore) []resources.Resource {
+	return []resources.Resource{
+		&kine.CertificateResource{
+			Client:    c,
+			DataStore: dataStore,
+		},
+		&kine.DeploymentResource{
+			Client:             c,
+			DataStore:          dataStore,
+			KineContainerImage: tcpReconcilerConfig.KineContainerImage,
+		},
+		&kine.ServiceResource{
+			Client:    c,
+			DataStore: dataStore,
+		},
+		&kine.PodMonitorResource{
+			Client:    c,
+			DataStore: dataStore,
+		},
 	}
 }
 
@@ -219,6 +291,14 @@ func getKubernetesDeploymentResources(c client.Client, tcpReconcilerConfig Tenan
 	}
 }
 
+func getAPIServerLogLevelResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&resources.APIServerLogLevel{
+			Client: c,
+		},
+	}
+}
+
 func getKubernetesIngressResources(c client.Client) []resources.Resource {
 	return []resources.Resource{
 		&resources.KubernetesIngressResource{
@@ -227,18 +307,33 @@ func getKubernetesIngressResources(c client.Client) []resources.Resource {
 	}
 }
 
+func getKubernetesFootprintResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&resources.KubernetesFootprintResource{
+			Client: c,
+		},
+	}
+}
+
 func GetExternalKonnectivityResources(c client.Client) []resources.Resource {
 	return []resources.Resource{
 		&konnectivity.Agent{Client: c},
 		&konnectivity.ServiceAccountResource{Client: c},
 		&konnectivity.ClusterRoleBindingResource{Client: c},
+		&konnectivity.LeaseRBACResource{Client: c},
+		&konnectivity.CapacityResource{Client: c},
+		&konnectivity.OverloadResource{Client: c},
+		&konnectivity.AvailabilityResource{Client: c},
 	}
 }
 
 func getKonnectivityServerRequirementsResources(c client.Client) []resources.Resource {
 	return []resources.Resource{
+		&konnectivity.RemovalGateResource{},
+		&konnectivity.RecreationResource{Client: c},
 		&konnectivity.EgressSelectorConfigurationResource{Client: c},
 		&konnectivity.CertificateResource{Client: c},
+		&konnectivity.ProxyCertificateResource{Client: c},
 		&konnectivity.KubeconfigResource{Client: c},
 	}
 }
@@ -247,6 +342,9 @@ func getKonnectivityServerPatchResources(c client.Client) []resources.Resource {
 	return []resources.Resource{
 		&konnectivity.KubernetesDeploymentResource{Client: c},
 		&konnectivity.ServiceResource{Client: c},
+		&konnectivity.DedicatedServiceResource{Client: c},
+		&konnectivity.StandaloneServiceResource{Client: c},
+		&konnectivity.StandaloneDeploymentResource{Client: c},
 	}
 }
 
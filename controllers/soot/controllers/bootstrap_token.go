@@ -0,0 +1,102 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// BootstrapToken rotates the bootstrap tokens of the Tenant Cluster, publishing the current one along with the join command:
+// the reconciliation is scheduled upon the renewal of the current token.
+type BootstrapToken struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	logger logr.Logger
+}
+
+func (b *BootstrapToken) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := b.GetTenantControlPlaneFunc()
+	if err != nil {
+		b.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	b.logger.Info("start processing")
+
+	resource := &resources.BootstrapTokenRotation{Client: b.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		b.logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		b.logger.Info("reconciliation completed")
+
+		return b.renewal(tcp), nil
+	}
+
+	if err = utils.UpdateStatus(ctx, b.AdminClient, tcp, resource); err != nil {
+		b.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	b.logger.Info("reconciliation processed")
+
+	return b.renewal(tcp), nil
+}
+
+// renewal schedules the reconciliation generating the next bootstrap token.
+func (b *BootstrapToken) renewal(tcp *kamajiv1alpha1.TenantControlPlane) reconcile.Result {
+	rotation, status := tcp.BootstrapTokenRotation(), tcp.Status.KubeadmPhase.JoinCommand
+	if rotation == nil || status == nil {
+		return reconcile.Result{}
+	}
+
+	after := time.Until(status.Expiration.Add(-rotation.RenewBefore.Duration))
+	if after < time.Second {
+		after = time.Second
+	}
+
+	return reconcile.Result{RequeueAfter: after}
+}
+
+func (b *BootstrapToken) SetupWithManager(mgr manager.Manager) error {
+	b.logger = mgr.GetLogger().WithName("bootstrap_token")
+	b.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			_, ok := object.GetLabels()[resources.BootstrapTokenRotationLabel]
+
+			return ok && object.GetNamespace() == kubeadm.KubeSystemNamespace
+		}))).
+		Watches(&source.Channel{Source: b.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(b)
+}
@@ -0,0 +1,212 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/clastix/kamaji/controllers/utils"
+)
+
+// KubeletServingCSRApprover approves the kubelet serving CertificateSigningRequests of the Tenant Cluster when the serving
+// Certificate Authority is managed by Kamaji: the requests are approved only when issued by the node they're referring to,
+// and the requested names are matching its addresses, otherwise they're left pending.
+type KubeletServingCSRApprover struct {
+	client client.Client
+	logger logr.Logger
+
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+}
+
+func (r *KubeletServingCSRApprover) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	tcp, err := r.GetTenantControlPlaneFunc()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !tcp.IsKubeletServingCSRApproved() {
+		return reconcile.Result{}, nil
+	}
+
+	csr := &certificatesv1.CertificateSigningRequest{}
+	if err = r.client.Get(ctx, request.NamespacedName, csr); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if !r.isPending(csr) {
+		return reconcile.Result{}, nil
+	}
+
+	if err = r.validate(ctx, csr); err != nil {
+		r.logger.Info("kubelet serving CertificateSigningRequest not approved", "name", csr.GetName(), "reason", err.Error())
+
+		return reconcile.Result{}, nil
+	}
+
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:           certificatesv1.CertificateApproved,
+		Status:         corev1.ConditionTrue,
+		Reason:         "KamajiApproved",
+		Message:        "approved by the Kamaji kubelet serving CertificateSigningRequest approver",
+		LastUpdateTime: metav1.Now(),
+	})
+
+	if err = r.client.SubResource("approval").Update(ctx, csr); err != nil {
+		r.logger.Error(err, "unable to approve the kubelet serving CertificateSigningRequest", "name", csr.GetName())
+
+		return reconcile.Result{}, err
+	}
+
+	r.logger.Info("kubelet serving CertificateSigningRequest approved", "name", csr.GetName())
+
+	return reconcile.Result{}, nil
+}
+
+func (r *KubeletServingCSRApprover) isPending(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved || condition.Type == certificatesv1.CertificateDenied {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validate ensures the request has been issued by the node it's referring to, for the names and addresses it's reporting.
+func (r *KubeletServingCSRApprover) validate(ctx context.Context, csr *certificatesv1.CertificateSigningRequest) error {
+	nodeName := strings.TrimPrefix(csr.Spec.Username, "system:node:")
+	if nodeName == csr.Spec.Username || len(nodeName) == 0 {
+		return fmt.Errorf("the requester %s is not a node", csr.Spec.Username)
+	}
+
+	var isNode bool
+
+	for _, group := range csr.Spec.Groups {
+		if group == "system:nodes" {
+			isNode = true
+		}
+	}
+
+	if !isNode {
+		return fmt.Errorf("the requester is not member of the system:nodes group")
+	}
+
+	for _, usage := range csr.Spec.Usages {
+		switch usage {
+		case certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment, certificatesv1.UsageServerAuth:
+		default:
+			return fmt.Errorf("the usage %s is not allowed", usage)
+		}
+	}
+
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return fmt.Errorf("the request is not a PEM encoded certificate request")
+	}
+
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("cannot parse the certificate request: %w", err)
+	}
+
+	if request.Subject.CommonName != csr.Spec.Username {
+		return fmt.Errorf("the common name %s is not matching the requester", request.Subject.CommonName)
+	}
+
+	if len(request.Subject.Organization) != 1 || request.Subject.Organization[0] != "system:nodes" {
+		return fmt.Errorf("the organization must be system:nodes")
+	}
+
+	if len(request.EmailAddresses) > 0 || len(request.URIs) > 0 {
+		return fmt.Errorf("email addresses and URIs are not allowed")
+	}
+
+	node := &corev1.Node{}
+	if err = r.client.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+		return fmt.Errorf("cannot retrieve the node %s: %w", nodeName, err)
+	}
+
+	addresses := make(map[string]corev1.NodeAddressType, len(node.Status.Addresses))
+	for _, address := range node.Status.Addresses {
+		addresses[address.Address] = address.Type
+	}
+
+	for _, name := range request.DNSNames {
+		switch addresses[name] {
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+		default:
+			return fmt.Errorf("the DNS name %s is not reported by the node", name)
+		}
+	}
+
+	for _, ip := range request.IPAddresses {
+		switch addresses[ip.String()] {
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+		default:
+			return fmt.Errorf("the IP address %s is not reported by the node", ip.String())
+		}
+	}
+
+	return nil
+}
+
+func (r *KubeletServingCSRApprover) isKubeletServingRequest(object client.Object) bool {
+	csr, ok := object.(*certificatesv1.CertificateSigningRequest)
+
+	return ok && csr.Spec.SignerName == certificatesv1.KubeletServingSignerName
+}
+
+func (r *KubeletServingCSRApprover) SetupWithManager(mgr manager.Manager) error {
+	r.client = mgr.GetClient()
+	r.logger = mgr.GetLogger().WithName("kubelet_serving_csr_approver")
+	r.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&certificatesv1.CertificateSigningRequest{}, builder.WithPredicates(predicate.NewPredicateFuncs(r.isKubeletServingRequest))).
+		// Enabling the approval, the pending requests must be taken into account.
+		Watches(&source.Channel{Source: r.TriggerChannel}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) (requests []reconcile.Request) {
+			csrList := &certificatesv1.CertificateSigningRequestList{}
+			if err := r.client.List(context.Background(), csrList); err != nil {
+				r.logger.Error(err, "unable to list the CertificateSigningRequests")
+
+				return nil
+			}
+
+			for i := range csrList.Items {
+				csr := csrList.Items[i]
+
+				if csr.Spec.SignerName == certificatesv1.KubeletServingSignerName && r.isPending(&csr) {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: csr.GetName()}})
+				}
+			}
+
+			return requests
+		})).
+		Complete(r)
+}
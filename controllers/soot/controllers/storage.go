@@ -0,0 +1,88 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+// Storage installs the storage addon in the Tenant Cluster,
+// reconciling it back upon the changes of its inventory.
+type Storage struct {
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	// TemplatesNamespace is the namespace of the storage templates provided by the operator.
+	TemplatesNamespace string
+
+	logger logr.Logger
+}
+
+func (s *Storage) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := s.GetTenantControlPlaneFunc()
+	if err != nil {
+		s.logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	s.logger.Info("start processing")
+
+	resource := &addons.Storage{Client: s.AdminClient, TemplatesNamespace: s.TemplatesNamespace}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		s.logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		s.logger.Info("reconciliation completed")
+
+		return reconcile.Result{}, nil
+	}
+
+	if err = utils.UpdateStatus(ctx, s.AdminClient, tcp, resource); err != nil {
+		s.logger.Error(err, "update status failed")
+
+		return reconcile.Result{}, err
+	}
+
+	s.logger.Info("reconciliation processed")
+
+	return reconcile.Result{}, nil
+}
+
+func (s *Storage) SetupWithManager(mgr manager.Manager) error {
+	s.logger = mgr.GetLogger().WithName("storage")
+	s.TriggerChannel = make(chan event.GenericEvent)
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			_, ok := object.GetLabels()[addons.StorageInventoryLabel]
+
+			return ok && object.GetNamespace() == kubeadm.KubeSystemNamespace
+		}))).
+		Watches(&source.Channel{Source: s.TriggerChannel}, &handler.EnqueueRequestForObject{}).
+		Complete(s)
+}
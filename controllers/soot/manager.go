@@ -7,7 +7,9 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -48,7 +50,9 @@ type Manager struct {
 	MigrateCABundle         []byte
 	MigrateServiceName      string
 	MigrateServiceNamespace string
-	AdminClient             client.Client
+	// StorageTemplatesNamespace is the namespace of the storage templates ConfigMaps, used by the storage addon.
+	StorageTemplatesNamespace string
+	AdminClient               client.Client
 }
 
 // retrieveTenantControlPlane is the function used to let an underlying controller of the soot manager
@@ -200,6 +204,23 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		return reconcile.Result{}, err
 	}
 
+	readOnly := &controllers.ReadOnly{
+		WebhookNamespace:          m.MigrateServiceNamespace,
+		WebhookServiceName:        m.MigrateServiceName,
+		WebhookCABundle:           m.MigrateCABundle,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = readOnly.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	kubeletServingCSRApprover := &controllers.KubeletServingCSRApprover{
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = kubeletServingCSRApprover.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	konnectivityAgent := &controllers.KonnectivityAgent{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
@@ -224,6 +245,39 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		return reconcile.Result{}, err
 	}
 
+	certManager := &controllers.CertManager{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = certManager.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cni := &controllers.CNI{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = cni.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	storage := &controllers.Storage{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		TemplatesNamespace:        m.StorageTemplatesNamespace,
+	}
+	if err = storage.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	manifests := &controllers.Manifests{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = manifests.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	uploadKubeadmConfig := &controllers.KubeadmPhase{
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
 		Phase: &resources.KubeadmPhase{
@@ -256,6 +310,14 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	if err = bootstrapToken.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
+
+	bootstrapTokenRotation := &controllers.BootstrapToken{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+	}
+	if err = bootstrapTokenRotation.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
 	// Starting the manager
 	go func() {
 		if err = mgr.Start(tcpCtx); err != nil {
@@ -270,12 +332,19 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	m.sootMap[request.NamespacedName.String()] = sootItem{
 		triggers: []chan event.GenericEvent{
 			migrate.TriggerChannel,
+			readOnly.TriggerChannel,
+			kubeletServingCSRApprover.TriggerChannel,
 			konnectivityAgent.TriggerChannel,
 			kubeProxy.TriggerChannel,
 			coreDNS.TriggerChannel,
+			certManager.TriggerChannel,
+			cni.TriggerChannel,
+			storage.TriggerChannel,
+			manifests.TriggerChannel,
 			uploadKubeadmConfig.TriggerChannel,
 			uploadKubeletConfig.TriggerChannel,
 			bootstrapToken.TriggerChannel,
+			bootstrapTokenRotation.TriggerChannel,
 		},
 		cancelFn: tcpCancelFn,
 	}
@@ -291,17 +360,55 @@ func (m *Manager) SetupWithManager(mgr manager.Manager) error {
 	return controllerruntime.NewControllerManagedBy(mgr).
 		Watches(&source.Channel{Source: m.sootManagerErrChan}, &handler.EnqueueRequestForObject{}).
 		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
-			obj := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
-			// status is required to understand if we have to start or stop the soot manager
-			if obj.Status.Kubernetes.Version.Status == nil {
-				return false
+			return m.isProvisioned(object.(*kamajiv1alpha1.TenantControlPlane)) //nolint:forcetypeassert
+		}))).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(m.manifestsAddonHandler)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(m.manifestsAddonHandler)).
+		Complete(m)
+}
+
+// isProvisioned returns true when the TenantControlPlane has been provisioned:
+// its status is required to understand if we have to start or stop the soot manager.
+func (m *Manager) isProvisioned(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	if tcp.Status.Kubernetes.Version.Status == nil {
+		return false
+	}
+
+	return *tcp.Status.Kubernetes.Version.Status != kamajiv1alpha1.VersionProvisioning
+}
+
+// manifestsAddonHandler enqueues the TenantControlPlanes referencing the changed ConfigMap, or Secret, in their manifests addons,
+// triggering the underlying controllers to apply them back.
+func (m *Manager) manifestsAddonHandler(object client.Object) []reconcile.Request {
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := m.client.List(context.Background(), tcpList, client.InNamespace(object.GetNamespace())); err != nil {
+		return nil
+	}
+
+	_, isSecret := object.(*corev1.Secret)
+
+	var requests []reconcile.Request
+
+	for i := range tcpList.Items {
+		tcp := tcpList.Items[i]
+
+		if !m.isProvisioned(&tcp) {
+			continue
+		}
+
+		for _, addon := range tcp.Spec.Addons.Manifests {
+			ref := addon.ConfigMapRef
+			if isSecret {
+				ref = addon.SecretRef
 			}
 
-			if *obj.Status.Kubernetes.Version.Status == kamajiv1alpha1.VersionProvisioning {
-				return false
+			if ref != nil && ref.Name == object.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}})
+
+				break
 			}
+		}
+	}
 
-			return true
-		}))).
-		Complete(m)
+	return requests
 }
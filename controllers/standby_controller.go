@@ -0,0 +1,187 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// errSnapshotInProgress reports the standby DataStore being overwritten by a snapshot: the previous one is lost.
+var errSnapshotInProgress = errors.New("snapshot in progress, the standby DataStore cannot be promoted until completed")
+
+// TenantControlPlaneStandby periodically ships a snapshot of the Tenant Control Plane data to its standby DataStore,
+// using the same copy performed by the migrations: the standby DataStore can be promoted at any time, losing the
+// changes occurred since the last snapshot.
+type TenantControlPlaneStandby struct {
+	client client.Client
+}
+
+func (r *TenantControlPlaneStandby) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := log.FromContext(ctx)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := r.client.Get(ctx, request.NamespacedName, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		log.Error(err, "unable to retrieve the request")
+
+		return reconcile.Result{}, err
+	}
+
+	spec := tcp.Spec.StandbyDataStore
+	if spec == nil || tcp.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+	// The snapshots are paused once the standby DataStore has been promoted,
+	// as well as while the storage is not ready, or it's being migrated.
+	if spec.DataStore == tcp.Spec.DataStore || spec.DataStore == tcp.Status.Storage.DataStoreName || len(tcp.Status.Storage.Setup.Schema) == 0 ||
+		(tcp.Status.Kubernetes.Version.Status != nil && *tcp.Status.Kubernetes.Version.Status == kamajiv1alpha1.VersionMigrating) {
+		return reconcile.Result{RequeueAfter: spec.Interval.Duration}, nil
+	}
+
+	if status := tcp.Status.Storage.Standby; status != nil && status.DataStoreName == spec.DataStore {
+		if wait := spec.Interval.Duration - time.Since(status.LastAttemptTime.Time); wait > 0 {
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	// The PostgreSQL snapshots are replacing the previous ones in a transaction, the etcd and MySQL ones are not:
+	// the standby DataStore cannot be promoted until the copy is completed, since it could be left partial.
+	if standby := tcp.Status.Storage.Standby; standby != nil && !standby.LastSyncTime.IsZero() && !r.isTransactional(ctx, spec.DataStore) {
+		if err := r.updateStatus(ctx, tcp, spec.DataStore, 0, errSnapshotInProgress); err != nil {
+			log.Error(err, "cannot invalidate the standby DataStore snapshot")
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	copied, err := r.snapshot(ctx, tcp)
+	if err != nil {
+		log.Error(err, "cannot ship the snapshot to the standby DataStore", "datastore", spec.DataStore)
+	}
+
+	if updateErr := r.updateStatus(ctx, tcp, spec.DataStore, copied, err); updateErr != nil {
+		log.Error(updateErr, "cannot update the standby DataStore status")
+
+		return reconcile.Result{}, updateErr
+	}
+
+	return reconcile.Result{RequeueAfter: spec.Interval.Duration}, nil
+}
+
+func (r *TenantControlPlaneStandby) snapshot(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (int64, error) {
+	origin, standby := &kamajiv1alpha1.DataStore{}, &kamajiv1alpha1.DataStore{}
+
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Name: tcp.Status.Storage.DataStoreName}, origin); err != nil {
+		return 0, err
+	}
+
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Name: tcp.Spec.StandbyDataStore.DataStore}, standby); err != nil {
+		return 0, err
+	}
+
+	originConnection, err := datastore.NewPrivilegedStorageConnection(ctx, r.client, *origin)
+	if err != nil {
+		return 0, err
+	}
+	defer originConnection.Close()
+
+	standbyConnection, err := datastore.NewPrivilegedStorageConnection(ctx, r.client, *standby)
+	if err != nil {
+		return 0, err
+	}
+	defer standbyConnection.Close()
+	// The SQL snapshots are replacing the previous ones, the etcd keys are not:
+	// dropping the prefix, since the keys deleted in the meanwhile would be kept.
+	if standbyConnection.Driver() == string(kamajiv1alpha1.EtcdDriver) {
+		if err = standbyConnection.DeleteDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+			return 0, err
+		}
+	}
+
+	var copied int64
+
+	if err = originConnection.Migrate(ctx, *tcp, standbyConnection, func(keys, _ int64) {
+		copied = keys
+	}, datastore.NoMigrationThrottle); err != nil {
+		return 0, err
+	}
+
+	return copied, nil
+}
+
+// isTransactional reports if the snapshots replace the previous ones atomically, keeping them valid upon failures.
+func (r *TenantControlPlaneStandby) isTransactional(ctx context.Context, dataStoreName string) bool {
+	standby := &kamajiv1alpha1.DataStore{}
+	if err := r.client.Get(ctx, k8stypes.NamespacedName{Name: dataStoreName}, standby); err != nil {
+		return false
+	}
+
+	return standby.Spec.Driver == kamajiv1alpha1.KinePostgreSQLDriver
+}
+
+func (r *TenantControlPlaneStandby) updateStatus(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, dataStoreName string, copied int64, snapshotErr error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kamajiv1alpha1.TenantControlPlane{}
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(tcp), latest); err != nil {
+			return err
+		}
+
+		status := latest.Status.Storage.Standby
+		if status == nil || status.DataStoreName != dataStoreName {
+			status = &kamajiv1alpha1.StandbyDataStoreStatus{DataStoreName: dataStoreName}
+		}
+
+		status.LastAttemptTime = metav1.Now()
+		status.LastError = ""
+
+		switch {
+		case errors.Is(snapshotErr, errSnapshotInProgress):
+			status.KeysCopied = 0
+			status.LastSyncTime = metav1.Time{}
+			status.LastError = snapshotErr.Error()
+		case snapshotErr != nil:
+			status.LastError = snapshotErr.Error()
+		default:
+			status.KeysCopied = copied
+			status.LastSyncTime = status.LastAttemptTime
+		}
+
+		latest.Status.Storage.Standby = status
+
+		return r.client.Status().Update(ctx, latest)
+	})
+}
+
+func (r *TenantControlPlaneStandby) InjectClient(client client.Client) error {
+	r.client = client
+
+	return nil
+}
+
+func (r *TenantControlPlaneStandby) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("tenantcontrolplane-standby").
+		// The snapshots are scheduled by the requeue interval: only the specification changes are taken into account.
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
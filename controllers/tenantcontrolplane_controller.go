@@ -16,7 +16,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apimachineryerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,9 +39,13 @@ import (
 	"github.com/clastix/kamaji/controllers/utils"
 	"github.com/clastix/kamaji/internal/datastore"
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/metrics"
 	"github.com/clastix/kamaji/internal/resources"
+	datastoreresources "github.com/clastix/kamaji/internal/resources/datastore"
 )
 
+const dataStoreMaintenanceReason = "DataStoreMaintenance"
+
 // TenantControlPlaneReconciler reconciles a TenantControlPlane object.
 type TenantControlPlaneReconciler struct {
 	Client                  client.Client
@@ -50,8 +57,13 @@ type TenantControlPlaneReconciler struct {
 	KamajiService           string
 	KamajiMigrateImage      string
 	MaxConcurrentReconciles int
+	// HealthyStartupDelay is the fixed delay applied to the reconciliation of the healthy Tenant Control Planes
+	// upon the operator start-up, and the periodic resync, giving a head start to the not ready ones:
+	// no delay is applied when zero.
+	HealthyStartupDelay time.Duration
 
-	clock mutex.Clock
+	clock    mutex.Clock
+	recorder record.EventRecorder
 }
 
 // TenantControlPlaneReconcilerConfig gives the necessary configuration for TenantControlPlaneReconciler.
@@ -59,6 +71,11 @@ type TenantControlPlaneReconcilerConfig struct {
 	DefaultDataStoreName string
 	KineContainerImage   string
 	TmpBaseDirectory     string
+	// IngressExposure allows the Tenant Control Planes to be exposed with an Ingress: when disabled,
+	// the Ingress objects are neither watched, nor managed, and no permission on them is required.
+	IngressExposure bool
+	// CleanupHookJobs lists the images, and the ServiceAccounts, allowed for the clean-up hook Jobs.
+	CleanupHookJobs kamajiv1alpha1.CleanupHookJobPolicy
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=get;list;watch;create;update;patch;delete
@@ -67,9 +84,12 @@ type TenantControlPlaneReconcilerConfig struct {
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;update;patch;delete
 
 func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -79,6 +99,9 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		if apimachineryerrors.IsNotFound(err) {
 			log.Info("resource may have been deleted, skipping")
 
+			metrics.DeleteResourceFootprint(req.Namespace, req.Name)
+			metrics.DeleteConflicts(req.Namespace, req.Name)
+
 			return ctrl.Result{}, nil
 		}
 
@@ -111,6 +134,37 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if markedToBeDeleted && !controllerutil.ContainsFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer) {
 		return ctrl.Result{}, nil
 	}
+	// The exported Tenant Control Plane is managed by the destination management cluster,
+	// sharing the same DataStore user and schema: they must not be deleted.
+	if tenantControlPlane.IsExported() {
+		if markedToBeDeleted {
+			log.Info("exported to another management cluster, releasing the DataStore with no clean-up")
+			// The user and the schema are handed over: they must not be garbage collected by this management cluster.
+			if err = r.forgetProvisioned(ctx, tenantControlPlane); err != nil {
+				log.Error(err, "cannot release the DataStore resources of the exported Tenant Control Plane")
+
+				return ctrl.Result{}, err
+			}
+
+			return ctrl.Result{}, r.RemoveFinalizer(ctx, tenantControlPlane)
+		}
+
+		log.Info("exported to another management cluster, skipping reconciliation")
+
+		return ctrl.Result{}, nil
+	}
+
+	if tenantControlPlane.IsImporting() {
+		log.Info("import from another management cluster in progress, skipping reconciliation")
+
+		return ctrl.Result{}, nil
+	}
+
+	if tenantControlPlane.IsPaused() && !markedToBeDeleted {
+		log.Info("reconciliation paused, skipping")
+
+		return ctrl.Result{}, nil
+	}
 	// Retrieving the DataStore to use for the current reconciliation
 	ds, err := r.dataStore(ctx, tenantControlPlane)
 	if err != nil {
@@ -118,6 +172,19 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		return ctrl.Result{}, err
 	}
+	// The reconciliation is paused until the DataStore leaves the maintenance mode: the DataStore controller
+	// triggers the dependent Tenant Control Planes upon any change, no need to requeue.
+	if err = r.handleDataStoreMaintenance(ctx, tenantControlPlane, ds); err != nil {
+		log.Error(err, "cannot update the DataStore maintenance condition")
+
+		return ctrl.Result{}, err
+	}
+
+	if ds.Spec.MaintenanceMode {
+		log.Info("DataStore is in maintenance mode, skipping reconciliation", "datastore", ds.GetName())
+
+		return ctrl.Result{}, nil
+	}
 
 	dsConnection, err := datastore.NewStorageConnection(ctx, r.Client, *ds)
 	if err != nil {
@@ -126,6 +193,9 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 	defer dsConnection.Close()
+	// The privileged credentials are loaded only if the tenant user, or schema, must be created, or deleted.
+	privilegedConnection := datastore.NewPrivilegedConnection(r.Client, *ds, dsConnection)
+	defer privilegedConnection.Close()
 
 	if markedToBeDeleted && controllerutil.ContainsFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer) {
 		log.Info("marked for deletion, performing clean-up")
@@ -136,10 +206,17 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			tcpReconcilerConfig: r.Config,
 			tenantControlPlane:  *tenantControlPlane,
 			connection:          dsConnection,
+			privileged:          privilegedConnection,
 		}
 
 		for _, resource := range GetDeletableResources(tenantControlPlane, groupDeletableResourceBuilderConfiguration) {
 			if err = resources.HandleDeletion(ctx, resource, tenantControlPlane); err != nil {
+				if kamajierrors.ShouldReconcileErrorBeIgnored(err) {
+					log.V(1).Info("sentinel error, enqueuing back request", "error", err.Error())
+
+					return ctrl.Result{Requeue: true}, nil
+				}
+
 				log.Error(err, "resource deletion failed", "resource", resource.GetName())
 
 				return ctrl.Result{}, err
@@ -157,17 +234,26 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		tcpReconcilerConfig:  r.Config,
 		tenantControlPlane:   *tenantControlPlane,
 		Connection:           dsConnection,
+		PrivilegedConnection: privilegedConnection,
 		DataStore:            *ds,
 		KamajiNamespace:      r.KamajiNamespace,
 		KamajiServiceAccount: r.KamajiServiceAccount,
 		KamajiService:        r.KamajiService,
 		KamajiMigrateImage:   r.KamajiMigrateImage,
+		recorder:             r.recorder,
 	}
 	registeredResources := GetResources(groupResourceBuilderConfiguration)
 
 	for _, resource := range registeredResources {
 		result, err := resources.Handle(ctx, resource, tenantControlPlane)
 		if err != nil {
+			var windowErr kamajierrors.MigrationWindowPendingError
+			if errors.As(err, &windowErr) {
+				log.Info("migration scheduled, enqueuing back request", "after", windowErr.RetryAfter.String())
+
+				return ctrl.Result{RequeueAfter: windowErr.RetryAfter}, nil
+			}
+
 			if kamajierrors.ShouldReconcileErrorBeIgnored(err) {
 				log.V(1).Info("sentinel error, enqueuing back request", "error", err.Error())
 
@@ -200,7 +286,28 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	log.Info(fmt.Sprintf("%s has been reconciled", tenantControlPlane.GetName()))
 
-	return ctrl.Result{}, nil
+	var result ctrl.Result
+
+	requeueAfter := func(retryAfter time.Duration) {
+		if retryAfter > 0 && (result.RequeueAfter == 0 || retryAfter < result.RequeueAfter) {
+			result.RequeueAfter = retryAfter
+		}
+	}
+	// The removal of the Konnectivity agent resources is waiting for the grace period:
+	// enqueuing back the request to notify the Tenant Cluster controllers once expired.
+	if tenantControlPlane.IsKonnectivityRemovalPending() {
+		_, _, retryAfter := tenantControlPlane.KonnectivityRemovalAllowed()
+		requeueAfter(retryAfter)
+	}
+	// The Konnectivity agents are unavailable, waiting for the fallback threshold to switch to the direct egress.
+	_, retryAfter := tenantControlPlane.KonnectivityFallbackActive()
+	requeueAfter(retryAfter)
+	// The storage usage changes with no event notifying it, thus the quota is accounted periodically.
+	if tenantControlPlane.Spec.DataStoreQuota != nil {
+		requeueAfter(datastoreresources.QuotaAccountingInterval)
+	}
+
+	return result, nil
 }
 
 func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
@@ -216,8 +323,9 @@ func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
 // SetupWithManager sets up the controller with the Manager.
 func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.clock = clock.RealClock{}
+	r.recorder = mgr.GetEventRecorderFor("tenantcontrolplane-controller")
 
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		Watches(&source.Channel{Source: r.TriggerChan}, handler.Funcs{GenericFunc: func(genericEvent event.GenericEvent, limitingInterface workqueue.RateLimitingInterface) {
 			limitingInterface.AddRateLimited(ctrl.Request{
 				NamespacedName: k8stypes.NamespacedName{
@@ -226,12 +334,21 @@ func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 				},
 			})
 		}}).
-		For(&kamajiv1alpha1.TenantControlPlane{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{}).
-		Owns(&networkingv1.Ingress{}).
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(r.startupDelayPredicate())).
+		Watches(&source.Kind{Type: &kamajiv1alpha1.TenantControlPlane{}}, r.startupDelayHandler()).
+		// The owned objects are mapped to their Tenant Control Plane applying the startup delay,
+		// since their initial listing would enqueue all of them right away.
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.startupDelayMapHandler(r.ownerTenantControlPlane)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.startupDelayMapHandler(r.dataStoreCredentialsHandler)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, r.startupDelayMapHandler(r.ownerTenantControlPlane)).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, r.startupDelayMapHandler(r.ownerTenantControlPlane)).
+		Watches(&source.Kind{Type: &corev1.Service{}}, r.startupDelayMapHandler(r.ownerTenantControlPlane))
+
+	if r.Config.IngressExposure {
+		controllerBuilder = controllerBuilder.Watches(&source.Kind{Type: &networkingv1.Ingress{}}, r.startupDelayMapHandler(r.ownerTenantControlPlane))
+	}
+
+	return controllerBuilder.
 		Watches(&source.Kind{Type: &batchv1.Job{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
 			labels := object.GetLabels()
 
@@ -260,12 +377,44 @@ func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 
 			return ok && v == "migrate"
 		}))).
+		// The clean-up hook Jobs are not owned by the Tenant Control Plane, thus they're mapped by labels.
+		Watches(&source.Kind{Type: &batchv1.Job{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+			return []reconcile.Request{
+				{
+					NamespacedName: k8stypes.NamespacedName{
+						Namespace: object.GetNamespace(),
+						Name:      object.GetLabels()["kamaji.clastix.io/name"],
+					},
+				},
+			}
+		}), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetLabels()["kamaji.clastix.io/component"] == resources.CleanupHookComponent
+		}))).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 		}).
 		Complete(r)
 }
 
+// dataStoreCredentialsHandler enqueues the Tenant Control Planes referencing the Secret as DataStore credentials,
+// propagating the changes applied by the external process providing them.
+func (r *TenantControlPlaneReconciler) dataStoreCredentialsHandler(object client.Object) []reconcile.Request {
+	tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+	if err := r.Client.List(context.Background(), tcpList, client.InNamespace(object.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for _, tcp := range tcpList.Items {
+		if ref := tcp.Spec.DataStoreCredentials; ref != nil && ref.Name == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}})
+		}
+	}
+
+	return requests
+}
+
 func (r *TenantControlPlaneReconciler) getTenantControlPlane(ctx context.Context, namespacedName k8stypes.NamespacedName) utils.TenantControlPlaneRetrievalFn {
 	return func() (*kamajiv1alpha1.TenantControlPlane, error) {
 		tcp := &kamajiv1alpha1.TenantControlPlane{}
@@ -283,6 +432,21 @@ func (r *TenantControlPlaneReconciler) RemoveFinalizer(ctx context.Context, tena
 	return r.Client.Update(ctx, tenantControlPlane)
 }
 
+// forgetProvisioned removes the user, and the schema, of the given Tenant Control Plane from the ones recorded
+// in its DataStore status, unless the DataStore no longer exists.
+func (r *TenantControlPlaneReconciler) forgetProvisioned(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	dataStoreName, setup := tenantControlPlane.Status.Storage.DataStoreName, tenantControlPlane.Status.Storage.Setup
+	if len(dataStoreName) == 0 {
+		return nil
+	}
+
+	if err := datastore.ForgetProvisioned(ctx, r.Client, dataStoreName, kamajiv1alpha1.DataStoreResourceUser, setup.User); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	return client.IgnoreNotFound(datastore.ForgetProvisioned(ctx, r.Client, dataStoreName, kamajiv1alpha1.DataStoreResourceSchema, setup.Schema))
+}
+
 // dataStore retrieves the override DataStore for the given Tenant Control Plane if specified,
 // otherwise fallback to the default one specified in the Kamaji setup.
 func (r *TenantControlPlaneReconciler) dataStore(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*kamajiv1alpha1.DataStore, error) {
@@ -298,3 +462,30 @@ func (r *TenantControlPlaneReconciler) dataStore(ctx context.Context, tenantCont
 
 	return ds, nil
 }
+
+// handleDataStoreMaintenance marks the Tenant Control Plane as Degraded while its DataStore is in maintenance mode,
+// removing the condition once the maintenance is over: the status is updated only upon changes, preventing churn.
+func (r *TenantControlPlaneReconciler) handleDataStoreMaintenance(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, ds *kamajiv1alpha1.DataStore) error {
+	previous := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeDegraded)
+
+	switch {
+	case ds.Spec.MaintenanceMode:
+		if previous != nil && previous.Status == metav1.ConditionTrue && previous.Reason == dataStoreMaintenanceReason {
+			return nil
+		}
+
+		meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+			Type:               kamajiv1alpha1.ConditionTypeDegraded,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: tenantControlPlane.GetGeneration(),
+			Reason:             dataStoreMaintenanceReason,
+			Message:            fmt.Sprintf("the DataStore %s is in maintenance mode, reconciliation is paused", ds.GetName()),
+		})
+	case previous != nil && previous.Reason == dataStoreMaintenanceReason:
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeDegraded)
+	default:
+		return nil
+	}
+
+	return r.Client.Status().Update(ctx, tenantControlPlane)
+}
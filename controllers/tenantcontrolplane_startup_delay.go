@@ -0,0 +1,141 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// isResyncEvent returns true for the update events not caused by an actual change of the Tenant Control Plane,
+// such as the ones generated by the periodic resync of the informer.
+func isResyncEvent(updateEvent event.UpdateEvent) bool {
+	return updateEvent.ObjectNew.GetResourceVersion() == updateEvent.ObjectOld.GetResourceVersion()
+}
+
+// isHealthyTenantControlPlane returns true when the Tenant Control Plane is ready, and not degraded.
+func isHealthyTenantControlPlane(object client.Object) bool {
+	tcp, ok := object.(*kamajiv1alpha1.TenantControlPlane)
+	if !ok {
+		return false
+	}
+
+	if status := tcp.Status.Kubernetes.Version.Status; status == nil || *status != kamajiv1alpha1.VersionReady {
+		return false
+	}
+
+	return !meta.IsStatusConditionTrue(tcp.Status.Conditions, kamajiv1alpha1.ConditionTypeDegraded)
+}
+
+// startupDelayPredicate filters out the creation events, such as the ones generated by the initial listing
+// upon the operator start-up, and the resync ones: both are handled by the startupDelayHandler.
+func (r *TenantControlPlaneReconciler) startupDelayPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+			return !isResyncEvent(updateEvent)
+		},
+	}
+}
+
+// startupDelayHandler enqueues the creation and resync events, such as the ones following an operator restart:
+// the healthy Tenant Control Planes are enqueued once the fixed startup delay expires, while the not ready, or degraded,
+// ones are enqueued right away. This is not an ordering of the queue: the healthy ones are processed after the others
+// only when these are reconciled within the delay, which should be tuned on the number of tenants.
+func (r *TenantControlPlaneReconciler) startupDelayHandler() handler.Funcs {
+	enqueue := func(object client.Object, queue workqueue.RateLimitingInterface) {
+		request := ctrl.Request{
+			NamespacedName: k8stypes.NamespacedName{
+				Namespace: object.GetNamespace(),
+				Name:      object.GetName(),
+			},
+		}
+
+		if r.HealthyStartupDelay > 0 && isHealthyTenantControlPlane(object) {
+			queue.AddAfter(request, r.HealthyStartupDelay)
+
+			return
+		}
+
+		queue.Add(request)
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(createEvent.Object, queue)
+		},
+		UpdateFunc: func(updateEvent event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			if isResyncEvent(updateEvent) {
+				enqueue(updateEvent.ObjectNew, queue)
+			}
+		},
+	}
+}
+
+// startupDelayMapHandler enqueues the Tenant Control Planes returned by the given function, such as the owners of the
+// watched objects: upon their creation, and resync, events, such as the ones generated by the initial listing of the
+// owned objects, the healthy Tenant Control Planes are delayed as the startupDelayHandler does.
+func (r *TenantControlPlaneReconciler) startupDelayMapHandler(mapFn handler.MapFunc) handler.Funcs {
+	enqueue := func(object client.Object, queue workqueue.RateLimitingInterface, delayed bool) {
+		for _, request := range mapFn(object) {
+			if delayed && r.HealthyStartupDelay > 0 {
+				tcp := &kamajiv1alpha1.TenantControlPlane{}
+				if err := r.Client.Get(context.Background(), request.NamespacedName, tcp); err == nil && isHealthyTenantControlPlane(tcp) {
+					queue.AddAfter(request, r.HealthyStartupDelay)
+
+					continue
+				}
+			}
+
+			queue.Add(request)
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(createEvent event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(createEvent.Object, queue, true)
+		},
+		UpdateFunc: func(updateEvent event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			resync := isResyncEvent(updateEvent)
+
+			enqueue(updateEvent.ObjectOld, queue, resync)
+			enqueue(updateEvent.ObjectNew, queue, resync)
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(deleteEvent.Object, queue, false)
+		},
+		GenericFunc: func(genericEvent event.GenericEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(genericEvent.Object, queue, false)
+		},
+	}
+}
+
+// ownerTenantControlPlane returns the Tenant Control Plane controlling the given object, if any.
+func (r *TenantControlPlaneReconciler) ownerTenantControlPlane(object client.Object) []reconcile.Request {
+	owner := metav1.GetControllerOf(object)
+	if owner == nil || owner.Kind != "TenantControlPlane" {
+		return nil
+	}
+
+	if gv, err := schema.ParseGroupVersion(owner.APIVersion); err != nil || gv.Group != kamajiv1alpha1.GroupVersion.Group {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: object.GetNamespace(), Name: owner.Name}}}
+}
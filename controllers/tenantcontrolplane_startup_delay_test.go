@@ -0,0 +1,117 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestStartupDelayMapHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	ready, provisioning := kamajiv1alpha1.VersionReady, kamajiv1alpha1.VersionProvisioning
+
+	healthy := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "healthy"}}
+	healthy.Status.Kubernetes.Version.Status = &ready
+
+	unhealthy := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unhealthy"}}
+	unhealthy.Status.Kubernetes.Version.Status = &provisioning
+
+	r := &TenantControlPlaneReconciler{
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(healthy, unhealthy).Build(),
+		HealthyStartupDelay: time.Hour,
+	}
+
+	owned := func(owner, resourceVersion string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            owner + "-secret",
+			ResourceVersion: resourceVersion,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kamajiv1alpha1.GroupVersion.String(),
+				Kind:       "TenantControlPlane",
+				Name:       owner,
+				Controller: pointer.Bool(true),
+			}},
+		}}
+	}
+
+	h := r.startupDelayMapHandler(r.ownerTenantControlPlane)
+
+	tests := []struct {
+		name     string
+		send     func(queue workqueue.RateLimitingInterface)
+		expected int
+	}{
+		{
+			name: "creation of an object owned by a healthy Tenant Control Plane is delayed",
+			send: func(queue workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: owned("healthy", "1")}, queue)
+			},
+			expected: 0,
+		},
+		{
+			name: "creation of an object owned by an unhealthy Tenant Control Plane is enqueued",
+			send: func(queue workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: owned("unhealthy", "1")}, queue)
+			},
+			expected: 1,
+		},
+		{
+			name: "resync of an object owned by a healthy Tenant Control Plane is delayed",
+			send: func(queue workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: owned("healthy", "1"), ObjectNew: owned("healthy", "1")}, queue)
+			},
+			expected: 0,
+		},
+		{
+			name: "change of an object owned by a healthy Tenant Control Plane is enqueued",
+			send: func(queue workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: owned("healthy", "1"), ObjectNew: owned("healthy", "2")}, queue)
+			},
+			expected: 1,
+		},
+		{
+			name: "deletion of an object owned by a healthy Tenant Control Plane is enqueued",
+			send: func(queue workqueue.RateLimitingInterface) {
+				h.Delete(event.DeleteEvent{Object: owned("healthy", "1")}, queue)
+			},
+			expected: 1,
+		},
+		{
+			name: "objects not owned by a Tenant Control Plane are ignored",
+			send: func(queue workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "secret"}}}, queue)
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+
+			tt.send(queue)
+
+			if queue.Len() != tt.expected {
+				t.Errorf("expected %d enqueued requests, got %d", tt.expected, queue.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+var _ = Describe("Deploy a TenantControlPlane resource with Konnectivity", func() {
+	// Fill TenantControlPlane object
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tcp-konnectivity-aggregator",
+			Namespace: "default",
+		},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			ControlPlane: kamajiv1alpha1.ControlPlane{
+				Deployment: kamajiv1alpha1.DeploymentSpec{
+					Replicas: 1,
+				},
+				Service: kamajiv1alpha1.ServiceSpec{
+					ServiceType: "ClusterIP",
+				},
+			},
+			NetworkProfile: kamajiv1alpha1.NetworkProfileSpec{
+				Address: "172.18.0.2",
+			},
+			Kubernetes: kamajiv1alpha1.KubernetesSpec{
+				Version: "v1.25.3",
+				Kubelet: kamajiv1alpha1.KubeletSpec{
+					CGroupFS: "cgroupfs",
+				},
+				AdmissionControllers: kamajiv1alpha1.AdmissionControllers{
+					"LimitRanger",
+					"ResourceQuota",
+				},
+			},
+			Addons: kamajiv1alpha1.AddonsSpec{
+				Konnectivity: &kamajiv1alpha1.KonnectivitySpec{
+					KonnectivityServerSpec: kamajiv1alpha1.KonnectivityServerSpec{
+						Port: 8132,
+					},
+				},
+			},
+		},
+	}
+
+	// Create a TenantControlPlane resource into the cluster
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.Background(), tcp)).NotTo(HaveOccurred())
+	})
+
+	// Delete the TenantControlPlane resource after test is finished
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), tcp)).Should(Succeed())
+	})
+	// Check if the aggregated APIs are reached through the Konnectivity tunnel
+	It("Should route the aggregated APIs through the cluster egress selector", func() {
+		StatusMustEqualTo(tcp, kamajiv1alpha1.VersionReady)
+
+		deployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}, deployment)).NotTo(HaveOccurred())
+
+		var args []string
+
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if container.Name == "kube-apiserver" {
+				args = container.Args
+			}
+		}
+
+		Expect(args).To(ContainElement("--enable-aggregator-routing=true"))
+		Expect(args).To(ContainElement(HavePrefix("--egress-selector-config-file=")))
+
+		Eventually(func() string {
+			if err := k8sClient.Get(context.Background(), types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}, tcp); err != nil {
+				return ""
+			}
+
+			configMap := &corev1.ConfigMap{}
+			if err := k8sClient.Get(context.Background(), types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Addons.Konnectivity.ConfigMap.Name}, configMap); err != nil {
+				return ""
+			}
+
+			return strings.TrimSpace(configMap.Data["egress-selector-configuration.yaml"])
+		}, time.Minute, time.Second).Should(ContainSubstring("name: cluster"))
+	})
+})
@@ -0,0 +1,426 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package adminapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	pathPrefix = "/api/v1alpha1/namespaces/"
+	// maxBodySize limits the size of the TenantControlPlane manifests submitted to the API.
+	maxBodySize = 1 << 20
+)
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// callerKey is the request context key of the authenticated caller.
+type callerKey struct{}
+
+// caller is the identity of the client: the holder of the static token is allowed to use all the routes.
+type caller struct {
+	static bool
+	user   authenticationv1.UserInfo
+}
+
+// Server exposes the lifecycle of the Tenant Control Planes over an authenticated REST API, allowing the platforms
+// fronting Kamaji with their own portal to automate it, without granting RBAC permissions on the management cluster.
+//
+// The bearer tokens are authenticated with a TokenReview, and each request is authorized with a SubjectAccessReview
+// of the equivalent Kubernetes API request: a ServiceAccount bound to a Role is limited to the Tenant Control Planes
+// of its namespace. The optional static token, stored in the token file, is allowed to use all the routes.
+//
+// The supported routes are the following:
+//   - POST   /api/v1alpha1/namespaces/{namespace}/tenantcontrolplanes: create a TenantControlPlane
+//   - GET    /api/v1alpha1/namespaces/{namespace}/tenantcontrolplanes/{name}: get a TenantControlPlane
+//   - DELETE /api/v1alpha1/namespaces/{namespace}/tenantcontrolplanes/{name}: delete a TenantControlPlane
+//   - POST   /api/v1alpha1/namespaces/{namespace}/tenantcontrolplanes/{name}/rotate: rotate the certificates
+//   - GET    /api/v1alpha1/namespaces/{namespace}/tenantcontrolplanes/{name}/kubeconfig: get the admin kubeconfig,
+//     requiring the permission to get both the TenantControlPlane and its admin kubeconfig Secret
+type Server struct {
+	BindAddress string
+	// TokenFile contains the optional static token, allowed to use all the routes: it's read upon each request,
+	// allowing its rotation with no restart.
+	TokenFile string
+	CertFile  string
+	KeyFile   string
+
+	client client.Client
+	// clientset performs the TokenReview and SubjectAccessReview requests, whose result is returned in their status.
+	clientset clientset.Interface
+	logger    logr.Logger
+}
+
+func (s *Server) SetupWithManager(mgr ctrl.Manager) error {
+	cs, err := clientset.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	s.client = mgr.GetClient()
+	s.clientset = cs
+	s.logger = mgr.GetLogger().WithName("admin-api")
+
+	return mgr.Add(s)
+}
+
+// NeedLeaderElection allows serving the API from all the replicas of the operator.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.authenticate(http.HandlerFunc(s.route)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		s.logger.Info("serving the admin API", "address", s.BindAddress)
+
+		errCh <- server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancelFn()
+
+		return server.Shutdown(shutdownCtx) //nolint:contextcheck
+	case err := <-errCh:
+		return err
+	}
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if len(token) == 0 || token == r.Header.Get("Authorization") {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("a valid bearer token is required"))
+
+			return
+		}
+
+		static, err := s.isStaticToken(token)
+		if err != nil {
+			s.logger.Error(err, "cannot read the admin API token file")
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("authentication is not available"))
+
+			return
+		}
+
+		if static {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerKey{}, &caller{static: true})))
+
+			return
+		}
+
+		review, err := s.clientset.AuthenticationV1().TokenReviews().Create(r.Context(), &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}, metav1.CreateOptions{})
+		if err != nil {
+			s.logger.Error(err, "cannot review the admin API token")
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("authentication is not available"))
+
+			return
+		}
+
+		if !review.Status.Authenticated {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("a valid bearer token is required"))
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerKey{}, &caller{user: review.Status.User})))
+	})
+}
+
+// isStaticToken checks if the given token is the static one, comparing it in constant time.
+func (s *Server) isStaticToken(token string) (bool, error) {
+	if len(s.TokenFile) == 0 {
+		return false, nil
+	}
+
+	expected, err := os.ReadFile(s.TokenFile)
+	if err != nil {
+		return false, err
+	}
+
+	if expected = []byte(strings.TrimSpace(string(expected))); len(expected) == 0 {
+		return false, nil
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), expected) == 1, nil
+}
+
+// authorize reviews the access of the caller to the given resource, writing the error response when not allowed.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, attributes *authorizationv1.ResourceAttributes) bool {
+	c, ok := r.Context().Value(callerKey{}).(*caller)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, fmt.Errorf("a valid bearer token is required"))
+
+		return false
+	}
+
+	if c.static {
+		return true
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(c.user.Extra))
+	for k, v := range c.user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	review, err := s.clientset.AuthorizationV1().SubjectAccessReviews().Create(r.Context(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               c.user.Username,
+			Groups:             c.user.Groups,
+			UID:                c.user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		s.logger.Error(err, "cannot review the admin API access")
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("authorization is not available"))
+
+		return false
+	}
+
+	if !review.Status.Allowed {
+		writeError(w, http.StatusForbidden, fmt.Errorf("%s cannot %s %s in the namespace %s", c.user.Username, attributes.Verb, attributes.Resource, attributes.Namespace))
+
+		return false
+	}
+
+	return true
+}
+
+// tenantControlPlaneAttributes returns the attributes of the Kubernetes API request equivalent to the route.
+func tenantControlPlaneAttributes(verb, namespace, name string) *authorizationv1.ResourceAttributes {
+	return &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     kamajiv1alpha1.GroupVersion.Group,
+		Version:   kamajiv1alpha1.GroupVersion.Version,
+		Resource:  "tenantcontrolplanes",
+		Name:      name,
+	}
+}
+
+// route dispatches the request according to its method and path, in the
+// {namespace}/tenantcontrolplanes[/{name}[/{subresource}]] form.
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, pathPrefix) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown path %s", r.URL.Path))
+
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, pathPrefix), "/"), "/")
+	if len(parts) < 2 || len(parts) > 4 || parts[1] != "tenantcontrolplanes" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown path %s", r.URL.Path))
+
+		return
+	}
+
+	namespace := parts[0]
+
+	var name string
+	if len(parts) > 2 {
+		name = parts[2]
+	}
+
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		if s.authorize(w, r, tenantControlPlaneAttributes("create", namespace, "")) {
+			s.create(w, r, namespace)
+		}
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		if s.authorize(w, r, tenantControlPlaneAttributes("get", namespace, name)) {
+			s.get(w, r, key)
+		}
+	case len(parts) == 3 && r.Method == http.MethodDelete:
+		if s.authorize(w, r, tenantControlPlaneAttributes("delete", namespace, name)) {
+			s.delete(w, r, key)
+		}
+	case len(parts) == 4 && parts[3] == "rotate" && r.Method == http.MethodPost:
+		if s.authorize(w, r, tenantControlPlaneAttributes("update", namespace, name)) {
+			s.rotate(w, r, key)
+		}
+	case len(parts) == 4 && parts[3] == "kubeconfig" && r.Method == http.MethodGet:
+		if s.authorize(w, r, tenantControlPlaneAttributes("get", namespace, name)) {
+			s.kubeconfig(w, r, key)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s is not supported for %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, namespace string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err = json.Unmarshal(body, tcp); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("cannot decode the TenantControlPlane: %w", err))
+
+		return
+	}
+
+	if len(tcp.GetNamespace()) > 0 && tcp.GetNamespace() != namespace {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("the TenantControlPlane namespace doesn't match the requested one"))
+
+		return
+	}
+
+	tcp.SetNamespace(namespace)
+	tcp.SetResourceVersion("")
+	tcp.Status = kamajiv1alpha1.TenantControlPlaneStatus{}
+
+	if err = s.client.Create(r.Context(), tcp); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	s.logger.Info("TenantControlPlane created", "namespace", namespace, "name", tcp.GetName())
+
+	tcp.SetGroupVersionKind(kamajiv1alpha1.GroupVersion.WithKind("TenantControlPlane"))
+
+	writeJSON(w, http.StatusCreated, tcp)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request, key types.NamespacedName) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := s.client.Get(r.Context(), key, tcp); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tcp)
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, key types.NamespacedName) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	tcp.SetNamespace(key.Namespace)
+	tcp.SetName(key.Name)
+
+	if err := s.client.Delete(r.Context(), tcp); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	s.logger.Info("TenantControlPlane deleted", "namespace", key.Namespace, "name", key.Name)
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// rotate requests the rotation of the leaf certificates, generated again by the next reconciliation.
+func (s *Server) rotate(w http.ResponseWriter, r *http.Request, key types.NamespacedName) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := s.client.Get(r.Context(), key, tcp); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	if err := utilities.RequestCertificatesRotation(r.Context(), s.client, tcp); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	s.logger.Info("TenantControlPlane certificates rotation requested", "namespace", key.Namespace, "name", key.Name)
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) kubeconfig(w http.ResponseWriter, r *http.Request, key types.NamespacedName) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := s.client.Get(r.Context(), key, tcp); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	secretName := tcp.Status.KubeConfig.Admin.SecretName
+	if len(secretName) == 0 {
+		writeError(w, http.StatusConflict, fmt.Errorf("the admin kubeconfig has not been generated yet"))
+
+		return
+	}
+	// The admin kubeconfig is stored in a Secret, requiring the permission to read it.
+	if !s.authorize(w, r, &authorizationv1.ResourceAttributes{Namespace: key.Namespace, Verb: "get", Version: "v1", Resource: "secrets", Name: secretName}) {
+		return
+	}
+
+	secret := &corev1.Secret{}
+	if err := s.client.Get(r.Context(), types.NamespacedName{Namespace: key.Namespace, Name: secretName}, secret); err != nil {
+		writeAPIError(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(secret.Data[resources.AdminKubeConfigFileName])
+}
+
+// writeAPIError translates the error of the Kubernetes API into the response status code.
+func writeAPIError(w http.ResponseWriter, err error) {
+	var status k8serrors.APIStatus
+
+	if errors.As(err, &status) {
+		writeError(w, int(status.Status().Code), err)
+
+		return
+	}
+
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,215 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package adminapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// newTestServer returns a Server authenticating the "valid" token as the "alice" user,
+// allowed to perform the given verbs on the resources in the resource/name format.
+func newTestServer(t *testing.T, allowed map[string][]string) (*Server, *[]authorizationv1.ResourceAttributes) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant"}}
+	tcp.Status.KubeConfig.Admin.SecretName = "tenant-admin-kubeconfig"
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-admin-kubeconfig"},
+		Data:       map[string][]byte{resources.AdminKubeConfigFileName: []byte("kubeconfig")},
+	}
+
+	var reviewed []authorizationv1.ResourceAttributes
+
+	cs := fakeclientset.NewSimpleClientset()
+	cs.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview).DeepCopy()
+		if review.Spec.Token == "valid" {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "alice"}
+		}
+
+		return true, review, nil
+	})
+	cs.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview).DeepCopy()
+
+		attributes := review.Spec.ResourceAttributes
+		reviewed = append(reviewed, *attributes)
+
+		for _, verb := range allowed[attributes.Resource+"/"+attributes.Name] {
+			if review.Spec.User == "alice" && verb == attributes.Verb {
+				review.Status.Allowed = true
+			}
+		}
+
+		return true, review, nil
+	})
+
+	return &Server{
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp, secret).Build(),
+		clientset: cs,
+		logger:    logr.Discard(),
+	}, &reviewed
+}
+
+func TestServerAuthentication(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("static\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		code          int
+	}{
+		{name: "missing token", authorization: "", code: http.StatusUnauthorized},
+		{name: "not a bearer token", authorization: "valid", code: http.StatusUnauthorized},
+		{name: "unauthenticated token", authorization: "Bearer invalid", code: http.StatusUnauthorized},
+		{name: "static token", authorization: "Bearer static", code: http.StatusOK},
+		{name: "reviewed token", authorization: "Bearer valid", code: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestServer(t, map[string][]string{"tenantcontrolplanes/tenant": {"get"}})
+			s.TokenFile = tokenFile
+
+			request := httptest.NewRequest(http.MethodGet, pathPrefix+"default/tenantcontrolplanes/tenant", nil)
+			if len(tt.authorization) > 0 {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			s.authenticate(http.HandlerFunc(s.route)).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.code {
+				t.Errorf("expected status code %d, got %d: %s", tt.code, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestServerAuthorization(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		allowed  map[string][]string
+		code     int
+		reviewed []authorizationv1.ResourceAttributes
+	}{
+		{
+			name:    "get allowed",
+			method:  http.MethodGet,
+			path:    "default/tenantcontrolplanes/tenant",
+			allowed: map[string][]string{"tenantcontrolplanes/tenant": {"get"}},
+			code:    http.StatusOK,
+			reviewed: []authorizationv1.ResourceAttributes{
+				*tenantControlPlaneAttributes("get", "default", "tenant"),
+			},
+		},
+		{
+			name:    "delete forbidden",
+			method:  http.MethodDelete,
+			path:    "default/tenantcontrolplanes/tenant",
+			allowed: map[string][]string{"tenantcontrolplanes/tenant": {"get"}},
+			code:    http.StatusForbidden,
+			reviewed: []authorizationv1.ResourceAttributes{
+				*tenantControlPlaneAttributes("delete", "default", "tenant"),
+			},
+		},
+		{
+			name:   "kubeconfig allowed",
+			method: http.MethodGet,
+			path:   "default/tenantcontrolplanes/tenant/kubeconfig",
+			allowed: map[string][]string{
+				"tenantcontrolplanes/tenant":      {"get"},
+				"secrets/tenant-admin-kubeconfig": {"get"},
+			},
+			code: http.StatusOK,
+			reviewed: []authorizationv1.ResourceAttributes{
+				*tenantControlPlaneAttributes("get", "default", "tenant"),
+				{Namespace: "default", Verb: "get", Version: "v1", Resource: "secrets", Name: "tenant-admin-kubeconfig"},
+			},
+		},
+		{
+			name:   "kubeconfig with the permission to read another Secret",
+			method: http.MethodGet,
+			path:   "default/tenantcontrolplanes/tenant/kubeconfig",
+			allowed: map[string][]string{
+				"tenantcontrolplanes/tenant": {"get"},
+				"secrets/another":            {"get"},
+			},
+			code: http.StatusForbidden,
+			reviewed: []authorizationv1.ResourceAttributes{
+				*tenantControlPlaneAttributes("get", "default", "tenant"),
+				{Namespace: "default", Verb: "get", Version: "v1", Resource: "secrets", Name: "tenant-admin-kubeconfig"},
+			},
+		},
+		{
+			name:    "kubeconfig of a Tenant Control Plane not readable",
+			method:  http.MethodGet,
+			path:    "default/tenantcontrolplanes/tenant/kubeconfig",
+			allowed: map[string][]string{"secrets/tenant-admin-kubeconfig": {"get"}},
+			code:    http.StatusForbidden,
+			reviewed: []authorizationv1.ResourceAttributes{
+				*tenantControlPlaneAttributes("get", "default", "tenant"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, reviewed := newTestServer(t, tt.allowed)
+
+			request := httptest.NewRequest(tt.method, pathPrefix+tt.path, nil)
+			request.Header.Set("Authorization", "Bearer valid")
+
+			recorder := httptest.NewRecorder()
+			s.authenticate(http.HandlerFunc(s.route)).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.code {
+				t.Errorf("expected status code %d, got %d: %s", tt.code, recorder.Code, recorder.Body.String())
+			}
+
+			if len(*reviewed) != len(tt.reviewed) {
+				t.Fatalf("expected %d access reviews, got %d: %v", len(tt.reviewed), len(*reviewed), *reviewed)
+			}
+
+			for i := range tt.reviewed {
+				if (*reviewed)[i] != tt.reviewed[i] {
+					t.Errorf("expected the access review %v, got %v", tt.reviewed[i], (*reviewed)[i])
+				}
+			}
+		})
+	}
+}
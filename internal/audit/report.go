@@ -0,0 +1,137 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+type CredentialType string
+
+const (
+	CredentialCertificate CredentialType = "certificate"
+	CredentialKubeconfig  CredentialType = "kubeconfig"
+	CredentialOpaque      CredentialType = "opaque"
+)
+
+// Report lists the credentials managed by Kamaji for a Tenant Control Plane.
+type Report struct {
+	TenantControlPlane string       `json:"tenantControlPlane"`
+	GeneratedAt        time.Time    `json:"generatedAt"`
+	Credentials        []Credential `json:"credentials"`
+}
+
+// Credential describes a single credential stored in a Secret key: the certificate details are reported
+// for certificates and kubeconfig files, the last rotation only for the opaque ones, such as passwords.
+type Credential struct {
+	Secret             string         `json:"secret"`
+	Key                string         `json:"key"`
+	Type               CredentialType `json:"type"`
+	Subject            string         `json:"subject,omitempty"`
+	Issuer             string         `json:"issuer,omitempty"`
+	Algorithm          string         `json:"algorithm,omitempty"`
+	SignatureAlgorithm string         `json:"signatureAlgorithm,omitempty"`
+	NotAfter           *time.Time     `json:"notAfter,omitempty"`
+	LastRotation       time.Time      `json:"lastRotation"`
+	Age                string         `json:"age"`
+}
+
+// NewReport collects the credentials stored in the given Secrets, sorted by Secret name and key.
+func NewReport(tenantControlPlane string, secrets []corev1.Secret, now time.Time) Report {
+	report := Report{
+		TenantControlPlane: tenantControlPlane,
+		GeneratedAt:        now,
+		Credentials:        []Credential{},
+	}
+
+	for i := range secrets {
+		for key, value := range secrets[i].Data {
+			credential, ok := newCredential(&secrets[i], key, value)
+			if !ok {
+				continue
+			}
+
+			credential.Age = now.Sub(credential.LastRotation).Truncate(time.Second).String()
+
+			report.Credentials = append(report.Credentials, credential)
+		}
+	}
+
+	sort.Slice(report.Credentials, func(i, j int) bool {
+		if report.Credentials[i].Secret != report.Credentials[j].Secret {
+			return report.Credentials[i].Secret < report.Credentials[j].Secret
+		}
+
+		return report.Credentials[i].Key < report.Credentials[j].Key
+	})
+
+	return report
+}
+
+func newCredential(secret *corev1.Secret, key string, value []byte) (Credential, bool) {
+	credential := Credential{
+		Secret: secret.GetName(),
+		Key:    key,
+	}
+
+	if crt, err := crypto.ParseCertificateBytes(value); err == nil {
+		credential.Type = CredentialCertificate
+		setCertificate(&credential, crt)
+
+		return credential, true
+	}
+	// Private and public keys are reported along with the certificates they belong to.
+	if block, _ := pem.Decode(value); block != nil {
+		return credential, false
+	}
+
+	if config, err := clientcmd.Load(value); err == nil && len(config.AuthInfos) > 0 {
+		for _, authInfo := range config.AuthInfos {
+			if crt, err := crypto.ParseCertificateBytes(authInfo.ClientCertificateData); err == nil {
+				credential.Type = CredentialKubeconfig
+				setCertificate(&credential, crt)
+
+				return credential, true
+			}
+		}
+	}
+
+	credential.Type = CredentialOpaque
+	credential.LastRotation = lastUpdate(secret)
+
+	return credential, true
+}
+
+func setCertificate(credential *Credential, crt *x509.Certificate) {
+	notAfter := crt.NotAfter.UTC()
+
+	credential.Subject = crt.Subject.String()
+	credential.Issuer = crt.Issuer.String()
+	credential.Algorithm = crt.PublicKeyAlgorithm.String()
+	credential.SignatureAlgorithm = crt.SignatureAlgorithm.String()
+	credential.NotAfter = &notAfter
+	credential.LastRotation = crt.NotBefore.UTC()
+}
+
+// lastUpdate returns the time of the last write of the Secret, according to its managed fields,
+// falling back to the creation one.
+func lastUpdate(secret *corev1.Secret) time.Time {
+	last := secret.GetCreationTimestamp().Time
+
+	for _, entry := range secret.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(last) {
+			last = entry.Time.Time
+		}
+	}
+
+	return last.UTC()
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	KineName           = "kine"
+	KinePort           = 2379
+	KineCACertName     = "ca.crt"
+	KineServerCertName = "server.crt"
+	KineServerKeyName  = "server.key"
+	KineClientCertName = "client.crt"
+	KineClientKeyName  = "client.key"
+	kineServerVolume   = "kine-server-certs"
+	// KineMetricsPortName is the name of the kine container port exposing the metrics endpoint.
+	KineMetricsPortName = "kine-metrics"
+)
+
+// KineServiceName returns the name of the Service exposing kine when running as a separate Deployment.
+func KineServiceName(tcp *kamajiv1alpha1.TenantControlPlane) string {
+	return utilities.AddTenantPrefix(KineName, tcp)
+}
+
+// KineEndpoint returns the URL used by the kube-apiserver to connect to kine when running as a separate Deployment.
+func KineEndpoint(tcp *kamajiv1alpha1.TenantControlPlane) string {
+	return fmt.Sprintf("https://%s.%s.svc:%d", KineServiceName(tcp), tcp.GetNamespace(), KinePort)
+}
+
+// Kine builds the Pod specification of kine when running as a separate Deployment,
+// shared by all the kube-apiserver replicas of the Tenant Control Plane.
+type Kine struct {
+	KineContainerImage string
+	DataStore          kamajiv1alpha1.DataStore
+}
+
+func (k *Kine) SetContainers(podSpec *corev1.PodSpec, tcp *kamajiv1alpha1.TenantControlPlane) {
+	d := &Deployment{KineContainerImage: k.KineContainerImage, DataStore: k.DataStore}
+
+	index := d.buildKineContainer(podSpec, tcp)
+	d.buildKineServerTLS(podSpec, index)
+
+	args := utilities.ArgsFromSliceToMap(podSpec.Containers[index].Args)
+	args["--listen-address"] = fmt.Sprintf("0.0.0.0:%d", KinePort)
+
+	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
+}
+
+func (k *Kine) SetVolumes(podSpec *corev1.PodSpec, tcp *kamajiv1alpha1.TenantControlPlane) {
+	d := &Deployment{KineContainerImage: k.KineContainerImage, DataStore: k.DataStore}
+
+	d.buildKineConfigVolume(podSpec, tcp)
+	d.buildKineCertsVolume(podSpec)
+	d.buildKineServerVolume(podSpec, tcp)
+}
+
+// buildKineServerTLS configures the kine container at the given index to serve using the dedicated certificate.
+func (d *Deployment) buildKineServerTLS(podSpec *corev1.PodSpec, index int) {
+	args := utilities.ArgsFromSliceToMap(podSpec.Containers[index].Args)
+	args["--server-cert-file"] = "/kine-server/" + KineServerCertName
+	args["--server-key-file"] = "/kine-server/" + KineServerKeyName
+
+	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
+	podSpec.Containers[index].VolumeMounts = append(podSpec.Containers[index].VolumeMounts, corev1.VolumeMount{
+		Name:      kineServerVolume,
+		ReadOnly:  true,
+		MountPath: "/kine-server",
+	})
+}
+
+func (d *Deployment) buildKineServerVolume(podSpec *corev1.PodSpec, tcp *kamajiv1alpha1.TenantControlPlane) {
+	found, index := utilities.HasNamedVolume(podSpec.Volumes, kineServerVolume)
+	if !found {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{})
+		index = len(podSpec.Volumes) - 1
+	}
+
+	podSpec.Volumes[index].Name = kineServerVolume
+	podSpec.Volumes[index].VolumeSource = corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName: tcp.Status.Storage.Kine.Certificate.SecretName,
+			Items: []corev1.KeyToPath{
+				{
+					Key:  KineCACertName,
+					Path: KineCACertName,
+				},
+				{
+					Key:  KineServerCertName,
+					Path: KineServerCertName,
+				},
+				{
+					Key:  KineServerKeyName,
+					Path: KineServerKeyName,
+				},
+			},
+			DefaultMode: pointer.Int32(420),
+		},
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"math/big"
 	mathrand "math/rand"
+	"net"
 	"time"
 
 	"github.com/pkg/errors"
@@ -161,6 +162,43 @@ func VerifyCertificate(cert, ca []byte, usages ...x509.ExtKeyUsage) (bool, error
 	return len(chains) > 0, err
 }
 
+// MissingSubjectAlternativeNames returns the given Subject Alternative Names, either IP addresses or DNS names,
+// which are not covered by the certificate.
+func MissingSubjectAlternativeNames(cert []byte, sans ...string) ([]string, error) {
+	crt, err := ParseCertificateBytes(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			found := false
+
+			for _, certIP := range crt.IPAddresses {
+				if certIP.Equal(ip) {
+					found = true
+
+					break
+				}
+			}
+
+			if !found {
+				missing = append(missing, san)
+			}
+
+			continue
+		}
+
+		if err = crt.VerifyHostname(san); err != nil {
+			missing = append(missing, san)
+		}
+	}
+
+	return missing, nil
+}
+
 func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*bytes.Buffer, *bytes.Buffer, error) {
 	certPrivKey, err := rsa.GenerateKey(cryptorand.Reader, 2048)
 	if err != nil {
@@ -227,3 +265,46 @@ func NewCertificateTemplate(commonName string) *x509.Certificate {
 		KeyUsage: x509.KeyUsageDigitalSignature,
 	}
 }
+
+// GenerateCertificateAuthorityPrivateKeyPair returns the bytes of a self-signed Certificate Authority, and of its key.
+func GenerateCertificateAuthorityPrivateKeyPair(commonName string) (*bytes.Buffer, *bytes.Buffer, error) {
+	return GenerateCertificateAuthorityPrivateKeyPairWithValidity(commonName, time.Until(time.Now().AddDate(10, 0, 0)))
+}
+
+// GenerateCertificateAuthorityPrivateKeyPairWithValidity returns the bytes of a self-signed Certificate Authority
+// expiring after the given validity, and of its key.
+func GenerateCertificateAuthorityPrivateKeyPairWithValidity(commonName string, validity time.Duration) (*bytes.Buffer, *bytes.Buffer, error) {
+	caPrivKey, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot generate an RSA key")
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(mathrand.Int63()),
+		Subject: pkix.Name{
+			CommonName: commonName,
+		},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(validity),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+	}
+
+	caBytes, err := x509.CreateCertificate(cryptorand.Reader, template, template, &caPrivKey.PublicKey, caPrivKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot create the Certificate Authority")
+	}
+
+	caPEM := &bytes.Buffer{}
+	if err = pem.Encode(caPEM, &pem.Block{Type: "CERTIFICATE", Bytes: caBytes}); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot encode the Certificate Authority bytes")
+	}
+
+	caPrivKeyPEM := &bytes.Buffer{}
+	if err = pem.Encode(caPrivKeyPEM, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(caPrivKey)}); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot encode private key")
+	}
+
+	return caPEM, caPrivKeyPEM, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// azureADDatabaseScope is the scope of the access tokens accepted by the Azure Database for PostgreSQL.
+	azureADDatabaseScope = "https://ossrdbms-aad.database.windows.net/.default"
+	// azureADDefaultAuthorityHost is used when the Azure Workload Identity webhook doesn't inject the authority host.
+	azureADDefaultAuthorityHost = "https://login.microsoftonline.com/"
+	// azureADTokenExpirationMargin is the time left before the expiration of an access token to request a new one.
+	azureADTokenExpirationMargin = 5 * time.Minute
+)
+
+type azureADToken struct {
+	value     string
+	expiresAt time.Time
+}
+
+// azureADTokens caches the Azure AD access tokens per client ID,
+// since a new connection is established at every reconciliation.
+var azureADTokens = struct {
+	sync.Mutex
+	tokens map[string]azureADToken
+}{tokens: map[string]azureADToken{}}
+
+// azureADAccessTokenFn returns the function retrieving the Azure AD access token of the operator workload identity,
+// used as password: the federated service account token is exchanged using the environment variables injected by
+// the Azure Workload Identity webhook, the client ID can be overridden.
+func azureADAccessTokenFn(clientID string) func(ctx context.Context, _ ConnectionEndpoint) (string, error) {
+	return func(ctx context.Context, _ ConnectionEndpoint) (string, error) {
+		id := clientID
+		if len(id) == 0 {
+			id = os.Getenv("AZURE_CLIENT_ID")
+		}
+
+		azureADTokens.Lock()
+		defer azureADTokens.Unlock()
+
+		if token, ok := azureADTokens.tokens[id]; ok && time.Now().Add(azureADTokenExpirationMargin).Before(token.expiresAt) {
+			return token.value, nil
+		}
+
+		token, err := exchangeAzureADFederatedToken(ctx, id)
+		if err != nil {
+			return "", errors.Wrap(err, "cannot retrieve the Azure AD access token")
+		}
+
+		azureADTokens.tokens[id] = token
+
+		return token.value, nil
+	}
+}
+
+func exchangeAzureADFederatedToken(ctx context.Context, clientID string) (azureADToken, error) {
+	tenantID, tokenFile := os.Getenv("AZURE_TENANT_ID"), os.Getenv("AZURE_FEDERATED_TOKEN_FILE")
+	if len(clientID) == 0 || len(tenantID) == 0 || len(tokenFile) == 0 {
+		return azureADToken{}, fmt.Errorf("the Azure Workload Identity is not configured for the operator")
+	}
+
+	assertion, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return azureADToken{}, errors.Wrap(err, "cannot read the federated token")
+	}
+
+	authorityHost := os.Getenv("AZURE_AUTHORITY_HOST")
+	if len(authorityHost) == 0 {
+		authorityHost = azureADDefaultAuthorityHost
+	}
+
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_id":             {clientID},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {strings.TrimSpace(string(assertion))},
+		"scope":                 {azureADDatabaseScope},
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/oauth2/v2.0/token", strings.TrimSuffix(authorityHost, "/"), tenantID)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return azureADToken{}, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return azureADToken{}, err
+	}
+	defer response.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		ErrorDescription string `json:"error_description"`
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return azureADToken{}, errors.Wrap(err, "cannot decode the token response")
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return azureADToken{}, fmt.Errorf("token request failed with status %d: %s", response.StatusCode, body.ErrorDescription)
+	}
+
+	return azureADToken{
+		value:     body.AccessToken,
+		expiresAt: time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+	}, nil
+}
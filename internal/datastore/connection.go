@@ -6,6 +6,7 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +15,10 @@ import (
 )
 
 func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) (Connection, error) {
+	if isFakeDataStore(ds) {
+		return NewFakeConnection(ds), nil
+	}
+
 	cc, err := NewConnectionConfig(ctx, client, ds)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create connection config object")
@@ -21,16 +26,19 @@ func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1
 
 	switch ds.Spec.Driver {
 	case kamajiv1alpha1.KineMySQLDriver:
-		cc.TLSConfig.ServerName = cc.Endpoints[0].Host
-		cc.Parameters = map[string][]string{
-			"multiStatements": {"true"},
+		cc.Parameters = kamajiv1alpha1.WithoutTemplatedParameters(ds.MySQLParameters())
+		cc.Parameters["multiStatements"] = []string{"true"}
+		// The IAM authentication tokens are sent in clear text, the connection is secured by TLS.
+		if cc.PasswordFn != nil {
+			cc.Parameters["allowCleartextPasswords"] = []string{"true"}
 		}
 
-		return NewMySQLConnection(*cc)
+		return newFailoverConnection(ctx, *cc, NewMySQLConnection)
 	case kamajiv1alpha1.KinePostgreSQLDriver:
-		cc.TLSConfig.ServerName = cc.Endpoints[0].Host
+		cc.Parameters = kamajiv1alpha1.WithoutTemplatedParameters(ds.PostgreSQLParameters())
+		cc.SharedDatabase = ds.PostgreSQLSharedDatabase()
 		//nolint:contextcheck
-		return NewPostgreSQLConnection(*cc)
+		return newFailoverConnection(ctx, *cc, NewPostgreSQLConnection)
 	case kamajiv1alpha1.EtcdDriver:
 		return NewETCDConnection(*cc)
 	default:
@@ -38,6 +46,81 @@ func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1
 	}
 }
 
+// CheckConnection establishes a connection to the DataStore using its endpoints, credentials, and TLS material,
+// returning the driver error when it cannot be reached.
+func CheckConnection(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) error {
+	conn, err := NewStorageConnection(ctx, client, ds)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Check(ctx)
+}
+
+// writableChecker is implemented by the SQL connections able to tell if the connected endpoint is accepting writes.
+type writableChecker interface {
+	isWritable(ctx context.Context) (bool, error)
+}
+
+// newFailoverConnection connects to the first writable endpoint, following the declared order, when multiple ones are
+// specified: this allows surviving the failover of the primary database with no reconciliation errors.
+func newFailoverConnection(ctx context.Context, config ConnectionConfig, fn func(ConnectionConfig) (Connection, error)) (Connection, error) {
+	var lastErr error
+
+	for i := range config.Endpoints {
+		candidate := config
+		candidate.Endpoints = make([]ConnectionEndpoint, 0, len(config.Endpoints))
+		candidate.Endpoints = append(candidate.Endpoints, config.Endpoints[i])
+		candidate.Endpoints = append(candidate.Endpoints, config.Endpoints[:i]...)
+		candidate.Endpoints = append(candidate.Endpoints, config.Endpoints[i+1:]...)
+		candidate.TLSConfig = config.TLSConfig.Clone()
+		candidate.TLSConfig.ServerName = config.Endpoints[i].Host
+
+		if config.PasswordFn != nil {
+			password, err := config.PasswordFn(ctx, config.Endpoints[i])
+			if err != nil {
+				lastErr = err
+
+				continue
+			}
+
+			candidate.Password = password
+		}
+
+		conn, err := fn(candidate)
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		if len(config.Endpoints) == 1 {
+			return conn, nil
+		}
+
+		checker, ok := conn.(writableChecker)
+		if !ok {
+			return conn, nil
+		}
+
+		writable, err := checker.isWritable(ctx)
+		if err == nil && writable {
+			return conn, nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("endpoint %s is read-only", config.Endpoints[i].String())
+		}
+
+		lastErr = err
+
+		_ = conn.Close()
+	}
+
+	return nil, errors.Wrap(lastErr, "no writable endpoint is available")
+}
+
 type Connection interface {
 	CreateUser(ctx context.Context, user, password string) error
 	CreateDB(ctx context.Context, dbName string) error
@@ -52,5 +135,70 @@ type Connection interface {
 	Close() error
 	Check(ctx context.Context) error
 	Driver() string
-	Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error
+	// Migrate copies the data of the given Tenant Control Plane to the target connection, notifying the progress,
+	// and throttling the copy when supported by the driver.
+	Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, progress MigrationProgressFn, throttle MigrationThrottleFn) error
+}
+
+// MigrationProgressFn is notified of the migration progress, with the number of keys, or rows, copied so far.
+type MigrationProgressFn func(copied, total int64)
+
+// Maintainer is implemented by the connections supporting the periodic maintenance of the data store.
+type Maintainer interface {
+	// Revision returns the current head revision.
+	Revision(ctx context.Context) (int64, error)
+	// Compact discards the superseded revisions up to the given one.
+	Compact(ctx context.Context, revision int64) error
+	// Defragment releases the free space of each member, returning the defragmented endpoints.
+	Defragment(ctx context.Context) ([]string, error)
+}
+
+// QuotaEnforcer is implemented by the connections supporting the per-tenant storage quota.
+type QuotaEnforcer interface {
+	// Usage returns the size in bytes of the data stored by the given tenant.
+	Usage(ctx context.Context, dbName string) (int64, error)
+	// SetReadOnly revokes, or restores, the write permission of the given tenant.
+	SetReadOnly(ctx context.Context, dbName string, readOnly bool) error
+}
+
+// PrivilegesChecker is implemented by the connections able to verify the privileges of the DataStore credentials.
+type PrivilegesChecker interface {
+	// CheckPrivileges returns an error when the credentials cannot manage the per-tenant users and schemas.
+	CheckPrivileges(ctx context.Context) error
+}
+
+// AuthenticationEnabler is implemented by the connections able to bootstrap the authentication of a data store
+// provisioned by Kamaji.
+type AuthenticationEnabler interface {
+	// EnableAuthentication creates the root user, and enables the authentication.
+	EnableAuthentication(ctx context.Context) error
+}
+
+// Enumerator is implemented by the connections able to list the users, and the schemas, of the data store,
+// allowing the garbage collection of the ones left behind by deleted Tenant Control Planes.
+type Enumerator interface {
+	// ListUsers returns the users able to log in.
+	ListUsers(ctx context.Context) ([]string, error)
+	// ListSchemas returns the databases, or the roles granting access to the etcd prefixes.
+	ListSchemas(ctx context.Context) ([]string, error)
+}
+
+// Prober is implemented by the connections able to measure the latency of the tenant data path.
+type Prober interface {
+	// Probe writes, and reads back, a sentinel key in the given tenant schema, returning the latency of both operations.
+	Probe(ctx context.Context, dbName string) (write time.Duration, read time.Duration, err error)
+}
+
+// Counter is implemented by the connections able to count the data of a tenant, such as before a migration.
+type Counter interface {
+	// Count returns the number of keys, or of rows, stored in the given tenant schema.
+	Count(ctx context.Context, dbName string) (int64, error)
+}
+
+// TableCleaner is implemented by the SQL connections able to reclaim the space left in the kine table by the compacted rows.
+type TableCleaner interface {
+	// TableSize returns the size in bytes of the kine table of the given tenant schema, including its indexes.
+	TableSize(ctx context.Context, dbName string) (int64, error)
+	// CleanUpTable reclaims the space of the deleted rows of the kine table of the given tenant schema.
+	CleanUpTable(ctx context.Context, dbName string) error
 }
@@ -33,6 +33,10 @@ type ConnectionConfig struct {
 	DBName     string
 	TLSConfig  *tls.Config
 	Parameters map[string][]string
+	// SharedDatabase is the PostgreSQL database holding a schema per tenant, rather than a database per tenant.
+	SharedDatabase string
+	// PasswordFn generates the password of the given endpoint upon connection, such as the IAM authentication tokens.
+	PasswordFn func(ctx context.Context, endpoint ConnectionEndpoint) (string, error)
 }
 
 func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) (*ConnectionConfig, error) {
@@ -95,7 +99,7 @@ func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1a
 		})
 	}
 
-	return &ConnectionConfig{
+	cc := &ConnectionConfig{
 		User:      user,
 		Password:  password,
 		Endpoints: eps,
@@ -103,7 +107,19 @@ func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1a
 			RootCAs:      rootCAs,
 			Certificates: []tls.Certificate{certificate},
 		},
-	}, nil
+	}
+
+	if iam := ds.Spec.IAMAuthentication; iam != nil {
+		cc.User = iam.Username
+		cc.PasswordFn = iamAuthenticationTokenFn(iam.Region, iam.Username)
+	}
+
+	if azureAD := ds.Spec.AzureADAuthentication; azureAD != nil {
+		cc.User = azureAD.Username
+		cc.PasswordFn = azureADAccessTokenFn(azureAD.ClientID)
+	}
+
+	return cc, nil
 }
 
 func (config ConnectionConfig) getDataSourceNameUserPassword() string {
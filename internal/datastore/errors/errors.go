@@ -48,3 +48,7 @@ func NewCheckConnectionError(err error) error {
 func NewCreateDBError(err error) error {
 	return errors.Wrap(err, "cannot create database")
 }
+
+func NewInsufficientPrivilegesError(err error) error {
+	return errors.Wrap(err, "insufficient privileges")
+}
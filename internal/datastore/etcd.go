@@ -6,6 +6,10 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	goerrors "github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/authpb"
@@ -21,6 +25,16 @@ const (
 	// If rangeEnd is ‘\0’, the range is all keys greater than or equal to the key argument
 	// source: https://etcd.io/docs/v3.5/learning/api/
 	rangeEnd = "\\0"
+	// usagePageSize is the number of keys retrieved at once when computing the storage usage of a tenant.
+	usagePageSize = 500
+	// canaryKey is the key written by the canary in the tenant prefix.
+	canaryKey = "kamaji-canary"
+	// etcdDialTimeout is the maximum time to establish the connection with a member.
+	etcdDialTimeout = 5 * time.Second
+	// etcdHealthCheckTimeout is the maximum time to retrieve the status of the members upon the connection.
+	etcdHealthCheckTimeout = 5 * time.Second
+	// rootUser is the etcd user, and role, granted with all the privileges once the authentication is enabled.
+	rootUser = "root"
 )
 
 func NewETCDConnection(config ConnectionConfig) (Connection, error) {
@@ -31,8 +45,9 @@ func NewETCDConnection(config ConnectionConfig) (Connection, error) {
 	}
 
 	cfg := etcdclient.Config{
-		Endpoints: endpoints,
-		TLS:       config.TLSConfig,
+		Endpoints:   endpoints,
+		TLS:         config.TLSConfig,
+		DialTimeout: etcdDialTimeout,
 	}
 
 	client, err := etcdclient.New(cfg)
@@ -40,13 +55,71 @@ func NewETCDConnection(config ConnectionConfig) (Connection, error) {
 		return nil, err
 	}
 
+	healthy, err := healthyEndpoints(client, endpoints)
+	if err != nil {
+		_ = client.Close()
+
+		return nil, err
+	}
+	// The requests are balanced among the healthy members only, the client retrying on the others upon their failure.
+	client.SetEndpoints(healthy...)
+
 	return &EtcdClient{
-		Client: *client,
+		Client:    *client,
+		endpoints: endpoints,
 	}, nil
 }
 
+// healthyEndpoints checks concurrently the status of each member, returning the healthy ones, following the declared order:
+// an error is returned only when none of them is healthy.
+func healthyEndpoints(client *etcdclient.Client, endpoints []string) ([]string, error) {
+	if len(endpoints) == 1 {
+		return endpoints, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdHealthCheckTimeout)
+	defer cancel()
+
+	results := make([]error, len(endpoints))
+
+	var wg sync.WaitGroup
+
+	for i := range endpoints {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, results[i] = client.Status(ctx, endpoints[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	healthy := make([]string, 0, len(endpoints))
+	failures := make([]string, 0, len(endpoints))
+
+	for i, err := range results {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", endpoints[i], err.Error()))
+
+			continue
+		}
+
+		healthy = append(healthy, endpoints[i])
+	}
+
+	if len(healthy) == 0 {
+		return nil, errors.NewCheckConnectionError(fmt.Errorf("no healthy etcd member is available (%s)", strings.Join(failures, ", ")))
+	}
+
+	return healthy, nil
+}
+
 type EtcdClient struct {
 	Client etcdclient.Client
+	// endpoints are the declared members, including the unhealthy ones.
+	endpoints []string
 }
 
 func (e *EtcdClient) CreateUser(ctx context.Context, user, password string) error {
@@ -127,6 +200,25 @@ func (e *EtcdClient) DeleteUser(ctx context.Context, user string) error {
 	return nil
 }
 
+func (e *EtcdClient) ListUsers(ctx context.Context) ([]string, error) {
+	res, err := e.Client.Auth.UserList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Users, nil
+}
+
+// ListSchemas returns the roles, since each Tenant Control Plane prefix is granted by a role named after it.
+func (e *EtcdClient) ListSchemas(ctx context.Context) ([]string, error) {
+	res, err := e.Client.Auth.RoleList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Roles, nil
+}
+
 func (e *EtcdClient) DeleteDB(ctx context.Context, dbName string) error {
 	prefix := e.buildKey(dbName)
 	if _, err := e.Client.Delete(ctx, prefix, etcdclient.WithPrefix()); err != nil {
@@ -166,6 +258,46 @@ func (e *EtcdClient) Check(ctx context.Context) error {
 	return nil
 }
 
+func (e *EtcdClient) CheckPrivileges(ctx context.Context) error {
+	// Listing the roles is allowed to the root user only, the one required to manage the per-tenant users and roles.
+	if _, err := e.Client.RoleList(ctx); err != nil {
+		return errors.NewInsufficientPrivilegesError(err)
+	}
+
+	return nil
+}
+
+// EnableAuthentication creates the root user, authenticated by the client certificate common name, and enables
+// the etcd authentication: it's a no-op when already enabled.
+func (e *EtcdClient) EnableAuthentication(ctx context.Context) error {
+	status, err := e.Client.AuthStatus(ctx)
+	if err != nil {
+		return errors.NewCheckConnectionError(err)
+	}
+
+	if status.Enabled {
+		return nil
+	}
+
+	if _, err = e.Client.Auth.UserAddWithOptions(ctx, rootUser, "", &etcdclient.UserAddOptions{NoPassword: true}); err != nil && !goerrors.Is(err, rpctypes.ErrUserAlreadyExist) {
+		return errors.NewCreateUserError(err)
+	}
+
+	if _, err = e.Client.Auth.RoleAdd(ctx, rootUser); err != nil && !goerrors.Is(err, rpctypes.ErrRoleAlreadyExist) {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	if _, err = e.Client.Auth.UserGrantRole(ctx, rootUser, rootUser); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	if _, err = e.Client.Auth.AuthEnable(ctx); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	return nil
+}
+
 func (e *EtcdClient) Driver() string {
 	return string(kamajiv1alpha1.EtcdDriver)
 }
@@ -174,23 +306,169 @@ func (e *EtcdClient) buildKey(key string) string {
 	return fmt.Sprintf("/%s/", key)
 }
 
-func (e *EtcdClient) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
+// etcdMigrationProgressInterval is the number of keys copied between two progress notifications.
+const etcdMigrationProgressInterval = 100
+
+func (e *EtcdClient) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, progress MigrationProgressFn, throttle MigrationThrottleFn) error {
 	targetClient := target.(*EtcdClient) //nolint:forcetypeassert
 
 	if err := target.Check(ctx); err != nil {
 		return err
 	}
 
-	response, err := e.Client.Get(ctx, e.buildKey(fmt.Sprintf("%s_%s", tcp.GetNamespace(), tcp.GetName())), etcdclient.WithRange(rangeEnd))
+	response, err := e.Client.Get(ctx, e.buildKey(tcp.Status.Storage.Setup.Schema), etcdclient.WithRange(rangeEnd))
 	if err != nil {
 		return err
 	}
 
-	for _, kv := range response.Kvs {
+	total := int64(len(response.Kvs))
+
+	progress(0, total)
+
+	for i, kv := range response.Kvs {
+		if err = throttle(ctx, 1, len(kv.Key)+len(kv.Value)); err != nil {
+			return err
+		}
+
 		if _, err = targetClient.Client.Put(ctx, string(kv.Key), string(kv.Value)); err != nil {
 			return err
 		}
+
+		if copied := int64(i + 1); copied%etcdMigrationProgressInterval == 0 || copied == total {
+			progress(copied, total)
+		}
+	}
+
+	return nil
+}
+
+func (e *EtcdClient) Revision(ctx context.Context) (int64, error) {
+	response, err := e.Client.Get(ctx, "/", etcdclient.WithCountOnly())
+	if err != nil {
+		return 0, goerrors.Wrap(err, "cannot retrieve the current revision")
+	}
+
+	return response.Header.GetRevision(), nil
+}
+
+func (e *EtcdClient) Compact(ctx context.Context, revision int64) error {
+	if _, err := e.Client.Compact(ctx, revision, etcdclient.WithCompactPhysical()); err != nil {
+		if goerrors.Is(err, rpctypes.ErrCompacted) {
+			return nil
+		}
+
+		return goerrors.Wrap(err, "cannot compact the revisions")
+	}
+
+	return nil
+}
+
+// Defragment performs the defragmentation of the members one at a time, the leader as the last one:
+// the defragmentation is blocking the member, the quorum must be available during the whole process.
+func (e *EtcdClient) Defragment(ctx context.Context) ([]string, error) {
+	// Defragmenting a member while another one is down could lose the quorum.
+	if healthy := len(e.Client.Endpoints()); healthy < len(e.endpoints) {
+		return nil, fmt.Errorf("cannot defragment with %d out of %d healthy members", healthy, len(e.endpoints))
+	}
+
+	var leader string
+
+	endpoints := make([]string, 0, len(e.Client.Endpoints()))
+
+	for _, ep := range e.Client.Endpoints() {
+		status, err := e.Client.Status(ctx, ep)
+		if err != nil {
+			return nil, goerrors.Wrap(err, fmt.Sprintf("cannot retrieve the status of the member %s", ep))
+		}
+
+		if status.Header.GetMemberId() == status.Leader {
+			leader = ep
+
+			continue
+		}
+
+		endpoints = append(endpoints, ep)
+	}
+
+	if len(leader) > 0 {
+		endpoints = append(endpoints, leader)
+	}
+
+	defragmented := make([]string, 0, len(endpoints))
+
+	for _, ep := range endpoints {
+		if _, err := e.Client.Defragment(ctx, ep); err != nil {
+			return defragmented, goerrors.Wrap(err, fmt.Sprintf("cannot defragment the member %s", ep))
+		}
+
+		defragmented = append(defragmented, ep)
+	}
+
+	return defragmented, nil
+}
+
+func (e *EtcdClient) Usage(ctx context.Context, dbName string) (int64, error) {
+	prefix := e.buildKey(dbName)
+	end := etcdclient.GetPrefixRangeEnd(prefix)
+
+	var size int64
+
+	for key := prefix; ; {
+		response, err := e.Client.Get(ctx, key, etcdclient.WithRange(end), etcdclient.WithLimit(usagePageSize), etcdclient.WithSort(etcdclient.SortByKey, etcdclient.SortAscend))
+		if err != nil {
+			return 0, goerrors.Wrap(err, "cannot retrieve the tenant keys")
+		}
+
+		for _, kv := range response.Kvs {
+			size += int64(len(kv.Key) + len(kv.Value))
+		}
+
+		if !response.More || len(response.Kvs) == 0 {
+			return size, nil
+		}
+		// Starting the next page from the key following the last retrieved one.
+		key = string(response.Kvs[len(response.Kvs)-1].Key) + "\x00"
+	}
+}
+
+func (e *EtcdClient) Count(ctx context.Context, dbName string) (int64, error) {
+	prefix := e.buildKey(dbName)
+
+	response, err := e.Client.Get(ctx, prefix, etcdclient.WithRange(etcdclient.GetPrefixRangeEnd(prefix)), etcdclient.WithCountOnly())
+	if err != nil {
+		return 0, goerrors.Wrap(err, "cannot count the tenant keys")
+	}
+
+	return response.Count, nil
+}
+
+func (e *EtcdClient) SetReadOnly(ctx context.Context, dbName string, readOnly bool) error {
+	permission := etcdclient.PermissionType(authpb.READWRITE)
+	if readOnly {
+		permission = etcdclient.PermissionType(authpb.READ)
+	}
+	// Granting a permission on the same key range overwrites the previous one.
+	if _, err := e.Client.RoleGrantPermission(ctx, dbName, e.buildKey(dbName), rangeEnd, permission); err != nil {
+		return errors.NewGrantPrivilegesError(err)
 	}
 
 	return nil
 }
+
+func (e *EtcdClient) Probe(ctx context.Context, dbName string) (time.Duration, time.Duration, error) {
+	key := e.buildKey(dbName) + canaryKey
+
+	start := time.Now()
+	if _, err := e.Client.Put(ctx, key, strconv.FormatInt(start.UnixNano(), 10)); err != nil {
+		return 0, 0, err
+	}
+
+	write := time.Since(start)
+
+	start = time.Now()
+	if _, err := e.Client.Get(ctx, key); err != nil {
+		return write, 0, err
+	}
+
+	return write, time.Since(start), nil
+}
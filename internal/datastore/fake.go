@@ -0,0 +1,270 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore/errors"
+)
+
+// FakeDriverAnnotation marks a DataStore to be served by the in-memory driver, rather than the declared one:
+// it's honoured only when the fake driver has been enabled, such as for the e2e tests and the development environments.
+const FakeDriverAnnotation = "kamaji.clastix.io/fake-driver"
+
+// fakeDriverEnabled is set upon the start-up, before establishing any connection.
+var fakeDriverEnabled bool
+
+// EnableFakeDriver allows the DataStore objects with the FakeDriverAnnotation to use the in-memory driver.
+func EnableFakeDriver() {
+	fakeDriverEnabled = true
+}
+
+// FakeDriverEnabled returns if the in-memory driver has been enabled.
+func FakeDriverEnabled() bool {
+	return fakeDriverEnabled
+}
+
+func isFakeDataStore(ds kamajiv1alpha1.DataStore) bool {
+	return FakeDriverEnabled() && ds.GetAnnotations()[FakeDriverAnnotation] == "true"
+}
+
+// fakeStore holds the users, schemas, and privileges of a fake DataStore, shared by all its connections.
+type fakeStore struct {
+	sync.Mutex
+	users map[string]string
+	// schemas contains the keys, and values, stored in each schema.
+	schemas map[string]map[string]string
+	// grants contains the schemas each user has been granted.
+	grants map[string]map[string]struct{}
+}
+
+var fakeStores = struct {
+	sync.Mutex
+	stores map[string]*fakeStore
+}{stores: map[string]*fakeStore{}}
+
+// FakeConnection is the in-memory Connection, exercising the reconciliation of the Tenant Control Planes
+// with no data store to provision: it emulates the behaviour of the DataStore declared driver.
+type FakeConnection struct {
+	name   string
+	driver kamajiv1alpha1.Driver
+	store  *fakeStore
+}
+
+func NewFakeConnection(ds kamajiv1alpha1.DataStore) Connection {
+	fakeStores.Lock()
+	defer fakeStores.Unlock()
+
+	store, ok := fakeStores.stores[ds.GetName()]
+	if !ok {
+		store = &fakeStore{
+			users:   map[string]string{},
+			schemas: map[string]map[string]string{},
+			grants:  map[string]map[string]struct{}{},
+		}
+		fakeStores.stores[ds.GetName()] = store
+	}
+
+	return &FakeConnection{name: ds.GetName(), driver: ds.Spec.Driver, store: store}
+}
+
+func (f *FakeConnection) CreateUser(_ context.Context, user, password string) error {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	if _, ok := f.store.users[user]; ok {
+		return errors.NewCreateUserError(fmt.Errorf("user %s already exists", user))
+	}
+
+	f.store.users[user] = password
+
+	return nil
+}
+
+func (f *FakeConnection) CreateDB(_ context.Context, dbName string) error {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	if _, ok := f.store.schemas[dbName]; !ok {
+		f.store.schemas[dbName] = map[string]string{}
+	}
+
+	return nil
+}
+
+func (f *FakeConnection) GrantPrivileges(_ context.Context, user, dbName string) error {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	if _, ok := f.store.users[user]; !ok {
+		return errors.NewGrantPrivilegesError(fmt.Errorf("user %s does not exist", user))
+	}
+
+	if _, ok := f.store.grants[user]; !ok {
+		f.store.grants[user] = map[string]struct{}{}
+	}
+
+	f.store.grants[user][dbName] = struct{}{}
+
+	return nil
+}
+
+func (f *FakeConnection) UserExists(_ context.Context, user string) (bool, error) {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	_, ok := f.store.users[user]
+
+	return ok, nil
+}
+
+func (f *FakeConnection) DBExists(_ context.Context, dbName string) (bool, error) {
+	f.store.Lock()
+	defer f.store.Unlock()
+	// The etcd prefixes don't need to be created.
+	if f.driver == kamajiv1alpha1.EtcdDriver {
+		return true, nil
+	}
+
+	_, ok := f.store.schemas[dbName]
+
+	return ok, nil
+}
+
+func (f *FakeConnection) GrantPrivilegesExists(_ context.Context, user, dbName string) (bool, error) {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	_, ok := f.store.grants[user][dbName]
+
+	return ok, nil
+}
+
+func (f *FakeConnection) DeleteUser(_ context.Context, user string) error {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	delete(f.store.users, user)
+	delete(f.store.grants, user)
+
+	return nil
+}
+
+func (f *FakeConnection) DeleteDB(_ context.Context, dbName string) error {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	delete(f.store.schemas, dbName)
+
+	return nil
+}
+
+func (f *FakeConnection) RevokePrivileges(_ context.Context, user, dbName string) error {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	delete(f.store.grants[user], dbName)
+
+	return nil
+}
+
+func (f *FakeConnection) GetConnectionString() string {
+	return fmt.Sprintf("fake://%s", f.name)
+}
+
+func (f *FakeConnection) Close() error {
+	return nil
+}
+
+func (f *FakeConnection) Check(context.Context) error {
+	return nil
+}
+
+func (f *FakeConnection) Driver() string {
+	return string(f.driver)
+}
+
+func (f *FakeConnection) Migrate(_ context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, progress MigrationProgressFn, _ MigrationThrottleFn) error {
+	targetConnection, ok := target.(*FakeConnection)
+	if !ok {
+		return fmt.Errorf("the fake driver can migrate to fake DataStore objects only")
+	}
+
+	schema := tcp.Status.Storage.Setup.Schema
+
+	f.store.Lock()
+	data := make(map[string]string, len(f.store.schemas[schema]))
+	for key, value := range f.store.schemas[schema] {
+		data[key] = value
+	}
+	f.store.Unlock()
+
+	total := int64(len(data))
+
+	progress(0, total)
+
+	targetConnection.store.Lock()
+	targetConnection.store.schemas[schema] = data
+	targetConnection.store.Unlock()
+
+	progress(total, total)
+
+	return nil
+}
+
+func (f *FakeConnection) ListUsers(context.Context) ([]string, error) {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	users := make([]string, 0, len(f.store.users))
+	for user := range f.store.users {
+		users = append(users, user)
+	}
+
+	sort.Strings(users)
+
+	return users, nil
+}
+
+func (f *FakeConnection) ListSchemas(context.Context) ([]string, error) {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	schemas := make([]string, 0, len(f.store.schemas))
+	for schema := range f.store.schemas {
+		schemas = append(schemas, schema)
+	}
+
+	sort.Strings(schemas)
+
+	return schemas, nil
+}
+
+func (f *FakeConnection) Probe(_ context.Context, dbName string) (time.Duration, time.Duration, error) {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	if _, ok := f.store.schemas[dbName]; !ok {
+		f.store.schemas[dbName] = map[string]string{}
+	}
+
+	start := time.Now()
+	f.store.schemas[dbName][canaryKey] = strconv.FormatInt(start.UnixNano(), 10)
+
+	return time.Since(start), 0, nil
+}
+
+func (f *FakeConnection) Count(_ context.Context, dbName string) (int64, error) {
+	f.store.Lock()
+	defer f.store.Unlock()
+
+	return int64(len(f.store.schemas[dbName])), nil
+}
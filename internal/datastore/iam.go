@@ -0,0 +1,61 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
+	"github.com/pkg/errors"
+)
+
+// iamTokenRefreshInterval is the age of an IAM authentication token after which a new one is generated:
+// the tokens are valid for 15 minutes, the margin covers the connections established right before the expiration.
+const iamTokenRefreshInterval = 10 * time.Minute
+
+type iamToken struct {
+	value       string
+	generatedAt time.Time
+}
+
+// iamTokens caches the IAM authentication tokens per database user and endpoint,
+// since a new connection is established at every reconciliation.
+var iamTokens = struct {
+	sync.Mutex
+	tokens map[string]iamToken
+}{tokens: map[string]iamToken{}}
+
+// iamAuthenticationTokenFn returns the function generating the IAM authentication token of the given user,
+// used as password: the AWS credentials are resolved using the default chain, such as the environment variables,
+// or the web identity token of IAM Roles for Service Accounts.
+func iamAuthenticationTokenFn(region, user string) func(ctx context.Context, endpoint ConnectionEndpoint) (string, error) {
+	return func(ctx context.Context, endpoint ConnectionEndpoint) (string, error) {
+		key := fmt.Sprintf("%s/%s@%s", region, user, endpoint.String())
+
+		iamTokens.Lock()
+		defer iamTokens.Unlock()
+
+		if token, ok := iamTokens.tokens[key]; ok && time.Since(token.generatedAt) < iamTokenRefreshInterval {
+			return token.value, nil
+		}
+
+		cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+		if err != nil {
+			return "", errors.Wrap(err, "cannot load the AWS configuration")
+		}
+
+		value, err := auth.BuildAuthToken(ctx, endpoint.String(), region, user, cfg.Credentials)
+		if err != nil {
+			return "", errors.Wrap(err, "cannot generate the IAM authentication token")
+		}
+
+		iamTokens.tokens[key] = iamToken{value: value, generatedAt: time.Now()}
+
+		return value, nil
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// MigrationThrottleFn blocks until the given number of keys, or rows, and bytes can be copied to the target DataStore.
+type MigrationThrottleFn func(ctx context.Context, keys, size int) error
+
+// NoMigrationThrottle doesn't limit the migration rate.
+func NoMigrationThrottle(context.Context, int, int) error {
+	return nil
+}
+
+// NewMigrationThrottle limits the migration rate to the given keys, and bytes, per second: zero disables the limit.
+func NewMigrationThrottle(keysPerSecond, bytesPerSecond int64) MigrationThrottleFn {
+	var limiters []*migrationLimiter
+
+	keys := newMigrationLimiter(keysPerSecond, func(keys, _ int) int { return keys })
+	if keys != nil {
+		limiters = append(limiters, keys)
+	}
+
+	size := newMigrationLimiter(bytesPerSecond, func(_, size int) int { return size })
+	if size != nil {
+		limiters = append(limiters, size)
+	}
+
+	if len(limiters) == 0 {
+		return NoMigrationThrottle
+	}
+
+	return func(ctx context.Context, keys, size int) error {
+		for _, limiter := range limiters {
+			if err := limiter.wait(ctx, limiter.tokens(keys, size)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+type migrationLimiter struct {
+	*rate.Limiter
+	tokens func(keys, size int) int
+}
+
+func newMigrationLimiter(perSecond int64, tokens func(keys, size int) int) *migrationLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	// The burst allows a second worth of tokens, the larger requests are split.
+	burst := int(perSecond)
+
+	return &migrationLimiter{Limiter: rate.NewLimiter(rate.Limit(perSecond), burst), tokens: tokens}
+}
+
+func (l *migrationLimiter) wait(ctx context.Context, n int) error {
+	for n > 0 {
+		chunk := n
+		if burst := l.Burst(); chunk > burst {
+			chunk = burst
+		}
+
+		if err := l.WaitN(ctx, chunk); err != nil {
+			return err
+		}
+
+		n -= chunk
+	}
+
+	return nil
+}
+
+// migrationReader throttles the rows streamed by a COPY statement, one per line, notifying the progress.
+type migrationReader struct {
+	ctx      context.Context //nolint:containedctx
+	reader   io.Reader
+	throttle MigrationThrottleFn
+	progress MigrationProgressFn
+	copied   int64
+	total    int64
+}
+
+func (r *migrationReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		rows := bytes.Count(p[:n], []byte("\n"))
+
+		if throttleErr := r.throttle(r.ctx, rows, n); throttleErr != nil {
+			return 0, throttleErr
+		}
+
+		if rows > 0 {
+			r.copied += int64(rows)
+			r.progress(r.copied, r.total)
+		}
+	}
+
+	return n, err
+}
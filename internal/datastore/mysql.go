@@ -33,6 +33,17 @@ const (
 	mysqlDropDBStatement           = "DROP DATABASE IF EXISTS `%s`"
 	mysqlDropUserStatement         = "DROP USER IF EXISTS `%s`"
 	mysqlRevokePrivilegesStatement = "REVOKE ALL PRIVILEGES ON `%s`.* FROM `%s`"
+	mysqlFetchPrivilegesStatement  = "SELECT Create_user_priv, Create_priv, Grant_priv FROM mysql.user WHERE CONCAT(User, '@', Host) = CURRENT_USER() LIMIT 1"
+	mysqlListUsersStatement        = "SELECT DISTINCT User FROM mysql.user"
+	mysqlListDBsStatement          = "SELECT SCHEMA_NAME FROM INFORMATION_SCHEMA.SCHEMATA"
+	mysqlFetchReadOnlyStatement    = "SELECT @@global.read_only"
+	mysqlCreateCanaryStatement     = "CREATE TABLE IF NOT EXISTS `%s`.`kamaji_canary` (id TINYINT PRIMARY KEY, updated BIGINT)"
+	mysqlWriteCanaryStatement      = "REPLACE INTO `%s`.`kamaji_canary` (id, updated) VALUES (1, ?)"
+	mysqlReadCanaryStatement       = "SELECT updated FROM `%s`.`kamaji_canary` WHERE id = 1"
+	mysqlCountStatement            = "SELECT COUNT(*) FROM `%s`.`kine`"
+	mysqlAnalyzeTableStatement     = "ANALYZE TABLE `%s`.`kine`"
+	mysqlOptimizeTableStatement    = "OPTIMIZE TABLE `%s`.`kine`"
+	mysqlTableSizeStatement        = "SELECT COALESCE(DATA_LENGTH + INDEX_LENGTH, 0) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = 'kine'"
 )
 
 type MySQLConnection struct {
@@ -40,7 +51,8 @@ type MySQLConnection struct {
 	connector ConnectionEndpoint
 }
 
-func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
+// Migrate imports the dump of the origin database at once, thus the copy cannot be throttled.
+func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, progress MigrationProgressFn, _ MigrationThrottleFn) error {
 	// Ensuring the connection is working as expected
 	if err := target.Check(ctx); err != nil {
 		return err
@@ -58,10 +70,19 @@ func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.Tenant
 	}
 	defer os.RemoveAll(dir)
 
-	if _, err = c.db.ExecContext(ctx, fmt.Sprintf("USE %s_%s", tcp.GetNamespace(), tcp.GetName())); err != nil {
+	if _, err = c.db.ExecContext(ctx, fmt.Sprintf("USE `%s`", tcp.Status.Storage.Setup.Schema)); err != nil {
 		return fmt.Errorf("unable to switch DB for MySQL migration: %w", err)
 	}
 
+	// Counting the rows to copy, the dump is imported at once
+	var total int64
+
+	if err = c.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM kine").Scan(&total); err != nil {
+		return fmt.Errorf("unable to count the rows of the origin datastore: %w", err)
+	}
+
+	progress(0, total)
+
 	dumper, err := mysqldump.Register(c.db, dir, fmt.Sprintf("%d", time.Now().Unix()))
 	if err != nil {
 		return fmt.Errorf("unable to create MySQL dumper: %w", err)
@@ -80,7 +101,7 @@ func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.Tenant
 	// Executing the import to the target datastore
 	targetClient := target.(*MySQLConnection) //nolint:forcetypeassert
 
-	if _, err = targetClient.db.ExecContext(ctx, fmt.Sprintf("USE %s_%s", tcp.GetNamespace(), tcp.GetName())); err != nil {
+	if _, err = targetClient.db.ExecContext(ctx, fmt.Sprintf("USE `%s`", tcp.Status.Storage.Setup.Schema)); err != nil {
 		return fmt.Errorf("unable to switch DB for MySQL migration: %w", err)
 	}
 
@@ -88,6 +109,8 @@ func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.Tenant
 		return fmt.Errorf("cannot execute dump statements for MySQL: %w", err)
 	}
 
+	progress(total, total)
+
 	return nil
 }
 
@@ -247,6 +270,35 @@ func (c *MySQLConnection) DeleteUser(ctx context.Context, user string) error {
 	return nil
 }
 
+func (c *MySQLConnection) ListUsers(ctx context.Context) ([]string, error) {
+	return c.list(ctx, mysqlListUsersStatement)
+}
+
+func (c *MySQLConnection) ListSchemas(ctx context.Context) ([]string, error) {
+	return c.list(ctx, mysqlListDBsStatement)
+}
+
+func (c *MySQLConnection) list(ctx context.Context, statement string) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, statement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
 func (c *MySQLConnection) DeleteDB(ctx context.Context, dbName string) error {
 	if err := c.mutate(ctx, mysqlDropDBStatement, dbName); err != nil {
 		return errors.NewCannotDeleteDatabaseError(err)
@@ -263,6 +315,20 @@ func (c *MySQLConnection) RevokePrivileges(ctx context.Context, user, dbName str
 	return nil
 }
 
+func (c *MySQLConnection) CheckPrivileges(ctx context.Context) error {
+	var createUser, createDB, grant string
+
+	if err := c.db.QueryRowContext(ctx, mysqlFetchPrivilegesStatement).Scan(&createUser, &createDB, &grant); err != nil {
+		return errors.NewInsufficientPrivilegesError(err)
+	}
+
+	if createUser != "Y" || createDB != "Y" || grant != "Y" {
+		return errors.NewInsufficientPrivilegesError(fmt.Errorf("the user must be able to create users and databases, and to grant privileges"))
+	}
+
+	return nil
+}
+
 func (c *MySQLConnection) check(ctx context.Context, nonFilledStatement string, checker func(*sql.Row) (bool, error), args ...any) (bool, error) {
 	statement, err := c.db.Prepare(nonFilledStatement)
 	if err != nil {
@@ -287,3 +353,66 @@ func (c *MySQLConnection) mutate(ctx context.Context, nonFilledStatement string,
 func (c *MySQLConnection) checkEmptyQueryResult(err error) bool {
 	return err.Error() == sqlErrorNoRows
 }
+
+func (c *MySQLConnection) Probe(ctx context.Context, dbName string) (time.Duration, time.Duration, error) {
+	if err := c.mutate(ctx, mysqlCreateCanaryStatement, dbName); err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+	if _, err := c.db.ExecContext(ctx, fmt.Sprintf(mysqlWriteCanaryStatement, dbName), start.UnixNano()); err != nil {
+		return 0, 0, err
+	}
+
+	write := time.Since(start)
+
+	var updated int64
+
+	start = time.Now()
+	if err := c.db.QueryRowContext(ctx, fmt.Sprintf(mysqlReadCanaryStatement, dbName)).Scan(&updated); err != nil {
+		return write, 0, err
+	}
+
+	return write, time.Since(start), nil
+}
+
+func (c *MySQLConnection) Count(ctx context.Context, dbName string) (int64, error) {
+	var count int64
+
+	if err := c.db.QueryRowContext(ctx, fmt.Sprintf(mysqlCountStatement, dbName)).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// TableSize returns the size of the kine table according to the table statistics, refreshed beforehand
+// since they're cached by the INFORMATION_SCHEMA tables.
+func (c *MySQLConnection) TableSize(ctx context.Context, dbName string) (int64, error) {
+	if err := c.mutate(ctx, mysqlAnalyzeTableStatement, dbName); err != nil {
+		return 0, err
+	}
+
+	var size int64
+
+	if err := c.db.QueryRowContext(ctx, mysqlTableSizeStatement, dbName).Scan(&size); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// CleanUpTable rebuilds the kine table: InnoDB performs it online, allowing the concurrent writes of kine.
+func (c *MySQLConnection) CleanUpTable(ctx context.Context, dbName string) error {
+	return c.mutate(ctx, mysqlOptimizeTableStatement, dbName)
+}
+
+func (c *MySQLConnection) isWritable(ctx context.Context) (bool, error) {
+	var readOnly int
+
+	if err := c.db.QueryRowContext(ctx, mysqlFetchReadOnlyStatement).Scan(&readOnly); err != nil {
+		return false, err
+	}
+
+	return readOnly == 0, nil
+}
@@ -7,7 +7,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-pg/pg/v10"
 
@@ -29,15 +32,28 @@ const (
 	postgresqlRevokePrivilegesStatement   = "REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s"
 	postgresqlDropRoleStatement           = "DROP ROLE %s"
 	postgresqlDropDBStatement             = "DROP DATABASE %s WITH (FORCE)"
+	postgresqlFetchPrivilegesStatement    = "SELECT rolsuper OR (rolcreaterole AND rolcreatedb) FROM pg_roles WHERE rolname = current_user"
+	postgresqlListUsersStatement          = "SELECT rolname FROM pg_roles WHERE rolcanlogin"
+	postgresqlListDBsStatement            = "SELECT datname FROM pg_database WHERE NOT datistemplate"
+	postgresqlFetchWritableStatement      = "SELECT NOT pg_is_in_recovery()"
+	postgresqlCreateCanaryStatement       = "CREATE TABLE IF NOT EXISTS kamaji_canary (id INTEGER PRIMARY KEY, updated BIGINT)"
+	postgresqlWriteCanaryStatement        = "INSERT INTO kamaji_canary (id, updated) VALUES (1, ?) ON CONFLICT (id) DO UPDATE SET updated = EXCLUDED.updated"
+	postgresqlReadCanaryStatement         = "SELECT updated FROM kamaji_canary WHERE id = 1"
+	postgresqlTableSizeStatement          = "SELECT pg_total_relation_size('kine')"
+	postgresqlVacuumTableStatement        = "VACUUM (ANALYZE) kine"
 )
 
 type PostgreSQLConnection struct {
 	db               *pg.DB
 	connection       ConnectionEndpoint
+	endpoints        []ConnectionEndpoint
 	switchDatabaseFn func(dbName string) *pg.DB
+	switchSchemaFn   func(schema string) *pg.DB
+	// sharedDatabase holds the schemas of the tenants, when isolated by schema rather than by database.
+	sharedDatabase string
 }
 
-func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
+func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, progress MigrationProgressFn, throttle MigrationThrottleFn) error {
 	// Ensuring the connection is working as expected
 	if err := target.Check(ctx); err != nil {
 		return fmt.Errorf("unable to check target datastore: %w", err)
@@ -49,7 +65,7 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 		}
 	}
 
-	targetConn := target.(*PostgreSQLConnection).switchDatabaseFn(tcp.Status.Storage.Setup.Schema) //nolint:forcetypeassert
+	targetConn := target.(*PostgreSQLConnection).tenantDatabase(tcp.Status.Storage.Setup.Schema) //nolint:forcetypeassert
 
 	err := targetConn.RunInTransaction(ctx, func(tx *pg.Tx) error {
 		for _, stm := range []string{
@@ -75,17 +91,30 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 				return fmt.Errorf("unable to perform schema creation: %w", err)
 			}
 		}
+		// Counting the rows to copy, the COPY statement doesn't report any progress: the streamed rows are counted instead.
+		var total int64
+
+		if _, err := r.tenantDatabase(tcp.Status.Storage.Setup.Schema).QueryOneContext(ctx, pg.Scan(&total), "SELECT COUNT(*) FROM kine"); err != nil { //nolint:contextcheck
+			return fmt.Errorf("unable to count the rows of the origin datastore: %w", err)
+		}
+
+		progress(0, total)
 		// Dumping the old datastore in a local buffer
 		var buf bytes.Buffer
 
-		if _, err := r.switchDatabaseFn(tcp.Status.Storage.Setup.Schema).WithContext(ctx).CopyTo(&buf, "COPY kine TO STDOUT"); err != nil { //nolint:contextcheck
+		if _, err := r.tenantDatabase(tcp.Status.Storage.Setup.Schema).WithContext(ctx).CopyTo(&buf, "COPY kine TO STDOUT"); err != nil { //nolint:contextcheck
 			return fmt.Errorf("unable to copy from the origin datastore: %w", err)
 		}
 
-		if _, err := tx.CopyFrom(&buf, "COPY kine FROM STDIN"); err != nil {
+		reader := &migrationReader{ctx: ctx, reader: &buf, throttle: throttle, progress: progress, total: total}
+
+		result, err := tx.CopyFrom(reader, "COPY kine FROM STDIN")
+		if err != nil {
 			return fmt.Errorf("unable to copy to the target datastore: %w", err)
 		}
 
+		progress(int64(result.RowsAffected()), total)
+
 		return nil
 	})
 	if err != nil {
@@ -103,18 +132,48 @@ func NewPostgreSQLConnection(config ConnectionConfig) (Connection, error) {
 		Password:  config.Password,
 		TLSConfig: config.TLSConfig,
 	}
+	// Honouring the DSN parameters supported by the driver, the remaining ones are used by kine only.
+	params := url.Values(config.Parameters)
+
+	if params.Get("sslmode") == "disable" {
+		opt.TLSConfig = nil
+	}
+
+	if timeout, err := strconv.Atoi(params.Get("connect_timeout")); err == nil && timeout > 0 {
+		opt.DialTimeout = time.Duration(timeout) * time.Second
+	}
+
+	if name := params.Get("application_name"); len(name) > 0 {
+		opt.ApplicationName = name
+	}
 
 	fn := func(dbName string) *pg.DB {
-		o := opt
+		// Copying the options, otherwise the database of the main connection would be switched too.
+		o := *opt
 		o.Database = dbName
 
-		return pg.Connect(o)
+		return pg.Connect(&o)
+	}
+
+	schemaFn := func(schema string) *pg.DB {
+		o := *opt
+		o.Database = config.SharedDatabase
+		o.OnConnect = func(ctx context.Context, cn *pg.Conn) error {
+			_, err := cn.ExecContext(ctx, fmt.Sprintf(postgresqlSetSearchPathStatement, schema))
+
+			return err
+		}
+
+		return pg.Connect(&o)
 	}
 
 	return &PostgreSQLConnection{
 		db:               pg.Connect(opt),
 		switchDatabaseFn: fn,
+		switchSchemaFn:   schemaFn,
+		sharedDatabase:   config.SharedDatabase,
 		connection:       config.Endpoints[0],
+		endpoints:        config.Endpoints,
 	}, nil
 }
 
@@ -141,6 +200,10 @@ func (r *PostgreSQLConnection) CreateUser(ctx context.Context, user, password st
 }
 
 func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (bool, error) {
+	if len(r.sharedDatabase) > 0 {
+		return r.schemaExists(ctx, dbName)
+	}
+
 	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, dbName)
 	if err != nil {
 		return false, errors.NewCheckDatabaseExistError(err)
@@ -150,6 +213,10 @@ func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (boo
 }
 
 func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		return r.createSchema(ctx, dbName)
+	}
+
 	_, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateDBStatement, dbName))
 	if err != nil {
 		return errors.NewCreateDBError(err)
@@ -159,6 +226,10 @@ func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) erro
 }
 
 func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error) {
+	if len(r.sharedDatabase) > 0 {
+		return r.schemaPrivilegesExists(ctx, user, dbName)
+	}
+
 	var hasDatabasePrivilege string
 
 	_, err := r.db.QueryContext(ctx, pg.Scan(&hasDatabasePrivilege), postgresqlShowGrantsStatement, dbName, user)
@@ -181,7 +252,7 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 	dbConn := r.switchDatabaseFn(dbName)
 	defer dbConn.Close()
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, "public")
 	if err != nil {
 		return false, errors.NewGrantPrivilegesError(err)
 	}
@@ -198,6 +269,10 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 }
 
 func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		return r.grantSchemaPrivileges(ctx, user, dbName)
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlGrantPrivilegesStatement, dbName, user)); err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
@@ -209,7 +284,7 @@ func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName
 		return errors.NewGrantPrivilegesError(err)
 	}
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, "public")
 	if err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
@@ -231,7 +306,35 @@ func (r *PostgreSQLConnection) DeleteUser(ctx context.Context, user string) erro
 	return nil
 }
 
+func (r *PostgreSQLConnection) ListUsers(ctx context.Context) ([]string, error) {
+	var users []string
+
+	if _, err := r.db.QueryContext(ctx, &users, postgresqlListUsersStatement); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *PostgreSQLConnection) ListSchemas(ctx context.Context) ([]string, error) {
+	if len(r.sharedDatabase) > 0 {
+		return r.listSchemas(ctx)
+	}
+
+	var dbs []string
+
+	if _, err := r.db.QueryContext(ctx, &dbs, postgresqlListDBsStatement); err != nil {
+		return nil, err
+	}
+
+	return dbs, nil
+}
+
 func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		return r.deleteSchema(ctx, dbName)
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDropDBStatement, dbName)); err != nil {
 		return errors.NewCannotDeleteDatabaseError(err)
 	}
@@ -240,6 +343,10 @@ func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) erro
 }
 
 func (r *PostgreSQLConnection) RevokePrivileges(ctx context.Context, user, dbName string) error {
+	if len(r.sharedDatabase) > 0 {
+		return r.revokeSchemaPrivileges(ctx, user, dbName)
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlRevokePrivilegesStatement, dbName, user)); err != nil {
 		return errors.NewRevokePrivilegesError(err)
 	}
@@ -247,8 +354,16 @@ func (r *PostgreSQLConnection) RevokePrivileges(ctx context.Context, user, dbNam
 	return nil
 }
 
+// GetConnectionString returns all the endpoints, starting from the writable one: the driver used by kine
+// is connecting to the first one accepting writes, according to the target_session_attrs parameter.
 func (r *PostgreSQLConnection) GetConnectionString() string {
-	return r.connection.String()
+	hosts := make([]string, 0, len(r.endpoints))
+
+	for _, ep := range r.endpoints {
+		hosts = append(hosts, ep.String())
+	}
+
+	return strings.Join(hosts, ",")
 }
 
 func (r *PostgreSQLConnection) Close() error {
@@ -267,12 +382,108 @@ func (r *PostgreSQLConnection) Check(ctx context.Context) error {
 	return nil
 }
 
-func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB) (bool, error) {
+func (r *PostgreSQLConnection) CheckPrivileges(ctx context.Context) error {
+	var privileged bool
+
+	if _, err := r.db.QueryOneContext(ctx, pg.Scan(&privileged), postgresqlFetchPrivilegesStatement); err != nil {
+		return errors.NewInsufficientPrivilegesError(err)
+	}
+
+	if !privileged {
+		return errors.NewInsufficientPrivilegesError(fmt.Errorf("the role must be able to create roles and databases"))
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB, schema string) (bool, error) {
 	var tableExists string
 
-	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, "public", "kine"); err != nil {
+	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, schema, "kine"); err != nil {
 		return false, err
 	}
 
 	return tableExists == "t", nil
 }
+
+func (r *PostgreSQLConnection) Probe(ctx context.Context, dbName string) (time.Duration, time.Duration, error) {
+	db := r.tenantDatabase(dbName)
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, postgresqlCreateCanaryStatement); err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+	if _, err := db.ExecContext(ctx, postgresqlWriteCanaryStatement, start.UnixNano()); err != nil {
+		return 0, 0, err
+	}
+
+	write := time.Since(start)
+
+	var updated int64
+
+	start = time.Now()
+	if _, err := db.QueryOneContext(ctx, pg.Scan(&updated), postgresqlReadCanaryStatement); err != nil {
+		return write, 0, err
+	}
+
+	return write, time.Since(start), nil
+}
+
+func (r *PostgreSQLConnection) Count(ctx context.Context, dbName string) (int64, error) {
+	db := r.tenantDatabase(dbName)
+	defer db.Close()
+
+	var count int64
+
+	if _, err := db.QueryOneContext(ctx, pg.Scan(&count), "SELECT COUNT(*) FROM kine"); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *PostgreSQLConnection) TableSize(ctx context.Context, dbName string) (int64, error) {
+	db := r.tenantDatabase(dbName)
+	defer db.Close()
+
+	var size int64
+
+	if _, err := db.QueryOneContext(ctx, pg.Scan(&size), postgresqlTableSizeStatement); err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// CleanUpTable vacuums the kine table, making the space of the compacted rows reusable without locking the table:
+// the space is returned to the operating system only when the trailing pages are empty.
+func (r *PostgreSQLConnection) CleanUpTable(ctx context.Context, dbName string) error {
+	db := r.tenantDatabase(dbName)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, postgresqlVacuumTableStatement)
+
+	return err
+}
+
+// tenantDatabase returns the connection to the data of the given tenant: either its own database,
+// or the shared one, resolving the unqualified names in the tenant schema.
+func (r *PostgreSQLConnection) tenantDatabase(dbName string) *pg.DB {
+	if len(r.sharedDatabase) > 0 {
+		return r.switchSchemaFn(dbName)
+	}
+
+	return r.switchDatabaseFn(dbName)
+}
+
+func (r *PostgreSQLConnection) isWritable(ctx context.Context) (bool, error) {
+	var writable bool
+
+	if _, err := r.db.QueryOneContext(ctx, pg.Scan(&writable), postgresqlFetchWritableStatement); err != nil {
+		return false, err
+	}
+
+	return writable, nil
+}
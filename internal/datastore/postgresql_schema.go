@@ -0,0 +1,176 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/clastix/kamaji/internal/datastore/errors"
+)
+
+const (
+	postgresqlSetSearchPathStatement            = "SET search_path TO %s"
+	postgresqlFetchSchemaStatement              = "SELECT FROM pg_namespace WHERE nspname = ?"
+	postgresqlCreateSchemaStatement             = "CREATE SCHEMA %s"
+	postgresqlDropSchemaStatement               = "DROP SCHEMA %s CASCADE"
+	postgresqlListSchemasStatement              = "SELECT nspname FROM pg_namespace"
+	postgresqlShowSchemaOwnershipStatement      = "SELECT 't' FROM pg_namespace WHERE nspname = ? AND pg_catalog.pg_get_userbyid(nspowner) = ?"
+	postgresqlShowConnectGrantsStatement        = "SELECT has_database_privilege(rolname, ?, 'connect') from pg_roles where rolcanlogin and rolname = ?"
+	postgresqlChangeSchemaOwnerStatement        = "ALTER SCHEMA %s OWNER TO %s"
+	postgresqlGrantConnectStatement             = "GRANT CONNECT ON DATABASE %s TO %s"
+	postgresqlRevokeConnectStatement            = "REVOKE CONNECT ON DATABASE %s FROM %s"
+	postgresqlRevokeSchemaPrivilegesStatement   = "REVOKE ALL PRIVILEGES ON SCHEMA %s FROM %s"
+	postgresqlResetSchemaOwnerStatement         = "ALTER SCHEMA %s OWNER TO CURRENT_USER"
+	postgresqlShowSchemaTableOwnershipStatement = "SELECT 't' from pg_tables where tableowner = ? AND schemaname = ? AND tablename = ?"
+)
+
+// The following functions are used when the tenants are isolated by schema in the shared database,
+// rather than by a dedicated database: the schema is owned by the tenant user, as the database would be.
+
+func (r *PostgreSQLConnection) sharedDatabaseConnection() *pg.DB {
+	return r.switchDatabaseFn(r.sharedDatabase)
+}
+
+func (r *PostgreSQLConnection) schemaExists(ctx context.Context, schema string) (bool, error) {
+	db := r.sharedDatabaseConnection()
+	defer db.Close()
+
+	rows, err := db.ExecContext(ctx, postgresqlFetchSchemaStatement, schema)
+	if err != nil {
+		return false, errors.NewCheckDatabaseExistError(err)
+	}
+
+	return rows.RowsReturned() > 0, nil
+}
+
+func (r *PostgreSQLConnection) createSchema(ctx context.Context, schema string) error {
+	db := r.sharedDatabaseConnection()
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateSchemaStatement, schema)); err != nil {
+		return errors.NewCreateDBError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) schemaPrivilegesExists(ctx context.Context, user, schema string) (bool, error) {
+	var canConnect string
+
+	if _, err := r.db.QueryContext(ctx, pg.Scan(&canConnect), postgresqlShowConnectGrantsStatement, r.sharedDatabase, user); err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	db := r.sharedDatabaseConnection()
+	defer db.Close()
+
+	var isOwner string
+
+	if _, err := db.QueryContext(ctx, pg.Scan(&isOwner), postgresqlShowSchemaOwnershipStatement, schema, user); err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, db, schema)
+	if err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	if tableExists {
+		var isTableOwner string
+
+		if _, err = db.QueryContext(ctx, pg.Scan(&isTableOwner), postgresqlShowSchemaTableOwnershipStatement, user, schema, "kine"); err != nil {
+			return false, errors.NewCheckGrantExistsError(err)
+		}
+
+		return canConnect == "t" && isOwner == "t" && isTableOwner == "t", nil
+	}
+
+	return canConnect == "t" && isOwner == "t", nil
+}
+
+func (r *PostgreSQLConnection) grantSchemaPrivileges(ctx context.Context, user, schema string) error {
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlGrantConnectStatement, r.sharedDatabase, user)); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	db := r.sharedDatabaseConnection()
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(postgresqlChangeSchemaOwnerStatement, schema, user)); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, db, schema)
+	if err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	if tableExists {
+		if _, err = db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s.kine OWNER TO %s", schema, user)); err != nil {
+			return errors.NewGrantPrivilegesError(err)
+		}
+	}
+
+	return nil
+}
+
+// revokeSchemaPrivileges takes back the ownership of the schema, and of its table:
+// the tenant user could not be deleted otherwise, when the schema is retained.
+func (r *PostgreSQLConnection) revokeSchemaPrivileges(ctx context.Context, user, schema string) error {
+	db := r.sharedDatabaseConnection()
+	defer db.Close()
+
+	statements := []string{
+		fmt.Sprintf(postgresqlRevokeSchemaPrivilegesStatement, schema, user),
+		fmt.Sprintf(postgresqlResetSchemaOwnerStatement, schema),
+	}
+
+	tableExists, err := r.kineTableExists(ctx, db, schema)
+	if err != nil {
+		return errors.NewRevokePrivilegesError(err)
+	}
+
+	if tableExists {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s.kine OWNER TO CURRENT_USER", schema))
+	}
+
+	for _, stm := range statements {
+		if _, err = db.ExecContext(ctx, stm); err != nil {
+			return errors.NewRevokePrivilegesError(err)
+		}
+	}
+
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlRevokeConnectStatement, r.sharedDatabase, user)); err != nil {
+		return errors.NewRevokePrivilegesError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) deleteSchema(ctx context.Context, schema string) error {
+	db := r.sharedDatabaseConnection()
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(postgresqlDropSchemaStatement, schema)); err != nil {
+		return errors.NewCannotDeleteDatabaseError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) listSchemas(ctx context.Context) ([]string, error) {
+	db := r.sharedDatabaseConnection()
+	defer db.Close()
+
+	var schemas []string
+
+	if _, err := db.QueryContext(ctx, &schemas, postgresqlListSchemasStatement); err != nil {
+		return nil, err
+	}
+
+	return schemas, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// NewPrivilegedStorageConnection connects to the DataStore with the privileged credentials, if any,
+// required to create, and delete, the per-tenant users, databases, and schemas.
+func NewPrivilegedStorageConnection(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) (Connection, error) {
+	if ds.Spec.PrivilegedAuth != nil {
+		ds.Spec.BasicAuth = ds.Spec.PrivilegedAuth
+	}
+
+	return NewStorageConnection(ctx, client, ds)
+}
+
+// PrivilegedConnection dials the DataStore with the privileged credentials upon the first privileged operation only,
+// keeping them out of memory for the reconciliations not requiring any: when no privileged credentials are declared,
+// the routine connection is used.
+type PrivilegedConnection struct {
+	client  client.Client
+	ds      kamajiv1alpha1.DataStore
+	routine Connection
+
+	lock       sync.Mutex
+	connection Connection
+}
+
+func NewPrivilegedConnection(client client.Client, ds kamajiv1alpha1.DataStore, routine Connection) *PrivilegedConnection {
+	return &PrivilegedConnection{client: client, ds: ds, routine: routine}
+}
+
+// Get returns the privileged connection, establishing it if required.
+func (p *PrivilegedConnection) Get(ctx context.Context) (Connection, error) {
+	if p.ds.Spec.PrivilegedAuth == nil {
+		return p.routine, nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.connection == nil {
+		connection, err := NewPrivilegedStorageConnection(ctx, p.client, p.ds)
+		if err != nil {
+			return nil, err
+		}
+
+		p.connection = connection
+	}
+
+	return p.connection, nil
+}
+
+// Close closes the privileged connection, if established: the routine one is owned by the caller.
+func (p *PrivilegedConnection) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.connection == nil {
+		return nil
+	}
+
+	err := p.connection.Close()
+	p.connection = nil
+
+	return err
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// RecordProvisioned records in the DataStore status the user, or the schema, about to be created for a Tenant Control
+// Plane: only the recorded ones are considered by the garbage collection.
+func RecordProvisioned(ctx context.Context, c client.Client, dataStore string, kind kamajiv1alpha1.DataStoreResourceKind, name string) error {
+	return updateProvisioned(ctx, c, dataStore, func(status *kamajiv1alpha1.DataStoreStatus) bool {
+		return status.AddProvisioned(kind, name, metav1.Now())
+	})
+}
+
+// ForgetProvisioned removes from the DataStore status the given users, or schemas, once deleted, or handed over
+// to another management cluster.
+func ForgetProvisioned(ctx context.Context, c client.Client, dataStore string, kind kamajiv1alpha1.DataStoreResourceKind, names ...string) error {
+	return updateProvisioned(ctx, c, dataStore, func(status *kamajiv1alpha1.DataStoreStatus) bool {
+		var changed bool
+
+		for _, name := range names {
+			changed = status.RemoveProvisioned(kind, name) || changed
+		}
+
+		return changed
+	})
+}
+
+func updateProvisioned(ctx context.Context, c client.Client, dataStore string, mutateFn func(status *kamajiv1alpha1.DataStoreStatus) bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ds := &kamajiv1alpha1.DataStore{}
+		if err := c.Get(ctx, types.NamespacedName{Name: dataStore}, ds); err != nil {
+			return err
+		}
+
+		if !mutateFn(&ds.Status) {
+			return nil
+		}
+
+		return c.Status().Update(ctx, ds)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// Discrepancy is a setting of a live component differing from the declared one.
+type Discrepancy struct {
+	Component string
+	Setting   string
+	Declared  string
+	Live      string
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("%s %s: declared %q, live %q", d.Component, d.Setting, d.Declared, d.Live)
+}
+
+// CompareArgs returns the declared flags missing from the live ones, or set to a different value:
+// the live flags not declared are ignored, since they're managed by Kamaji.
+func CompareArgs(component string, declared, live []string) []Discrepancy {
+	liveArgs := utilities.ArgsFromSliceToMap(live)
+
+	var discrepancies []Discrepancy
+
+	for flag, value := range utilities.ArgsFromSliceToMap(declared) {
+		liveValue, ok := liveArgs[flag]
+		if !ok {
+			liveValue = "<unset>"
+		}
+
+		if !ok || liveValue != value {
+			discrepancies = append(discrepancies, Discrepancy{Component: component, Setting: flag, Declared: value, Live: liveValue})
+		}
+	}
+
+	return discrepancies
+}
+
+// CompareImageTag returns a discrepancy when the tag of the live container image differs from the declared one.
+func CompareImageTag(component, declared, image string) []Discrepancy {
+	if tag := ImageTag(image); tag != declared {
+		return []Discrepancy{{Component: component, Setting: "image tag", Declared: declared, Live: tag}}
+	}
+
+	return nil
+}
+
+// ImageTag returns the tag of the given container image, ignoring the digest.
+func ImageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+
+	if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+		return image[index+1:]
+	}
+
+	return "latest"
+}
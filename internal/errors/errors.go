@@ -3,12 +3,23 @@
 
 package errors
 
+import "time"
+
 type MigrationInProcessError struct{}
 
 func (n MigrationInProcessError) Error() string {
 	return "cannot continue reconciliation, the current TenantControlPlane is still in migration status"
 }
 
+// MigrationWindowPendingError is returned when the migration cannot start until its window opens.
+type MigrationWindowPendingError struct {
+	RetryAfter time.Duration
+}
+
+func (m MigrationWindowPendingError) Error() string {
+	return "cannot start the migration, the migration window is not open yet"
+}
+
 type NonExposedLoadBalancerError struct{}
 
 func (n NonExposedLoadBalancerError) Error() string {
@@ -20,3 +31,11 @@ type MissingValidIPError struct{}
 func (m MissingValidIPError) Error() string {
 	return "the actual resource doesn't have yet a valid IP address"
 }
+
+type CleanupHookPendingError struct {
+	Hook string
+}
+
+func (c CleanupHookPendingError) Error() string {
+	return "cannot continue the deletion, the clean-up hook " + c.Hook + " is still in progress"
+}
@@ -4,6 +4,8 @@
 package kubeadm
 
 import (
+	"time"
+
 	json "github.com/json-iterator/go"
 	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
@@ -43,6 +45,9 @@ type Parameters struct {
 	TenantDNSServiceIPs            []string
 	TenantControlPlaneVersion      string
 	TenantControlPlaneCGroupDriver string
+	TenantStreamingIdleTimeout     time.Duration
+	TenantServerTLSBootstrap       bool
+	TenantNodePoolCGroupDrivers    map[string]string
 	ETCDs                          []string
 	CertificatesDir                string
 	KubeconfigDir                  string
@@ -59,6 +64,8 @@ type KubeletConfiguration struct {
 	TenantControlPlaneDomain        string
 	TenantControlPlaneDNSServiceIPs []string
 	TenantControlPlaneCgroupDriver  string
+	TenantStreamingIdleTimeout      time.Duration
+	TenantServerTLSBootstrap        bool
 }
 
 type CertificatePrivateKeyPair struct {
@@ -4,9 +4,14 @@
 package kubeadm
 
 import (
+	"context"
+	"fmt"
+	"sort"
+
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	kubelettypes "k8s.io/kubelet/config/v1beta1"
@@ -19,6 +24,9 @@ import (
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
+// nodePoolLabel is the label of the ConfigMaps storing the kubelet configuration of the tenant node pools.
+const nodePoolLabel = "kamaji.clastix.io/kubelet-node-pool"
+
 func UploadKubeadmConfig(client kubernetes.Interface, config *Configuration) ([]byte, error) {
 	return nil, uploadconfig.UploadConfiguration(&config.InitConfiguration, client)
 }
@@ -28,6 +36,8 @@ func UploadKubeletConfig(client kubernetes.Interface, config *Configuration) ([]
 		TenantControlPlaneDomain:        config.InitConfiguration.Networking.DNSDomain,
 		TenantControlPlaneDNSServiceIPs: config.Parameters.TenantDNSServiceIPs,
 		TenantControlPlaneCgroupDriver:  config.Parameters.TenantControlPlaneCGroupDriver,
+		TenantStreamingIdleTimeout:      config.Parameters.TenantStreamingIdleTimeout,
+		TenantServerTLSBootstrap:        config.Parameters.TenantServerTLSBootstrap,
 	}
 	content, err := getKubeletConfigmapContent(kubeletConfiguration)
 	if err != nil {
@@ -50,13 +60,77 @@ func UploadKubeletConfig(client kubernetes.Interface, config *Configuration) ([]
 		return nil, err
 	}
 
-	if err = createConfigMapRBACRules(client); err != nil {
+	configMapNames, err := uploadNodePoolKubeletConfigs(client, kubeletConfiguration, config.Parameters.TenantNodePoolCGroupDrivers)
+	if err != nil {
+		return nil, errors.Wrap(err, "error uploading the node pools kubelet configuration")
+	}
+
+	if err = createConfigMapRBACRules(client, append([]string{configMapName}, configMapNames...)); err != nil {
 		return nil, errors.Wrap(err, "error creating kubelet configuration configmap RBAC rules")
 	}
 
 	return nil, nil
 }
 
+// NodePoolKubeletConfigMapName returns the name of the ConfigMap storing the kubelet configuration of the given node pool.
+func NodePoolKubeletConfigMapName(pool string) string {
+	return fmt.Sprintf("%s-%s", kubeadmconstants.KubeletBaseConfigurationConfigMap, pool)
+}
+
+// uploadNodePoolKubeletConfigs uploads the kubelet configuration of each node pool, diverging from the default one
+// for the cgroup driver only, pruning the ones of the node pools removed in the meanwhile.
+func uploadNodePoolKubeletConfigs(client kubernetes.Interface, kubeletConfiguration KubeletConfiguration, cgroupDrivers map[string]string) ([]string, error) {
+	names := make([]string, 0, len(cgroupDrivers))
+	declared := make(map[string]struct{}, len(cgroupDrivers))
+
+	for pool, cgroupDriver := range cgroupDrivers {
+		poolConfiguration := kubeletConfiguration
+		poolConfiguration.TenantControlPlaneCgroupDriver = cgroupDriver
+
+		content, err := getKubeletConfigmapContent(poolConfiguration)
+		if err != nil {
+			return nil, err
+		}
+
+		name := NodePoolKubeletConfigMapName(pool)
+
+		if err = apiclient.CreateOrUpdateConfigMap(client, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceSystem,
+				Labels:    map[string]string{nodePoolLabel: pool},
+			},
+			Data: map[string]string{
+				kubeadmconstants.KubeletBaseConfigurationConfigMapKey: string(content),
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+		declared[name] = struct{}{}
+	}
+	// Sorting the names keeps the RBAC rules stable across the reconciliations.
+	sort.Strings(names)
+
+	configMaps, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).List(context.TODO(), metav1.ListOptions{LabelSelector: nodePoolLabel})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, configMap := range configMaps.Items {
+		if _, ok := declared[configMap.GetName()]; ok {
+			continue
+		}
+
+		if err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Delete(context.TODO(), configMap.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return names, nil
+}
+
 func getKubeletConfigmapContent(kubeletConfiguration KubeletConfiguration) ([]byte, error) {
 	zeroDuration := metav1.Duration{Duration: 0}
 
@@ -102,11 +176,12 @@ func getKubeletConfigmapContent(kubeletConfiguration KubeletConfiguration) ([]by
 		NodeStatusUpdateFrequency:        zeroDuration,
 		NodeStatusReportFrequency:        zeroDuration,
 		RotateCertificates:               true,
+		ServerTLSBootstrap:               kubeletConfiguration.TenantServerTLSBootstrap,
 		RuntimeRequestTimeout:            zeroDuration,
 		ShutdownGracePeriod:              zeroDuration,
 		ShutdownGracePeriodCriticalPods:  zeroDuration,
 		StaticPodPath:                    "/etc/kubernetes/manifests",
-		StreamingConnectionIdleTimeout:   zeroDuration,
+		StreamingConnectionIdleTimeout:   metav1.Duration{Duration: kubeletConfiguration.TenantStreamingIdleTimeout},
 		SyncFrequency:                    zeroDuration,
 		VolumeStatsAggPeriod:             zeroDuration,
 	}
@@ -114,7 +189,7 @@ func getKubeletConfigmapContent(kubeletConfiguration KubeletConfiguration) ([]by
 	return utilities.EncodeToYaml(&kc)
 }
 
-func createConfigMapRBACRules(client kubernetes.Interface) error {
+func createConfigMapRBACRules(client kubernetes.Interface, configMapNames []string) error {
 	configMapRBACName := kubeadmconstants.KubeletBaseConfigMapRole
 
 	if err := apiclient.CreateOrUpdateRole(client, &rbacv1.Role{
@@ -127,7 +202,7 @@ func createConfigMapRBACRules(client kubernetes.Interface) error {
 				Verbs:         []string{"get"},
 				APIGroups:     []string{""},
 				Resources:     []string{"configmaps"},
-				ResourceNames: []string{kubeadmconstants.KubeletBaseConfigurationConfigMap},
+				ResourceNames: configMapNames,
 			},
 		},
 	}); err != nil {
@@ -0,0 +1,51 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	canaryLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kamaji",
+		Subsystem: "datastore_canary",
+		Name:      "latency_seconds",
+		Help:      "Latency of the canary operations performed through the data path of the Tenant Control Plane.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"namespace", "name", "datastore", "operation"})
+	canaryFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kamaji",
+		Subsystem: "datastore_canary",
+		Name:      "failures_total",
+		Help:      "Number of failed canary probes performed through the data path of the Tenant Control Plane.",
+	}, []string{"namespace", "name", "datastore"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(canaryLatency, canaryFailures)
+}
+
+// RecordCanaryLatency publishes the latency of the canary write and read operations of the given Tenant Control Plane:
+// the percentiles can be computed using the histogram_quantile function.
+func RecordCanaryLatency(namespace, name, dataStore string, write, read time.Duration) {
+	canaryLatency.WithLabelValues(namespace, name, dataStore, "write").Observe(write.Seconds())
+	canaryLatency.WithLabelValues(namespace, name, dataStore, "read").Observe(read.Seconds())
+}
+
+// RecordCanaryFailure counts a failed canary probe of the given Tenant Control Plane.
+func RecordCanaryFailure(namespace, name, dataStore string) {
+	canaryFailures.WithLabelValues(namespace, name, dataStore).Inc()
+}
+
+// DeleteCanary removes all the canary series of the given Tenant Control Plane.
+func DeleteCanary(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+
+	canaryLatency.DeletePartialMatch(labels)
+	canaryFailures.DeletePartialMatch(labels)
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	resourceConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "resource_conflicts_total",
+		Help:      "Number of conflicts faced by the resource handlers of the Tenant Control Plane upon the update of the managed objects.",
+	}, []string{"namespace", "name", "handler"})
+	resourceRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "resource_retries_total",
+		Help:      "Number of retries performed by the resource handlers of the Tenant Control Plane upon a conflict.",
+	}, []string{"namespace", "name", "handler"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(resourceConflicts, resourceRetries)
+}
+
+type handlerScopeKey struct{}
+
+type handlerScope struct {
+	namespace string
+	name      string
+	handler   string
+}
+
+// WithHandlerScope returns a copy of the context carrying the Tenant Control Plane, and the resource handler,
+// the conflicts faced while updating the managed objects are attributed to.
+func WithHandlerScope(ctx context.Context, namespace, name, handler string) context.Context {
+	return context.WithValue(ctx, handlerScopeKey{}, handlerScope{namespace: namespace, name: name, handler: handler})
+}
+
+// RecordConflict counts a conflict faced by the resource handler in the context scope, if any.
+func RecordConflict(ctx context.Context) {
+	if scope, ok := ctx.Value(handlerScopeKey{}).(handlerScope); ok {
+		resourceConflicts.WithLabelValues(scope.namespace, scope.name, scope.handler).Inc()
+	}
+}
+
+// RecordRetry counts a retry performed by the resource handler in the context scope, if any.
+func RecordRetry(ctx context.Context) {
+	if scope, ok := ctx.Value(handlerScopeKey{}).(handlerScope); ok {
+		resourceRetries.WithLabelValues(scope.namespace, scope.name, scope.handler).Inc()
+	}
+}
+
+// DeleteConflicts removes all the conflict series of the given Tenant Control Plane.
+func DeleteConflicts(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+
+	resourceConflicts.DeletePartialMatch(labels)
+	resourceRetries.DeletePartialMatch(labels)
+}
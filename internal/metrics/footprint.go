@@ -0,0 +1,73 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+var (
+	footprintRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "resource_requests",
+		Help:      "Compute resources requested by the Tenant Control Plane in the management cluster.",
+	}, []string{"namespace", "name", "resource"})
+	footprintLimits = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "resource_limits",
+		Help:      "Compute resources limits of the Tenant Control Plane in the management cluster.",
+	}, []string{"namespace", "name", "resource"})
+	footprintStorage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "storage_bytes",
+		Help:      "Storage requested by the PersistentVolumeClaims owned by the Tenant Control Plane.",
+	}, []string{"namespace", "name"})
+	footprintServices = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "services",
+		Help:      "Number of Services owned by the Tenant Control Plane.",
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(footprintRequests, footprintLimits, footprintStorage, footprintServices)
+}
+
+// RecordResourceFootprint publishes the resource footprint of the given Tenant Control Plane:
+// the gauges can be summed up to get the aggregated consumption of the management cluster.
+func RecordResourceFootprint(namespace, name string, footprint *kamajiv1alpha1.ResourceFootprintStatus) {
+	DeleteResourceFootprint(namespace, name)
+
+	if footprint == nil {
+		return
+	}
+
+	for resourceName, quantity := range footprint.Requests {
+		footprintRequests.WithLabelValues(namespace, name, string(resourceName)).Set(quantity.AsApproximateFloat64())
+	}
+
+	for resourceName, quantity := range footprint.Limits {
+		footprintLimits.WithLabelValues(namespace, name, string(resourceName)).Set(quantity.AsApproximateFloat64())
+	}
+
+	footprintStorage.WithLabelValues(namespace, name).Set(footprint.Storage.AsApproximateFloat64())
+	footprintServices.WithLabelValues(namespace, name).Set(float64(footprint.Services))
+}
+
+// DeleteResourceFootprint removes all the resource footprint series of the given Tenant Control Plane.
+func DeleteResourceFootprint(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+
+	footprintRequests.DeletePartialMatch(labels)
+	footprintLimits.DeletePartialMatch(labels)
+	footprintStorage.DeletePartialMatch(labels)
+	footprintServices.DeletePartialMatch(labels)
+}
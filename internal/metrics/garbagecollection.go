@@ -0,0 +1,47 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	gcOrphaned = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "datastore_gc",
+		Name:      "orphaned",
+		Help:      "Number of users, or schemas, of the DataStore whose Tenant Control Plane no longer exists.",
+	}, []string{"datastore", "kind"})
+	gcDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kamaji",
+		Subsystem: "datastore_gc",
+		Name:      "deleted_total",
+		Help:      "Number of orphaned users, etcd roles, or schemas, deleted from the DataStore.",
+	}, []string{"datastore", "kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(gcOrphaned, gcDeleted)
+}
+
+// RecordGarbageCollectionFindings publishes the number of orphaned users, and schemas, found in the given DataStore.
+func RecordGarbageCollectionFindings(dataStore string, users, schemas int) {
+	gcOrphaned.WithLabelValues(dataStore, "user").Set(float64(users))
+	gcOrphaned.WithLabelValues(dataStore, "schema").Set(float64(schemas))
+}
+
+// RecordGarbageCollectionDeletion counts an orphaned user, etcd role, or schema, deleted from the given DataStore.
+func RecordGarbageCollectionDeletion(dataStore, kind string) {
+	gcDeleted.WithLabelValues(dataStore, kind).Inc()
+}
+
+// DeleteGarbageCollection removes all the garbage collection series of the given DataStore.
+func DeleteGarbageCollection(dataStore string) {
+	labels := prometheus.Labels{"datastore": dataStore}
+
+	gcOrphaned.DeletePartialMatch(labels)
+	gcDeleted.DeletePartialMatch(labels)
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type EventType string
+
+const (
+	EventCreated             EventType = "created"
+	EventReady               EventType = "ready"
+	EventUpgraded            EventType = "upgraded"
+	EventDegraded            EventType = "degraded"
+	EventDeleted             EventType = "deleted"
+	EventCertificateExpiring EventType = "certificate-expiring"
+)
+
+// EventTypes returns all the lifecycle events supported by the notification subsystem.
+func EventTypes() []EventType {
+	return []EventType{EventCreated, EventReady, EventUpgraded, EventDegraded, EventDeleted, EventCertificateExpiring}
+}
+
+// ParseEventTypes validates the comma separated list of event types used to filter the notifications.
+func ParseEventTypes(value string) (sets.String, error) {
+	supported := sets.NewString()
+	for _, t := range EventTypes() {
+		supported.Insert(string(t))
+	}
+
+	if len(value) == 0 {
+		return supported, nil
+	}
+
+	selected := sets.NewString()
+
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+
+		if !supported.Has(t) {
+			return nil, fmt.Errorf("unsupported notification event %q, must be one of %s", t, strings.Join(supported.List(), ", "))
+		}
+
+		selected.Insert(t)
+	}
+
+	return selected, nil
+}
+
+// Event is a significant lifecycle transition of a Tenant Control Plane.
+type Event struct {
+	Type      EventType `json:"type"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Time      time.Time `json:"time"`
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("TenantControlPlane %s/%s %s: %s", e.Namespace, e.Name, e.Type, e.Message)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SinkType string
+
+const (
+	SinkWebhook     SinkType = "webhook"
+	SinkSlack       SinkType = "slack"
+	SinkCloudEvents SinkType = "cloudevents"
+)
+
+const sinkTimeout = 10 * time.Second
+
+// Sink delivers the lifecycle events to an external system.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// NewSink returns the Sink for the given type, delivering the events to the given endpoint.
+func NewSink(sinkType SinkType, endpoint string) (Sink, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid notification endpoint: %w", err)
+	}
+
+	client := &http.Client{Timeout: sinkTimeout}
+
+	switch sinkType {
+	case SinkWebhook:
+		return &webhookSink{client: client, endpoint: endpoint}, nil
+	case SinkSlack:
+		return &slackSink{client: client, endpoint: endpoint}, nil
+	case SinkCloudEvents:
+		return &cloudEventsSink{client: client, endpoint: endpoint}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notification sink %q", sinkType)
+	}
+}
+
+func post(ctx context.Context, client *http.Client, endpoint, contentType string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot marshal the notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("notification endpoint replied with status %s", res.Status)
+	}
+
+	return nil
+}
+
+// webhookSink sends the event as a plain JSON object.
+type webhookSink struct {
+	client   *http.Client
+	endpoint string
+}
+
+func (w *webhookSink) Send(ctx context.Context, event Event) error {
+	return post(ctx, w.client, w.endpoint, "application/json", event)
+}
+
+// slackSink sends the event as a Slack incoming webhook message.
+type slackSink struct {
+	client   *http.Client
+	endpoint string
+}
+
+func (s *slackSink) Send(ctx context.Context, event Event) error {
+	return post(ctx, s.client, s.endpoint, "application/json", map[string]string{
+		"text": event.String(),
+	})
+}
+
+// cloudEventsSink sends the event as a CloudEvent v1.0 using the structured content mode.
+type cloudEventsSink struct {
+	client   *http.Client
+	endpoint string
+}
+
+func (c *cloudEventsSink) Send(ctx context.Context, event Event) error {
+	return post(ctx, c.client, c.endpoint, "application/cloudevents+json", map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              uuid.New().String(),
+		"source":          "kamaji.clastix.io/tenantcontrolplanes",
+		"type":            fmt.Sprintf("io.clastix.kamaji.tenantcontrolplane.%s", event.Type),
+		"subject":         fmt.Sprintf("%s/%s", event.Namespace, event.Name),
+		"time":            event.Time.UTC().Format(time.RFC3339),
+		"datacontenttype": "application/json",
+		"data":            event,
+	})
+}
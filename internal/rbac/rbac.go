@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	readVerbs    = []string{"get", "list", "watch"}
+	manageVerbs  = []string{"create", "delete", "get", "list", "patch", "update", "watch"}
+	statusVerbs  = []string{"get", "patch", "update"}
+	eventVerbs   = []string{"create", "patch"}
+	updateVerbs  = []string{"get", "list", "patch", "update", "watch"}
+	jobVerbs     = []string{"create", "delete", "get", "list", "watch"}
+	monitorVerbs = []string{"create", "delete", "get", "patch", "update"}
+)
+
+// Features are the optional features of the operator requiring additional permissions, matching the flags of the manager.
+type Features struct {
+	// IngressExposure allows the Tenant Control Planes to be exposed with an Ingress.
+	IngressExposure bool
+	// EtcdClusterController runs the controller of the EtcdCluster objects, backed by StatefulSets.
+	EtcdClusterController bool
+	// PodMonitors allows the creation of the Prometheus Operator PodMonitor objects scraping the kine metrics.
+	PodMonitors bool
+	// AdminAPI authenticates, and authorizes, the clients of the admin API with the Kubernetes API.
+	AdminAPI bool
+}
+
+// ClusterRoleRules returns the minimal rules required by the operator with the given features.
+func ClusterRoleRules(features Features) []rbacv1.PolicyRule {
+	rules := []rbacv1.PolicyRule{
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: manageVerbs},
+		{APIGroups: []string{"batch"}, Resources: []string{"jobs"}, Verbs: jobVerbs},
+		{APIGroups: []string{""}, Resources: []string{"configmaps", "secrets", "services"}, Verbs: manageVerbs},
+		{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: eventVerbs},
+		{APIGroups: []string{""}, Resources: []string{"namespaces", "persistentvolumeclaims", "pods"}, Verbs: readVerbs},
+		{APIGroups: []string{""}, Resources: []string{"pods/status"}, Verbs: statusVerbs},
+		{APIGroups: []string{"kamaji.clastix.io"}, Resources: []string{"datastores", "tenantcontrolplanes"}, Verbs: manageVerbs},
+		{APIGroups: []string{"kamaji.clastix.io"}, Resources: []string{"bulkactions"}, Verbs: updateVerbs},
+		{APIGroups: []string{"kamaji.clastix.io"}, Resources: []string{"bulkactions/status", "datastores/status", "tenantcontrolplanes/status"}, Verbs: statusVerbs},
+		{APIGroups: []string{"kamaji.clastix.io"}, Resources: []string{"tenantcontrolplanes/finalizers"}, Verbs: []string{"update"}},
+	}
+
+	if features.EtcdClusterController {
+		rules = append(rules,
+			rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"statefulsets"}, Verbs: manageVerbs},
+			rbacv1.PolicyRule{APIGroups: []string{"kamaji.clastix.io"}, Resources: []string{"etcdclusters"}, Verbs: updateVerbs},
+			rbacv1.PolicyRule{APIGroups: []string{"kamaji.clastix.io"}, Resources: []string{"etcdclusters/status"}, Verbs: statusVerbs},
+		)
+	}
+
+	if features.IngressExposure {
+		rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: manageVerbs})
+	}
+
+	if features.PodMonitors {
+		rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{"monitoring.coreos.com"}, Resources: []string{"podmonitors"}, Verbs: monitorVerbs})
+	}
+
+	if features.AdminAPI {
+		rules = append(rules,
+			rbacv1.PolicyRule{APIGroups: []string{"authentication.k8s.io"}, Resources: []string{"tokenreviews"}, Verbs: []string{"create"}},
+			rbacv1.PolicyRule{APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"subjectaccessreviews"}, Verbs: []string{"create"}},
+		)
+	}
+
+	return rules
+}
+
+// LeaderElectionRules returns the rules required by the leader election, granted in the namespace of the operator.
+func LeaderElectionRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: manageVerbs},
+		{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: manageVerbs},
+		{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: eventVerbs},
+	}
+}
+
+// Manifests returns the ClusterRole, and the leader election Role, granted to the given ServiceAccount of the operator,
+// along with their bindings.
+func Manifests(prefix, namespace, serviceAccount string, features Features) []client.Object {
+	subjects := []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount, Namespace: namespace},
+	}
+
+	managerName, leaderElectionName := fmt.Sprintf("%s-manager-role", prefix), fmt.Sprintf("%s-leader-election-role", prefix)
+
+	return []client.Object{
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: managerName},
+			Rules:      ClusterRoleRules(features),
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-manager-rolebinding", prefix)},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: managerName},
+			Subjects:   subjects,
+		},
+		&rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{Name: leaderElectionName, Namespace: namespace},
+			Rules:      LeaderElectionRules(),
+		},
+		&rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-leader-election-rolebinding", prefix), Namespace: namespace},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: leaderElectionName},
+			Subjects:   subjects,
+		},
+	}
+}
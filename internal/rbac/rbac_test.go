@@ -0,0 +1,60 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// permissions flattens the rules to the granted group, resource, and verb tuples, ignoring how they are grouped.
+func permissions(rules []rbacv1.PolicyRule) sets.String {
+	granted := sets.NewString()
+
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, verb := range rule.Verbs {
+					granted.Insert(group + "/" + resource + ":" + verb)
+				}
+			}
+		}
+	}
+
+	return granted
+}
+
+// TestClusterRoleRulesMatchRoleManifest ensures the rules of the rbac command, with all the features enabled,
+// are the ones generated from the kubebuilder markers in config/rbac/role.yaml.
+func TestClusterRoleRulesMatchRoleManifest(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "..", "config", "rbac", "role.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := &rbacv1.ClusterRole{}
+	if err = sigsyaml.Unmarshal(content, role); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(role.Rules) == 0 {
+		t.Fatal("no rules found in config/rbac/role.yaml")
+	}
+
+	expected := permissions(role.Rules)
+	actual := permissions(ClusterRoleRules(Features{IngressExposure: true, EtcdClusterController: true, PodMonitors: true, AdminAPI: true}))
+
+	if missing := expected.Difference(actual).List(); len(missing) > 0 {
+		t.Errorf("permissions of config/rbac/role.yaml missing from the generated rules: %v", missing)
+	}
+
+	if extra := actual.Difference(expected).List(); len(extra) > 0 {
+		t.Errorf("generated permissions missing from config/rbac/role.yaml: %v", extra)
+	}
+}
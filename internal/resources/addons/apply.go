@@ -0,0 +1,23 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// applyFn applies the decoded addon manifest to the Tenant Cluster.
+type applyFn func(ctx context.Context, c client.Client, obj client.Object) (controllerutil.OperationResult, error)
+
+// installOnce creates the addon resource if missing: the decoded manifest is already complete, thus it's not mutated.
+func installOnce(ctx context.Context, c client.Client, obj client.Object) (controllerutil.OperationResult, error) {
+	return utilities.CreateOnce(ctx, c, obj, func() error {
+		return nil
+	})
+}
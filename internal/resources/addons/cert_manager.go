@@ -0,0 +1,188 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// CertManagerInventoryLabel labels the inventory ConfigMap of the cert-manager addon.
+	CertManagerInventoryLabel = "addons.kamaji.clastix.io/cert-manager-inventory"
+
+	certManagerAddonLabel     = "addons.kamaji.clastix.io/cert-manager"
+	certManagerInventoryName  = "kamaji-cert-manager"
+	certManagerImageRegistry  = "quay.io/jetstack/"
+	certManagerControllerName = "cert-manager"
+)
+
+// CertManager installs cert-manager in the Tenant Cluster from its release manifests, applying the declared overrides:
+// upon its removal the CustomResourceDefinitions are retained, preserving the certificates of the tenant.
+type CertManager struct {
+	Client client.Client
+}
+
+func (c *CertManager) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (c *CertManager) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.CertManager == nil && tcp.Status.Addons.CertManager.Enabled
+}
+
+func (c *CertManager) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", c.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, c.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if _, err = c.inventory().remove(ctx, tenantClient, func(reference corev1.ObjectReference) bool {
+		return reference.Kind == "CustomResourceDefinition"
+	}); err != nil {
+		logger.Error(err, "cannot remove the cert-manager resources")
+
+		return false, err
+	}
+	// The status must be updated regardless of the inventory, which could have been already deleted.
+	return true, nil
+}
+
+func (c *CertManager) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", c.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, c.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	spec := tcp.Spec.Addons.CertManager
+
+	objects, err := c.decodeManifests(ctx, spec)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	return c.inventory().apply(ctx, tenantClient, objects, map[string]string{certManagerAddonLabel: "true"}, spec.ReconciliationMode)
+}
+
+func (c *CertManager) GetName() string {
+	return "cert-manager"
+}
+
+func (c *CertManager) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return (tcp.Spec.Addons.CertManager != nil) != tcp.Status.Addons.CertManager.Enabled
+}
+
+func (c *CertManager) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.CertManager.Enabled = tcp.Spec.Addons.CertManager != nil
+	tcp.Status.Addons.CertManager.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+func (c *CertManager) inventory() *manifestsInventory {
+	return &manifestsInventory{
+		name:   certManagerInventoryName,
+		labels: map[string]string{CertManagerInventoryLabel: "true"},
+	}
+}
+
+func (c *CertManager) decodeManifests(ctx context.Context, spec *kamajiv1alpha1.CertManagerAddonSpec) ([]*unstructured.Unstructured, error) {
+	manifestsURL, err := spec.ReleaseManifestsURL()
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := fetchReleaseManifests(ctx, manifestsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := decodeManifests(map[string][]byte{"cert-manager.yaml": manifests})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, obj := range objects {
+		if obj.GetKind() != "Deployment" {
+			continue
+		}
+
+		if objects[i], err = applyCertManagerOverrides(obj, spec); err != nil {
+			return nil, err
+		}
+	}
+
+	return objects, nil
+}
+
+// applyCertManagerOverrides replaces the defaults of the cert-manager Deployments with the declared values.
+func applyCertManagerOverrides(obj *unstructured.Unstructured, spec *kamajiv1alpha1.CertManagerAddonSpec) (*unstructured.Unstructured, error) {
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deployment); err != nil {
+		return nil, fmt.Errorf("cannot convert the Deployment %s: %w", obj.GetName(), err)
+	}
+
+	podSpec := &deployment.Spec.Template.Spec
+
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		// The ACME solver image is passed as an argument of the controller.
+		if len(spec.ImageRepository) > 0 {
+			repository := strings.TrimSuffix(spec.ImageRepository, "/") + "/"
+
+			container.Image = strings.Replace(container.Image, certManagerImageRegistry, repository, 1)
+			for j := range container.Args {
+				container.Args[j] = strings.ReplaceAll(container.Args[j], certManagerImageRegistry, repository)
+			}
+		}
+
+		if values := spec.Values; values != nil && deployment.GetName() == certManagerControllerName && i == 0 {
+			container.Args = append(container.Args, values.ExtraArgs...)
+		}
+	}
+
+	if values := spec.Values; values != nil {
+		if values.Replicas != nil {
+			deployment.Spec.Replicas = values.Replicas
+		}
+
+		if len(values.Tolerations) > 0 {
+			podSpec.Tolerations = values.Tolerations
+		}
+
+		if len(values.NodeSelector) > 0 {
+			podSpec.NodeSelector = values.NodeSelector
+		}
+	}
+
+	converted, err := toUnstructured(deployment)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert the Deployment %s: %w", obj.GetName(), err)
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// CNIInventoryLabel labels the inventory ConfigMap of the CNI addon.
+	CNIInventoryLabel = "addons.kamaji.clastix.io/cni-inventory"
+
+	cniAddonLabel    = "addons.kamaji.clastix.io/cni"
+	cniInventoryName = "kamaji-cni"
+	calicoConfigName = "calico-config"
+	calicoNodeName   = "calico-node"
+	ciliumConfigName = "cilium-config"
+	vxlanBackend     = "vxlan"
+)
+
+// CNI installs the chosen Container Network Interface plugin in the Tenant Cluster from its release manifests,
+// configuring the Pod CIDR and the encapsulation: upon its removal the CustomResourceDefinitions are retained.
+type CNI struct {
+	Client client.Client
+}
+
+func (c *CNI) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (c *CNI) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.CNI == nil && tcp.Status.Addons.CNI.Enabled
+}
+
+func (c *CNI) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", c.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, c.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if _, err = c.inventory().remove(ctx, tenantClient, func(reference corev1.ObjectReference) bool {
+		return reference.Kind == "CustomResourceDefinition"
+	}); err != nil {
+		logger.Error(err, "cannot remove the CNI resources")
+
+		return false, err
+	}
+	// The status must be updated regardless of the inventory, which could have been already deleted.
+	return true, nil
+}
+
+func (c *CNI) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", c.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, c.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	spec := tcp.Spec.Addons.CNI
+
+	objects, err := c.decodeManifests(ctx, tcp, spec)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	return c.inventory().apply(ctx, tenantClient, objects, map[string]string{cniAddonLabel: strings.ToLower(string(spec.Provider))}, spec.ReconciliationMode)
+}
+
+func (c *CNI) GetName() string {
+	return "cni"
+}
+
+func (c *CNI) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return (tcp.Spec.Addons.CNI != nil) != tcp.Status.Addons.CNI.Enabled
+}
+
+func (c *CNI) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.CNI.Enabled = tcp.Spec.Addons.CNI != nil
+	tcp.Status.Addons.CNI.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+func (c *CNI) inventory() *manifestsInventory {
+	return &manifestsInventory{
+		name:   cniInventoryName,
+		labels: map[string]string{CNIInventoryLabel: "true"},
+	}
+}
+
+func (c *CNI) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, spec *kamajiv1alpha1.CNIAddonSpec) ([]*unstructured.Unstructured, error) {
+	manifestsURL, err := spec.ReleaseManifestsURL()
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := fetchReleaseManifests(ctx, manifestsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := decodeManifests(map[string][]byte{"cni.yaml": manifests})
+	if err != nil {
+		return nil, err
+	}
+
+	podCIDR := spec.PodCIDR
+	if len(podCIDR) == 0 {
+		podCIDR = tcp.Spec.NetworkProfile.PodCIDR
+	}
+
+	for i, obj := range objects {
+		switch {
+		case spec.Provider == kamajiv1alpha1.CNIProviderCalico && obj.GetKind() == "ConfigMap" && obj.GetName() == calicoConfigName:
+			backend := "bird"
+			if spec.Encapsulation == kamajiv1alpha1.CNIEncapsulationVXLAN {
+				backend = vxlanBackend
+			}
+
+			err = unstructured.SetNestedField(obj.Object, backend, "data", "calico_backend")
+		case spec.Provider == kamajiv1alpha1.CNIProviderCalico && obj.GetKind() == "DaemonSet" && obj.GetName() == calicoNodeName:
+			objects[i], err = applyCalicoNodeOverrides(obj, podCIDR, spec.Encapsulation)
+		case spec.Provider == kamajiv1alpha1.CNIProviderCilium && obj.GetKind() == "ConfigMap" && obj.GetName() == ciliumConfigName:
+			err = applyCiliumConfigOverrides(obj, podCIDR, spec.Encapsulation)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return objects, nil
+}
+
+// applyCalicoNodeOverrides configures the default IP pool created by the calico-node DaemonSet: the BIRD health checks
+// are removed with the VXLAN encapsulation, since the BGP daemon is not running.
+func applyCalicoNodeOverrides(obj *unstructured.Unstructured, podCIDR string, encapsulation kamajiv1alpha1.CNIEncapsulation) (*unstructured.Unstructured, error) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, daemonSet); err != nil {
+		return nil, fmt.Errorf("cannot convert the DaemonSet %s: %w", obj.GetName(), err)
+	}
+
+	ipip, vxlan := "Never", "Never"
+
+	switch encapsulation {
+	case kamajiv1alpha1.CNIEncapsulationIPIP:
+		ipip = "Always"
+	case kamajiv1alpha1.CNIEncapsulationVXLAN:
+		vxlan = "Always"
+	}
+
+	for i := range daemonSet.Spec.Template.Spec.Containers {
+		container := &daemonSet.Spec.Template.Spec.Containers[i]
+		if container.Name != calicoNodeName {
+			continue
+		}
+
+		setContainerEnv(container, "CALICO_IPV4POOL_CIDR", podCIDR)
+		setContainerEnv(container, "CALICO_IPV4POOL_IPIP", ipip)
+		setContainerEnv(container, "CALICO_IPV4POOL_VXLAN", vxlan)
+
+		if encapsulation != kamajiv1alpha1.CNIEncapsulationVXLAN {
+			continue
+		}
+
+		for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe} {
+			if probe == nil || probe.Exec == nil {
+				continue
+			}
+
+			var command []string
+
+			for _, arg := range probe.Exec.Command {
+				if arg != "-bird-live" && arg != "-bird-ready" {
+					command = append(command, arg)
+				}
+			}
+
+			probe.Exec.Command = command
+		}
+	}
+
+	converted, err := toUnstructured(daemonSet)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert the DaemonSet %s: %w", obj.GetName(), err)
+	}
+
+	return converted, nil
+}
+
+// applyCiliumConfigOverrides configures the cluster-pool IPAM and the routing mode of Cilium: both the tunnel key,
+// and the routing-mode and tunnel-protocol ones replacing it since v1.14, are set.
+func applyCiliumConfigOverrides(obj *unstructured.Unstructured, podCIDR string, encapsulation kamajiv1alpha1.CNIEncapsulation) error {
+	data := map[string]string{
+		"cluster-pool-ipv4-cidr": podCIDR,
+	}
+
+	switch encapsulation {
+	case kamajiv1alpha1.CNIEncapsulationNone:
+		data["tunnel"] = "disabled"
+		data["routing-mode"] = "native"
+		data["auto-direct-node-routes"] = "true"
+		data["ipv4-native-routing-cidr"] = podCIDR
+	case kamajiv1alpha1.CNIEncapsulationGeneve:
+		data["tunnel"] = "geneve"
+		data["routing-mode"] = "tunnel"
+		data["tunnel-protocol"] = "geneve"
+	default:
+		data["tunnel"] = vxlanBackend
+		data["routing-mode"] = "tunnel"
+		data["tunnel-protocol"] = vxlanBackend
+	}
+
+	for key, value := range data {
+		if err := unstructured.SetNestedField(obj.Object, value, "data", key); err != nil {
+			return fmt.Errorf("cannot configure the ConfigMap %s: %w", obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// setContainerEnv sets the given environment variable, replacing the existing one.
+func setContainerEnv(container *corev1.Container, name, value string) {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i] = corev1.EnvVar{Name: name, Value: value}
+
+			return
+		}
+	}
+
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+}
@@ -33,6 +33,7 @@ type CoreDNS struct {
 	clusterRole        *rbacv1.ClusterRole
 	clusterRoleBinding *rbacv1.ClusterRoleBinding
 	serviceAccount     *corev1.ServiceAccount
+	affinity           *corev1.Affinity
 }
 
 func (c *CoreDNS) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
@@ -120,6 +121,13 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 		return controllerutil.OperationResultNone, err
 	}
 
+	switch {
+	case tcp.Spec.Addons.CoreDNS.ReconciliationMode == kamajiv1alpha1.AddonReconciliationModeInstallOnce:
+		return c.applyManifests(ctx, tenantClient, installOnce)
+	case tcp.Spec.Addons.CoreDNS.ServerSideApply:
+		return c.applyManifests(ctx, tenantClient, utilities.ServerSideApply)
+	}
+
 	var operationResult controllerutil.OperationResult
 
 	reconciliationResult := controllerutil.OperationResultNone
@@ -190,6 +198,37 @@ func (c *CoreDNS) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv
 	return nil
 }
 
+// applyManifests applies the decoded manifests with the given function, rather than mutating the single fields of the existing resources:
+// the ClusterRoleBinding goes first, since it's the owner of the other ones.
+func (c *CoreDNS) applyManifests(ctx context.Context, tenantClient client.Client, apply applyFn) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", c.GetName())
+
+	owned := map[client.Object]struct{}{}
+	for _, obj := range []client.Object{c.deployment, c.configMap, c.service, c.clusterRole, c.serviceAccount} {
+		owned[obj] = struct{}{}
+	}
+
+	reconciliationResult := controllerutil.OperationResultNone
+
+	for _, obj := range []client.Object{c.clusterRoleBinding, c.deployment, c.configMap, c.service, c.clusterRole, c.serviceAccount} {
+		if _, ok := owned[obj]; ok {
+			if err := controllerutil.SetControllerReference(c.clusterRoleBinding, obj, tenantClient.Scheme()); err != nil {
+				return controllerutil.OperationResultNone, err
+			}
+		}
+
+		operationResult, err := apply(ctx, tenantClient, obj)
+		if err != nil {
+			logger.Error(err, "manifest apply failed", "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+	}
+
+	return reconciliationResult, nil
+}
+
 func (c *CoreDNS) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
 	tcpClient, config, err := resources.GetKubeadmManifestDeps(ctx, c.Client, tcp)
 	if err != nil {
@@ -222,6 +261,12 @@ func (c *CoreDNS) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Tenan
 		return errors.Wrap(err, "unable to decode ConfigMap manifest")
 	}
 
+	c.applyDeploymentOverrides(tcp.Spec.Addons.CoreDNS)
+
+	if c.configMap.Data[corefileKey], err = renderCorefile(c.configMap.Data[corefileKey], tcp.Spec.Addons.CoreDNS); err != nil {
+		return errors.Wrap(err, "unable to render the Corefile")
+	}
+
 	if err = utilities.DecodeFromYAML(string(parts[3]), c.service); err != nil {
 		return errors.Wrap(err, "unable to decode Service manifest")
 	}
@@ -241,6 +286,36 @@ func (c *CoreDNS) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Tenan
 	return nil
 }
 
+// applyDeploymentOverrides replaces the kubeadm defaults of the CoreDNS Deployment with the declared sizing and placement.
+func (c *CoreDNS) applyDeploymentOverrides(spec *kamajiv1alpha1.CoreDNSAddonSpec) {
+	podSpec := &c.deployment.Spec.Template.Spec
+
+	if spec.Replicas != nil {
+		c.deployment.Spec.Replicas = spec.Replicas
+	}
+
+	if spec.Resources != nil {
+		podSpec.Containers[0].Resources = corev1.ResourceRequirements{
+			Limits:   spec.Resources.Limits,
+			Requests: spec.Resources.Requests,
+		}
+	}
+
+	if len(spec.Tolerations) > 0 {
+		podSpec.Tolerations = spec.Tolerations
+	}
+
+	if len(spec.NodeSelector) > 0 {
+		podSpec.NodeSelector = spec.NodeSelector
+	}
+
+	c.affinity = nil
+	if spec.Affinity != nil {
+		c.affinity = spec.Affinity.DeepCopy()
+		podSpec.Affinity = c.affinity
+	}
+}
+
 func (c *CoreDNS) mutateClusterRoleBinding(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
 	crb := &rbacv1.ClusterRoleBinding{}
 	crb.SetName(c.clusterRoleBinding.GetName())
@@ -335,6 +410,14 @@ func (c *CoreDNS) mutateDeployment(ctx context.Context, tenantClient client.Clie
 			}
 		}
 		d.Spec.Template.Spec.Affinity.PodAffinity = c.deployment.Spec.Template.Spec.Affinity.PodAffinity
+		if c.affinity != nil {
+			d.Spec.Template.Spec.Affinity.NodeAffinity = c.affinity.NodeAffinity
+			d.Spec.Template.Spec.Affinity.PodAffinity = c.affinity.PodAffinity
+			d.Spec.Template.Spec.Affinity.PodAntiAffinity = c.affinity.PodAntiAffinity
+		} else {
+			d.Spec.Template.Spec.Affinity.NodeAffinity = nil
+			d.Spec.Template.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
 		d.Spec.Template.Spec.Tolerations = c.deployment.Spec.Template.Spec.Tolerations
 		d.Spec.Template.Spec.PriorityClassName = c.deployment.Spec.Template.Spec.PriorityClassName
 		d.Spec.Strategy.Type = c.deployment.Spec.Strategy.Type
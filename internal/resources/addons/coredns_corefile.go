@@ -0,0 +1,47 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"fmt"
+	"strings"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	corefileKey             = "Corefile"
+	corefileDefaultUpstream = "forward . /etc/resolv.conf"
+)
+
+// renderCorefile customizes the Corefile generated by kubeadm with the upstreams, and the stub zones:
+// each stub zone is rendered as a dedicated server block forwarding the queries to the given DNS servers.
+func renderCorefile(corefile string, spec *kamajiv1alpha1.CoreDNSAddonSpec) (string, error) {
+	if err := spec.Validate(); err != nil {
+		return "", err
+	}
+
+	if len(spec.Upstreams) > 0 {
+		if !strings.Contains(corefile, corefileDefaultUpstream) {
+			return "", fmt.Errorf("unable to find the default forward directive in the Corefile")
+		}
+
+		corefile = strings.Replace(corefile, corefileDefaultUpstream, fmt.Sprintf("forward . %s", strings.Join(spec.Upstreams, " ")), 1)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(strings.TrimRight(corefile, "\n"))
+	sb.WriteString("\n")
+
+	for _, stub := range spec.StubZones {
+		sb.WriteString(fmt.Sprintf("%s:53 {\n", stub.NormalizedZone()))
+		sb.WriteString("    errors\n")
+		sb.WriteString("    cache 30\n")
+		sb.WriteString(fmt.Sprintf("    forward . %s\n", strings.Join(stub.Servers, " ")))
+		sb.WriteString("}\n")
+	}
+
+	return sb.String(), nil
+}
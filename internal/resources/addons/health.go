@@ -0,0 +1,187 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/drift"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources/konnectivity"
+)
+
+const (
+	AddonHealthyReason          = "WorkloadsAvailable"
+	AddonUnhealthyReason        = "WorkloadsUnavailable"
+	AddonWorkloadsMissingReason = "WorkloadsNotFound"
+)
+
+// Health is the health of an addon, collected from its workloads in the Tenant Cluster.
+type Health struct {
+	Version string
+	Healthy bool
+	Reason  string
+	Message string
+}
+
+// CollectHealth returns the health of the given addon workloads: an addon is healthy once all of them are available.
+func CollectHealth(ctx context.Context, tenantClient client.Client, workloads []corev1.ObjectReference) (Health, error) {
+	var (
+		version     string
+		found       int
+		unavailable []string
+	)
+
+	for _, reference := range workloads {
+		var (
+			object             client.Object
+			desired, available int32
+			containers         []corev1.Container
+		)
+
+		switch reference.Kind {
+		case "Deployment":
+			object = &appsv1.Deployment{}
+		case "DaemonSet":
+			object = &appsv1.DaemonSet{}
+		case "StatefulSet":
+			object = &appsv1.StatefulSet{}
+		default:
+			continue
+		}
+
+		if err := tenantClient.Get(ctx, k8stypes.NamespacedName{Namespace: reference.Namespace, Name: reference.Name}, object); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return Health{}, err
+		}
+
+		switch o := object.(type) {
+		case *appsv1.Deployment:
+			desired, available, containers = 1, o.Status.AvailableReplicas, o.Spec.Template.Spec.Containers
+			if o.Spec.Replicas != nil {
+				desired = *o.Spec.Replicas
+			}
+		case *appsv1.DaemonSet:
+			desired, available, containers = o.Status.DesiredNumberScheduled, o.Status.NumberAvailable, o.Spec.Template.Spec.Containers
+		case *appsv1.StatefulSet:
+			desired, available, containers = 1, o.Status.AvailableReplicas, o.Spec.Template.Spec.Containers
+			if o.Spec.Replicas != nil {
+				desired = *o.Spec.Replicas
+			}
+		}
+
+		found++
+
+		if len(version) == 0 && len(containers) > 0 {
+			version = drift.ImageTag(containers[0].Image)
+		}
+
+		if available < desired {
+			unavailable = append(unavailable, fmt.Sprintf("%s %s/%s: %d/%d available", reference.Kind, reference.Namespace, reference.Name, available, desired))
+		}
+	}
+
+	switch {
+	case found == 0:
+		return Health{Reason: AddonWorkloadsMissingReason, Message: "No workload of the addon has been found"}, nil
+	case len(unavailable) > 0:
+		return Health{Version: version, Reason: AddonUnhealthyReason, Message: strings.Join(unavailable, "; ")}, nil
+	default:
+		return Health{Version: version, Healthy: true, Reason: AddonHealthyReason, Message: fmt.Sprintf("All the %d workloads are available", found)}, nil
+	}
+}
+
+// Workloads returns the workloads of the enabled addons, keyed by the addon status they're reported to:
+// the ones installed from manifests are read from their inventory.
+func Workloads(ctx context.Context, tenantClient client.Client, tcp *kamajiv1alpha1.TenantControlPlane) (map[*kamajiv1alpha1.AddonHealthStatus][]corev1.ObjectReference, error) {
+	workloads := map[*kamajiv1alpha1.AddonHealthStatus][]corev1.ObjectReference{}
+
+	addons, status := tcp.Spec.Addons, &tcp.Status.Addons
+
+	if addons.CoreDNS != nil {
+		workloads[&status.CoreDNS.AddonHealthStatus] = []corev1.ObjectReference{{Kind: "Deployment", Namespace: kubeadm.KubeSystemNamespace, Name: "coredns"}}
+	}
+
+	if addons.KubeProxy != nil {
+		workloads[&status.KubeProxy.AddonHealthStatus] = []corev1.ObjectReference{{Kind: "DaemonSet", Namespace: kubeadm.KubeSystemNamespace, Name: "kube-proxy"}}
+	}
+
+	if addons.Konnectivity != nil {
+		workloads[&status.Konnectivity.AddonHealthStatus] = []corev1.ObjectReference{{Kind: "DaemonSet", Namespace: konnectivity.AgentNamespace, Name: konnectivity.AgentName}}
+	}
+
+	for inventory, addonStatus := range map[string]*kamajiv1alpha1.AddonStatus{
+		certManagerInventoryName: &status.CertManager,
+		cniInventoryName:         &status.CNI,
+		storageInventoryName:     &status.Storage,
+	} {
+		if !addonStatus.Enabled {
+			continue
+		}
+
+		references, err := inventoryReferences(ctx, tenantClient, inventory)
+		if err != nil {
+			return nil, err
+		}
+
+		workloads[&addonStatus.AddonHealthStatus] = references
+	}
+
+	return workloads, nil
+}
+
+// inventoryReferences returns the resources recorded in the given inventory.
+func inventoryReferences(ctx context.Context, tenantClient client.Client, name string) ([]corev1.ObjectReference, error) {
+	inventory := &corev1.ConfigMap{}
+	if err := tenantClient.Get(ctx, k8stypes.NamespacedName{Namespace: kubeadm.KubeSystemNamespace, Name: name}, inventory); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var references []corev1.ObjectReference
+	if err := json.Unmarshal([]byte(inventory.Data[manifestsInventoryResourcesKey]), &references); err != nil {
+		return nil, fmt.Errorf("cannot decode the inventory %s: %w", name, err)
+	}
+
+	return references, nil
+}
+
+// SetHealth reports the given health, and the time of its collection, in the addon status.
+func SetHealth(status *kamajiv1alpha1.AddonHealthStatus, health Health, generation int64) {
+	conditionStatus := metav1.ConditionFalse
+	if health.Healthy {
+		conditionStatus = metav1.ConditionTrue
+	}
+
+	now := metav1.Now()
+
+	status.Version = health.Version
+	status.LastSync = &now
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeAddonHealthy,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             health.Reason,
+		Message:            health.Message,
+	})
+}
@@ -0,0 +1,358 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	manifestsInventoryResourcesKey = "resources"
+	manifestsInventoryModeKey      = "reconciliationMode"
+)
+
+// manifestsInventory is the ConfigMap, in the kube-system namespace of the Tenant Cluster, recording the resources
+// applied by an addon built from plain manifests: the enforced addons prune the resources applied previously, and no more declared.
+type manifestsInventory struct {
+	name   string
+	labels map[string]string
+}
+
+// apply applies the given objects to the Tenant Cluster according to the reconciliation mode, labelling them, and records them.
+func (i *manifestsInventory) apply(ctx context.Context, tenantClient client.Client, objects []*unstructured.Unstructured, labels map[string]string, mode kamajiv1alpha1.AddonReconciliationMode) (controllerutil.OperationResult, error) {
+	apply := utilities.ServerSideApply
+	if mode == kamajiv1alpha1.AddonReconciliationModeInstallOnce {
+		apply = installOnce
+	}
+
+	reconciliationResult := controllerutil.OperationResultNone
+
+	references := make([]corev1.ObjectReference, 0, len(objects))
+
+	for _, obj := range objects {
+		if err := setDefaultNamespace(tenantClient, obj); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+
+		obj.SetLabels(utilities.MergeMaps(obj.GetLabels(), labels))
+
+		operationResult, err := apply(ctx, tenantClient, obj)
+		if err != nil {
+			return controllerutil.OperationResultNone, fmt.Errorf("cannot apply the %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+
+		references = append(references, corev1.ObjectReference{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		})
+	}
+
+	inventory := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      i.name,
+			Namespace: kubeadm.KubeSystemNamespace,
+		},
+	}
+
+	if err := tenantClient.Get(ctx, client.ObjectKeyFromObject(inventory), inventory); err != nil && !k8serrors.IsNotFound(err) {
+		return controllerutil.OperationResultNone, err
+	}
+
+	if err := pruneInventory(ctx, tenantClient, inventory, references, nil); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	encoded, err := json.Marshal(references)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	operationResult, err := utilities.CreateOrUpdateWithConflict(ctx, tenantClient, inventory, func() error {
+		inventory.SetLabels(utilities.MergeMaps(inventory.GetLabels(), utilities.KamajiLabels(), i.labels))
+		inventory.Data = map[string]string{
+			manifestsInventoryResourcesKey: string(encoded),
+			manifestsInventoryModeKey:      string(mode),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return utils.UpdateOperationResult(reconciliationResult, operationResult), nil
+}
+
+// remove prunes all the recorded resources, but the retained ones, along with the inventory:
+// it returns true if the inventory has been deleted.
+func (i *manifestsInventory) remove(ctx context.Context, tenantClient client.Client, retain func(reference corev1.ObjectReference) bool) (bool, error) {
+	inventory := &corev1.ConfigMap{}
+	if err := tenantClient.Get(ctx, k8stypes.NamespacedName{Namespace: kubeadm.KubeSystemNamespace, Name: i.name}, inventory); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if err := pruneInventory(ctx, tenantClient, inventory, nil, retain); err != nil {
+		return false, err
+	}
+
+	if err := tenantClient.Delete(ctx, inventory); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// pruneInventory deletes the resources recorded in the inventory which are not part of the current ones, nor retained:
+// the resources installed once have been handed over to the tenant, thus they're left untouched.
+func pruneInventory(ctx context.Context, tenantClient client.Client, inventory *corev1.ConfigMap, current []corev1.ObjectReference, retain func(reference corev1.ObjectReference) bool) error {
+	if kamajiv1alpha1.AddonReconciliationMode(inventory.Data[manifestsInventoryModeKey]) == kamajiv1alpha1.AddonReconciliationModeInstallOnce {
+		return nil
+	}
+
+	encoded, ok := inventory.Data[manifestsInventoryResourcesKey]
+	if !ok {
+		return nil
+	}
+
+	var previous []corev1.ObjectReference
+	if err := json.Unmarshal([]byte(encoded), &previous); err != nil {
+		return fmt.Errorf("cannot decode the inventory %s: %w", inventory.GetName(), err)
+	}
+
+	retained := make(map[corev1.ObjectReference]struct{}, len(current))
+	for _, reference := range current {
+		retained[reference] = struct{}{}
+	}
+
+	for _, reference := range previous {
+		if _, ok = retained[reference]; ok {
+			continue
+		}
+
+		if retain != nil && retain(reference) {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(reference.APIVersion, reference.Kind))
+		obj.SetNamespace(reference.Namespace)
+		obj.SetName(reference.Name)
+
+		if err := tenantClient.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return fmt.Errorf("cannot prune the %s %s: %w", reference.Kind, reference.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeManifests decodes the multi-document YAML, or JSON, manifests in the order of their keys.
+func decodeManifests(data map[string][]byte) ([]*unstructured.Unstructured, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var objects []*unstructured.Unstructured
+
+	for _, key := range keys {
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data[key]), 4096)
+
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if err == io.EOF {
+					break
+				}
+
+				return nil, fmt.Errorf("cannot decode the manifests of the key %s: %w", key, err)
+			}
+			// Skipping the empty documents.
+			if len(obj.Object) == 0 {
+				continue
+			}
+
+			if len(obj.GetAPIVersion()) == 0 || len(obj.GetKind()) == 0 || len(obj.GetName()) == 0 {
+				return nil, fmt.Errorf("the manifests of the key %s contain a resource with no apiVersion, kind, or name", key)
+			}
+
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects, nil
+}
+
+// setDefaultNamespace places the namespaced resources with no namespace in the default one, as kubectl does.
+func setDefaultNamespace(tenantClient client.Client, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+
+	mapping, err := tenantClient.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("cannot map the %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	switch {
+	case mapping.Scope.Name() != meta.RESTScopeNameNamespace:
+		obj.SetNamespace("")
+	case len(obj.GetNamespace()) == 0:
+		obj.SetNamespace(metav1.NamespaceDefault)
+	}
+
+	return nil
+}
+
+const (
+	// releaseManifestsLimit is the maximum size of the downloaded release manifests.
+	releaseManifestsLimit = 16 << 20
+	// releaseManifestsCacheSize is the maximum number of release manifests kept in memory,
+	// evicting the least recently used ones.
+	releaseManifestsCacheSize = 16
+	// releaseManifestsCacheTTL expires the cached release manifests, such as the ones of a moving tag.
+	releaseManifestsCacheTTL = time.Hour
+)
+
+// DefaultReleaseManifestsHosts are the hosts serving the default release manifests of the addons,
+// including the ones the GitHub release downloads are redirected to.
+var DefaultReleaseManifestsHosts = []string{
+	"github.com",
+	"objects.githubusercontent.com",
+	"release-assets.githubusercontent.com",
+	"raw.githubusercontent.com",
+}
+
+var (
+	// releaseManifests caches the downloaded release manifests by their URL.
+	releaseManifests = cache.NewLRUExpireCache(releaseManifestsCacheSize)
+	// releaseManifestsHosts are the hosts the release manifests can be downloaded from, set upon the start-up.
+	releaseManifestsHosts = sets.NewString(DefaultReleaseManifestsHosts...)
+)
+
+// SetReleaseManifestsAllowedHosts restricts the hosts the release manifests of the addons can be downloaded from,
+// the redirections included, since the manifests URL is declared by the Tenant Control Plane owners.
+func SetReleaseManifestsAllowedHosts(hosts []string) {
+	releaseManifestsHosts = sets.NewString(hosts...)
+}
+
+// checkReleaseManifestsHost returns an error when the given URL is not served by an allowed host, over HTTPS.
+func checkReleaseManifestsHost(manifestsURL *url.URL) error {
+	if manifestsURL.Scheme != "https" {
+		return fmt.Errorf("the release manifests must be downloaded over HTTPS, found %s", manifestsURL.Redacted())
+	}
+
+	if !releaseManifestsHosts.Has(manifestsURL.Hostname()) {
+		return fmt.Errorf("the release manifests host %s is not allowed by the operator", manifestsURL.Hostname())
+	}
+
+	return nil
+}
+
+// releaseManifestsClient is not following the redirections to the hosts not allowed.
+var releaseManifestsClient = &http.Client{
+	CheckRedirect: func(request *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+
+		return checkReleaseManifestsHost(request.URL)
+	},
+}
+
+// fetchReleaseManifests downloads the release manifests of an addon from the given URL, unless already cached.
+func fetchReleaseManifests(ctx context.Context, manifestsURL string) ([]byte, error) {
+	parsedURL, err := url.Parse(manifestsURL)
+	if err != nil {
+		return nil, fmt.Errorf("the release manifests URL is not valid: %w", err)
+	}
+
+	if err = checkReleaseManifestsHost(parsedURL); err != nil {
+		return nil, err
+	}
+
+	if cached, ok := releaseManifests.Get(manifestsURL); ok {
+		return cached.([]byte), nil //nolint:forcetypeassert
+	}
+
+	ctx, cancelFn := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelFn()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := releaseManifestsClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("cannot download the release manifests: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot download the release manifests from %s, unexpected status %d", manifestsURL, response.StatusCode)
+	}
+
+	manifests, err := io.ReadAll(io.LimitReader(response.Body, releaseManifestsLimit))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the release manifests: %w", err)
+	}
+
+	releaseManifests.Add(manifestsURL, manifests, releaseManifestsCacheTTL)
+
+	return manifests, nil
+}
+
+// toUnstructured converts the given typed object, overriding the release manifests, back to an unstructured one:
+// since it's server-side applied, the empty status and creation timestamps are dropped.
+func toUnstructured(object interface{}) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+
+	unstructured.RemoveNestedField(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(content, "spec", "template", "metadata", "creationTimestamp")
+
+	return &unstructured.Unstructured{Object: content}, nil
+}
@@ -0,0 +1,26 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFetchReleaseManifestsAllowedHosts(t *testing.T) {
+	defer SetReleaseManifestsAllowedHosts(DefaultReleaseManifestsHosts)
+
+	SetReleaseManifestsAllowedHosts([]string{"mirror.example.com"})
+
+	for _, manifestsURL := range []string{
+		"https://github.com/cert-manager/cert-manager/releases/download/v1.11.0/cert-manager.yaml",
+		"https://169.254.169.254/latest/meta-data",
+		"http://mirror.example.com/cert-manager.yaml",
+		"https://mirror.example.com.evil.com/cert-manager.yaml",
+	} {
+		if _, err := fetchReleaseManifests(context.Background(), manifestsURL); err == nil {
+			t.Errorf("expected the release manifests URL %s to be refused", manifestsURL)
+		}
+	}
+}
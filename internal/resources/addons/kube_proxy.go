@@ -13,6 +13,8 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeproxyv1alpha1 "k8s.io/kube-proxy/config/v1alpha1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -122,6 +124,13 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 		return controllerutil.OperationResultNone, err
 	}
 
+	switch {
+	case tcp.Spec.Addons.KubeProxy.ReconciliationMode == kamajiv1alpha1.AddonReconciliationModeInstallOnce:
+		return k.applyManifests(ctx, tenantClient, installOnce)
+	case tcp.Spec.Addons.KubeProxy.ServerSideApply:
+		return k.applyManifests(ctx, tenantClient, utilities.ServerSideApply)
+	}
+
 	var operationResult controllerutil.OperationResult
 
 	reconciliationResult := controllerutil.OperationResultNone
@@ -342,6 +351,7 @@ func (k *KubeProxy) mutateDaemonSet(ctx context.Context, tenantClient client.Cli
 		ds.Spec.Template.Spec.ServiceAccountName = k.daemonSet.Spec.Template.Spec.ServiceAccountName
 		ds.Spec.Template.Spec.HostNetwork = k.daemonSet.Spec.Template.Spec.HostNetwork
 		ds.Spec.Template.Spec.Tolerations = k.daemonSet.Spec.Template.Spec.Tolerations
+		ds.Spec.Template.Spec.Affinity = k.daemonSet.Spec.Template.Spec.Affinity
 		ds.Spec.Template.Spec.PriorityClassName = k.daemonSet.Spec.Template.Spec.PriorityClassName
 		ds.Spec.UpdateStrategy.Type = k.daemonSet.Spec.UpdateStrategy.Type
 
@@ -349,6 +359,37 @@ func (k *KubeProxy) mutateDaemonSet(ctx context.Context, tenantClient client.Cli
 	})
 }
 
+// applyManifests applies the decoded manifests with the given function, rather than mutating the single fields of the existing resources:
+// the ClusterRoleBinding goes first, since it's the owner of the other ones.
+func (k *KubeProxy) applyManifests(ctx context.Context, tenantClient client.Client, apply applyFn) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", k.GetName())
+
+	owned := map[client.Object]struct{}{}
+	for _, obj := range []client.Object{k.daemonSet, k.roleBinding, k.role, k.serviceAccount} {
+		owned[obj] = struct{}{}
+	}
+
+	reconciliationResult := controllerutil.OperationResultNone
+
+	for _, obj := range []client.Object{k.clusterRoleBinding, k.daemonSet, k.configMap, k.roleBinding, k.role, k.serviceAccount} {
+		if _, ok := owned[obj]; ok {
+			if err := controllerutil.SetControllerReference(k.clusterRoleBinding, obj, tenantClient.Scheme()); err != nil {
+				return controllerutil.OperationResultNone, err
+			}
+		}
+
+		operationResult, err := apply(ctx, tenantClient, obj)
+		if err != nil {
+			logger.Error(err, "manifest apply failed", "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+	}
+
+	return reconciliationResult, nil
+}
+
 func (k *KubeProxy) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
 	tcpClient, config, err := resources.GetKubeadmManifestDeps(ctx, k.Client, tcp)
 	if err != nil {
@@ -400,5 +441,77 @@ func (k *KubeProxy) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Ten
 		return errors.Wrap(err, "unable to decode DaemonSet manifest")
 	}
 
+	k.applyDaemonSetOverrides(tcp.Spec.Addons.KubeProxy)
+
+	return k.applyConfigurationOverrides(tcp.Spec.Addons.KubeProxy)
+}
+
+// applyDaemonSetOverrides replaces the kubeadm defaults of the kube-proxy DaemonSet with the declared placement,
+// restricting it to the nodes managed along with the Tenant Control Plane.
+func (k *KubeProxy) applyDaemonSetOverrides(spec *kamajiv1alpha1.KubeProxyAddonSpec) {
+	podSpec := &k.daemonSet.Spec.Template.Spec
+
+	if len(spec.Tolerations) > 0 {
+		podSpec.Tolerations = spec.Tolerations
+	}
+
+	if len(spec.NodeSelector) > 0 {
+		podSpec.NodeSelector = spec.NodeSelector
+	}
+
+	if spec.Affinity != nil {
+		podSpec.Affinity = spec.Affinity.DeepCopy()
+	}
+}
+
+// applyConfigurationOverrides patches the KubeProxyConfiguration generated by kubeadm with the options of the addon:
+// the configuration is left untouched when none is declared.
+func (k *KubeProxy) applyConfigurationOverrides(spec *kamajiv1alpha1.KubeProxyAddonSpec) error {
+	if len(spec.Mode) == 0 && len(spec.ClusterCIDR) == 0 && len(spec.MetricsBindAddress) == 0 && spec.Conntrack == nil {
+		return nil
+	}
+
+	config := &kubeproxyv1alpha1.KubeProxyConfiguration{}
+	if err := utilities.DecodeFromYAML(k.configMap.Data[kubeadmconstants.KubeProxyConfigMapKey], config); err != nil {
+		return errors.Wrap(err, "unable to decode the kube-proxy configuration")
+	}
+
+	if len(spec.Mode) > 0 {
+		config.Mode = kubeproxyv1alpha1.ProxyMode(spec.Mode)
+	}
+
+	if len(spec.ClusterCIDR) > 0 {
+		config.ClusterCIDR = spec.ClusterCIDR
+	}
+
+	if len(spec.MetricsBindAddress) > 0 {
+		config.MetricsBindAddress = spec.MetricsBindAddress
+	}
+
+	if conntrack := spec.Conntrack; conntrack != nil {
+		if conntrack.MaxPerCore != nil {
+			config.Conntrack.MaxPerCore = conntrack.MaxPerCore
+		}
+
+		if conntrack.Min != nil {
+			config.Conntrack.Min = conntrack.Min
+		}
+
+		if conntrack.TCPEstablishedTimeout != nil {
+			config.Conntrack.TCPEstablishedTimeout = conntrack.TCPEstablishedTimeout
+		}
+
+		if conntrack.TCPCloseWaitTimeout != nil {
+			config.Conntrack.TCPCloseWaitTimeout = conntrack.TCPCloseWaitTimeout
+		}
+	}
+
+	content, err := utilities.EncodeToYaml(config)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the kube-proxy configuration")
+	}
+
+	k.configMap.Data[kubeadmconstants.KubeProxyConfigMapKey] = string(content)
+
 	return nil
 }
@@ -0,0 +1,216 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// ManifestsAddonLabel labels the Tenant Cluster resources applied by a manifests addon with its name.
+	ManifestsAddonLabel = "addons.kamaji.clastix.io/manifests"
+	// ManifestsInventoryLabel labels the inventory ConfigMap of a manifests addon with its name.
+	ManifestsInventoryLabel = "addons.kamaji.clastix.io/manifests-inventory"
+)
+
+// ManifestsInventoryName returns the name of the ConfigMap, in the kube-system namespace of the Tenant Cluster,
+// keeping track of the resources applied by the given manifests addon.
+func ManifestsInventoryName(addon string) string {
+	return fmt.Sprintf("kamaji-manifests-%s", addon)
+}
+
+// Manifests applies the user-provided manifests to the Tenant Cluster: the applied resources are recorded in an inventory,
+// letting the enforced addons prune the ones removed from the manifests, or all of them once the addon is removed.
+type Manifests struct {
+	Client client.Client
+
+	statuses []kamajiv1alpha1.ManifestsAddonStatus
+}
+
+func (m *Manifests) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	m.statuses = nil
+
+	return nil
+}
+
+func (m *Manifests) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (m *Manifests) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (m *Manifests) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if len(tcp.Spec.Addons.Manifests) == 0 && len(tcp.Status.Addons.Manifests) == 0 {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	logger := log.FromContext(ctx, "addon", m.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, m.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	reconciliationResult := controllerutil.OperationResultNone
+
+	declared := map[string]struct{}{}
+
+	for _, addon := range tcp.Spec.Addons.Manifests {
+		declared[addon.Name] = struct{}{}
+
+		operationResult, status, applyErr := m.apply(ctx, tenantClient, tcp, addon)
+		if applyErr != nil {
+			logger.Error(applyErr, "manifests apply failed", "name", addon.Name)
+
+			return controllerutil.OperationResultNone, applyErr
+		}
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+
+		m.statuses = append(m.statuses, status)
+	}
+	// Pruning the resources of the removed addons, along with their inventory.
+	inventories := &corev1.ConfigMapList{}
+	if err = tenantClient.List(ctx, inventories, client.InNamespace(kubeadm.KubeSystemNamespace), client.HasLabels{ManifestsInventoryLabel}); err != nil {
+		logger.Error(err, "cannot list the manifests inventories")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	for i := range inventories.Items {
+		inventory := inventories.Items[i]
+
+		if _, ok := declared[inventory.GetLabels()[ManifestsInventoryLabel]]; ok {
+			continue
+		}
+
+		if err = pruneInventory(ctx, tenantClient, &inventory, nil, nil); err != nil {
+			logger.Error(err, "cannot prune the removed manifests", "name", inventory.GetLabels()[ManifestsInventoryLabel])
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		if err = tenantClient.Delete(ctx, &inventory); err != nil && !k8serrors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, controllerutil.OperationResultUpdated)
+	}
+
+	return reconciliationResult, nil
+}
+
+func (m *Manifests) GetName() string {
+	return "manifests"
+}
+
+func (m *Manifests) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	if len(m.statuses) != len(tcp.Status.Addons.Manifests) {
+		return true
+	}
+
+	for i, status := range m.statuses {
+		current := tcp.Status.Addons.Manifests[i]
+
+		if current.Name != status.Name || current.Checksum != status.Checksum || current.Resources != status.Resources {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Manifests) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	current := map[string]kamajiv1alpha1.ManifestsAddonStatus{}
+	for _, status := range tcp.Status.Addons.Manifests {
+		current[status.Name] = status
+	}
+
+	statuses := make([]kamajiv1alpha1.ManifestsAddonStatus, 0, len(m.statuses))
+
+	for _, status := range m.statuses {
+		if previous, ok := current[status.Name]; ok && previous.Checksum == status.Checksum && previous.Resources == status.Resources {
+			status.LastUpdate = previous.LastUpdate
+		} else {
+			status.LastUpdate = metav1.Now()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	tcp.Status.Addons.Manifests = statuses
+
+	return nil
+}
+
+// apply decodes the manifests of the given addon, applying them to the Tenant Cluster according to its reconciliation mode.
+func (m *Manifests) apply(ctx context.Context, tenantClient client.Client, tcp *kamajiv1alpha1.TenantControlPlane, addon kamajiv1alpha1.ManifestsAddonSpec) (controllerutil.OperationResult, kamajiv1alpha1.ManifestsAddonStatus, error) {
+	status := kamajiv1alpha1.ManifestsAddonStatus{Name: addon.Name}
+
+	data, err := m.source(ctx, tcp, addon)
+	if err != nil {
+		return controllerutil.OperationResultNone, status, err
+	}
+
+	objects, err := decodeManifests(data)
+	if err != nil {
+		return controllerutil.OperationResultNone, status, err
+	}
+
+	inventory := &manifestsInventory{
+		name:   ManifestsInventoryName(addon.Name),
+		labels: map[string]string{ManifestsInventoryLabel: addon.Name},
+	}
+
+	reconciliationResult, err := inventory.apply(ctx, tenantClient, objects, map[string]string{ManifestsAddonLabel: addon.Name}, addon.ReconciliationMode)
+	if err != nil {
+		return controllerutil.OperationResultNone, status, err
+	}
+
+	status.Checksum = utilities.CalculateMapChecksum(data)
+	status.Resources = int32(len(objects))
+
+	return reconciliationResult, status, nil
+}
+
+// source returns the manifests stored in the referenced ConfigMap, or Secret.
+func (m *Manifests) source(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, addon kamajiv1alpha1.ManifestsAddonSpec) (map[string][]byte, error) {
+	if ref := addon.SecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := m.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("cannot retrieve the manifests Secret %s: %w", ref.Name, err)
+		}
+
+		return secret.Data, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := m.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.GetNamespace(), Name: addon.ConfigMapRef.Name}, configMap); err != nil {
+		return nil, fmt.Errorf("cannot retrieve the manifests ConfigMap %s: %w", addon.ConfigMapRef.Name, err)
+	}
+
+	data := make(map[string][]byte, len(configMap.Data))
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+
+	return data, nil
+}
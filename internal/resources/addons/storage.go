@@ -0,0 +1,224 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// StorageInventoryLabel labels the inventory ConfigMap of the storage addon.
+	StorageInventoryLabel = "addons.kamaji.clastix.io/storage-inventory"
+	// StorageTemplateLabel labels the ConfigMaps of the Kamaji namespace which can be used as storage templates,
+	// preventing the Tenant Control Plane owners from installing any other ConfigMap.
+	StorageTemplateLabel = "addons.kamaji.clastix.io/storage-template"
+
+	storageAddonLabel    = "addons.kamaji.clastix.io/storage"
+	storageInventoryName = "kamaji-storage"
+	// defaultStorageClassAnnotation marks the default StorageClass of the cluster.
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+)
+
+// Storage installs the CSI driver of the storage template provided by the operator, along with the declared StorageClass
+// objects, in the Tenant Cluster: the changes of the template are applied upon the next reconciliation of the addon.
+type Storage struct {
+	Client client.Client
+	// TemplatesNamespace is the namespace of the storage templates ConfigMaps.
+	TemplatesNamespace string
+}
+
+// storageTemplateData are the values available to the storage templates.
+type storageTemplateData struct {
+	Name       string
+	Namespace  string
+	Version    string
+	Parameters map[string]string
+}
+
+func (s *Storage) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (s *Storage) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.Storage == nil && tcp.Status.Addons.Storage.Enabled
+}
+
+func (s *Storage) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", s.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, s.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if _, err = s.inventory().remove(ctx, tenantClient, func(reference corev1.ObjectReference) bool {
+		return reference.Kind == "CustomResourceDefinition"
+	}); err != nil {
+		logger.Error(err, "cannot remove the storage resources")
+
+		return false, err
+	}
+	// The status must be updated regardless of the inventory, which could have been already deleted.
+	return true, nil
+}
+
+func (s *Storage) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", s.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, s.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	spec := tcp.Spec.Addons.Storage
+
+	objects, err := s.decodeManifests(ctx, tcp, spec)
+	if err != nil {
+		logger.Error(err, "manifest decoding failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	return s.inventory().apply(ctx, tenantClient, objects, map[string]string{storageAddonLabel: "true"}, spec.ReconciliationMode)
+}
+
+func (s *Storage) GetName() string {
+	return "storage"
+}
+
+func (s *Storage) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return (tcp.Spec.Addons.Storage != nil) != tcp.Status.Addons.Storage.Enabled
+}
+
+func (s *Storage) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.Storage.Enabled = tcp.Spec.Addons.Storage != nil
+	tcp.Status.Addons.Storage.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+func (s *Storage) inventory() *manifestsInventory {
+	return &manifestsInventory{
+		name:   storageInventoryName,
+		labels: map[string]string{StorageInventoryLabel: "true"},
+	}
+}
+
+func (s *Storage) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, spec *kamajiv1alpha1.StorageAddonSpec) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	if len(spec.Template) > 0 {
+		manifests, err := s.renderTemplate(ctx, tcp, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if objects, err = decodeManifests(manifests); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, storageClass := range spec.StorageClasses {
+		object, err := toUnstructured(s.storageClass(storageClass))
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert the StorageClass %s: %w", storageClass.Name, err)
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// renderTemplate returns the manifests of the referenced storage template, rendered with the Tenant Control Plane values.
+func (s *Storage) renderTemplate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, spec *kamajiv1alpha1.StorageAddonSpec) (map[string][]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, k8stypes.NamespacedName{Namespace: s.TemplatesNamespace, Name: spec.Template}, configMap); err != nil {
+		return nil, fmt.Errorf("cannot retrieve the storage template %s: %w", spec.Template, err)
+	}
+
+	if _, ok := configMap.GetLabels()[StorageTemplateLabel]; !ok {
+		return nil, fmt.Errorf("the ConfigMap %s is not a storage template, missing the %s label", spec.Template, StorageTemplateLabel)
+	}
+
+	data := storageTemplateData{
+		Name:       tcp.GetName(),
+		Namespace:  tcp.GetNamespace(),
+		Version:    tcp.Spec.Kubernetes.Version,
+		Parameters: spec.Parameters,
+	}
+
+	keys := make([]string, 0, len(configMap.Data))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	manifests := make(map[string][]byte, len(keys))
+
+	for _, key := range keys {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(configMap.Data[key])
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse the storage template %s, key %s: %w", spec.Template, key, err)
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err = tmpl.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("cannot render the storage template %s, key %s: %w", spec.Template, key, err)
+		}
+
+		manifests[key] = buf.Bytes()
+	}
+
+	return manifests, nil
+}
+
+func (s *Storage) storageClass(spec kamajiv1alpha1.StorageClassSpec) *storagev1.StorageClass {
+	storageClass := &storagev1.StorageClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: storagev1.SchemeGroupVersion.String(),
+			Kind:       "StorageClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spec.Name,
+			Annotations: map[string]string{
+				defaultStorageClassAnnotation: strconv.FormatBool(spec.Default),
+			},
+		},
+		Provisioner:          spec.Provisioner,
+		Parameters:           spec.Parameters,
+		ReclaimPolicy:        spec.ReclaimPolicy,
+		AllowVolumeExpansion: spec.AllowVolumeExpansion,
+		MountOptions:         spec.MountOptions,
+	}
+
+	if spec.VolumeBindingMode != nil {
+		mode := storagev1.VolumeBindingMode(*spec.VolumeBindingMode)
+		storageClass.VolumeBindingMode = &mode
+	}
+
+	return storageClass
+}
@@ -7,10 +7,12 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,9 +26,14 @@ import (
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
+// CertificateSANDriftReason is the reason of the events recorded when a certificate is issued again
+// since its Subject Alternative Names are not covering the Tenant Control Plane addresses anymore.
+const CertificateSANDriftReason = "CertificateSANDrift"
+
 type APIServerCertificate struct {
 	resource     *corev1.Secret
 	Client       client.Client
+	Recorder     record.EventRecorder
 	TmpDirectory string
 }
 
@@ -108,7 +115,7 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerCertAndKeyBaseName, err.Error()))
 			}
 
-			if isCAValid && isCertValid {
+			if isCAValid && isCertValid && !r.hasSANDrift(ctx, tenantControlPlane) {
 				return nil
 			}
 		}
@@ -155,3 +162,38 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
+
+// hasSANDrift checks if the Subject Alternative Names of the certificate are still covering the ones of the kubeadm
+// configuration, which are changing along with the Service addresses, such as the IP assigned by the load balancer:
+// the drift is recorded as an event of the Tenant Control Plane, since the certificate is going to be issued again.
+func (r *APIServerCertificate) hasSANDrift(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	config, err := getStoredKubeadmConfiguration(ctx, r.Client, r.TmpDirectory, tenantControlPlane)
+	if err != nil {
+		logger.Info(fmt.Sprintf("cannot retrieve kubeadm configuration for the SAN drift detection: %s", err.Error()))
+
+		return false
+	}
+
+	missing, err := crypto.MissingSubjectAlternativeNames(r.resource.Data[kubeadmconstants.APIServerCertName], config.InitConfiguration.APIServer.CertSANs...)
+	if err != nil {
+		logger.Info(fmt.Sprintf("cannot verify the certificate SANs: %s", err.Error()))
+
+		return false
+	}
+
+	if len(missing) == 0 {
+		return false
+	}
+
+	message := fmt.Sprintf("The %s certificate is not covering the SANs %s, issuing it again", kubeadmconstants.APIServerCertAndKeyBaseName, strings.Join(missing, ", "))
+
+	logger.Info(message)
+
+	if r.Recorder != nil {
+		r.Recorder.Event(tenantControlPlane, corev1.EventTypeWarning, CertificateSANDriftReason, message)
+	}
+
+	return true
+}
@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
 	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/crypto"
 	"github.com/clastix/kamaji/internal/kubeadm"
@@ -84,58 +85,33 @@ func (r *APIServerKubeletClientCertificate) UpdateTenantControlPlaneStatus(_ con
 func (r *APIServerKubeletClientCertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
-		// Retrieving the TenantControlPlane CA:
-		// this is required to trigger a new generation in case of Certificate Authority rotation.
-		namespacedName := k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}
-		secretCA := &corev1.Secret{}
-		if err := r.Client.Get(ctx, namespacedName, secretCA); err != nil {
-			logger.Error(err, "cannot retrieve CA secret")
 
-			return err
-		}
+		var data map[string][]byte
 
-		if checksum := tenantControlPlane.Status.Certificates.APIServerKubeletClient.Checksum; len(checksum) > 0 && checksum == r.resource.GetAnnotations()[constants.Checksum] {
-			isCAValid, err := crypto.VerifyCertificate(r.resource.Data[kubeadmconstants.APIServerKubeletClientCertName], secretCA.Data[kubeadmconstants.CACertName], x509.ExtKeyUsageClientAuth)
-			if err != nil {
-				logger.Info(fmt.Sprintf("certificate-authority verify failed: %s", err.Error()))
-			}
+		var err error
 
-			isValid, err := crypto.CheckCertificateAndPrivateKeyPairValidity(
-				r.resource.Data[kubeadmconstants.APIServerKubeletClientCertName],
-				r.resource.Data[kubeadmconstants.APIServerKubeletClientKeyName],
-			)
-			if err != nil {
-				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName, err.Error()))
-			}
+		kubeletTLS := tenantControlPlane.Spec.Kubernetes.Kubelet.TLS
 
-			if isValid && isCAValid {
-				return nil
-			}
+		switch {
+		case kubeletTLS != nil && kubeletTLS.ClientCertificate != nil:
+			data, err = r.externalCertificate(ctx, kubeletTLS.ClientCertificate)
+		default:
+			data, err = r.signedCertificate(ctx, tenantControlPlane)
 		}
 
-		config, err := getStoredKubeadmConfiguration(ctx, r.Client, r.TmpDirectory, tenantControlPlane)
 		if err != nil {
-			logger.Error(err, "cannot retrieve kubeadm configuration")
-
 			return err
 		}
 
-		ca := kubeadm.CertificatePrivateKeyPair{
-			Name:        kubeadmconstants.CACertAndKeyBaseName,
-			Certificate: secretCA.Data[kubeadmconstants.CACertName],
-			PrivateKey:  secretCA.Data[kubeadmconstants.CAKeyName],
-		}
-		certificateKeyPair, err := kubeadm.GenerateCertificatePrivateKeyPair(kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName, config, ca)
-		if err != nil {
-			logger.Error(err, "cannot generate certificate and private key")
+		if kubeletTLS != nil && kubeletTLS.CertificateAuthority != nil {
+			if data[builder.KubeletCACertName], err = kubeletTLS.CertificateAuthority.GetContent(ctx, r.Client); err != nil {
+				logger.Error(err, "cannot retrieve the kubelet Certificate Authority content")
 
-			return err
+				return err
+			}
 		}
 
-		r.resource.Data = map[string][]byte{
-			kubeadmconstants.APIServerKubeletClientCertName: certificateKeyPair.Certificate,
-			kubeadmconstants.APIServerKubeletClientKeyName:  certificateKeyPair.PrivateKey,
-		}
+		r.resource.Data = data
 
 		r.resource.SetLabels(utilities.MergeMaps(
 			utilities.KamajiLabels(),
@@ -155,3 +131,93 @@ func (r *APIServerKubeletClientCertificate) mutate(ctx context.Context, tenantCo
 		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
+
+// externalCertificate returns the client certificate and private key supplied by the user,
+// used when the kubelets are trusting an external Certificate Authority.
+func (r *APIServerKubeletClientCertificate) externalCertificate(ctx context.Context, clientCertificate *kamajiv1alpha1.ClientCertificate) (map[string][]byte, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	crt, err := clientCertificate.Certificate.GetContent(ctx, r.Client)
+	if err != nil {
+		logger.Error(err, "cannot retrieve the kubelet client certificate content")
+
+		return nil, err
+	}
+
+	key, err := clientCertificate.PrivateKey.GetContent(ctx, r.Client)
+	if err != nil {
+		logger.Error(err, "cannot retrieve the kubelet client private key content")
+
+		return nil, err
+	}
+
+	if isValid, err := crypto.CheckCertificateAndPrivateKeyPairValidity(crt, key); !isValid {
+		return nil, fmt.Errorf("the kubelet client certificate-private_key pair is not valid: %w", err)
+	}
+
+	return map[string][]byte{
+		kubeadmconstants.APIServerKubeletClientCertName: crt,
+		kubeadmconstants.APIServerKubeletClientKeyName:  key,
+	}, nil
+}
+
+// signedCertificate returns the client certificate and private key signed by the Tenant Control Plane CA,
+// generating a new pair only if the current one is not valid anymore.
+func (r *APIServerKubeletClientCertificate) signedCertificate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (map[string][]byte, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+	// Retrieving the TenantControlPlane CA:
+	// this is required to trigger a new generation in case of Certificate Authority rotation.
+	namespacedName := k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}
+	secretCA := &corev1.Secret{}
+	if err := r.Client.Get(ctx, namespacedName, secretCA); err != nil {
+		logger.Error(err, "cannot retrieve CA secret")
+
+		return nil, err
+	}
+
+	if checksum := tenantControlPlane.Status.Certificates.APIServerKubeletClient.Checksum; len(checksum) > 0 && checksum == r.resource.GetAnnotations()[constants.Checksum] {
+		isCAValid, err := crypto.VerifyCertificate(r.resource.Data[kubeadmconstants.APIServerKubeletClientCertName], secretCA.Data[kubeadmconstants.CACertName], x509.ExtKeyUsageClientAuth)
+		if err != nil {
+			logger.Info(fmt.Sprintf("certificate-authority verify failed: %s", err.Error()))
+		}
+
+		isValid, err := crypto.CheckCertificateAndPrivateKeyPairValidity(
+			r.resource.Data[kubeadmconstants.APIServerKubeletClientCertName],
+			r.resource.Data[kubeadmconstants.APIServerKubeletClientKeyName],
+		)
+		if err != nil {
+			logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName, err.Error()))
+		}
+
+		if isValid && isCAValid {
+			return map[string][]byte{
+				kubeadmconstants.APIServerKubeletClientCertName: r.resource.Data[kubeadmconstants.APIServerKubeletClientCertName],
+				kubeadmconstants.APIServerKubeletClientKeyName:  r.resource.Data[kubeadmconstants.APIServerKubeletClientKeyName],
+			}, nil
+		}
+	}
+
+	config, err := getStoredKubeadmConfiguration(ctx, r.Client, r.TmpDirectory, tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "cannot retrieve kubeadm configuration")
+
+		return nil, err
+	}
+
+	ca := kubeadm.CertificatePrivateKeyPair{
+		Name:        kubeadmconstants.CACertAndKeyBaseName,
+		Certificate: secretCA.Data[kubeadmconstants.CACertName],
+		PrivateKey:  secretCA.Data[kubeadmconstants.CAKeyName],
+	}
+	certificateKeyPair, err := kubeadm.GenerateCertificatePrivateKeyPair(kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName, config, ca)
+	if err != nil {
+		logger.Error(err, "cannot generate certificate and private key")
+
+		return nil, err
+	}
+
+	return map[string][]byte{
+		kubeadmconstants.APIServerKubeletClientCertName: certificateKeyPair.Certificate,
+		kubeadmconstants.APIServerKubeletClientKeyName:  certificateKeyPair.PrivateKey,
+	}, nil
+}
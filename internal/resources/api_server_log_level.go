@@ -0,0 +1,194 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	restclient "k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// maxAPIServerLogLevel is the greatest verbosity accepted by the API Server.
+const maxAPIServerLogLevel = 10
+
+// APIServerLogLevel changes the verbosity of the running API Server instances when requested with the APIServerLogLevelAnnotation,
+// using the dynamic /debug/flags/v endpoint of each Pod, rather than rolling out the Deployment.
+// The change is not persisted: the Pods started afterwards use the verbosity declared in the API Server extra arguments.
+type APIServerLogLevel struct {
+	Client client.Client
+
+	condition *metav1.Condition
+}
+
+func (r *APIServerLogLevel) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	r.condition = nil
+
+	return nil
+}
+
+func (r *APIServerLogLevel) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *APIServerLogLevel) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *APIServerLogLevel) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	level, ok := tenantControlPlane.GetAnnotations()[kamajiv1alpha1.APIServerLogLevelAnnotation]
+	if !ok {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.condition = &metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeAPIServerLogLevelChanged,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             "Applied",
+	}
+
+	if v, err := strconv.Atoi(level); err != nil || v < 0 || v > maxAPIServerLogLevel {
+		r.condition.Status = metav1.ConditionFalse
+		r.condition.Reason = "InvalidLevel"
+		r.condition.Message = fmt.Sprintf("the log level %q must be an integer between 0 and %d", level, maxAPIServerLogLevel)
+	} else {
+		applied, failures, applyErr := r.apply(ctx, tenantControlPlane, level)
+		if applyErr != nil {
+			logger.Error(applyErr, "cannot change the API Server log level")
+
+			return controllerutil.OperationResultNone, applyErr
+		}
+
+		r.condition.Message = fmt.Sprintf("log level set to %s on %d API Server instances", level, applied)
+
+		if len(failures) > 0 {
+			r.condition.Status = metav1.ConditionFalse
+			r.condition.Reason = "Failed"
+			r.condition.Message = fmt.Sprintf("log level set to %s on %d API Server instances, failed on: %s", level, applied, strings.Join(failures, "; "))
+		}
+	}
+	// The request is removed once performed, even if partially failed: the Pods could not be running anymore,
+	// and the users can request it again.
+	patch := client.MergeFrom(tenantControlPlane.DeepCopy())
+
+	annotations := tenantControlPlane.GetAnnotations()
+	delete(annotations, kamajiv1alpha1.APIServerLogLevelAnnotation)
+	tenantControlPlane.SetAnnotations(annotations)
+
+	if err := r.Client.Patch(ctx, tenantControlPlane, patch); err != nil {
+		logger.Error(err, "cannot remove the API Server log level annotation")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	logger.Info("API Server log level has been changed", "level", level, "result", r.condition.Reason)
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// apply sends the verbosity to each running API Server Pod, returning the number of the updated ones,
+// along with the failures: an error is returned only when the Pods cannot be reached at all.
+func (r *APIServerLogLevel) apply(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, level string) (int, []string, error) {
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.GetName()}, deployment); err != nil {
+		return 0, nil, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	pods := &corev1.PodList{}
+	if err = r.Client.List(ctx, pods, client.InNamespace(tenantControlPlane.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, nil, err
+	}
+
+	config, err := utilities.GetRESTClientConfig(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		return 0, nil, err
+	}
+	// The Pods are dialled by IP, which is not part of the API Server certificate.
+	config.TLSClientConfig.ServerName = "kubernetes"
+
+	httpClient, err := restclient.HTTPClientFor(config)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var applied int
+
+	var failures []string
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.GetDeletionTimestamp() != nil || len(pod.Status.PodIP) == 0 {
+			continue
+		}
+
+		if err = r.put(ctx, httpClient, fmt.Sprintf("https://%s:%d/debug/flags/v", pod.Status.PodIP, tenantControlPlane.Spec.NetworkProfile.Port), level); err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s)", pod.GetName(), err.Error()))
+
+			continue
+		}
+
+		applied++
+	}
+
+	return applied, failures, nil
+}
+
+func (r *APIServerLogLevel) put(ctx context.Context, httpClient *http.Client, url, level string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader(level))
+	if err != nil {
+		return err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+
+		return fmt.Errorf("unexpected status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+func (r *APIServerLogLevel) GetName() string {
+	return "apiserver-log-level"
+}
+
+func (r *APIServerLogLevel) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return r.condition != nil
+}
+
+func (r *APIServerLogLevel) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.condition != nil {
+		meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, *r.condition)
+	}
+
+	return nil
+}
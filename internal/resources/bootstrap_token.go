@@ -0,0 +1,258 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	bootstraputil "k8s.io/cluster-bootstrap/token/util"
+	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pubkeypin"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// BootstrapTokenRotationLabel labels the bootstrap token Secrets generated in the Tenant Cluster by the rotation.
+	BootstrapTokenRotationLabel = "kamaji.clastix.io/bootstrap-token-rotation"
+
+	JoinCommandTokenKey       = "token"
+	JoinCommandCACertHashKey  = "ca-cert-hash"
+	JoinCommandEndpointKey    = "endpoint"
+	JoinCommandKubeadmJoinKey = "join-command"
+)
+
+// BootstrapTokenRotation generates the bootstrap tokens of the Tenant Cluster, a new one being created once the current
+// is about to expire, and publishes the current one, along with the kubeadm join command, in the <name>-join-command Secret:
+// the expired tokens are deleted by the token cleaner of the kube-controller-manager.
+type BootstrapTokenRotation struct {
+	Client client.Client
+
+	status *kamajiv1alpha1.JoinCommandStatus
+}
+
+func (r *BootstrapTokenRotation) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	r.status = nil
+
+	return nil
+}
+
+func (r *BootstrapTokenRotation) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.BootstrapTokenRotation() == nil && tenantControlPlane.Status.KubeadmPhase.JoinCommand != nil
+}
+
+func (r *BootstrapTokenRotation) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+	// The generated bootstrap tokens are left in the Tenant Cluster until their expiration.
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tenantControlPlane.Status.KubeadmPhase.JoinCommand.SecretName,
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	if err := r.Client.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the join command Secret")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *BootstrapTokenRotation) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	rotation := tenantControlPlane.BootstrapTokenRotation()
+	if rotation == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	token, err := r.currentToken(ctx, tenantClient, rotation)
+	if err != nil {
+		logger.Error(err, "cannot retrieve the current bootstrap token")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	tokenResult := controllerutil.OperationResultNone
+
+	if token == nil {
+		if token, err = r.generateToken(ctx, tenantClient, rotation); err != nil {
+			logger.Error(err, "cannot generate the bootstrap token")
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		tokenResult = controllerutil.OperationResultCreated
+	}
+
+	caCertHash, err := r.caCertHash(ctx, tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "cannot compute the CA certificate hash")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	endpoint := tenantControlPlane.Status.ControlPlaneEndpoint
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix("join-command", tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	secretResult, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels()))
+		secret.Data = map[string][]byte{
+			JoinCommandTokenKey:      []byte(token.Token.String()),
+			JoinCommandCACertHashKey: []byte(caCertHash),
+			JoinCommandEndpointKey:   []byte(endpoint),
+			JoinCommandKubeadmJoinKey: []byte(fmt.Sprintf("kubeadm join %s --token %s --discovery-token-ca-cert-hash %s",
+				endpoint, token.Token.String(), caCertHash)),
+		}
+
+		return ctrl.SetControllerReference(tenantControlPlane, secret, r.Client.Scheme())
+	})
+	if err != nil {
+		logger.Error(err, "cannot publish the join command")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	r.status = &kamajiv1alpha1.JoinCommandStatus{
+		SecretName: secret.GetName(),
+		TokenID:    token.Token.ID,
+		Expiration: *token.Expires,
+	}
+
+	if tokenResult != controllerutil.OperationResultNone {
+		return tokenResult, nil
+	}
+
+	return secretResult, nil
+}
+
+// currentToken returns the generated bootstrap token with the latest expiration, unless it's about to expire.
+func (r *BootstrapTokenRotation) currentToken(ctx context.Context, tenantClient client.Client, rotation *kamajiv1alpha1.BootstrapTokenSpec) (*bootstraptokenv1.BootstrapToken, error) {
+	secrets := &corev1.SecretList{}
+	if err := tenantClient.List(ctx, secrets, client.InNamespace(metav1.NamespaceSystem), client.HasLabels{BootstrapTokenRotationLabel}); err != nil {
+		return nil, err
+	}
+
+	var current *bootstraptokenv1.BootstrapToken
+
+	for i := range secrets.Items {
+		token, err := bootstraptokenv1.BootstrapTokenFromSecret(&secrets.Items[i])
+		if err != nil || token.Expires == nil {
+			continue
+		}
+
+		if current == nil || token.Expires.After(current.Expires.Time) {
+			current = token
+		}
+	}
+
+	if current == nil || time.Until(current.Expires.Time) <= rotation.RenewBefore.Duration {
+		return nil, nil
+	}
+
+	return current, nil
+}
+
+func (r *BootstrapTokenRotation) generateToken(ctx context.Context, tenantClient client.Client, rotation *kamajiv1alpha1.BootstrapTokenSpec) (*bootstraptokenv1.BootstrapToken, error) {
+	value, err := bootstraputil.GenerateBootstrapToken()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenString, err := bootstraptokenv1.NewBootstrapTokenString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &bootstraptokenv1.BootstrapToken{
+		Token:       tokenString,
+		Description: "Generated by Kamaji for the nodes joining the Tenant Cluster.",
+		TTL:         &metav1.Duration{Duration: rotation.TTL.Duration},
+		Usages:      []string{bootstrapapi.BootstrapTokenUsageSigningKey, bootstrapapi.BootstrapTokenUsageAuthentication},
+		Groups:      []string{constants.NodeBootstrapTokenAuthGroup},
+	}
+
+	secret := bootstraptokenv1.BootstrapTokenToSecret(token)
+	secret.SetLabels(map[string]string{BootstrapTokenRotationLabel: "true"})
+
+	if err = tenantClient.Create(ctx, secret); err != nil {
+		return nil, err
+	}
+	// The expiration is computed upon the Secret generation, thus it must be read back.
+	return bootstraptokenv1.BootstrapTokenFromSecret(secret)
+}
+
+// caCertHash returns the hash of the Tenant Cluster CA public key, used by the nodes to validate the cluster-info discovery.
+func (r *BootstrapTokenRotation) caCertHash(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	kubeconfig, err := utilities.GetTenantKubeconfig(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		return "", err
+	}
+
+	if len(kubeconfig.Clusters) == 0 {
+		return "", fmt.Errorf("the admin kubeconfig contains no cluster")
+	}
+
+	certificate, err := crypto.ParseCertificateBytes(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData)
+	if err != nil {
+		return "", err
+	}
+
+	return pubkeypin.Hash(certificate), nil
+}
+
+func (r *BootstrapTokenRotation) GetName() string {
+	return "bootstrap-token-rotation"
+}
+
+func (r *BootstrapTokenRotation) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	current := tenantControlPlane.Status.KubeadmPhase.JoinCommand
+
+	if r.status == nil || current == nil {
+		return (r.status == nil) != (current == nil)
+	}
+
+	return current.SecretName != r.status.SecretName || current.TokenID != r.status.TokenID || !current.Expiration.Equal(&r.status.Expiration)
+}
+
+func (r *BootstrapTokenRotation) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.status == nil {
+		tenantControlPlane.Status.KubeadmPhase.JoinCommand = nil
+
+		return nil
+	}
+
+	r.status.LastUpdate = metav1.Now()
+	tenantControlPlane.Status.KubeadmPhase.JoinCommand = r.status
+
+	return nil
+}
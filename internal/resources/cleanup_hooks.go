@@ -0,0 +1,307 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// CleanupHookComponent is the component label of the clean-up hook Jobs.
+const CleanupHookComponent = "cleanup-hook"
+
+// CleanupHooksResource performs the clean-up hooks declared by the Tenant Control Plane upon its deletion, in order:
+// the deletion doesn't proceed until each of them is completed, reporting the progress in the status.
+type CleanupHooksResource struct {
+	Client client.Client
+	// JobPolicy lists the images, and the ServiceAccounts, allowed for the Jobs.
+	JobPolicy kamajiv1alpha1.CleanupHookJobPolicy
+}
+
+func (r *CleanupHooksResource) GetName() string {
+	return "cleanup-hooks"
+}
+
+func (r *CleanupHooksResource) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *CleanupHooksResource) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	for _, hook := range tenantControlPlane.Spec.CleanupHooks {
+		previous := make([]kamajiv1alpha1.CleanupHookStatus, 0, len(tenantControlPlane.Status.CleanupHooks))
+		for _, status := range tenantControlPlane.Status.CleanupHooks {
+			previous = append(previous, *status.DeepCopy())
+		}
+
+		status := tenantControlPlane.CleanupHookStatus(hook.Name)
+		if status.CompletionTime != nil {
+			continue
+		}
+
+		var done bool
+
+		var err error
+
+		switch {
+		case hook.Webhook != nil:
+			done, err = r.webhook(ctx, tenantControlPlane, hook, status)
+		case hook.Job != nil:
+			done, err = r.job(ctx, tenantControlPlane, hook, status)
+		}
+
+		if !reflect.DeepEqual(previous, tenantControlPlane.Status.CleanupHooks) {
+			if updateErr := r.Client.Status().Update(ctx, tenantControlPlane); updateErr != nil {
+				logger.Error(updateErr, "cannot update the clean-up hooks status")
+
+				return updateErr
+			}
+		}
+
+		if err != nil {
+			logger.Error(err, "clean-up hook failed", "hook", hook.Name)
+
+			return err
+		}
+
+		if !done {
+			return kamajierrors.CleanupHookPendingError{Hook: hook.Name}
+		}
+
+		logger.Info("clean-up hook completed", "hook", hook.Name, "result", status.Result)
+	}
+
+	return nil
+}
+
+// complete marks the hook as completed with the given result.
+func (r *CleanupHooksResource) complete(status *kamajiv1alpha1.CleanupHookStatus, result kamajiv1alpha1.CleanupHookResult, message string) {
+	now := metav1.Now()
+
+	status.Result = result
+	status.Message = message
+	status.CompletionTime = &now
+}
+
+// fail reports the failed attempt: the hook is completed only with the Ignore policy, otherwise it's retried.
+func (r *CleanupHooksResource) fail(hook kamajiv1alpha1.CleanupHook, status *kamajiv1alpha1.CleanupHookStatus, message string) bool {
+	if hook.FailurePolicy == kamajiv1alpha1.CleanupHookFailurePolicyIgnore {
+		r.complete(status, kamajiv1alpha1.CleanupHookResultFailed, message)
+
+		return true
+	}
+
+	status.Message = message
+
+	return false
+}
+
+func (r *CleanupHooksResource) webhook(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, hook kamajiv1alpha1.CleanupHook, status *kamajiv1alpha1.CleanupHookStatus) (bool, error) {
+	now := metav1.Now()
+
+	status.Attempts++
+	status.StartTime = &now
+
+	if err := r.call(ctx, tenantControlPlane, hook); err != nil {
+		if r.fail(hook, status, err.Error()) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	r.complete(status, kamajiv1alpha1.CleanupHookResultSucceeded, "")
+
+	return true, nil
+}
+
+func (r *CleanupHooksResource) call(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, hook kamajiv1alpha1.CleanupHook) error {
+	payload, err := json.Marshal(map[string]string{
+		"hook":      hook.Name,
+		"namespace": tenantControlPlane.GetNamespace(),
+		"name":      tenantControlPlane.GetName(),
+		"uid":       string(tenantControlPlane.GetUID()),
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.Webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if ref := hook.Webhook.AuthorizationSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err = r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: ref.Name}, secret); err != nil {
+			return fmt.Errorf("cannot retrieve the authorization Secret: %w", err)
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return fmt.Errorf("the authorization Secret %s is missing the %s key", ref.Name, ref.Key)
+		}
+
+		request.Header.Set("Authorization", strings.TrimSpace(string(value)))
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(hook.Webhook.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(hook.Webhook.CABundle) {
+			return fmt.Errorf("the CA bundle doesn't contain any valid certificate")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	timeout := hook.Timeout.Duration
+	if timeout > kamajiv1alpha1.CleanupHookWebhookMaxTimeout {
+		timeout = kamajiv1alpha1.CleanupHookWebhookMaxTimeout
+	}
+
+	httpClient := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+		return fmt.Errorf("unexpected status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// job runs the hook Job: it's not owned by the Tenant Control Plane, since the garbage collector would remove it
+// with the foreground deletion, thus it's deleted once completed.
+func (r *CleanupHooksResource) job(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, hook kamajiv1alpha1.CleanupHook, status *kamajiv1alpha1.CleanupHookStatus) (bool, error) {
+	job := &batchv1.Job{}
+	job.SetName(utilities.AddTenantPrefix(fmt.Sprintf("cleanup-%s", hook.Name), tenantControlPlane))
+	job.SetNamespace(tenantControlPlane.GetNamespace())
+
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		// The hooks are immutable upon the deletion: a Job no longer allowed would block it forever.
+		if err = r.JobPolicy.Allows(hook.Job); err != nil {
+			r.complete(status, kamajiv1alpha1.CleanupHookResultFailed, err.Error())
+
+			return true, nil
+		}
+
+		r.defineJob(job, tenantControlPlane, hook)
+
+		if err = r.Client.Create(ctx, job); err != nil {
+			return false, err
+		}
+
+		now := metav1.Now()
+
+		status.Attempts++
+		status.StartTime = &now
+
+		return false, nil
+	}
+
+	if job.GetDeletionTimestamp() != nil {
+		return false, nil
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			if err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+				return false, err
+			}
+
+			r.complete(status, kamajiv1alpha1.CleanupHookResultSucceeded, "")
+
+			return true, nil
+		case batchv1.JobFailed:
+			// Deleting the failed Job: it's created again upon the next attempt, if any.
+			if err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+				return false, err
+			}
+
+			return r.fail(hook, status, fmt.Sprintf("the Job %s failed: %s", job.GetName(), condition.Message)), nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *CleanupHooksResource) defineJob(job *batchv1.Job, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, hook kamajiv1alpha1.CleanupHook) {
+	job.SetLabels(utilities.MergeMaps(
+		utilities.KamajiLabels(),
+		map[string]string{
+			"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+			"kamaji.clastix.io/component": CleanupHookComponent,
+		},
+	))
+
+	env := append([]corev1.EnvVar{
+		{Name: "TENANT_CONTROL_PLANE_NAME", Value: tenantControlPlane.GetName()},
+		{Name: "TENANT_CONTROL_PLANE_NAMESPACE", Value: tenantControlPlane.GetNamespace()},
+	}, hook.Job.Env...)
+
+	job.Spec = batchv1.JobSpec{
+		ActiveDeadlineSeconds: pointer.Int64(int64(hook.Timeout.Duration.Seconds())),
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: job.GetLabels(),
+			},
+			Spec: corev1.PodSpec{
+				RestartPolicy:      corev1.RestartPolicyNever,
+				ServiceAccountName: hook.Job.ServiceAccountName,
+				Containers: []corev1.Container{
+					{
+						Name:    hook.Name,
+						Image:   hook.Job.Image,
+						Command: hook.Job.Command,
+						Args:    hook.Job.Args,
+						Env:     env,
+					},
+				},
+			},
+		},
+	}
+}
@@ -6,6 +6,7 @@ package datastore
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -91,7 +92,12 @@ func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1al
 
 		if r.resource.GetAnnotations()[constants.Checksum] == utilities.CalculateMapChecksum(r.resource.Data) {
 			if r.DataStore.Spec.Driver == kamajiv1alpha1.EtcdDriver {
-				if isValid, _ := crypto.IsValidCertificateKeyPairBytes(r.resource.Data["server.crt"], r.resource.Data["server.key"]); isValid {
+				// The certificate must be regenerated also when the DataStore CA has been rotated:
+				// a valid key pair signed by the previous CA would be rejected by the etcd cluster.
+				isValid, _ := crypto.IsValidCertificateKeyPairBytes(r.resource.Data["server.crt"], r.resource.Data["server.key"])
+				isSigned, _ := crypto.VerifyCertificate(r.resource.Data["server.crt"], ca, x509.ExtKeyUsageClientAuth)
+
+				if isValid && isSigned {
 					return nil
 				}
 			}
@@ -6,16 +6,19 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/utilities"
@@ -96,6 +99,16 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 	if d.actualDatastore.GetName() == d.desiredDatastore.GetName() {
 		return controllerutil.OperationResultNone, nil
 	}
+	// The standby DataStore already holds the data: it's promoted with no migration.
+	if tenantControlPlane.IsStandbyDataStorePromotion() {
+		return controllerutil.OperationResultNone, nil
+	}
+	// The migration Job is started only within the window, if any.
+	if len(d.job.GetResourceVersion()) == 0 {
+		if err := d.waitForWindow(ctx, tenantControlPlane); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+	}
 
 	res, err := utilities.CreateOrUpdateWithConflict(ctx, d.Client, d.job, func() error {
 		d.job.SetLabels(map[string]string{
@@ -119,6 +132,24 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 			fmt.Sprintf("--target-datastore=%s", tenantControlPlane.Spec.DataStore),
 		}
 
+		if migration := tenantControlPlane.Spec.DataStoreMigration; migration != nil {
+			if migration.KeysPerSecond != nil {
+				d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--keys-per-second=%d", *migration.KeysPerSecond))
+			}
+
+			if migration.BytesPerSecond != nil {
+				d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--bytes-per-second=%d", migration.BytesPerSecond.Value()))
+			}
+			// The throttled migrations last longer than the default timeout.
+			if migration.KeysPerSecond != nil || migration.BytesPerSecond != nil {
+				d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--timeout=%s", throttledMigrationTimeout.String()))
+			}
+		}
+
+		if datastore.FakeDriverEnabled() {
+			d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, "--datastore-fake-driver")
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -144,6 +175,48 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 	}
 }
 
+// throttledMigrationTimeout is the timeout of the migrations subject to the rate limits.
+const throttledMigrationTimeout = 24 * time.Hour
+
+// waitForWindow returns an error, reporting the scheduled migration in the status, until the migration window opens.
+func (d *Migrate) waitForWindow(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	migration := tenantControlPlane.Spec.DataStoreMigration
+	if migration == nil || migration.Window == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	opening := migration.Window.NextOpening(now)
+	if !opening.After(now) {
+		return nil
+	}
+
+	status := tenantControlPlane.Status.Storage.Migration
+	if status == nil || status.Phase != kamajiv1alpha1.DataStoreMigrationPhaseScheduled || status.TargetDataStore != d.desiredDatastore.GetName() || status.NextWindow == nil || !status.NextWindow.Time.Equal(opening) {
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			latest := &kamajiv1alpha1.TenantControlPlane{}
+			if err := d.Client.Get(ctx, client.ObjectKeyFromObject(tenantControlPlane), latest); err != nil {
+				return err
+			}
+
+			nextWindow := metav1.NewTime(opening)
+			latest.Status.Storage.Migration = &kamajiv1alpha1.DataStoreMigrationStatus{
+				Phase:           kamajiv1alpha1.DataStoreMigrationPhaseScheduled,
+				TargetDataStore: d.desiredDatastore.GetName(),
+				NextWindow:      &nextWindow,
+				LastUpdate:      metav1.Now(),
+			}
+
+			return d.Client.Status().Update(ctx, latest)
+		}); err != nil {
+			return fmt.Errorf("unable to report the scheduled migration: %w", err)
+		}
+	}
+
+	return kamajierrors.MigrationWindowPendingError{RetryAfter: opening.Sub(now)}
+}
+
 func (d *Migrate) GetName() string {
 	return "migrate"
 }
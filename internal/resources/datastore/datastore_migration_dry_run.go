@@ -0,0 +1,232 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// MigrationDryRun validates the migration to the DataStore requested with the MigrationDryRunAnnotation,
+// with no data being copied: the target driver compatibility, its capacity, and its reachability are checked,
+// and the size, along with the duration, of the migration are estimated from the current DataStore.
+// The result is reported by the DataStoreMigrationValidated condition, letting users abort before the cutover.
+type MigrationDryRun struct {
+	Client     client.Client
+	Connection datastore.Connection
+
+	condition *metav1.Condition
+}
+
+func (r *MigrationDryRun) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	r.condition = nil
+
+	return nil
+}
+
+func (r *MigrationDryRun) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *MigrationDryRun) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *MigrationDryRun) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	target := tenantControlPlane.MigrationDryRunTarget()
+	if len(target) == 0 || len(tenantControlPlane.Status.Storage.DataStoreName) == 0 {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.condition = &metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeDataStoreMigrationValidated,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             "ValidationSucceeded",
+	}
+
+	summary, failures, err := r.validate(ctx, tenantControlPlane, target)
+	if err != nil {
+		logger.Error(err, "cannot validate the DataStore migration", "target", target)
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	r.condition.Message = fmt.Sprintf("migration to %s: %s", target, summary)
+
+	if len(failures) > 0 {
+		r.condition.Status = metav1.ConditionFalse
+		r.condition.Reason = "ValidationFailed"
+		r.condition.Message = fmt.Sprintf("migration to %s: %s", target, strings.Join(failures, "; "))
+	}
+	// The request is removed once the result is available: the validation is not repeated by the next reconciliations.
+	patch := client.MergeFrom(tenantControlPlane.DeepCopy())
+
+	annotations := tenantControlPlane.GetAnnotations()
+	delete(annotations, kamajiv1alpha1.MigrationDryRunAnnotation)
+	tenantControlPlane.SetAnnotations(annotations)
+
+	if err = r.Client.Patch(ctx, tenantControlPlane, patch); err != nil {
+		logger.Error(err, "cannot remove the migration dry-run annotation")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	logger.Info("DataStore migration has been validated", "target", target, "result", r.condition.Reason)
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// validate returns the estimation of the migration, along with the failed checks:
+// an error is returned only when the checks cannot be performed, and must be retried.
+func (r *MigrationDryRun) validate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, target string) (string, []string, error) {
+	if target == tenantControlPlane.Status.Storage.DataStoreName {
+		return "", []string{"the target is the current DataStore"}, nil
+	}
+
+	targetDs := kamajiv1alpha1.DataStore{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: target}, &targetDs); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return "", []string{"the target DataStore doesn't exist"}, nil
+		}
+
+		return "", nil, err
+	}
+
+	currentDs := kamajiv1alpha1.DataStore{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: tenantControlPlane.Status.Storage.DataStoreName}, &currentDs); err != nil {
+		return "", nil, err
+	}
+
+	failures, err := r.checkCompatibility(ctx, tenantControlPlane, currentDs, targetDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rtt, err := r.checkReachability(ctx, targetDs)
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("the target DataStore is unreachable: %s", err.Error()))
+	}
+
+	schema := tenantControlPlane.Status.Storage.Setup.Schema
+	details := []string{fmt.Sprintf("round trip time %s", rtt.Round(time.Millisecond))}
+
+	if enforcer, ok := r.Connection.(datastore.QuotaEnforcer); ok {
+		used, usageErr := enforcer.Usage(ctx, schema)
+		if usageErr != nil {
+			return "", nil, usageErr
+		}
+
+		size := resource.NewQuantity(used, resource.BinarySI)
+		details = append(details, fmt.Sprintf("%s to copy", size.String()))
+		// The quota is enforced on the target as well: the Tenant Control Plane would be exceeding it since the cutover.
+		if quota := tenantControlPlane.Spec.DataStoreQuota; quota != nil && used > quota.Size.Value() {
+			failures = append(failures, fmt.Sprintf("the data to copy (%s) exceeds the DataStore quota (%s)", size.String(), quota.Size.String()))
+		}
+	}
+
+	if counter, ok := r.Connection.(datastore.Counter); ok {
+		keys, countErr := counter.Count(ctx, schema)
+		if countErr != nil {
+			return "", nil, countErr
+		}
+		// Pessimistic estimation, assuming a round trip to the target for each key.
+		details = append(details, fmt.Sprintf("%d keys to copy", keys), fmt.Sprintf("estimated duration %s", (time.Duration(keys)*rtt).Round(time.Second)))
+	}
+
+	return strings.Join(details, ", "), failures, nil
+}
+
+// checkCompatibility returns the reasons preventing the Tenant Control Plane to be placed on the target DataStore,
+// the same ones enforced by the migration, and by the scheduling.
+func (r *MigrationDryRun) checkCompatibility(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, currentDs, targetDs kamajiv1alpha1.DataStore) ([]string, error) {
+	var failures []string
+
+	if currentDs.Spec.Driver != targetDs.Spec.Driver {
+		failures = append(failures, fmt.Sprintf("the target driver %s differs from the current one %s", targetDs.Spec.Driver, currentDs.Spec.Driver))
+	}
+
+	if currentDs.Spec.Driver == kamajiv1alpha1.KinePostgreSQLDriver && (len(currentDs.PostgreSQLSharedDatabase()) == 0) != (len(targetDs.PostgreSQLSharedDatabase()) == 0) {
+		failures = append(failures, "the target PostgreSQL isolation mode differs from the current one")
+	}
+
+	if targetDs.GetDeletionTimestamp() != nil {
+		failures = append(failures, "the target DataStore is being deleted")
+	}
+
+	if targetDs.Spec.MaintenanceMode {
+		failures = append(failures, "the target DataStore is in maintenance mode")
+	}
+
+	if targetDs.Spec.MaxTenants != nil {
+		tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+		if err := r.Client.List(ctx, tcpList, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, targetDs.GetName())}); err != nil {
+			return nil, err
+		}
+
+		if len(tcpList.Items) >= int(*targetDs.Spec.MaxTenants) {
+			failures = append(failures, fmt.Sprintf("the target DataStore reached the maximum number of tenants (%d)", *targetDs.Spec.MaxTenants))
+		}
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: tenantControlPlane.GetNamespace()}, namespace); err != nil {
+		return nil, err
+	}
+
+	if allowed, err := targetDs.IsNamespaceAllowed(namespace); err != nil || !allowed {
+		failures = append(failures, "the target DataStore is not allowed for the namespace")
+	}
+
+	return failures, nil
+}
+
+// checkReachability connects to the target DataStore, returning the round trip time of the connection check.
+func (r *MigrationDryRun) checkReachability(ctx context.Context, targetDs kamajiv1alpha1.DataStore) (time.Duration, error) {
+	connection, err := datastore.NewStorageConnection(ctx, r.Client, targetDs)
+	if err != nil {
+		return 0, err
+	}
+	defer connection.Close()
+
+	start := time.Now()
+	if err = connection.Check(ctx); err != nil {
+		return 0, err
+	}
+
+	return time.Since(start), nil
+}
+
+func (r *MigrationDryRun) GetName() string {
+	return "datastore-migration-dry-run"
+}
+
+func (r *MigrationDryRun) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return r.condition != nil
+}
+
+func (r *MigrationDryRun) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.condition != nil {
+		meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, *r.condition)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// QuotaAccountingInterval is the minimum interval between two storage usage accountings,
+// since the Tenant Control Plane data is constantly changing, such as the leases renewal:
+// the Tenant Control Planes with a quota are enqueued back once it expires.
+const QuotaAccountingInterval = time.Minute
+
+// Quota computes the storage used by the Tenant Control Plane in a shared etcd DataStore,
+// reporting the DataStoreQuotaExceeded condition and, if required, revoking its write permission.
+type Quota struct {
+	Client     client.Client
+	Connection datastore.Connection
+	DataStore  kamajiv1alpha1.DataStore
+
+	status *kamajiv1alpha1.DataStoreQuotaStatus
+	// accounted is true when the storage usage has been computed in the current reconciliation.
+	accounted bool
+}
+
+func (r *Quota) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	r.status, r.accounted = nil, false
+
+	return nil
+}
+
+func (r *Quota) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.DataStoreQuota == nil && tenantControlPlane.Status.Storage.Quota != nil
+}
+
+// CleanUp restores the write permission, if revoked, when the quota is removed.
+func (r *Quota) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	if !tenantControlPlane.Status.Storage.Quota.ReadOnly {
+		return true, nil
+	}
+
+	enforcer, ok := r.Connection.(datastore.QuotaEnforcer)
+	if !ok {
+		return true, nil
+	}
+
+	if err := enforcer.SetReadOnly(ctx, tenantControlPlane.Status.Storage.Setup.Schema, false); err != nil {
+		log.FromContext(ctx, "resource", r.GetName()).Error(err, "cannot restore the write permission")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *Quota) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	quota := tenantControlPlane.Spec.DataStoreQuota
+	if quota == nil || len(tenantControlPlane.Status.Storage.Setup.Schema) == 0 {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	enforcer, ok := r.Connection.(datastore.QuotaEnforcer)
+	if !ok {
+		return controllerutil.OperationResultNone, fmt.Errorf("the %s driver doesn't support the DataStore quota", r.Connection.Driver())
+	}
+
+	current := tenantControlPlane.Status.Storage.Quota
+	// The accounting is throttled, unless the quota has been changed, or the write permission has to be restored.
+	if current != nil && current.Size.Cmp(quota.Size) == 0 && (!current.ReadOnly || quota.Enforcement == kamajiv1alpha1.DataStoreQuotaEnforcementReadOnly) &&
+		time.Since(current.LastUpdate.Time) < QuotaAccountingInterval {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	used, err := enforcer.Usage(ctx, tenantControlPlane.Status.Storage.Setup.Schema)
+	if err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to compute the storage usage")
+	}
+
+	r.accounted = true
+	r.status = &kamajiv1alpha1.DataStoreQuotaStatus{
+		Used:       *resource.NewQuantity(used, resource.BinarySI),
+		Size:       quota.Size,
+		LastUpdate: metav1.Now(),
+	}
+
+	readOnly := quota.Enforcement == kamajiv1alpha1.DataStoreQuotaEnforcementReadOnly && used > quota.Size.Value()
+	if current != nil && current.ReadOnly == readOnly {
+		r.status.ReadOnly = readOnly
+
+		return controllerutil.OperationResultNone, nil
+	}
+
+	if current != nil || readOnly {
+		if err = enforcer.SetReadOnly(ctx, tenantControlPlane.Status.Storage.Setup.Schema, readOnly); err != nil {
+			return controllerutil.OperationResultNone, errors.Wrap(err, "unable to enforce the DataStore quota")
+		}
+	}
+
+	r.status.ReadOnly = readOnly
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+func (r *Quota) GetName() string {
+	return "datastore-quota"
+}
+
+func (r *Quota) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Spec.DataStoreQuota == nil {
+		return tenantControlPlane.Status.Storage.Quota != nil
+	}
+
+	return r.accounted
+}
+
+func (r *Quota) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.Spec.DataStoreQuota == nil {
+		tenantControlPlane.Status.Storage.Quota = nil
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeDataStoreQuotaExceeded)
+
+		return nil
+	}
+
+	if r.status == nil {
+		return nil
+	}
+
+	tenantControlPlane.Status.Storage.Quota = r.status
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeDataStoreQuotaExceeded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             "WithinQuota",
+		Message:            fmt.Sprintf("using %s out of %s", r.status.Used.String(), r.status.Size.String()),
+	}
+
+	if r.status.Used.Cmp(r.status.Size) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "QuotaExceeded"
+
+		if r.status.ReadOnly {
+			condition.Message += ", the write permission has been revoked"
+		}
+	}
+
+	meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, condition)
+
+	return nil
+}
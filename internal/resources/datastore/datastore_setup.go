@@ -31,6 +31,9 @@ type Setup struct {
 	resource   *SetupResource
 	Client     client.Client
 	Connection datastore.Connection
+	// Privileged performs the creation, and the deletion, of the tenant user, schema, and privileges:
+	// when nil, Connection is used.
+	Privileged *datastore.PrivilegedConnection
 	DataStore  kamajiv1alpha1.DataStore
 }
 
@@ -143,10 +146,14 @@ func (r *Setup) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 		return err
 	}
 
-	if err := r.deleteDB(ctx, tenantControlPlane); err != nil {
-		logger.Error(err, "unable to delete datastore data")
+	if tenantControlPlane.Spec.DataStoreRetentionPolicy != kamajiv1alpha1.DataStoreRetentionPolicyRetain {
+		if err := r.deleteDB(ctx, tenantControlPlane); err != nil {
+			logger.Error(err, "unable to delete datastore data")
 
-		return err
+			return err
+		}
+	} else {
+		logger.Info("retaining datastore data according to the retention policy", "schema", r.resource.schema)
 	}
 
 	if err := r.deleteUser(ctx, tenantControlPlane); err != nil {
@@ -191,7 +198,17 @@ func (r *Setup) createDB(ctx context.Context, _ *kamajiv1alpha1.TenantControlPla
 		return controllerutil.OperationResultNone, nil
 	}
 
-	if err := r.Connection.CreateDB(ctx, r.resource.schema); err != nil {
+	privileged, err := r.privileged(ctx)
+	if err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to establish the privileged connection")
+	}
+
+	// Recording the schema before its creation, so it's never left untracked by a failure in between.
+	if err = datastore.RecordProvisioned(ctx, r.Client, r.DataStore.GetName(), kamajiv1alpha1.DataStoreResourceSchema, r.resource.schema); err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to record the datastore")
+	}
+
+	if err = privileged.CreateDB(ctx, r.resource.schema); err != nil {
 		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to create the datastore")
 	}
 
@@ -208,14 +225,19 @@ func (r *Setup) deleteDB(ctx context.Context, _ *kamajiv1alpha1.TenantControlPla
 		return nil
 	}
 
-	if err := r.Connection.DeleteDB(ctx, r.resource.schema); err != nil {
+	privileged, err := r.privileged(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to establish the privileged connection")
+	}
+
+	if err = privileged.DeleteDB(ctx, r.resource.schema); err != nil {
 		return errors.Wrap(err, "unable to delete the datastore")
 	}
 
-	return nil
+	return datastore.ForgetProvisioned(ctx, r.Client, r.DataStore.GetName(), kamajiv1alpha1.DataStoreResourceSchema, r.resource.schema)
 }
 
-func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+func (r *Setup) createUser(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	exists, err := r.Connection.UserExists(ctx, r.resource.user)
 	if err != nil {
 		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to check if user exists")
@@ -224,15 +246,33 @@ func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlP
 	if exists {
 		return controllerutil.OperationResultNone, nil
 	}
+	// The user-supplied credentials are provisioned externally: waiting for the user, rather than creating it.
+	if tenantControlPlane.Spec.DataStoreCredentials != nil {
+		return controllerutil.OperationResultNone, errors.Errorf("the user %s, supplied by the DataStore credentials, does not exist yet", r.resource.user)
+	}
+
+	privileged, err := r.privileged(ctx)
+	if err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to establish the privileged connection")
+	}
+
+	if err = datastore.RecordProvisioned(ctx, r.Client, r.DataStore.GetName(), kamajiv1alpha1.DataStoreResourceUser, r.resource.user); err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to record the user")
+	}
 
-	if err := r.Connection.CreateUser(ctx, r.resource.user, r.resource.password); err != nil {
+	if err = privileged.CreateUser(ctx, r.resource.user, r.resource.password); err != nil {
 		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to create the user")
 	}
 
 	return controllerutil.OperationResultCreated, nil
 }
 
-func (r *Setup) deleteUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
+func (r *Setup) deleteUser(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	// The user-supplied credentials are removed by the same external process which provisioned them.
+	if tenantControlPlane.Spec.DataStoreCredentials != nil {
+		return nil
+	}
+
 	exists, err := r.Connection.UserExists(ctx, r.resource.user)
 	if err != nil {
 		return errors.Wrap(err, "unable to check if user exists")
@@ -242,11 +282,16 @@ func (r *Setup) deleteUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlP
 		return nil
 	}
 
-	if err := r.Connection.DeleteUser(ctx, r.resource.user); err != nil {
+	privileged, err := r.privileged(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to establish the privileged connection")
+	}
+
+	if err = privileged.DeleteUser(ctx, r.resource.user); err != nil {
 		return errors.Wrap(err, "unable to remove the user")
 	}
 
-	return nil
+	return datastore.ForgetProvisioned(ctx, r.Client, r.DataStore.GetName(), kamajiv1alpha1.DataStoreResourceUser, r.resource.user)
 }
 
 func (r *Setup) createGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
@@ -259,7 +304,12 @@ func (r *Setup) createGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.Ten
 		return controllerutil.OperationResultNone, nil
 	}
 
-	if err := r.Connection.GrantPrivileges(ctx, r.resource.user, r.resource.schema); err != nil {
+	privileged, err := r.privileged(ctx)
+	if err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to establish the privileged connection")
+	}
+
+	if err = privileged.GrantPrivileges(ctx, r.resource.user, r.resource.schema); err != nil {
 		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to grant privileges")
 	}
 
@@ -276,9 +326,24 @@ func (r *Setup) revokeGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.Ten
 		return nil
 	}
 
-	if err := r.Connection.RevokePrivileges(ctx, r.resource.user, r.resource.schema); err != nil {
+	privileged, err := r.privileged(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to establish the privileged connection")
+	}
+
+	if err = privileged.RevokePrivileges(ctx, r.resource.user, r.resource.schema); err != nil {
 		return errors.Wrap(err, "unable to revoke privileges")
 	}
 
 	return nil
 }
+
+// privileged returns the connection performing the privileged operations,
+// established upon the first one only.
+func (r *Setup) privileged(ctx context.Context) (datastore.Connection, error) {
+	if r.Privileged == nil {
+		return r.Connection, nil
+	}
+
+	return r.Privileged.Get(ctx)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -75,7 +76,7 @@ func (r *Config) UpdateTenantControlPlaneStatus(_ context.Context, tenantControl
 	return nil
 }
 
-func (r *Config) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+func (r *Config) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		var password []byte
 
@@ -99,10 +100,27 @@ func (r *Config) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.Te
 			return []byte(fmt.Sprintf("%s_%s", tenantControlPlane.GetNamespace(), tenantControlPlane.GetName()))
 		}
 
+		schema := coalesceFn(tenantControlPlane.Status.Storage.Setup.Schema)
+		// The schema override is taken into account only for fresh new configurations,
+		// since it cannot be changed once the Tenant Control Plane has been created.
+		if override := tenantControlPlane.Spec.DataStoreSchema; len(override) > 0 && len(tenantControlPlane.Status.Storage.Setup.Schema) == 0 {
+			schema = []byte(override)
+		}
+
+		user := coalesceFn(tenantControlPlane.Status.Storage.Setup.User)
+		// The user-supplied credentials are provisioned by an external process, taking precedence over the generated ones.
+		if ref := tenantControlPlane.Spec.DataStoreCredentials; ref != nil {
+			var err error
+
+			if user, password, err = r.credentials(ctx, tenantControlPlane.GetNamespace(), ref.Name); err != nil {
+				return err
+			}
+		}
+
 		r.resource.Data = map[string][]byte{
 			"DB_CONNECTION_STRING": []byte(r.ConnString),
-			"DB_SCHEMA":            coalesceFn(tenantControlPlane.Status.Storage.Setup.Schema),
-			"DB_USER":              coalesceFn(tenantControlPlane.Status.Storage.Setup.User),
+			"DB_SCHEMA":            schema,
+			"DB_USER":              user,
 			"DB_PASSWORD":          password,
 		}
 
@@ -125,3 +143,19 @@ func (r *Config) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.Te
 		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
+
+// credentials returns the DataStore user, and password, stored in the user-supplied Secret.
+func (r *Config) credentials(ctx context.Context, namespace, name string) (user []byte, password []byte, err error) {
+	secret := &corev1.Secret{}
+	if err = r.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, nil, fmt.Errorf("cannot retrieve the DataStore credentials Secret: %w", err)
+	}
+
+	for _, key := range []string{"DB_USER", "DB_PASSWORD"} {
+		if len(secret.Data[key]) == 0 {
+			return nil, nil, fmt.Errorf("the DataStore credentials Secret %s is missing the %s key", name, key)
+		}
+	}
+
+	return secret.Data["DB_USER"], secret.Data["DB_PASSWORD"], nil
+}
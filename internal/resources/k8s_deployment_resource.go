@@ -6,14 +6,19 @@ package resources
 import (
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"fmt"
+	"hash"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,6 +34,10 @@ type KubernetesDeploymentResource struct {
 	DataStore          kamajiv1alpha1.DataStore
 	Name               string
 	KineContainerImage string
+	// pending contains the components whose Secrets changes are held by the checksum strategy.
+	pending []string
+	// approved is true when the held changes have been approved, and rolled out.
+	approved bool
 }
 
 func (r *KubernetesDeploymentResource) isStatusEqual(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
@@ -36,7 +45,8 @@ func (r *KubernetesDeploymentResource) isStatusEqual(tenantControlPlane *kamajiv
 }
 
 func (r *KubernetesDeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return !r.isStatusEqual(tenantControlPlane) || tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version
+	return !r.isStatusEqual(tenantControlPlane) || tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version ||
+		r.rolloutPendingMessage() != r.currentRolloutPendingMessage(tenantControlPlane)
 }
 
 func (r *KubernetesDeploymentResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -78,7 +88,12 @@ func (r *KubernetesDeploymentResource) mutate(ctx context.Context, tenantControl
 		}
 		d.SetLabels(r.resource, utilities.MergeMaps(utilities.CommonLabels(tenantControlPlane.GetName()), tenantControlPlane.Spec.ControlPlane.Deployment.AdditionalMetadata.Labels))
 		d.SetAnnotations(r.resource, utilities.MergeMaps(r.resource.Annotations, tenantControlPlane.Spec.ControlPlane.Deployment.AdditionalMetadata.Annotations))
+		// The mutation could be retried upon a conflict.
+		r.pending, r.approved = nil, false
 		d.SetTemplateLabels(&r.resource.Spec.Template, r.deploymentTemplateLabels(ctx, tenantControlPlane))
+		d.SetTemplateAnnotations(&r.resource.Spec.Template, r.deploymentTemplateAnnotations(tenantControlPlane))
+		// The map iteration order is random: sorting keeps the condition message stable across the reconciliations.
+		sort.Strings(r.pending)
 		d.SetNodeSelector(&r.resource.Spec.Template.Spec, tenantControlPlane)
 		d.SetToleration(&r.resource.Spec.Template.Spec, tenantControlPlane)
 		d.SetAffinity(&r.resource.Spec.Template.Spec, tenantControlPlane)
@@ -96,7 +111,22 @@ func (r *KubernetesDeploymentResource) mutate(ctx context.Context, tenantControl
 }
 
 func (r *KubernetesDeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	res, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	if err != nil || !r.approved {
+		return res, err
+	}
+	// The approval is consumed by the rollout: the following changes must be approved again.
+	patch := client.MergeFrom(tenantControlPlane.DeepCopy())
+
+	annotations := tenantControlPlane.GetAnnotations()
+	delete(annotations, kamajiv1alpha1.ApproveRolloutAnnotation)
+	tenantControlPlane.SetAnnotations(annotations)
+
+	if err = r.Client.Patch(ctx, tenantControlPlane, patch); err != nil {
+		return res, errors.Wrap(err, "cannot remove the rollout approval annotation")
+	}
+
+	return res, nil
 }
 
 func (r *KubernetesDeploymentResource) GetName() string {
@@ -124,32 +154,116 @@ func (r *KubernetesDeploymentResource) UpdateTenantControlPlaneStatus(_ context.
 		LastUpdate:       metav1.Now(),
 	}
 
+	if message := r.rolloutPendingMessage(); len(message) > 0 {
+		meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+			Type:               kamajiv1alpha1.ConditionTypeRolloutPending,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: tenantControlPlane.GetGeneration(),
+			Reason:             string(tenantControlPlane.Spec.ControlPlane.Deployment.ChecksumPolicy.Strategy),
+			Message:            message,
+		})
+	} else {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeRolloutPending)
+	}
+
 	return nil
 }
 
+func (r *KubernetesDeploymentResource) rolloutPendingMessage() string {
+	if len(r.pending) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("changed Secrets not rolled out: %s", strings.Join(r.pending, ", "))
+}
+
+func (r *KubernetesDeploymentResource) currentRolloutPendingMessage(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	condition := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeRolloutPending)
+	if condition == nil {
+		return ""
+	}
+
+	return condition.Message
+}
+
 func (r *KubernetesDeploymentResource) deploymentTemplateLabels(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (labels map[string]string) {
-	hash := func(ctx context.Context, namespace, secretName string) string {
-		h, _ := r.SecretHashValue(ctx, r.Client, namespace, secretName)
+	policy := tenantControlPlane.Spec.ControlPlane.Deployment.ChecksumPolicy
+
+	secrets := map[string]string{
+		"component.kamaji.clastix.io/api-server-certificate":                tenantControlPlane.Status.Certificates.APIServer.SecretName,
+		"component.kamaji.clastix.io/api-server-kubelet-client-certificate": tenantControlPlane.Status.Certificates.APIServerKubeletClient.SecretName,
+		"component.kamaji.clastix.io/ca":                                    tenantControlPlane.Status.Certificates.CA.SecretName,
+		"component.kamaji.clastix.io/controller-manager-kubeconfig":         tenantControlPlane.Status.KubeConfig.ControllerManager.SecretName,
+		"component.kamaji.clastix.io/front-proxy-ca-certificate":            tenantControlPlane.Status.Certificates.FrontProxyCA.SecretName,
+		"component.kamaji.clastix.io/front-proxy-client-certificate":        tenantControlPlane.Status.Certificates.FrontProxyClient.SecretName,
+		"component.kamaji.clastix.io/service-account":                       tenantControlPlane.Status.Certificates.SA.SecretName,
+		"component.kamaji.clastix.io/scheduler-kubeconfig":                  tenantControlPlane.Status.KubeConfig.Scheduler.SecretName,
+	}
 
-		return h
+	if status := tenantControlPlane.Status.Certificates.KubeletServingCA; status != nil {
+		secrets["component.kamaji.clastix.io/kubelet-serving-ca"] = status.SecretName
 	}
 
 	labels = map[string]string{
-		"kamaji.clastix.io/soot":                                            tenantControlPlane.GetName(),
-		"component.kamaji.clastix.io/api-server-certificate":                hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.APIServer.SecretName),
-		"component.kamaji.clastix.io/api-server-kubelet-client-certificate": hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.APIServerKubeletClient.SecretName),
-		"component.kamaji.clastix.io/ca":                                    hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.CA.SecretName),
-		"component.kamaji.clastix.io/controller-manager-kubeconfig":         hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.KubeConfig.ControllerManager.SecretName),
-		"component.kamaji.clastix.io/front-proxy-ca-certificate":            hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.FrontProxyCA.SecretName),
-		"component.kamaji.clastix.io/front-proxy-client-certificate":        hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.FrontProxyClient.SecretName),
-		"component.kamaji.clastix.io/service-account":                       hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.SA.SecretName),
-		"component.kamaji.clastix.io/scheduler-kubeconfig":                  hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.KubeConfig.Scheduler.SecretName),
-		"component.kamaji.clastix.io/datastore":                             tenantControlPlane.Spec.DataStore,
+		"kamaji.clastix.io/soot":                tenantControlPlane.GetName(),
+		"component.kamaji.clastix.io/datastore": tenantControlPlane.Spec.DataStore,
+	}
+
+	checksums := map[string]string{
+		"component.kamaji.clastix.io/datastore-certificate": tenantControlPlane.Status.Storage.Certificate.Checksum,
+	}
+
+	if kine := tenantControlPlane.Status.Storage.Kine; kine != nil {
+		checksums["component.kamaji.clastix.io/kine-certificate"] = kine.Certificate.Checksum
+	}
+
+	for label, secretName := range secrets {
+		checksums[label], _ = r.SecretHashValue(ctx, r.Client, tenantControlPlane.GetNamespace(), secretName, policy)
+	}
+
+	current := r.resource.Spec.Template.GetLabels()
+
+	for label, checksum := range checksums {
+		labels[label] = r.heldChecksum(tenantControlPlane, current, label, checksum)
 	}
 
 	return labels
 }
 
+// deploymentTemplateAnnotations returns the checksum of the DataStore configuration Secret, consumed as environment variables:
+// a change of the connection string, or of the credentials, is rolling out the Tenant Control Plane Pods.
+func (r *KubernetesDeploymentResource) deploymentTemplateAnnotations(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	const annotation = "component.kamaji.clastix.io/datastore-config-checksum"
+
+	return map[string]string{
+		annotation: r.heldChecksum(tenantControlPlane, r.resource.Spec.Template.GetAnnotations(), annotation, tenantControlPlane.Status.Storage.Config.Checksum),
+	}
+}
+
+// heldChecksum returns the checksum to set in the Pod template label, or annotation, with the given key:
+// the changes held by the checksum strategy keep the current value, and the component is reported as pending.
+func (r *KubernetesDeploymentResource) heldChecksum(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, current map[string]string, key, checksum string) string {
+	policy := tenantControlPlane.Spec.ControlPlane.Deployment.ChecksumPolicy
+	// With no strategy holding the changes, or upon the approval, the Pods are rolled out.
+	hold := policy != nil && policy.Strategy != kamajiv1alpha1.ChecksumStrategyRollout
+	approved := policy != nil && policy.Strategy == kamajiv1alpha1.ChecksumStrategyManual && tenantControlPlane.GetAnnotations()[kamajiv1alpha1.ApproveRolloutAnnotation] == "true"
+
+	previous := current[key]
+
+	switch {
+	case !hold || len(previous) == 0 || previous == checksum:
+		return checksum
+	case approved:
+		r.approved = true
+
+		return checksum
+	default:
+		r.pending = append(r.pending, strings.TrimPrefix(key, "component.kamaji.clastix.io/"))
+
+		return previous
+	}
+}
+
 func (r *KubernetesDeploymentResource) isProgressingUpgrade() bool {
 	if r.resource.ObjectMeta.GetGeneration() != r.resource.Status.ObservedGeneration {
 		return true
@@ -176,32 +290,56 @@ func (r *KubernetesDeploymentResource) isNotReady() bool {
 	return r.resource.Status.ReadyReplicas == 0
 }
 
-// SecretHashValue function returns the md5 value for the secret of the given name and namespace.
-func (r *KubernetesDeploymentResource) SecretHashValue(ctx context.Context, client client.Client, namespace, name string) (string, error) {
+// SecretHashValue function returns the checksum for the secret of the given name and namespace.
+func (r *KubernetesDeploymentResource) SecretHashValue(ctx context.Context, client client.Client, namespace, name string, policy *kamajiv1alpha1.ChecksumPolicy) (string, error) {
 	secret := &corev1.Secret{}
 	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
 		return "", errors.Wrap(err, "cannot retrieve *corev1.Secret for resource version retrieval")
 	}
 
-	return r.HashValue(*secret), nil
+	return r.HashValue(*secret, policy), nil
 }
 
-// HashValue function returns the md5 value for the given secret.
-func (r *KubernetesDeploymentResource) HashValue(secret corev1.Secret) string {
+// HashValue function returns the checksum for the given secret, computed according to the checksum policy:
+// with no policy, the MD5 of all the keys is returned.
+func (r *KubernetesDeploymentResource) HashValue(secret corev1.Secret, policy *kamajiv1alpha1.ChecksumPolicy) string {
 	// Go access map values in random way, it means we have to sort them.
 	keys := make([]string, 0, len(secret.Data))
 
-	for k := range secret.Data {
-		keys = append(keys, k)
+	if policy != nil {
+		for _, k := range policy.Keys {
+			if _, ok := secret.Data[k]; ok {
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
 	}
 
 	sort.Strings(keys)
-	// Generating MD5 of Secret values, sorted by key
-	h := md5.New()
+	// Generating the hash of Secret values, sorted by key
+	var h hash.Hash
+
+	switch {
+	case policy != nil && policy.Algorithm == kamajiv1alpha1.ChecksumAlgorithmSHA256:
+		h = sha256.New()
+	default:
+		h = md5.New()
+	}
 
 	for _, key := range keys {
 		h.Write(secret.Data[key])
 	}
 
-	return fmt.Sprintf("%x", h.Sum(nil))
+	// The checksums are used as Pod template label values, limited to 63 characters: the SHA256 one is truncated.
+	checksum := fmt.Sprintf("%x", h.Sum(nil))
+	if len(checksum) > validation.LabelValueMaxLength {
+		checksum = checksum[:validation.LabelValueMaxLength]
+	}
+
+	return checksum
 }
@@ -0,0 +1,147 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func newDeploymentResource(t *testing.T, tcp *kamajiv1alpha1.TenantControlPlane) *KubernetesDeploymentResource {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tcp.GetNamespace(), Name: "api-server-certificate"},
+		Data:       map[string][]byte{"tls.crt": []byte("certificate"), "tls.key": []byte("key")},
+	}
+
+	r := &KubernetesDeploymentResource{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()}
+	if err := r.Define(context.Background(), tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func newChecksumTenantControlPlane(policy *kamajiv1alpha1.ChecksumPolicy) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tcp"}}
+	tcp.Spec.DataStore = "default"
+	tcp.Spec.ControlPlane.Deployment.ChecksumPolicy = policy
+	tcp.Status.Certificates.APIServer.SecretName = "api-server-certificate"
+	tcp.Status.Storage.Certificate.Checksum = "datastore-certificate-checksum"
+	tcp.Status.Storage.Config.Checksum = "datastore-config-checksum"
+
+	return tcp
+}
+
+func TestDeploymentTemplateLabelsAreValid(t *testing.T) {
+	for _, algorithm := range []kamajiv1alpha1.ChecksumAlgorithm{kamajiv1alpha1.ChecksumAlgorithmMD5, kamajiv1alpha1.ChecksumAlgorithmSHA256} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			tcp := newChecksumTenantControlPlane(&kamajiv1alpha1.ChecksumPolicy{Algorithm: algorithm})
+
+			r := newDeploymentResource(t, tcp)
+
+			labels := r.deploymentTemplateLabels(context.Background(), tcp)
+			if len(labels["component.kamaji.clastix.io/api-server-certificate"]) == 0 {
+				t.Fatal("expected the checksum of the API Server certificate")
+			}
+
+			for key, value := range labels {
+				if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+					t.Errorf("invalid value %q for the label %s: %v", value, key, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentTemplateChecksumsHeldByStrategy(t *testing.T) {
+	tests := []struct {
+		name            string
+		strategy        kamajiv1alpha1.ChecksumStrategy
+		approved        bool
+		expectedPending []string
+	}{
+		{
+			name:     "rollout",
+			strategy: kamajiv1alpha1.ChecksumStrategyRollout,
+		},
+		{
+			name:            "status only",
+			strategy:        kamajiv1alpha1.ChecksumStrategyStatusOnly,
+			expectedPending: []string{"api-server-certificate", "datastore-certificate", "datastore-config-checksum", "kine-certificate"},
+		},
+		{
+			name:            "manual",
+			strategy:        kamajiv1alpha1.ChecksumStrategyManual,
+			expectedPending: []string{"api-server-certificate", "datastore-certificate", "datastore-config-checksum", "kine-certificate"},
+		},
+		{
+			name:     "manual approved",
+			strategy: kamajiv1alpha1.ChecksumStrategyManual,
+			approved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcp := newChecksumTenantControlPlane(&kamajiv1alpha1.ChecksumPolicy{Strategy: tt.strategy})
+			tcp.Status.Storage.Kine = &kamajiv1alpha1.KineStatus{}
+			tcp.Status.Storage.Kine.Certificate.Checksum = "kine-certificate-checksum"
+
+			if tt.approved {
+				tcp.SetAnnotations(map[string]string{kamajiv1alpha1.ApproveRolloutAnnotation: "true"})
+			}
+
+			r := newDeploymentResource(t, tcp)
+			// The Pod template was rolled out with different contents.
+			previous := map[string]string{
+				"component.kamaji.clastix.io/api-server-certificate": "previous",
+				"component.kamaji.clastix.io/datastore-certificate":  "previous",
+				"component.kamaji.clastix.io/kine-certificate":       "previous",
+			}
+			r.resource.Spec.Template.SetLabels(previous)
+			r.resource.Spec.Template.SetAnnotations(map[string]string{"component.kamaji.clastix.io/datastore-config-checksum": "previous"})
+
+			labels := r.deploymentTemplateLabels(context.Background(), tcp)
+			annotations := r.deploymentTemplateAnnotations(tcp)
+
+			sort.Strings(r.pending)
+
+			if strings.Join(r.pending, ",") != strings.Join(tt.expectedPending, ",") {
+				t.Fatalf("expected the pending components %v, got %v", tt.expectedPending, r.pending)
+			}
+
+			held := len(tt.expectedPending) > 0
+			for key := range previous {
+				if (labels[key] == "previous") != held {
+					t.Errorf("unexpected value %q for the label %s", labels[key], key)
+				}
+			}
+
+			if (annotations["component.kamaji.clastix.io/datastore-config-checksum"] == "previous") != held {
+				t.Errorf("unexpected value %q for the DataStore configuration checksum", annotations["component.kamaji.clastix.io/datastore-config-checksum"])
+			}
+
+			if r.approved != tt.approved {
+				t.Errorf("expected the approval to be %t", tt.approved)
+			}
+		})
+	}
+}
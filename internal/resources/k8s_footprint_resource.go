@@ -0,0 +1,138 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+// KubernetesFootprintResource computes the resources consumed by the Tenant Control Plane in the management cluster:
+// it doesn't manage any object, rather it's aggregating the Deployment, PersistentVolumeClaim, and Service ones.
+type KubernetesFootprintResource struct {
+	footprint *kamajiv1alpha1.ResourceFootprintStatus
+	Client    client.Client
+}
+
+func (r *KubernetesFootprintResource) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	r.footprint = &kamajiv1alpha1.ResourceFootprintStatus{}
+
+	return nil
+}
+
+func (r *KubernetesFootprintResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *KubernetesFootprintResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *KubernetesFootprintResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+
+	var deployments appsv1.DeploymentList
+	if err := r.Client.List(ctx, &deployments, client.InNamespace(tenantControlPlane.GetNamespace())); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	for _, deployment := range deployments.Items {
+		if !metav1.IsControlledBy(&deployment, tenantControlPlane) {
+			continue
+		}
+
+		replicas := int64(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = int64(*deployment.Spec.Replicas)
+		}
+
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			addResourceList(requests, container.Resources.Requests, replicas)
+			addResourceList(limits, container.Resources.Limits, replicas)
+		}
+	}
+
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := r.Client.List(ctx, &pvcs, client.InNamespace(tenantControlPlane.GetNamespace())); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	for _, pvc := range pvcs.Items {
+		if !metav1.IsControlledBy(&pvc, tenantControlPlane) {
+			continue
+		}
+
+		r.footprint.PersistentVolumeClaims++
+
+		if storage, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			r.footprint.Storage.Add(storage)
+		}
+	}
+
+	var services corev1.ServiceList
+	if err := r.Client.List(ctx, &services, client.InNamespace(tenantControlPlane.GetNamespace())); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	for _, service := range services.Items {
+		if metav1.IsControlledBy(&service, tenantControlPlane) {
+			r.footprint.Services++
+		}
+	}
+
+	if len(requests) > 0 {
+		r.footprint.Requests = requests
+	}
+
+	if len(limits) > 0 {
+		r.footprint.Limits = limits
+	}
+
+	metrics.RecordResourceFootprint(tenantControlPlane.GetNamespace(), tenantControlPlane.GetName(), r.footprint)
+
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *KubernetesFootprintResource) GetName() string {
+	return "resource_footprint"
+}
+
+func (r *KubernetesFootprintResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	current := tenantControlPlane.Status.ResourceFootprint
+	if current == nil {
+		return true
+	}
+
+	return !apiequality.Semantic.DeepEqual(current.Requests, r.footprint.Requests) ||
+		!apiequality.Semantic.DeepEqual(current.Limits, r.footprint.Limits) ||
+		current.Storage.Cmp(r.footprint.Storage) != 0 ||
+		current.PersistentVolumeClaims != r.footprint.PersistentVolumeClaims ||
+		current.Services != r.footprint.Services
+}
+
+func (r *KubernetesFootprintResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.footprint.LastUpdate = metav1.Now()
+	tenantControlPlane.Status.ResourceFootprint = r.footprint
+
+	return nil
+}
+
+func addResourceList(total, list corev1.ResourceList, replicas int64) {
+	for name, quantity := range list {
+		value := total[name]
+		value.Add(*resource.NewMilliQuantity(quantity.MilliValue()*replicas, quantity.Format))
+
+		total[name] = value
+	}
+}
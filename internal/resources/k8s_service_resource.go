@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,7 +29,10 @@ type KubernetesServiceResource struct {
 func (r *KubernetesServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
 	return tenantControlPlane.Status.Kubernetes.Service.Name != r.resource.GetName() ||
 		tenantControlPlane.Status.Kubernetes.Service.Namespace != r.resource.GetNamespace() ||
-		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port
+		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port ||
+		// The address assigned by the load balancer can change, drifting from the certificates and kubeconfigs SANs:
+		// the Tenant Control Plane endpoint must follow it, triggering their generation.
+		!equality.Semantic.DeepEqual(tenantControlPlane.Status.Kubernetes.Service.LoadBalancer, r.resource.Status.LoadBalancer)
 }
 
 func (r *KubernetesServiceResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -120,6 +124,17 @@ func (r *KubernetesServiceResource) mutate(ctx context.Context, tenantControlPla
 				r.resource.Spec.ExternalIPs = []string{address}
 			}
 		}
+		// The external traffic policy is not allowed for ClusterIP Services, unless external IPs are assigned.
+		switch policy := tenantControlPlane.Spec.ControlPlane.Service.ExternalTrafficPolicy; {
+		case len(policy) > 0:
+			r.resource.Spec.ExternalTrafficPolicy = policy
+		case r.resource.Spec.Type != corev1.ServiceTypeClusterIP || len(r.resource.Spec.ExternalIPs) > 0:
+			r.resource.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+		default:
+			r.resource.Spec.ExternalTrafficPolicy = ""
+		}
+
+		utilities.SetServiceTopology(r.resource, tenantControlPlane.Spec.ControlPlane.Service.ServiceTopologySpec, tenantControlPlane.Spec.ControlPlane.Service.AdditionalMetadata.Annotations)
 
 		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
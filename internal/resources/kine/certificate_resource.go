@@ -0,0 +1,175 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kine
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// CertificateResource generates the certificates used by kine and the kube-apiserver to communicate using mutual TLS,
+// signed by the Tenant Control Plane CA: the serving one is valid for both the sidecar and the Deployment modes.
+type CertificateResource struct {
+	resource  *corev1.Secret
+	Client    client.Client
+	DataStore kamajiv1alpha1.DataStore
+}
+
+func (r *CertificateResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Status.Storage.Kine == nil {
+		return true
+	}
+
+	return tenantControlPlane.Status.Storage.Kine.Certificate.Checksum != r.resource.GetAnnotations()[constants.Checksum]
+}
+
+func (r *CertificateResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !isMutualTLS(tenantControlPlane, r.DataStore)
+}
+
+func (r *CertificateResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the required resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *CertificateResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *CertificateResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *CertificateResource) GetName() string {
+	return "kine-certificate"
+}
+
+func (r *CertificateResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !isMutualTLS(tenantControlPlane, r.DataStore) {
+		tenantControlPlane.Status.Storage.Kine = nil
+
+		return nil
+	}
+
+	if tenantControlPlane.Status.Storage.Kine == nil {
+		tenantControlPlane.Status.Storage.Kine = &kamajiv1alpha1.KineStatus{}
+	}
+
+	tenantControlPlane.Status.Storage.Kine.Certificate.LastUpdate = metav1.Now()
+	tenantControlPlane.Status.Storage.Kine.Certificate.SecretName = r.resource.GetName()
+	tenantControlPlane.Status.Storage.Kine.Certificate.Checksum = r.resource.GetAnnotations()[constants.Checksum]
+
+	return nil
+}
+
+func (r *CertificateResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		if checksum := r.resource.GetAnnotations()[constants.Checksum]; len(checksum) > 0 && checksum == utilities.CalculateMapChecksum(r.resource.Data) {
+			serverValid, _ := crypto.IsValidCertificateKeyPairBytes(r.resource.Data[builder.KineServerCertName], r.resource.Data[builder.KineServerKeyName])
+			clientValid, _ := crypto.IsValidCertificateKeyPairBytes(r.resource.Data[builder.KineClientCertName], r.resource.Data[builder.KineClientKeyName])
+
+			if serverValid && clientValid {
+				return nil
+			}
+		}
+
+		namespacedName := k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}
+		secretCA := &corev1.Secret{}
+		if err := r.Client.Get(ctx, namespacedName, secretCA); err != nil {
+			logger.Error(err, "cannot retrieve the CA secret")
+
+			return err
+		}
+
+		caCert, caKey := secretCA.Data[kubeadmconstants.CACertName], secretCA.Data[kubeadmconstants.CAKeyName]
+
+		serviceName := builder.KineServiceName(tenantControlPlane)
+
+		serverTemplate := crypto.NewCertificateTemplate(fmt.Sprintf("%s-server", builder.KineName))
+		serverTemplate.DNSNames = []string{
+			"localhost",
+			serviceName,
+			fmt.Sprintf("%s.%s", serviceName, tenantControlPlane.GetNamespace()),
+			fmt.Sprintf("%s.%s.svc", serviceName, tenantControlPlane.GetNamespace()),
+			fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, tenantControlPlane.GetNamespace()),
+		}
+		serverTemplate.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+
+		serverCert, serverKey, err := crypto.GenerateCertificatePrivateKeyPair(serverTemplate, caCert, caKey)
+		if err != nil {
+			logger.Error(err, "unable to generate kine server certificate and private key")
+
+			return err
+		}
+
+		clientCert, clientKey, err := crypto.GenerateCertificatePrivateKeyPair(crypto.NewCertificateTemplate(kubeadmconstants.APIServerEtcdClientCertCommonName), caCert, caKey)
+		if err != nil {
+			logger.Error(err, "unable to generate kine client certificate and private key")
+
+			return err
+		}
+
+		r.resource.Data = map[string][]byte{
+			builder.KineCACertName:     caCert,
+			builder.KineServerCertName: serverCert.Bytes(),
+			builder.KineServerKeyName:  serverKey.Bytes(),
+			builder.KineClientCertName: clientCert.Bytes(),
+			builder.KineClientKeyName:  clientKey.Bytes(),
+		}
+
+		r.resource.SetLabels(utilities.MergeMaps(
+			utilities.KamajiLabels(),
+			map[string]string{
+				"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+				"kamaji.clastix.io/component": r.GetName(),
+			},
+		))
+
+		annotations := r.resource.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[constants.Checksum] = utilities.CalculateMapChecksum(r.resource.Data)
+		r.resource.SetAnnotations(annotations)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
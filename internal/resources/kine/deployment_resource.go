@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kine
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// DeploymentResource runs kine as a separate Deployment shared by all the kube-apiserver replicas:
+// the amount of connections to the DataStore doesn't depend anymore on the Tenant Control Plane replicas.
+type DeploymentResource struct {
+	resource           *appsv1.Deployment
+	Client             client.Client
+	DataStore          kamajiv1alpha1.DataStore
+	KineContainerImage string
+}
+
+func (r *DeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Status.Storage.Kine == nil {
+		return true
+	}
+
+	return tenantControlPlane.Status.Storage.Kine.Deployment.Name != r.resource.GetName() ||
+		tenantControlPlane.Status.Storage.Kine.Deployment.Namespace != r.resource.GetNamespace()
+}
+
+func (r *DeploymentResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !isStandalone(tenantControlPlane, r.DataStore)
+}
+
+func (r *DeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the required resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *DeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(builder.KineName, tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *DeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *DeploymentResource) GetName() string {
+	return "kine-deployment"
+}
+
+func (r *DeploymentResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.Status.Storage.Kine == nil {
+		return nil
+	}
+
+	tenantControlPlane.Status.Storage.Kine.Deployment = kamajiv1alpha1.ExternalKubernetesObjectStatus{
+		Name:       r.resource.GetName(),
+		Namespace:  r.resource.GetNamespace(),
+		LastUpdate: metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *DeploymentResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		if tenantControlPlane.Status.Storage.Kine == nil || len(tenantControlPlane.Status.Storage.Kine.Certificate.SecretName) == 0 {
+			return fmt.Errorf("kine certificate is not yet available")
+		}
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), commonLabels(tenantControlPlane)))
+
+		r.resource.Spec.Replicas = pointer.Int32(tenantControlPlane.Spec.ControlPlane.Kine.Replicas)
+		r.resource.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels(tenantControlPlane)}
+		r.resource.Spec.Template.SetLabels(utilities.MergeMaps(r.resource.Spec.Template.GetLabels(), labels(tenantControlPlane), map[string]string{
+			"component.kamaji.clastix.io/kine-certificate":      tenantControlPlane.Status.Storage.Kine.Certificate.Checksum,
+			"component.kamaji.clastix.io/datastore-config":      tenantControlPlane.Status.Storage.Config.Checksum,
+			"component.kamaji.clastix.io/datastore-certificate": tenantControlPlane.Status.Storage.Certificate.Checksum,
+		}))
+
+		k := builder.Kine{
+			KineContainerImage: r.KineContainerImage,
+			DataStore:          r.DataStore,
+		}
+		k.SetContainers(&r.resource.Spec.Template.Spec, tenantControlPlane)
+		k.SetVolumes(&r.resource.Spec.Template.Spec, tenantControlPlane)
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kine
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// podMonitorGVK is the Prometheus Operator PodMonitor, managed as unstructured object since its CRD is optional.
+var podMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+
+// PodMonitorResource generates the PodMonitor scraping the kine metrics endpoint: the samples are labelled with
+// the Tenant Control Plane name and namespace, making the SQL data store latency observable per tenant.
+type PodMonitorResource struct {
+	resource  *unstructured.Unstructured
+	Client    client.Client
+	DataStore kamajiv1alpha1.DataStore
+}
+
+func (r *PodMonitorResource) isEnabled(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	_, enabled := tenantControlPlane.KineMetricsPort()
+
+	return enabled && r.DataStore.Spec.Driver != kamajiv1alpha1.EtcdDriver
+}
+
+func (r *PodMonitorResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *PodMonitorResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isEnabled(tenantControlPlane)
+}
+
+func (r *PodMonitorResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	// The operator could have been granted no permission on the PodMonitor objects, which thus cannot exist.
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) && !k8serrors.IsForbidden(err) && !meta.IsNoMatchError(err) {
+			logger.Error(err, "cannot delete the required resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *PodMonitorResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &unstructured.Unstructured{}
+	r.resource.SetGroupVersionKind(podMonitorGVK)
+	r.resource.SetName(utilities.AddTenantPrefix(r.GetName(), tenantControlPlane))
+	r.resource.SetNamespace(tenantControlPlane.GetNamespace())
+
+	return nil
+}
+
+func (r *PodMonitorResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *PodMonitorResource) GetName() string {
+	return "kine-podmonitor"
+}
+
+func (r *PodMonitorResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *PodMonitorResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		metrics := tenantControlPlane.Spec.ControlPlane.Kine.Metrics
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), metrics.Labels, commonLabels(tenantControlPlane)))
+		// Kine runs as a sidecar of the Tenant Control Plane Pods, unless it's deployed separately.
+		selector := map[string]interface{}{"kamaji.clastix.io/soot": tenantControlPlane.GetName()}
+		if isStandalone(tenantControlPlane, r.DataStore) {
+			selector = map[string]interface{}{}
+			for k, v := range labels(tenantControlPlane) {
+				selector[k] = v
+			}
+		}
+
+		endpoint := map[string]interface{}{
+			"port": builder.KineMetricsPortName,
+			"path": "/metrics",
+			"relabelings": []interface{}{
+				map[string]interface{}{
+					"targetLabel": "tenant_control_plane",
+					"replacement": tenantControlPlane.GetName(),
+				},
+				map[string]interface{}{
+					"targetLabel": "tenant_control_plane_namespace",
+					"replacement": tenantControlPlane.GetNamespace(),
+				},
+			},
+		}
+
+		if len(metrics.Interval) > 0 {
+			endpoint["interval"] = metrics.Interval
+		}
+
+		r.resource.Object["spec"] = map[string]interface{}{
+			"selector": map[string]interface{}{
+				"matchLabels": selector,
+			},
+			"podMetricsEndpoints": []interface{}{endpoint},
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
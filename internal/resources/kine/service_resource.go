@@ -0,0 +1,124 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kine
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ServiceResource exposes the kine Deployment to the kube-apiserver replicas.
+type ServiceResource struct {
+	resource  *corev1.Service
+	Client    client.Client
+	DataStore kamajiv1alpha1.DataStore
+}
+
+func (r *ServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !isStandalone(tenantControlPlane, r.DataStore) {
+		return false
+	}
+
+	if tenantControlPlane.Status.Storage.Kine == nil {
+		return true
+	}
+
+	return tenantControlPlane.Status.Storage.Kine.Service.Name != r.resource.GetName() ||
+		tenantControlPlane.Status.Storage.Kine.Service.Namespace != r.resource.GetNamespace() ||
+		tenantControlPlane.Status.Storage.Kine.Service.Port != r.port()
+}
+
+func (r *ServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !isStandalone(tenantControlPlane, r.DataStore)
+}
+
+func (r *ServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the required resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *ServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.KineServiceName(tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *ServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *ServiceResource) GetName() string {
+	return "kine-service"
+}
+
+func (r *ServiceResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !isStandalone(tenantControlPlane, r.DataStore) || tenantControlPlane.Status.Storage.Kine == nil {
+		return nil
+	}
+
+	tenantControlPlane.Status.Storage.Kine.Service = kamajiv1alpha1.KubernetesServiceStatus{
+		ServiceStatus: r.resource.Status,
+		Name:          r.resource.GetName(),
+		Namespace:     r.resource.GetNamespace(),
+		Port:          r.port(),
+	}
+
+	return nil
+}
+
+// port returns the port of the Service, which is not yet defined when the resource has not been created.
+func (r *ServiceResource) port() int32 {
+	if len(r.resource.Spec.Ports) == 0 {
+		return 0
+	}
+
+	return r.resource.Spec.Ports[0].Port
+}
+
+func (r *ServiceResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), commonLabels(tenantControlPlane)))
+
+		r.resource.Spec.Type = corev1.ServiceTypeClusterIP
+		r.resource.Spec.Selector = labels(tenantControlPlane)
+
+		if len(r.resource.Spec.Ports) != 1 {
+			r.resource.Spec.Ports = make([]corev1.ServicePort, 1)
+		}
+
+		r.resource.Spec.Ports[0].Name = builder.KineName
+		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		r.resource.Spec.Ports[0].Port = builder.KinePort
+		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(builder.KinePort)
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
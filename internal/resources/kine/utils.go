@@ -0,0 +1,31 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kine
+
+import (
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// isStandalone returns true when kine must run as a separate Deployment for the given Tenant Control Plane.
+func isStandalone(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore) bool {
+	return dataStore.Spec.Driver != kamajiv1alpha1.EtcdDriver && tenantControlPlane.DesiredKineMode() == kamajiv1alpha1.KineModeDeployment
+}
+
+// isMutualTLS returns true when the kube-apiserver must connect to kine using mutual TLS for the given Tenant Control Plane.
+func isMutualTLS(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore) bool {
+	return dataStore.Spec.Driver != kamajiv1alpha1.EtcdDriver && tenantControlPlane.KineMutualTLSEnabled()
+}
+
+func labels(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	return map[string]string{
+		"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+		"kamaji.clastix.io/component": builder.KineName,
+	}
+}
+
+func commonLabels(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	return utilities.MergeMaps(utilities.KamajiLabels(), labels(tenantControlPlane))
+}
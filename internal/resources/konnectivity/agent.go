@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajierrors "github.com/clastix/kamaji/internal/errors"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -32,7 +33,9 @@ func (r *Agent) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kam
 }
 
 func (r *Agent) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Spec.Addons.Konnectivity == nil
+	allowed, _, _ := tenantControlPlane.KonnectivityRemovalAllowed()
+
+	return tenantControlPlane.Spec.Addons.Konnectivity == nil && allowed
 }
 
 func (r *Agent) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
@@ -72,7 +75,7 @@ func (r *Agent) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 
 func (r *Agent) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	if tenantControlPlane.Spec.Addons.Konnectivity != nil {
-		return controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.resource, r.mutate(ctx, tenantControlPlane))
+		return createOrUpdate(ctx, r.tenantClient, r.resource, tenantControlPlane, r.mutate(ctx, tenantControlPlane))
 	}
 
 	return controllerutil.OperationResultNone, nil
@@ -108,6 +111,14 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 
 			return err
 		}
+		// The agents dial the load balancer of the dedicated Service, once assigned.
+		if tenantControlPlane.IsKonnectivityDedicatedService() && tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Service.ServiceType == kamajiv1alpha1.ServiceTypeLoadBalancer {
+			if address, err = r.dedicatedServiceAddress(tenantControlPlane); err != nil {
+				logger.Info("waiting for the Konnectivity Service load balancer address")
+
+				return err
+			}
+		}
 
 		r.resource.SetLabels(utilities.MergeMaps(
 			utilities.KamajiLabels(),
@@ -131,17 +142,38 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 			},
 		))
 
+		agentSpec := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec
+		// The scheduling settings are always enforced, replacing any manual change in the Tenant Cluster:
+		// the declared ones override the defaults.
 		r.resource.Spec.Template.Spec.PriorityClassName = "system-cluster-critical"
+		if len(agentSpec.PriorityClassName) > 0 {
+			r.resource.Spec.Template.Spec.PriorityClassName = agentSpec.PriorityClassName
+		}
+
 		r.resource.Spec.Template.Spec.Tolerations = []corev1.Toleration{
 			{
 				Key:      "CriticalAddonsOnly",
 				Operator: "Exists",
 			},
 		}
+		if len(agentSpec.Tolerations) > 0 {
+			r.resource.Spec.Template.Spec.Tolerations = agentSpec.Tolerations
+		}
+
 		r.resource.Spec.Template.Spec.NodeSelector = map[string]string{
 			"kubernetes.io/os": "linux",
 		}
+		if len(agentSpec.NodeSelector) > 0 {
+			r.resource.Spec.Template.Spec.NodeSelector = agentSpec.NodeSelector
+		}
+
+		r.resource.Spec.Template.Spec.Affinity = agentSpec.Affinity
+		r.resource.Spec.Template.Spec.ImagePullSecrets = agentSpec.ImagePullSecrets
 		r.resource.Spec.Template.Spec.ServiceAccountName = AgentName
+		// The agent authenticates using a bound token, audience-scoped to the Konnectivity server, and refreshed by the kubelet:
+		// the legacy Service Account token, either mounted or stored in a Secret, is not required,
+		// allowing the agent to run in the clusters enforcing LegacyServiceAccountTokenNoAutoGeneration.
+		r.resource.Spec.Template.Spec.AutomountServiceAccountToken = pointer.Bool(false)
 		r.resource.Spec.Template.Spec.Volumes = []corev1.Volume{
 			{
 				Name: agentTokenName,
@@ -151,10 +183,23 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 							{
 								ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
 									Path:              agentTokenName,
-									Audience:          tenantControlPlane.Status.Addons.Konnectivity.ClusterRoleBinding.Name,
+									Audience:          CertCommonName,
 									ExpirationSeconds: pointer.Int64(3600),
 								},
 							},
+							{
+								ConfigMap: &corev1.ConfigMapProjection{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: agentRootCAConfigMapName,
+									},
+									Items: []corev1.KeyToPath{
+										{
+											Key:  "ca.crt",
+											Path: "ca.crt",
+										},
+									},
+								},
+							},
 						},
 						DefaultMode: pointer.Int32(420),
 					},
@@ -174,12 +219,30 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 
 		args["-v"] = "8"
 		args["--logtostderr"] = "true"
-		args["--ca-cert"] = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
-		args["--proxy-server-host"] = address
-		args["--proxy-server-port"] = fmt.Sprintf("%d", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
-		args["--admin-server-port"] = "8133"
-		args["--health-server-port"] = "8134"
-		args["--service-account-token-path"] = "/var/run/secrets/tokens/konnectivity-agent-token"
+		args["--ca-cert"] = "/var/run/secrets/tokens/ca.crt"
+		proxyServerHost, proxyServerPort := tenantControlPlane.Spec.Addons.Konnectivity.ProxyServer(address)
+
+		args["--proxy-server-host"] = proxyServerHost
+		args["--proxy-server-port"] = fmt.Sprintf("%d", proxyServerPort)
+		adminPort, healthPort := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Ports()
+
+		args["--admin-server-port"] = fmt.Sprintf("%d", adminPort)
+		args["--health-server-port"] = fmt.Sprintf("%d", healthPort)
+		args["--service-account-token-path"] = "/var/run/secrets/tokens/" + agentTokenName
+
+		if streaming := tenantControlPlane.Spec.Kubernetes.Streaming; streaming != nil && streaming.KeepaliveTime != nil {
+			args["--keepalive-time"] = streaming.KeepaliveTime.Duration.String()
+		}
+
+		for flag, value := range tenantControlPlane.Spec.Addons.Konnectivity.TLSArgs() {
+			args[flag] = value
+		}
+
+		if tenantControlPlane.IsKonnectivityLeaseCounting() {
+			args["--count-server-leases"] = "true"
+			args["--lease-namespace"] = AgentNamespace
+			args["--lease-label"] = leaseLabel
+		}
 
 		r.resource.Spec.Template.Spec.Containers[0].Args = utilities.ArgsFromMapToSlice(args)
 		r.resource.Spec.Template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
@@ -192,7 +255,7 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
 					Path:   "/healthz",
-					Port:   intstr.FromInt(8134),
+					Port:   intstr.FromInt(int(healthPort)),
 					Scheme: corev1.URISchemeHTTP,
 				},
 			},
@@ -206,3 +269,22 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 		return nil
 	}
 }
+
+// dedicatedServiceAddress returns the load balancer address of the dedicated Konnectivity Service, preferring the IP one.
+func (r *Agent) dedicatedServiceAddress(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	ingresses := tenantControlPlane.Status.Addons.Konnectivity.Service.LoadBalancer.Ingress
+
+	for _, ingress := range ingresses {
+		if len(ingress.IP) > 0 {
+			return ingress.IP, nil
+		}
+	}
+
+	for _, ingress := range ingresses {
+		if len(ingress.Hostname) > 0 {
+			return ingress.Hostname, nil
+		}
+	}
+
+	return "", kamajierrors.NonExposedLoadBalancerError{}
+}
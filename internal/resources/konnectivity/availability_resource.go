@@ -0,0 +1,94 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// AvailabilityResource tracks since when no Konnectivity agent is available in the Tenant Cluster,
+// letting the egress selector configuration fall back to the direct egress once the threshold is expired.
+type AvailabilityResource struct {
+	Client      client.Client
+	unavailable bool
+}
+
+func (r *AvailabilityResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.unavailable = false
+
+	konnectivity := tenantControlPlane.Spec.Addons.Konnectivity
+	if konnectivity == nil || konnectivity.Fallback == nil {
+		return nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "unable to retrieve the Tenant Control Plane client")
+
+		return err
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err = tenantClient.Get(ctx, k8stypes.NamespacedName{Namespace: AgentNamespace, Name: AgentName}, ds); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		logger.Error(err, "unable to retrieve the Konnectivity agent")
+
+		return err
+	}
+	// With no nodes, there's nothing to reach: falling back would be pointless.
+	r.unavailable = ds.Status.DesiredNumberScheduled > 0 && ds.Status.NumberAvailable == 0
+
+	return nil
+}
+
+func (r *AvailabilityResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *AvailabilityResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *AvailabilityResource) CreateOrUpdate(context.Context, *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *AvailabilityResource) GetName() string {
+	return "konnectivity-availability"
+}
+
+func (r *AvailabilityResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return r.unavailable != (tenantControlPlane.Status.Addons.Konnectivity.AgentsUnavailableSince != nil)
+}
+
+func (r *AvailabilityResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.unavailable {
+		tenantControlPlane.Status.Addons.Konnectivity.AgentsUnavailableSince = nil
+
+		return nil
+	}
+
+	if tenantControlPlane.Status.Addons.Konnectivity.AgentsUnavailableSince == nil {
+		now := metav1.Now()
+		tenantControlPlane.Status.Addons.Konnectivity.AgentsUnavailableSince = &now
+	}
+
+	return nil
+}
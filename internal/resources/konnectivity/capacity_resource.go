@@ -0,0 +1,111 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// CapacityResource compares the number of Konnectivity agents scheduled in the Tenant Cluster with the capacity
+// of the servers, reporting the KonnectivityCapacityExceeded condition along with the replicas required to serve them.
+type CapacityResource struct {
+	Client  client.Client
+	message string
+}
+
+func (r *CapacityResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.message = ""
+
+	konnectivity := tenantControlPlane.Spec.Addons.Konnectivity
+	if konnectivity == nil || konnectivity.KonnectivityServerSpec.AgentsPerServer == nil {
+		return nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "unable to retrieve the Tenant Control Plane client")
+
+		return err
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err = tenantClient.Get(ctx, k8stypes.NamespacedName{Namespace: AgentNamespace, Name: AgentName}, ds); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		logger.Error(err, "unable to retrieve the Konnectivity agent")
+
+		return err
+	}
+
+	agents, perServer := ds.Status.DesiredNumberScheduled, *konnectivity.KonnectivityServerSpec.AgentsPerServer
+	servers := tenantControlPlane.KonnectivityServerCount()
+
+	if agents <= servers*perServer {
+		return nil
+	}
+
+	r.message = fmt.Sprintf("%d agents exceed the capacity of %d Konnectivity servers serving %d agents each, at least %d replicas are required", agents, servers, perServer, (agents+perServer-1)/perServer)
+
+	return nil
+}
+
+func (r *CapacityResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *CapacityResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *CapacityResource) CreateOrUpdate(context.Context, *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *CapacityResource) GetName() string {
+	return "konnectivity-capacity"
+}
+
+func (r *CapacityResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	condition := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityCapacityExceeded)
+	if len(r.message) == 0 {
+		return condition != nil
+	}
+
+	return condition == nil || condition.Message != r.message
+}
+
+func (r *CapacityResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if len(r.message) == 0 {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityCapacityExceeded)
+
+		return nil
+	}
+
+	meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeKonnectivityCapacityExceeded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             "AgentsExceedServers",
+		Message:            r.message,
+	})
+
+	return nil
+}
@@ -37,7 +37,12 @@ func (r *CertificateResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.T
 	return tenantControlPlane.Spec.Addons.Konnectivity == nil
 }
 
-func (r *CertificateResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+func (r *CertificateResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// The Tenant Control Plane Pods could still reference it: waiting for the Deployment clean-up first.
+	if tenantControlPlane.Status.Addons.Konnectivity.Enabled {
+		return false, nil
+	}
+
 	logger := log.FromContext(ctx, "resource", r.GetName())
 
 	if err := r.Client.Delete(ctx, r.resource); err != nil {
@@ -11,7 +11,10 @@ const (
 	AgentName      = "konnectivity-agent"
 	CertCommonName = "system:konnectivity-server"
 	AgentNamespace = core.NamespaceSystem
+	// AgentsConnectedReadinessGate is the Pod readiness gate satisfied once the Konnectivity server is connected to the agents.
+	AgentsConnectedReadinessGate = "kamaji.clastix.io/konnectivity-agents-connected"
 
+	agentRootCAConfigMapName        = "kube-root-ca.crt"
 	agentTokenName                  = "konnectivity-agent-token"
 	apiServerAPIVersion             = "apiserver.k8s.io/v1beta1"
 	defaultClusterName              = "kubernetes"
@@ -21,5 +24,16 @@ const (
 	konnectivityCertAndKeyBaseName  = "konnectivity"
 	konnectivityKubeconfigFileName  = "konnectivity-server.conf"
 	kubeconfigAPIVersion            = "v1"
+	leaseLabel                      = "k8s-app=konnectivity-server"
+	leaseRoleName                   = "system:konnectivity-server-leases"
+	leaseAgentRoleName              = "system:konnectivity-agent-leases"
 	roleAuthDelegator               = "system:auth-delegator"
+
+	proxyCACertName     = "ca.crt"
+	proxyServerCertName = "proxy-server.crt"
+	proxyServerKeyName  = "proxy-server.key"
+	proxyClientCertName = "proxy-client.crt"
+	proxyClientKeyName  = "proxy-client.key"
+	proxyPath           = "/etc/kubernetes/konnectivity/proxy"
+	proxyServerPort     = 8131
 )
@@ -0,0 +1,137 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// DedicatedServiceResource exposes the Konnectivity server with its own Service, rather than sharing the API Server one,
+// for the networks routing the tunnel traffic differently: the shared Service port is removed by the ServiceResource.
+type DedicatedServiceResource struct {
+	resource *corev1.Service
+	Client   client.Client
+}
+
+func (r *DedicatedServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Addons.Konnectivity.Service
+
+	return status.Name != r.resource.GetName() ||
+		status.Namespace != r.resource.GetNamespace() ||
+		status.Port != r.resource.Spec.Ports[0].Port ||
+		!reflect.DeepEqual(status.ServiceStatus, r.resource.Status)
+}
+
+func (r *DedicatedServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.IsKonnectivityDedicatedService()
+}
+
+func (r *DedicatedServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *DedicatedServiceResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	// When the Service is not dedicated, the status is reported by the ServiceResource.
+	if !tenantControlPlane.IsKonnectivityDedicatedService() {
+		return nil
+	}
+
+	tenantControlPlane.Status.Addons.Konnectivity.Service.Name = r.resource.GetName()
+	tenantControlPlane.Status.Addons.Konnectivity.Service.Namespace = r.resource.GetNamespace()
+	tenantControlPlane.Status.Addons.Konnectivity.Service.Port = r.resource.Spec.Ports[0].Port
+	tenantControlPlane.Status.Addons.Konnectivity.Service.ServiceStatus = r.resource.Status
+
+	return nil
+}
+
+func (r *DedicatedServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix("konnectivity", tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *DedicatedServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *DedicatedServiceResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		spec := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec
+		service := spec.Service
+
+		r.resource.SetLabels(utilities.MergeMaps(utilities.CommonLabels(tenantControlPlane.GetName()), service.AdditionalMetadata.Labels))
+		r.resource.SetAnnotations(utilities.MergeMaps(r.resource.GetAnnotations(), service.AdditionalMetadata.Annotations))
+
+		r.resource.Spec.Selector = map[string]string{
+			"kamaji.clastix.io/soot": tenantControlPlane.GetName(),
+		}
+		// The standalone servers are not running in the Tenant Control Plane Pods.
+		if tenantControlPlane.IsKonnectivityStandalone() {
+			r.resource.Spec.Selector = standaloneLabels(tenantControlPlane)
+		}
+
+		port := spec.Port
+		if service.Port > 0 {
+			port = service.Port
+		}
+
+		if len(r.resource.Spec.Ports) != 1 {
+			r.resource.Spec.Ports = make([]corev1.ServicePort, 1)
+		}
+
+		r.resource.Spec.Ports[0].Name = "konnectivity-server"
+		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		r.resource.Spec.Ports[0].Port = port
+		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(int(spec.Port))
+
+		switch service.ServiceType {
+		case kamajiv1alpha1.ServiceTypeLoadBalancer:
+			r.resource.Spec.Type = corev1.ServiceTypeLoadBalancer
+			r.resource.Spec.Ports[0].NodePort = 0
+		case kamajiv1alpha1.ServiceTypeNodePort:
+			r.resource.Spec.Type = corev1.ServiceTypeNodePort
+			r.resource.Spec.Ports[0].NodePort = port
+		default:
+			r.resource.Spec.Type = corev1.ServiceTypeClusterIP
+			r.resource.Spec.Ports[0].NodePort = 0
+		}
+
+		utilities.SetServiceTopology(r.resource, service.ServiceTopologySpec, service.AdditionalMetadata.Annotations)
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *DedicatedServiceResource) GetName() string {
+	return "konnectivity-dedicated-service"
+}
@@ -29,6 +29,10 @@ const (
 	egressSelectorConfigurationVolume  = "egress-selector-configuration"
 	konnectivityUDSVolume              = "konnectivity-uds"
 	konnectivityServerKubeconfigVolume = "konnectivity-server-kubeconfig"
+	konnectivityProxyVolume            = "konnectivity-proxy"
+
+	egressSelectorConfigurationChecksumAnnotation = "component.kamaji.clastix.io/konnectivity-egress-checksum"
+	proxyCertificateChecksumAnnotation            = "component.kamaji.clastix.io/konnectivity-proxy-checksum"
 )
 
 type KubernetesDeploymentResource struct {
@@ -48,10 +52,12 @@ func (r *KubernetesDeploymentResource) ShouldStatusBeUpdated(_ context.Context,
 }
 
 func (r *KubernetesDeploymentResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Spec.Addons.Konnectivity == nil && tenantControlPlane.Status.Addons.Konnectivity.Enabled
+	// The status could be stale when the addon is toggled rapidly: the clean-up is idempotent,
+	// verifying the Deployment is free of any Konnectivity leftover.
+	return tenantControlPlane.Spec.Addons.Konnectivity == nil
 }
 
-func (r *KubernetesDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+func (r *KubernetesDeploymentResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
 	logger := log.FromContext(ctx)
 
 	logger.Info("performing clean-up from Deployment of Konnectivity")
@@ -77,7 +83,7 @@ func (r *KubernetesDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1a
 				r.resource.Spec.Template.Spec.Containers[index].Args = utilities.ArgsFromMapToSlice(argsMap)
 			}
 
-			for _, volumeName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume} {
+			for _, volumeName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume, konnectivityServerKubeconfigVolume, konnectivityProxyVolume} {
 				if volumeFound, volumeIndex := utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, volumeName); volumeFound {
 					logger.Info("removing Konnectivity volume " + volumeName)
 
@@ -90,7 +96,7 @@ func (r *KubernetesDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1a
 				}
 			}
 
-			for _, volumeMountName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume, konnectivityServerKubeconfigVolume} {
+			for _, volumeMountName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume, konnectivityServerKubeconfigVolume, konnectivityProxyVolume} {
 				if ok, i := utilities.HasNamedVolumeMount(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, volumeMountName); ok {
 					logger.Info("removing Konnectivity volume mount " + volumeMountName)
 
@@ -104,10 +110,19 @@ func (r *KubernetesDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1a
 			}
 		}
 
+		r.syncReadinessGate(false)
+
+		if annotations := r.resource.Spec.Template.GetAnnotations(); annotations != nil {
+			delete(annotations, egressSelectorConfigurationChecksumAnnotation)
+			delete(annotations, proxyCertificateChecksumAnnotation)
+			r.resource.Spec.Template.SetAnnotations(annotations)
+		}
+
 		return nil
 	})
-
-	return res == controllerutil.OperationResultUpdated, err
+	// Reporting the clean-up also when the Deployment was already free of leftovers,
+	// letting the requirements to be removed once the status is no more enabled.
+	return res == controllerutil.OperationResultUpdated || tenantControlPlane.Status.Addons.Konnectivity.Enabled, err
 }
 
 func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
@@ -123,47 +138,126 @@ func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPl
 
 func (r *KubernetesDeploymentResource) syncContainer(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
 	found, index := utilities.HasNamedContainer(r.resource.Spec.Template.Spec.Containers, konnectivityServerName)
+	// The standalone server runs in its own Deployment.
+	if tenantControlPlane.IsKonnectivityStandalone() {
+		if found {
+			containers := r.resource.Spec.Template.Spec.Containers
+
+			r.resource.Spec.Template.Spec.Containers = append(containers[:index:index], containers[index+1:]...)
+		}
+
+		return
+	}
+
 	if !found {
 		r.resource.Spec.Template.Spec.Containers = append(r.resource.Spec.Template.Spec.Containers, corev1.Container{})
 		index = len(r.resource.Spec.Template.Spec.Containers) - 1
 	}
 
-	r.resource.Spec.Template.Spec.Containers[index].Name = konnectivityServerName
-	r.resource.Spec.Template.Spec.Containers[index].Image = fmt.Sprintf("%s:%s", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Image, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Version)
-	r.resource.Spec.Template.Spec.Containers[index].Command = []string{"/proxy-server"}
+	syncServerContainer(&r.resource.Spec.Template.Spec.Containers[index], tenantControlPlane)
+}
+
+// syncServerContainer defines the Konnectivity server container, either the sidecar or the standalone one.
+func syncServerContainer(container *corev1.Container, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	container.Name = konnectivityServerName
+	container.Image = fmt.Sprintf("%s:%s", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Image, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Version)
+	container.Command = []string{"/proxy-server"}
 
 	args := utilities.ArgsFromSliceToMap(tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.ExtraArgs)
 
-	args["--uds-name"] = fmt.Sprintf("%s/konnectivity-server.socket", konnectivityServerPath)
 	args["--cluster-cert"] = "/etc/kubernetes/pki/apiserver.crt"
 	args["--cluster-key"] = "/etc/kubernetes/pki/apiserver.key"
-	args["--mode"] = "grpc"
-	args["--server-port"] = "0"
+
+	if tenantControlPlane.IsKonnectivityHTTPConnect() {
+		// The API Server dials the server on the loopback interface, or through the Service of the standalone one,
+		// authenticated with the proxy certificates.
+		delete(args, "--uds-name")
+		args["--mode"] = "http-connect"
+		args["--server-port"] = fmt.Sprintf("%d", proxyServerPort)
+		args["--server-ca-cert"] = fmt.Sprintf("%s/%s", proxyPath, proxyCACertName)
+		args["--server-cert"] = fmt.Sprintf("%s/%s", proxyPath, proxyServerCertName)
+		args["--server-key"] = fmt.Sprintf("%s/%s", proxyPath, proxyServerKeyName)
+	} else {
+		args["--uds-name"] = fmt.Sprintf("%s/konnectivity-server.socket", konnectivityServerPath)
+		args["--mode"] = "grpc"
+		args["--server-port"] = "0"
+	}
+
 	args["--agent-port"] = fmt.Sprintf("%d", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
-	args["--admin-port"] = "8133"
-	args["--health-port"] = "8134"
+	adminPort, healthPort := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Ports()
+
+	args["--admin-port"] = fmt.Sprintf("%d", adminPort)
+	args["--health-port"] = fmt.Sprintf("%d", healthPort)
 	args["--agent-namespace"] = "kube-system"
 	args["--agent-service-account"] = AgentName
 	args["--kubeconfig"] = "/etc/kubernetes/konnectivity-server.conf"
 	args["--authentication-audience"] = CertCommonName
-	args["--server-count"] = fmt.Sprintf("%d", tenantControlPlane.Spec.ControlPlane.Deployment.Replicas)
+	// Each replica runs a server: the agents connect to all of them, identified by the Pod name.
+	args["--server-id"] = "$(POD_NAME)"
+
+	if leaseCounting := tenantControlPlane.Spec.Addons.Konnectivity.LeaseCounting; leaseCounting != nil {
+		// The agents count the Leases held by the running servers, rather than the static count.
+		delete(args, "--server-count")
+		args["--enable-lease-controller"] = "true"
+		args["--lease-duration"] = leaseCounting.LeaseDuration.Duration.String()
+		args["--lease-renewal-interval"] = leaseCounting.RenewalInterval.Duration.String()
+		args["--lease-namespace"] = AgentNamespace
+		args["--lease-label"] = leaseLabel
+	} else {
+		args["--server-count"] = fmt.Sprintf("%d", tenantControlPlane.KonnectivityServerCount())
+	}
 
-	r.resource.Spec.Template.Spec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
-	r.resource.Spec.Template.Spec.Containers[index].LivenessProbe = &corev1.Probe{
+	if streaming := tenantControlPlane.Spec.Kubernetes.Streaming; streaming != nil && streaming.KeepaliveTime != nil {
+		args["--keepalive-time"] = streaming.KeepaliveTime.Duration.String()
+	}
+
+	if limits := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Limits; limits != nil && limits.FrontendKeepaliveTime != nil {
+		args["--frontend-keepalive-time"] = limits.FrontendKeepaliveTime.Duration.String()
+	}
+
+	for flag, value := range tenantControlPlane.Spec.Addons.Konnectivity.TLSArgs() {
+		args[flag] = value
+	}
+
+	container.Args = utilities.ArgsFromMapToSlice(args)
+	container.Env = []corev1.EnvVar{
+		{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.name",
+				},
+			},
+		},
+	}
+	container.LivenessProbe = &corev1.Probe{
 		InitialDelaySeconds: 30,
 		TimeoutSeconds:      60,
 		PeriodSeconds:       10,
 		SuccessThreshold:    1,
 		FailureThreshold:    3,
-		ProbeHandler: corev1.ProbeHandler{
-			HTTPGet: &corev1.HTTPGetAction{
-				Path:   "/healthz",
-				Port:   intstr.FromInt(8134),
-				Scheme: corev1.URISchemeHTTP,
-			},
-		},
+		ProbeHandler:        healthProbeHandler(healthPort),
+	}
+	container.ReadinessProbe = nil
+
+	if probes := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Probes; probes != nil {
+		applyProbeTimings(container.LivenessProbe, probes.Liveness)
+
+		if probes.Readiness != nil {
+			readiness := &corev1.Probe{
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+				ProbeHandler:     healthProbeHandler(healthPort),
+			}
+			applyProbeTimings(readiness, probes.Readiness)
+
+			container.ReadinessProbe = readiness
+		}
 	}
-	r.resource.Spec.Template.Spec.Containers[index].Ports = []corev1.ContainerPort{
+	container.Ports = []corev1.ContainerPort{
 		{
 			Name:          "agentport",
 			ContainerPort: tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port,
@@ -171,18 +265,18 @@ func (r *KubernetesDeploymentResource) syncContainer(tenantControlPlane *kamajiv
 		},
 		{
 			Name:          "adminport",
-			ContainerPort: 8133,
+			ContainerPort: adminPort,
 			Protocol:      corev1.ProtocolTCP,
 		},
 		{
 			Name:          "healthport",
-			ContainerPort: 8134,
+			ContainerPort: healthPort,
 			Protocol:      corev1.ProtocolTCP,
 		},
 	}
-	r.resource.Spec.Template.Spec.Containers[index].VolumeMounts = []corev1.VolumeMount{
+	container.VolumeMounts = []corev1.VolumeMount{
 		{
-			Name:      "etc-kubernetes-pki",
+			Name:      kubernetesPKIVolume,
 			MountPath: "/etc/kubernetes/pki",
 			ReadOnly:  true,
 		},
@@ -192,21 +286,79 @@ func (r *KubernetesDeploymentResource) syncContainer(tenantControlPlane *kamajiv
 			SubPath:   "konnectivity-server.conf",
 			ReadOnly:  true,
 		},
-		{
+	}
+
+	if !tenantControlPlane.IsKonnectivityStandalone() {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 			Name:      konnectivityUDSVolume,
 			MountPath: konnectivityServerPath,
 			ReadOnly:  false,
-		},
+		})
+	}
+
+	if tenantControlPlane.IsKonnectivityHTTPConnect() {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      konnectivityProxyVolume,
+			MountPath: proxyPath,
+			ReadOnly:  true,
+		})
 	}
-	r.resource.Spec.Template.Spec.Containers[index].ImagePullPolicy = corev1.PullAlways
-	r.resource.Spec.Template.Spec.Containers[index].Resources = corev1.ResourceRequirements{
+	container.ImagePullPolicy = corev1.PullAlways
+	if policy := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.ImagePullPolicy; len(policy) > 0 {
+		container.ImagePullPolicy = policy
+	}
+	container.Resources = corev1.ResourceRequirements{
 		Limits:   nil,
 		Requests: nil,
 	}
 
 	if resources := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Resources; resources != nil {
-		r.resource.Spec.Template.Spec.Containers[index].Resources.Limits = resources.Limits
-		r.resource.Spec.Template.Spec.Containers[index].Resources.Requests = resources.Requests
+		container.Resources.Limits = resources.Limits
+		container.Resources.Requests = resources.Requests
+	}
+	// The memory limit bounds the tunnels served by the server, which is restarted on its own once exceeded.
+	if limits := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Limits; limits != nil && limits.Memory != nil {
+		if _, ok := container.Resources.Limits[corev1.ResourceMemory]; !ok {
+			resourceLimits := corev1.ResourceList{corev1.ResourceMemory: *limits.Memory}
+			for name, quantity := range container.Resources.Limits {
+				resourceLimits[name] = quantity
+			}
+
+			container.Resources.Limits = resourceLimits
+		}
+	}
+}
+
+func healthProbeHandler(healthPort int32) corev1.ProbeHandler {
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   "/healthz",
+			Port:   intstr.FromInt(int(healthPort)),
+			Scheme: corev1.URISchemeHTTP,
+		},
+	}
+}
+
+// applyProbeTimings overrides the probe timings with the specified ones.
+func applyProbeTimings(probe *corev1.Probe, spec *kamajiv1alpha1.ProbeSpec) {
+	if spec == nil {
+		return
+	}
+
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
 	}
 }
 
@@ -224,11 +376,25 @@ func (r *KubernetesDeploymentResource) mutate(_ context.Context, tenantControlPl
 
 		r.syncContainer(tenantControlPlane)
 
-		if err = r.patchKubeAPIServerContainer(); err != nil {
+		if err = r.patchKubeAPIServerContainer(tenantControlPlane); err != nil {
 			return errors.Wrap(err, "cannot sync patch kube-apiserver container")
 		}
 
 		r.syncVolumes(tenantControlPlane)
+		r.syncReadinessGate(tenantControlPlane.Spec.Addons.Konnectivity.ReadinessGate)
+		// The API Server doesn't reload the egress selector configuration: rolling out the Pods upon a change,
+		// such as when switching to the direct egress, and back.
+		annotations := utilities.MergeMaps(r.resource.Spec.Template.GetAnnotations(), map[string]string{
+			egressSelectorConfigurationChecksumAnnotation: tenantControlPlane.Status.Addons.Konnectivity.ConfigMap.Checksum,
+		})
+		// The proxy certificates are read upon the start only.
+		delete(annotations, proxyCertificateChecksumAnnotation)
+
+		if tenantControlPlane.IsKonnectivityHTTPConnect() {
+			annotations[proxyCertificateChecksumAnnotation] = tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate.Checksum
+		}
+
+		r.resource.Spec.Template.SetAnnotations(annotations)
 
 		return nil
 	}
@@ -248,7 +414,7 @@ func (r *KubernetesDeploymentResource) UpdateTenantControlPlaneStatus(_ context.
 	return nil
 }
 
-func (r *KubernetesDeploymentResource) patchKubeAPIServerContainer() error {
+func (r *KubernetesDeploymentResource) patchKubeAPIServerContainer(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	// Patching VolumesMounts
 	found, index := false, 0
 
@@ -263,16 +429,24 @@ func (r *KubernetesDeploymentResource) patchKubeAPIServerContainer() error {
 
 	r.resource.Spec.Template.Spec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
 
-	vFound, vIndex := false, 0
-	// Patching the volume mounts
-	if vFound, vIndex = utilities.HasNamedVolumeMount(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, konnectivityUDSVolume); !vFound {
-		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts = append(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, corev1.VolumeMount{})
-		vIndex = len(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts) - 1
-	}
+	// Patching the volume mounts: the socket is shared with the sidecar server only
+	vFound, vIndex := utilities.HasNamedVolumeMount(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, konnectivityUDSVolume)
 
-	r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].Name = konnectivityUDSVolume
-	r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].ReadOnly = false
-	r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].MountPath = konnectivityServerPath
+	switch {
+	case !tenantControlPlane.IsKonnectivityStandalone():
+		if !vFound {
+			r.resource.Spec.Template.Spec.Containers[index].VolumeMounts = append(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, corev1.VolumeMount{})
+			vIndex = len(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts) - 1
+		}
+
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].Name = konnectivityUDSVolume
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].ReadOnly = false
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].MountPath = konnectivityServerPath
+	case vFound:
+		mounts := r.resource.Spec.Template.Spec.Containers[index].VolumeMounts
+
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts = append(mounts[:vIndex:vIndex], mounts[vIndex+1:]...)
+	}
 
 	if vFound, vIndex = utilities.HasNamedVolumeMount(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, egressSelectorConfigurationVolume); !vFound {
 		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts = append(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, corev1.VolumeMount{})
@@ -283,23 +457,53 @@ func (r *KubernetesDeploymentResource) patchKubeAPIServerContainer() error {
 	r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].ReadOnly = false
 	r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].MountPath = "/etc/kubernetes/konnectivity/configurations"
 
+	vFound, vIndex = utilities.HasNamedVolumeMount(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, konnectivityProxyVolume)
+
+	switch {
+	case tenantControlPlane.IsKonnectivityHTTPConnect():
+		if !vFound {
+			r.resource.Spec.Template.Spec.Containers[index].VolumeMounts = append(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts, corev1.VolumeMount{})
+			vIndex = len(r.resource.Spec.Template.Spec.Containers[index].VolumeMounts) - 1
+		}
+
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].Name = konnectivityProxyVolume
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].ReadOnly = true
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts[vIndex].MountPath = proxyPath
+	case vFound:
+		mounts := r.resource.Spec.Template.Spec.Containers[index].VolumeMounts
+
+		r.resource.Spec.Template.Spec.Containers[index].VolumeMounts = append(mounts[:vIndex:vIndex], mounts[vIndex+1:]...)
+	}
+
 	return nil
 }
 
 func (r *KubernetesDeploymentResource) syncVolumes(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	standalone := tenantControlPlane.IsKonnectivityStandalone()
+	// The standalone server mounts its own kubeconfig, and it's not sharing the UDS socket.
+	for _, volumeName := range []string{konnectivityUDSVolume, konnectivityServerKubeconfigVolume} {
+		if found, index := utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, volumeName); found && standalone {
+			volumes := r.resource.Spec.Template.Spec.Volumes
+
+			r.resource.Spec.Template.Spec.Volumes = append(volumes[:index:index], volumes[index+1:]...)
+		}
+	}
+
 	found, index := false, 0
 	// Defining volumes for the UDS socket
-	found, index = utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, konnectivityUDSVolume)
-	if !found {
-		r.resource.Spec.Template.Spec.Volumes = append(r.resource.Spec.Template.Spec.Volumes, corev1.Volume{})
-		index = len(r.resource.Spec.Template.Spec.Volumes) - 1
-	}
+	if !standalone {
+		found, index = utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, konnectivityUDSVolume)
+		if !found {
+			r.resource.Spec.Template.Spec.Volumes = append(r.resource.Spec.Template.Spec.Volumes, corev1.Volume{})
+			index = len(r.resource.Spec.Template.Spec.Volumes) - 1
+		}
 
-	r.resource.Spec.Template.Spec.Volumes[index].Name = konnectivityUDSVolume
-	r.resource.Spec.Template.Spec.Volumes[index].VolumeSource = corev1.VolumeSource{
-		EmptyDir: &corev1.EmptyDirVolumeSource{
-			Medium: "Memory",
-		},
+		r.resource.Spec.Template.Spec.Volumes[index].Name = konnectivityUDSVolume
+		r.resource.Spec.Template.Spec.Volumes[index].VolumeSource = corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium: "Memory",
+			},
+		}
 	}
 	// Defining volumes for the egress selector configuration
 	found, index = utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, egressSelectorConfigurationVolume)
@@ -318,17 +522,58 @@ func (r *KubernetesDeploymentResource) syncVolumes(tenantControlPlane *kamajiv1a
 		},
 	}
 	// Defining volume for the Konnectivity kubeconfig
-	found, index = utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, konnectivityServerKubeconfigVolume)
-	if !found {
-		r.resource.Spec.Template.Spec.Volumes = append(r.resource.Spec.Template.Spec.Volumes, corev1.Volume{})
-		index = len(r.resource.Spec.Template.Spec.Volumes) - 1
+	if !standalone {
+		found, index = utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, konnectivityServerKubeconfigVolume)
+		if !found {
+			r.resource.Spec.Template.Spec.Volumes = append(r.resource.Spec.Template.Spec.Volumes, corev1.Volume{})
+			index = len(r.resource.Spec.Template.Spec.Volumes) - 1
+		}
+
+		r.resource.Spec.Template.Spec.Volumes[index].Name = konnectivityServerKubeconfigVolume
+		r.resource.Spec.Template.Spec.Volumes[index].VolumeSource = corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.SecretName,
+				DefaultMode: pointer.Int32(420),
+			},
+		}
 	}
+	// Defining volume for the proxy certificates, required by the http-connect mode only
+	found, index = utilities.HasNamedVolume(r.resource.Spec.Template.Spec.Volumes, konnectivityProxyVolume)
 
-	r.resource.Spec.Template.Spec.Volumes[index].Name = konnectivityServerKubeconfigVolume
-	r.resource.Spec.Template.Spec.Volumes[index].VolumeSource = corev1.VolumeSource{
-		Secret: &corev1.SecretVolumeSource{
-			SecretName:  tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.SecretName,
-			DefaultMode: pointer.Int32(420),
-		},
+	switch {
+	case tenantControlPlane.IsKonnectivityHTTPConnect():
+		if !found {
+			r.resource.Spec.Template.Spec.Volumes = append(r.resource.Spec.Template.Spec.Volumes, corev1.Volume{})
+			index = len(r.resource.Spec.Template.Spec.Volumes) - 1
+		}
+
+		r.resource.Spec.Template.Spec.Volumes[index].Name = konnectivityProxyVolume
+		r.resource.Spec.Template.Spec.Volumes[index].VolumeSource = corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate.SecretName,
+				DefaultMode: pointer.Int32(420),
+			},
+		}
+	case found:
+		volumes := r.resource.Spec.Template.Spec.Volumes
+
+		r.resource.Spec.Template.Spec.Volumes = append(volumes[:index:index], volumes[index+1:]...)
+	}
+}
+
+// syncReadinessGate adds, or removes, the readiness gate waiting for the Konnectivity agents to be connected.
+func (r *KubernetesDeploymentResource) syncReadinessGate(enabled bool) {
+	var gates []corev1.PodReadinessGate
+
+	for _, gate := range r.resource.Spec.Template.Spec.ReadinessGates {
+		if gate.ConditionType != AgentsConnectedReadinessGate {
+			gates = append(gates, gate)
+		}
 	}
+
+	if enabled {
+		gates = append(gates, corev1.PodReadinessGate{ConditionType: AgentsConnectedReadinessGate})
+	}
+
+	r.resource.Spec.Template.Spec.ReadinessGates = gates
 }
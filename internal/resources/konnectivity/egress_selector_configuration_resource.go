@@ -5,9 +5,12 @@ package konnectivity
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiserverv1alpha1 "k8s.io/apiserver/pkg/apis/apiserver/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -23,6 +26,7 @@ import (
 type EgressSelectorConfigurationResource struct {
 	resource *corev1.ConfigMap
 	Client   client.Client
+	fallback bool
 }
 
 func (r *EgressSelectorConfigurationResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
@@ -33,6 +37,8 @@ func (r *EgressSelectorConfigurationResource) Define(ctx context.Context, tenant
 		},
 	}
 
+	r.fallback, _ = tenantControlPlane.KonnectivityFallbackActive()
+
 	return nil
 }
 
@@ -41,6 +47,11 @@ func (r *EgressSelectorConfigurationResource) ShouldCleanup(tenantControlPlane *
 }
 
 func (r *EgressSelectorConfigurationResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// The Tenant Control Plane Pods could still reference it: waiting for the Deployment clean-up first.
+	if tenantControlPlane.Status.Addons.Konnectivity.Enabled {
+		return false, nil
+	}
+
 	logger := log.FromContext(ctx, "resource", r.GetName())
 
 	if err := r.Client.Delete(ctx, r.resource); err != nil {
@@ -65,7 +76,9 @@ func (r *EgressSelectorConfigurationResource) GetName() string {
 }
 
 func (r *EgressSelectorConfigurationResource) ShouldStatusBeUpdated(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Addons.Konnectivity.ConfigMap.Checksum != r.resource.GetAnnotations()[constants.Checksum]
+	degraded := meta.IsStatusConditionTrue(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityDegraded)
+
+	return tenantControlPlane.Status.Addons.Konnectivity.ConfigMap.Checksum != r.resource.GetAnnotations()[constants.Checksum] || degraded != r.fallback
 }
 
 func (r *EgressSelectorConfigurationResource) UpdateTenantControlPlaneStatus(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
@@ -73,18 +86,71 @@ func (r *EgressSelectorConfigurationResource) UpdateTenantControlPlaneStatus(ctx
 		tenantControlPlane.Status.Addons.Konnectivity.ConfigMap.Name = r.resource.GetName()
 		tenantControlPlane.Status.Addons.Konnectivity.ConfigMap.Checksum = r.resource.GetAnnotations()[constants.Checksum]
 
+		r.setDegradedCondition(tenantControlPlane)
+
 		return nil
 	}
 
 	tenantControlPlane.Status.Addons.Konnectivity.ConfigMap = kamajiv1alpha1.KonnectivityConfigMap{}
+	meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityDegraded)
 
 	return nil
 }
 
+func (r *EgressSelectorConfigurationResource) setDegradedCondition(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	if !r.fallback {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityDegraded)
+
+		return
+	}
+
+	meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeKonnectivityDegraded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             "AgentsUnavailable",
+		Message:            fmt.Sprintf("no Konnectivity agent available since %s, using the direct egress", tenantControlPlane.Status.Addons.Konnectivity.AgentsUnavailableSince.UTC().Format(time.RFC3339)),
+	})
+}
+
 func (r *EgressSelectorConfigurationResource) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) func() error {
 	return func() error {
 		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels()))
 
+		connection := apiserverv1alpha1.Connection{
+			ProxyProtocol: apiserverv1alpha1.ProtocolGRPC,
+			Transport: &apiserverv1alpha1.Transport{
+				UDS: &apiserverv1alpha1.UDSTransport{
+					UDSName: defaultUDSName,
+				},
+			},
+		}
+		if tenantControlPlane.IsKonnectivityHTTPConnect() {
+			// The standalone server is reached through its Service, rather than on the loopback interface.
+			host := "127.0.0.1"
+			if tenantControlPlane.IsKonnectivityStandalone() {
+				host = standaloneServerHost(tenantControlPlane)
+			}
+
+			connection = apiserverv1alpha1.Connection{
+				ProxyProtocol: apiserverv1alpha1.ProtocolHTTPConnect,
+				Transport: &apiserverv1alpha1.Transport{
+					TCP: &apiserverv1alpha1.TCPTransport{
+						URL: fmt.Sprintf("https://%s:%d", host, proxyServerPort),
+						TLSConfig: &apiserverv1alpha1.TLSConfig{
+							CABundle:   fmt.Sprintf("%s/%s", proxyPath, proxyCACertName),
+							ClientKey:  fmt.Sprintf("%s/%s", proxyPath, proxyClientKeyName),
+							ClientCert: fmt.Sprintf("%s/%s", proxyPath, proxyClientCertName),
+						},
+					},
+				},
+			}
+		}
+		// The agents are unavailable: reaching the worker nodes directly until they're back.
+		if r.fallback {
+			connection = apiserverv1alpha1.Connection{ProxyProtocol: apiserverv1alpha1.ProtocolDirect}
+		}
+
 		configuration := &apiserverv1alpha1.EgressSelectorConfiguration{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       egressSelectorConfigurationKind,
@@ -92,15 +158,8 @@ func (r *EgressSelectorConfigurationResource) mutate(_ context.Context, tenantCo
 			},
 			EgressSelections: []apiserverv1alpha1.EgressSelection{
 				{
-					Name: egressSelectorConfigurationName,
-					Connection: apiserverv1alpha1.Connection{
-						ProxyProtocol: apiserverv1alpha1.ProtocolGRPC,
-						Transport: &apiserverv1alpha1.Transport{
-							UDS: &apiserverv1alpha1.UDSTransport{
-								UDSName: defaultUDSName,
-							},
-						},
-					},
+					Name:       egressSelectorConfigurationName,
+					Connection: connection,
 				},
 			},
 		}
@@ -6,6 +6,7 @@ package konnectivity
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -29,15 +30,22 @@ type KubeconfigResource struct {
 }
 
 func (r *KubeconfigResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.Checksum != r.resource.GetAnnotations()[constants.Checksum]
+	return tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.Checksum != r.resource.GetAnnotations()[constants.Checksum] ||
+		!reflect.DeepEqual(tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.LegacyKeys, utilities.LegacyKubeconfigKeys(r.resource.Data, konnectivityKubeconfigFileName))
 }
 
 func (r *KubeconfigResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
 	return tenantControlPlane.Spec.Addons.Konnectivity == nil
 }
 
-func (r *KubeconfigResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+func (r *KubeconfigResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// The Tenant Control Plane Pods could still reference it: waiting for the Deployment clean-up first.
+	if tenantControlPlane.Status.Addons.Konnectivity.Enabled {
+		return false, nil
+	}
+
 	logger := log.FromContext(ctx, "resource", r.GetName())
+
 	if err := r.Client.Delete(ctx, r.resource); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			logger.Error(err, "cannot delete the requested resourece")
@@ -75,6 +83,7 @@ func (r *KubeconfigResource) UpdateTenantControlPlaneStatus(_ context.Context, t
 		tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.LastUpdate = metav1.Now()
 		tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.SecretName = r.resource.GetName()
 		tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.Checksum = r.resource.GetAnnotations()[constants.Checksum]
+		tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.LegacyKeys = utilities.LegacyKubeconfigKeys(r.resource.Data, konnectivityKubeconfigFileName)
 
 		return nil
 	}
@@ -89,6 +98,12 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 		logger := log.FromContext(ctx, "resource", r.GetName())
 
 		if checksum := tenantControlPlane.Status.Addons.Konnectivity.Certificate.Checksum; len(checksum) > 0 && checksum == r.resource.GetAnnotations()[constants.Checksum] {
+			if tenantControlPlane.LegacyKubeconfigFormatsDisabled() {
+				for _, key := range utilities.LegacyKubeconfigKeys(r.resource.Data, konnectivityKubeconfigFileName) {
+					delete(r.resource.Data, key)
+				}
+			}
+
 			return nil
 		}
 
@@ -110,6 +125,11 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 
 		userName := CertCommonName
 		clusterName := defaultClusterName
+		// The standalone server is not running along with the API Server, reached through its Service.
+		server := "localhost"
+		if tenantControlPlane.IsKonnectivityStandalone() {
+			server = fmt.Sprintf("%s.%s.svc", tenantControlPlane.GetName(), tenantControlPlane.GetNamespace())
+		}
 		contextName := fmt.Sprintf("%s@%s", userName, clusterName)
 
 		kubeconfig := &clientcmdapiv1.Config{
@@ -128,7 +148,7 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 				{
 					Name: clusterName,
 					Cluster: clientcmdapiv1.Cluster{
-						Server:                   fmt.Sprintf("https://%s:%d", "localhost", tenantControlPlane.Spec.NetworkProfile.Port),
+						Server:                   fmt.Sprintf("https://%s:%d", server, tenantControlPlane.Spec.NetworkProfile.Port),
 						CertificateAuthorityData: secretCA.Data[kubeadmconstants.CACertName],
 					},
 				},
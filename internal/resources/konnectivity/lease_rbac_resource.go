@@ -0,0 +1,189 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// LeaseRBACResource grants the access to the Konnectivity server Leases in the Tenant Cluster when they're used to count the servers:
+// the servers manage their own Leases, the agents read them.
+type LeaseRBACResource struct {
+	Client client.Client
+
+	serverRole        *rbacv1.Role
+	serverRoleBinding *rbacv1.RoleBinding
+	agentRole         *rbacv1.Role
+	agentRoleBinding  *rbacv1.RoleBinding
+	tenantClient      client.Client
+}
+
+func (r *LeaseRBACResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.IsKonnectivityLeaseCounting() {
+		return tenantControlPlane.Status.Addons.Konnectivity.Leases.Name != r.serverRole.GetName()
+	}
+
+	return len(tenantControlPlane.Status.Addons.Konnectivity.Leases.Name) > 0
+}
+
+func (r *LeaseRBACResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.IsKonnectivityLeaseCounting() || len(tenantControlPlane.Status.Addons.Konnectivity.Leases.Name) == 0 {
+		return false
+	}
+
+	if tenantControlPlane.Spec.Addons.Konnectivity == nil {
+		allowed, _, _ := tenantControlPlane.KonnectivityRemovalAllowed()
+
+		return allowed
+	}
+
+	return true
+}
+
+func (r *LeaseRBACResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	var deleted bool
+
+	for _, obj := range []client.Object{r.serverRoleBinding, r.agentRoleBinding, r.serverRole, r.agentRole} {
+		if err := r.tenantClient.Delete(ctx, obj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+func (r *LeaseRBACResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	r.serverRole = &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: leaseRoleName, Namespace: AgentNamespace}}
+	r.serverRoleBinding = &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: leaseRoleName, Namespace: AgentNamespace}}
+	r.agentRole = &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: leaseAgentRoleName, Namespace: AgentNamespace}}
+	r.agentRoleBinding = &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: leaseAgentRoleName, Namespace: AgentNamespace}}
+
+	if !tenantControlPlane.IsKonnectivityLeaseCounting() && len(tenantControlPlane.Status.Addons.Konnectivity.Leases.Name) == 0 {
+		return nil
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		logger.Error(err, "cannot get Tenant Control Plane client")
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *LeaseRBACResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !tenantControlPlane.IsKonnectivityLeaseCounting() {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	serverSubject := rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: CertCommonName}
+	agentSubject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: AgentName, Namespace: AgentNamespace}
+
+	result := controllerutil.OperationResultNone
+
+	for _, fn := range []func() (controllerutil.OperationResult, error){
+		func() (controllerutil.OperationResult, error) {
+			return createOrUpdate(ctx, r.tenantClient, r.serverRole, tenantControlPlane, r.mutateRole(r.serverRole, "get", "list", "watch", "create", "update", "delete"))
+		},
+		func() (controllerutil.OperationResult, error) {
+			return createOrUpdate(ctx, r.tenantClient, r.serverRoleBinding, tenantControlPlane, r.mutateRoleBinding(r.serverRoleBinding, serverSubject))
+		},
+		func() (controllerutil.OperationResult, error) {
+			return createOrUpdate(ctx, r.tenantClient, r.agentRole, tenantControlPlane, r.mutateRole(r.agentRole, "get", "list", "watch"))
+		},
+		func() (controllerutil.OperationResult, error) {
+			return createOrUpdate(ctx, r.tenantClient, r.agentRoleBinding, tenantControlPlane, r.mutateRoleBinding(r.agentRoleBinding, agentSubject))
+		},
+	} {
+		res, err := fn()
+		if err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+
+		if res != controllerutil.OperationResultNone {
+			result = res
+		}
+	}
+
+	return result, nil
+}
+
+func (r *LeaseRBACResource) GetName() string {
+	return "konnectivity-lease-rbac"
+}
+
+func (r *LeaseRBACResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.IsKonnectivityLeaseCounting() {
+		tenantControlPlane.Status.Addons.Konnectivity.Leases = kamajiv1alpha1.ExternalKubernetesObjectStatus{
+			Name:       r.serverRole.GetName(),
+			Namespace:  r.serverRole.GetNamespace(),
+			LastUpdate: metav1.Now(),
+		}
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Addons.Konnectivity.Leases = kamajiv1alpha1.ExternalKubernetesObjectStatus{}
+
+	return nil
+}
+
+func (r *LeaseRBACResource) mutateRole(role *rbacv1.Role, verbs ...string) controllerutil.MutateFn {
+	return func() error {
+		role.SetLabels(utilities.MergeMaps(role.GetLabels(), utilities.KamajiLabels()))
+
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{coordinationv1.GroupName},
+				Resources: []string{"leases"},
+				Verbs:     verbs,
+			},
+		}
+
+		return nil
+	}
+}
+
+func (r *LeaseRBACResource) mutateRoleBinding(binding *rbacv1.RoleBinding, subject rbacv1.Subject) controllerutil.MutateFn {
+	return func() error {
+		binding.SetLabels(utilities.MergeMaps(binding.GetLabels(), utilities.KamajiLabels()))
+
+		binding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     binding.GetName(),
+		}
+		binding.Subjects = []rbacv1.Subject{subject}
+
+		return nil
+	}
+}
+
+// IsLeaseRoleBinding returns true for the RoleBindings granting the access to the Konnectivity server Leases.
+func IsLeaseRoleBinding(object client.Object) bool {
+	return object.GetNamespace() == AgentNamespace && (object.GetName() == leaseRoleName || object.GetName() == leaseAgentRoleName)
+}
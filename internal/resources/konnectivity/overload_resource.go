@@ -0,0 +1,140 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// OverloadResource inspects the Konnectivity server containers of the Tenant Control Plane Pods, reporting the
+// KonnectivityServerOverloaded condition when they have been restarted for exceeding their memory limit.
+type OverloadResource struct {
+	Client  client.Client
+	message string
+}
+
+func (r *OverloadResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.message = ""
+
+	konnectivity := tenantControlPlane.Spec.Addons.Konnectivity
+	if konnectivity == nil {
+		return nil
+	}
+
+	threshold := int32(1)
+	if limits := konnectivity.KonnectivityServerSpec.Limits; limits != nil && limits.RestartThreshold > 0 {
+		threshold = limits.RestartThreshold
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.GetName()}, deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		logger.Error(err, "unable to retrieve the Tenant Control Plane deployment")
+
+		return err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	pods := &corev1.PodList{}
+	if err = r.Client.List(ctx, pods, client.InNamespace(tenantControlPlane.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.Error(err, "unable to list the Tenant Control Plane pods")
+
+		return err
+	}
+
+	var overloaded []string
+
+	for _, pod := range pods.Items {
+		if pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != konnectivityServerName || status.RestartCount < threshold {
+				continue
+			}
+
+			if terminated := status.LastTerminationState.Terminated; terminated != nil && terminated.Reason == "OOMKilled" {
+				overloaded = append(overloaded, fmt.Sprintf("%s (%d restarts)", pod.GetName(), status.RestartCount))
+			}
+		}
+	}
+
+	if len(overloaded) == 0 {
+		return nil
+	}
+
+	sort.Strings(overloaded)
+
+	r.message = fmt.Sprintf("the Konnectivity server exceeded its memory limit in the pods %s", strings.Join(overloaded, ", "))
+
+	return nil
+}
+
+func (r *OverloadResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *OverloadResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *OverloadResource) CreateOrUpdate(context.Context, *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *OverloadResource) GetName() string {
+	return "konnectivity-overload"
+}
+
+func (r *OverloadResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	condition := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityServerOverloaded)
+	if len(r.message) == 0 {
+		return condition != nil
+	}
+
+	return condition == nil || condition.Message != r.message
+}
+
+func (r *OverloadResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if len(r.message) == 0 {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityServerOverloaded)
+
+		return nil
+	}
+
+	meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeKonnectivityServerOverloaded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             "MemoryLimitExceeded",
+		Message:            r.message,
+	})
+
+	return nil
+}
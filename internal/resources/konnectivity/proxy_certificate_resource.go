@@ -0,0 +1,199 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ProxyCertificateResource generates the certificates securing the connection between the API Server and the Konnectivity server
+// in the http-connect mode: the server one is presented by the Konnectivity server on the loopback interface,
+// the client one is used by the API Server, both signed by the Tenant Control Plane CA.
+type ProxyCertificateResource struct {
+	resource *corev1.Secret
+	Client   client.Client
+}
+
+func (r *ProxyCertificateResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate.Checksum != r.resource.GetAnnotations()[constants.Checksum]
+}
+
+func (r *ProxyCertificateResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.IsKonnectivityHTTPConnect()
+}
+
+func (r *ProxyCertificateResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// The Tenant Control Plane Pods could still reference it: waiting for the Deployment clean-up first.
+	if tenantControlPlane.Spec.Addons.Konnectivity == nil && tenantControlPlane.Status.Addons.Konnectivity.Enabled {
+		return false, nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the required resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *ProxyCertificateResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *ProxyCertificateResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return controllerutil.CreateOrUpdate(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *ProxyCertificateResource) GetName() string {
+	return "konnectivity-proxy-certificate"
+}
+
+func (r *ProxyCertificateResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.IsKonnectivityHTTPConnect() {
+		tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate.LastUpdate = metav1.Now()
+		tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate.SecretName = r.resource.GetName()
+		tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate.Checksum = r.resource.GetAnnotations()[constants.Checksum]
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate = kamajiv1alpha1.CertificatePrivateKeyPairStatus{}
+
+	return nil
+}
+
+func (r *ProxyCertificateResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		if checksum := tenantControlPlane.Status.Addons.Konnectivity.ProxyCertificate.Checksum; len(checksum) > 0 && checksum == utilities.CalculateMapChecksum(r.resource.Data) {
+			serverValid, serverErr := crypto.IsValidCertificateKeyPairBytes(r.resource.Data[proxyServerCertName], r.resource.Data[proxyServerKeyName])
+			if serverErr != nil {
+				logger.Info(fmt.Sprintf("proxy server certificate-private_key pair is not valid: %s", serverErr.Error()))
+			}
+
+			clientValid, clientErr := crypto.IsValidCertificateKeyPairBytes(r.resource.Data[proxyClientCertName], r.resource.Data[proxyClientKeyName])
+			if clientErr != nil {
+				logger.Info(fmt.Sprintf("proxy client certificate-private_key pair is not valid: %s", clientErr.Error()))
+			}
+
+			missing, sansErr := crypto.MissingSubjectAlternativeNames(r.resource.Data[proxyServerCertName], r.serverSANs(tenantControlPlane)...)
+			if sansErr != nil {
+				logger.Info(fmt.Sprintf("proxy server certificate SANs cannot be verified: %s", sansErr.Error()))
+			}
+
+			if serverValid && clientValid && sansErr == nil && len(missing) == 0 {
+				return nil
+			}
+		}
+
+		namespacedName := k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}
+		secretCA := &corev1.Secret{}
+		if err := r.Client.Get(ctx, namespacedName, secretCA); err != nil {
+			logger.Error(err, "cannot retrieve the CA secret")
+
+			return err
+		}
+		serverTemplate := crypto.NewCertificateTemplate(konnectivityServerName)
+		serverTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+
+		for _, san := range r.serverSANs(tenantControlPlane) {
+			if ip := net.ParseIP(san); ip != nil {
+				serverTemplate.IPAddresses = append(serverTemplate.IPAddresses, ip)
+
+				continue
+			}
+
+			serverTemplate.DNSNames = append(serverTemplate.DNSNames, san)
+		}
+
+		serverCert, serverKey, err := crypto.GenerateCertificatePrivateKeyPair(serverTemplate, secretCA.Data[kubeadmconstants.CACertName], secretCA.Data[kubeadmconstants.CAKeyName])
+		if err != nil {
+			logger.Error(err, "unable to generate the proxy server certificate and private key")
+
+			return err
+		}
+
+		clientTemplate := crypto.NewCertificateTemplate(kubeadmconstants.APIServerCertCommonName)
+		clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+		clientCert, clientKey, err := crypto.GenerateCertificatePrivateKeyPair(clientTemplate, secretCA.Data[kubeadmconstants.CACertName], secretCA.Data[kubeadmconstants.CAKeyName])
+		if err != nil {
+			logger.Error(err, "unable to generate the proxy client certificate and private key")
+
+			return err
+		}
+
+		r.resource.Data = map[string][]byte{
+			proxyCACertName:     secretCA.Data[kubeadmconstants.CACertName],
+			proxyServerCertName: serverCert.Bytes(),
+			proxyServerKeyName:  serverKey.Bytes(),
+			proxyClientCertName: clientCert.Bytes(),
+			proxyClientKeyName:  clientKey.Bytes(),
+		}
+
+		r.resource.SetLabels(utilities.MergeMaps(
+			utilities.KamajiLabels(),
+			map[string]string{
+				"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+				"kamaji.clastix.io/component": r.GetName(),
+			},
+		))
+
+		annotations := r.resource.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[constants.Checksum] = utilities.CalculateMapChecksum(r.resource.Data)
+		r.resource.SetAnnotations(annotations)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+// serverSANs returns the Subject Alternative Names of the Konnectivity server certificate: the server is dialled by the
+// API Server on the loopback interface of the Pod, or through the Service of the standalone one.
+func (r *ProxyCertificateResource) serverSANs(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) []string {
+	sans := []string{"localhost", "127.0.0.1"}
+
+	if tenantControlPlane.IsKonnectivityStandalone() {
+		name := utilities.AddTenantPrefix(konnectivityServerName, tenantControlPlane)
+
+		sans = append(sans, name, fmt.Sprintf("%s.%s", name, tenantControlPlane.GetNamespace()), standaloneServerHost(tenantControlPlane))
+	}
+
+	return sans
+}
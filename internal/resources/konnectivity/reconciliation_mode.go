@@ -0,0 +1,24 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// createOrUpdate reconciles the agent resources of the Tenant Cluster according to the addon reconciliation mode:
+// once installed, they're left untouched if handed over to the tenant.
+func createOrUpdate(ctx context.Context, c client.Client, obj client.Object, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	if tenantControlPlane.Spec.Addons.Konnectivity.ReconciliationMode == kamajiv1alpha1.AddonReconciliationModeInstallOnce {
+		return utilities.CreateOnce(ctx, c, obj, f)
+	}
+
+	return controllerutil.CreateOrUpdate(ctx, c, obj, f)
+}
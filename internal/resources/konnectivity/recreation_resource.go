@@ -0,0 +1,124 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// RecreationResource tears down the Konnectivity resources when requested with the KonnectivityRecreationAnnotation,
+// letting the following resources rebuild them from scratch: the agents are removed first from the Tenant Cluster,
+// then the server credentials, and finally the egress selector configuration, which is recreated before the API Server
+// Pods are rolled.
+type RecreationResource struct {
+	Client client.Client
+}
+
+func (r *RecreationResource) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *RecreationResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *RecreationResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *RecreationResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !tenantControlPlane.IsKonnectivityRecreationRequested() {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "cannot get Tenant Control Plane client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	tenantResources := []client.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: AgentName, Namespace: AgentNamespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: CertCommonName}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: AgentName, Namespace: AgentNamespace}},
+	}
+
+	for _, obj := range tenantResources {
+		if err = r.delete(ctx, tenantClient, obj); err != nil {
+			logger.Error(err, "cannot delete the Konnectivity agent resource", "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	resources := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: utilities.AddTenantPrefix((&KubeconfigResource{}).GetName(), tenantControlPlane), Namespace: tenantControlPlane.GetNamespace()}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: utilities.AddTenantPrefix((&CertificateResource{}).GetName(), tenantControlPlane), Namespace: tenantControlPlane.GetNamespace()}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: utilities.AddTenantPrefix((&ProxyCertificateResource{}).GetName(), tenantControlPlane), Namespace: tenantControlPlane.GetNamespace()}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: utilities.AddTenantPrefix((&EgressSelectorConfigurationResource{}).GetName(), tenantControlPlane), Namespace: tenantControlPlane.GetNamespace()}},
+	}
+
+	for _, obj := range resources {
+		if err = r.delete(ctx, r.Client, obj); err != nil {
+			logger.Error(err, "cannot delete the Konnectivity server resource", "name", obj.GetName())
+
+			return controllerutil.OperationResultNone, err
+		}
+	}
+	// Removing the request only once all the resources have been deleted:
+	// upon a failure, the tear down is performed again by the next reconciliation.
+	patch := client.MergeFrom(tenantControlPlane.DeepCopy())
+
+	annotations := tenantControlPlane.GetAnnotations()
+	delete(annotations, kamajiv1alpha1.KonnectivityRecreationAnnotation)
+	tenantControlPlane.SetAnnotations(annotations)
+
+	if err = r.Client.Patch(ctx, tenantControlPlane, patch); err != nil {
+		logger.Error(err, "cannot remove the Konnectivity recreation annotation")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	logger.Info("Konnectivity resources have been torn down, rebuilding them")
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+func (r *RecreationResource) delete(ctx context.Context, c client.Client, obj client.Object) error {
+	if err := c.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RecreationResource) GetName() string {
+	return "konnectivity-recreation"
+}
+
+func (r *RecreationResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *RecreationResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	// Resetting the status, the checksums of the deleted resources are no more valid.
+	tenantControlPlane.Status.Addons.Konnectivity = kamajiv1alpha1.KonnectivityStatus{}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// RemovalGateResource tracks the removal of the Konnectivity agent resources from the Tenant Cluster:
+// it records when the addon has been disabled, and reports the KonnectivityRemovalPending condition
+// until the removal policy allows the clean-up.
+type RemovalGateResource struct{}
+
+func (r *RemovalGateResource) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *RemovalGateResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *RemovalGateResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *RemovalGateResource) CreateOrUpdate(context.Context, *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *RemovalGateResource) GetName() string {
+	return "konnectivity-removal-gate"
+}
+
+func (r *RemovalGateResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	pending := tenantControlPlane.IsKonnectivityRemovalPending()
+
+	if pending != (tenantControlPlane.Status.Addons.Konnectivity.RemovalRequestedAt != nil) {
+		return true
+	}
+
+	allowed, reason, _ := tenantControlPlane.KonnectivityRemovalAllowed()
+
+	condition := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityRemovalPending)
+	if !pending || allowed {
+		return condition != nil
+	}
+
+	return condition == nil || condition.Message != reason
+}
+
+func (r *RemovalGateResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !tenantControlPlane.IsKonnectivityRemovalPending() {
+		tenantControlPlane.Status.Addons.Konnectivity.RemovalRequestedAt = nil
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityRemovalPending)
+
+		return nil
+	}
+
+	if tenantControlPlane.Status.Addons.Konnectivity.RemovalRequestedAt == nil {
+		now := metav1.Now()
+		tenantControlPlane.Status.Addons.Konnectivity.RemovalRequestedAt = &now
+	}
+
+	allowed, reason, _ := tenantControlPlane.KonnectivityRemovalAllowed()
+	if allowed {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionTypeKonnectivityRemovalPending)
+
+		return nil
+	}
+
+	meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionTypeKonnectivityRemovalPending,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             "RemovalPolicy",
+		Message:            reason,
+	})
+
+	return nil
+}
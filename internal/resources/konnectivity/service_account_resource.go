@@ -29,7 +29,9 @@ func (r *ServiceAccountResource) ShouldStatusBeUpdated(_ context.Context, tenant
 }
 
 func (r *ServiceAccountResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Spec.Addons.Konnectivity == nil && len(tenantControlPlane.Status.Addons.Konnectivity.ServiceAccount.Name) > 0
+	allowed, _, _ := tenantControlPlane.KonnectivityRemovalAllowed()
+
+	return tenantControlPlane.Spec.Addons.Konnectivity == nil && len(tenantControlPlane.Status.Addons.Konnectivity.ServiceAccount.Name) > 0 && allowed
 }
 
 func (r *ServiceAccountResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
@@ -69,7 +71,7 @@ func (r *ServiceAccountResource) Define(ctx context.Context, tenantControlPlane
 
 func (r *ServiceAccountResource) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	if tcp.Spec.Addons.Konnectivity != nil {
-		return controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.resource, r.mutate())
+		return createOrUpdate(ctx, r.tenantClient, r.resource, tcp, r.mutate())
 	}
 
 	return controllerutil.OperationResultNone, nil
@@ -0,0 +1,244 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	kubernetesPKIVolume = "etc-kubernetes-pki"
+
+	kubeconfigChecksumAnnotation           = "component.kamaji.clastix.io/konnectivity-kubeconfig-checksum"
+	apiServerCertificateChecksumAnnotation = "component.kamaji.clastix.io/api-server-certificate-checksum"
+)
+
+// standaloneServerHost returns the in-cluster host of the Service exposing the standalone servers to the API Server.
+func standaloneServerHost(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	return fmt.Sprintf("%s.%s.svc", utilities.AddTenantPrefix(konnectivityServerName, tenantControlPlane), tenantControlPlane.GetNamespace())
+}
+
+func standaloneLabels(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	return map[string]string{
+		"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+		"kamaji.clastix.io/component": konnectivityServerName,
+	}
+}
+
+// StandaloneDeploymentResource runs the Konnectivity servers in their own Deployment, scaled and restarted independently
+// of the Tenant Control Plane Pods: they present the API Server certificate to the agents, as the sidecar ones.
+type StandaloneDeploymentResource struct {
+	resource *appsv1.Deployment
+	Client   client.Client
+}
+
+func (r *StandaloneDeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Status.Addons.Konnectivity.ServerDeployment.Name != r.resource.GetName() ||
+		tenantControlPlane.Status.Addons.Konnectivity.ServerDeployment.Namespace != r.resource.GetNamespace()
+}
+
+func (r *StandaloneDeploymentResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.IsKonnectivityStandalone()
+}
+
+func (r *StandaloneDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the required resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *StandaloneDeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(konnectivityServerName, tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *StandaloneDeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *StandaloneDeploymentResource) GetName() string {
+	return "konnectivity-standalone-deployment"
+}
+
+func (r *StandaloneDeploymentResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !tenantControlPlane.IsKonnectivityStandalone() {
+		tenantControlPlane.Status.Addons.Konnectivity.ServerDeployment = kamajiv1alpha1.ExternalKubernetesObjectStatus{}
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Addons.Konnectivity.ServerDeployment = kamajiv1alpha1.ExternalKubernetesObjectStatus{
+		Name:       r.resource.GetName(),
+		Namespace:  r.resource.GetNamespace(),
+		LastUpdate: metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *StandaloneDeploymentResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		status := tenantControlPlane.Status
+
+		if len(status.Addons.Konnectivity.ProxyCertificate.SecretName) == 0 || len(status.Addons.Konnectivity.Kubeconfig.SecretName) == 0 {
+			return fmt.Errorf("konnectivity proxy certificate and kubeconfig are not yet available")
+		}
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(), standaloneLabels(tenantControlPlane)))
+
+		r.resource.Spec.Replicas = pointer.Int32(tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Deployment.Replicas)
+		r.resource.Spec.Selector = &metav1.LabelSelector{MatchLabels: standaloneLabels(tenantControlPlane)}
+		r.resource.Spec.Template.SetLabels(utilities.MergeMaps(r.resource.Spec.Template.GetLabels(), standaloneLabels(tenantControlPlane)))
+		// The certificates, and the kubeconfig, are read upon the start only.
+		r.resource.Spec.Template.SetAnnotations(utilities.MergeMaps(r.resource.Spec.Template.GetAnnotations(), map[string]string{
+			proxyCertificateChecksumAnnotation:     status.Addons.Konnectivity.ProxyCertificate.Checksum,
+			kubeconfigChecksumAnnotation:           status.Addons.Konnectivity.Kubeconfig.Checksum,
+			apiServerCertificateChecksumAnnotation: status.Certificates.APIServer.Checksum,
+		}))
+
+		podSpec := &r.resource.Spec.Template.Spec
+
+		if len(podSpec.Containers) != 1 {
+			podSpec.Containers = make([]corev1.Container, 1)
+		}
+
+		syncServerContainer(&podSpec.Containers[0], tenantControlPlane)
+
+		podSpec.Volumes = []corev1.Volume{
+			{
+				Name: kubernetesPKIVolume,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  status.Certificates.APIServer.SecretName,
+						DefaultMode: pointer.Int32(420),
+					},
+				},
+			},
+			{
+				Name: konnectivityServerKubeconfigVolume,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  status.Addons.Konnectivity.Kubeconfig.SecretName,
+						DefaultMode: pointer.Int32(420),
+					},
+				},
+			},
+			{
+				Name: konnectivityProxyVolume,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  status.Addons.Konnectivity.ProxyCertificate.SecretName,
+						DefaultMode: pointer.Int32(420),
+					},
+				},
+			},
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+// StandaloneServiceResource exposes the standalone Konnectivity servers to the API Server, in the http-connect mode:
+// the agents connect to them through the dedicated Service.
+type StandaloneServiceResource struct {
+	resource *corev1.Service
+	Client   client.Client
+}
+
+func (r *StandaloneServiceResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *StandaloneServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.IsKonnectivityStandalone()
+}
+
+func (r *StandaloneServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the required resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *StandaloneServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(konnectivityServerName, tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *StandaloneServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *StandaloneServiceResource) GetName() string {
+	return "konnectivity-standalone-service"
+}
+
+func (r *StandaloneServiceResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *StandaloneServiceResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(), standaloneLabels(tenantControlPlane)))
+
+		r.resource.Spec.Type = corev1.ServiceTypeClusterIP
+		r.resource.Spec.Selector = standaloneLabels(tenantControlPlane)
+
+		if len(r.resource.Spec.Ports) != 1 {
+			r.resource.Spec.Ports = make([]corev1.ServicePort, 1)
+		}
+
+		r.resource.Spec.Ports[0].Name = "proxy-server"
+		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		r.resource.Spec.Ports[0].Port = proxyServerPort
+		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(proxyServerPort)
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
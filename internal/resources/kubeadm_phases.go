@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
 	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
@@ -91,8 +92,18 @@ func (r *KubeadmPhase) SetKubeadmConfigChecksum(checksum string) {
 	r.checksum = checksum
 }
 
+func (r *KubeadmPhase) isSkipped(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return sets.NewString(tenantControlPlane.Status.KubeadmPhase.Skipped...).Has(r.GetName())
+}
+
 func (r *KubeadmPhase) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return !r.isStatusEqual(tenantControlPlane)
+	// The skipped phases are reported in the status, and removed once enabled again.
+	enabled := tenantControlPlane.KubeadmPhasesEnabled()
+	if enabled == r.isSkipped(tenantControlPlane) {
+		return true
+	}
+
+	return enabled && !r.isStatusEqual(tenantControlPlane)
 }
 
 func (r *KubeadmPhase) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -159,6 +170,18 @@ func (r *KubeadmPhase) GetName() string {
 func (r *KubeadmPhase) UpdateTenantControlPlaneStatus(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	logger := log.FromContext(ctx, "resource", r.GetName(), "phase", r.Phase.String())
 
+	skipped := sets.NewString(tenantControlPlane.Status.KubeadmPhase.Skipped...)
+
+	if !tenantControlPlane.KubeadmPhasesEnabled() {
+		tenantControlPlane.Status.KubeadmPhase.Skipped = skipped.Insert(r.GetName()).List()
+
+		return nil
+	}
+
+	if skipped.Has(r.GetName()) {
+		tenantControlPlane.Status.KubeadmPhase.Skipped = skipped.Delete(r.GetName()).List()
+	}
+
 	status, err := r.GetStatus(tenantControlPlane)
 	if err != nil {
 		logger.Error(err, "unable to update the status")
@@ -186,6 +209,10 @@ func (r *KubeadmPhase) GetStatus(tenantControlPlane *kamajiv1alpha1.TenantContro
 
 func (r *KubeadmPhase) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	logger := log.FromContext(ctx, "resource", r.GetName(), "phase", r.Phase.String())
+	// The Tenant Cluster is bootstrapped externally, the skipped phase is reported in the status.
+	if !tenantControlPlane.KubeadmPhasesEnabled() {
+		return controllerutil.OperationResultNone, nil
+	}
 
 	return KubeadmPhaseCreate(ctx, r, logger, tenantControlPlane)
 }
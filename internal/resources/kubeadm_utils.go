@@ -40,10 +40,24 @@ func GetKubeadmManifestDeps(ctx context.Context, client client.Client, tenantCon
 		TenantControlPlaneVersion:      tenantControlPlane.Spec.Kubernetes.Version,
 		TenantControlPlanePodCIDR:      tenantControlPlane.Spec.NetworkProfile.PodCIDR,
 		TenantControlPlaneAddress:      address,
-		TenantControlPlaneCertSANs:     tenantControlPlane.Spec.NetworkProfile.CertSANs,
+		TenantControlPlaneCertSANs:     tenantControlPlane.APIServerCertSANs(),
 		TenantControlPlanePort:         tenantControlPlane.Spec.NetworkProfile.Port,
 		TenantControlPlaneCGroupDriver: tenantControlPlane.Spec.Kubernetes.Kubelet.CGroupFS.String(),
+		TenantServerTLSBootstrap:       tenantControlPlane.IsKubeletServingCAManaged(),
 	}
+
+	if streaming := tenantControlPlane.Spec.Kubernetes.Streaming; streaming != nil && streaming.IdleTimeout != nil {
+		config.Parameters.TenantStreamingIdleTimeout = streaming.IdleTimeout.Duration
+	}
+
+	if nodePools := tenantControlPlane.Spec.Kubernetes.Kubelet.NodePools; len(nodePools) > 0 {
+		config.Parameters.TenantNodePoolCGroupDrivers = make(map[string]string, len(nodePools))
+
+		for _, nodePool := range nodePools {
+			config.Parameters.TenantNodePoolCGroupDrivers[nodePool.Name] = nodePool.CGroupFS.String()
+		}
+	}
+
 	// If CoreDNS addon is enabled and with an override, adding these to the kubeadm init configuration
 	if coreDNS := tenantControlPlane.Spec.Addons.CoreDNS; coreDNS != nil {
 		config.Parameters.CoreDNSOptions = &kubeadm.AddonOptions{}
@@ -110,9 +124,22 @@ func KubeadmPhaseCreate(ctx context.Context, r KubeadmPhaseResource, logger logr
 		TenantControlPlaneVersion:      tenantControlPlane.Spec.Kubernetes.Version,
 		TenantControlPlanePodCIDR:      tenantControlPlane.Spec.NetworkProfile.PodCIDR,
 		TenantControlPlaneAddress:      address,
-		TenantControlPlaneCertSANs:     tenantControlPlane.Spec.NetworkProfile.CertSANs,
+		TenantControlPlaneCertSANs:     tenantControlPlane.APIServerCertSANs(),
 		TenantControlPlanePort:         tenantControlPlane.Spec.NetworkProfile.Port,
 		TenantControlPlaneCGroupDriver: tenantControlPlane.Spec.Kubernetes.Kubelet.CGroupFS.String(),
+		TenantServerTLSBootstrap:       tenantControlPlane.IsKubeletServingCAManaged(),
+	}
+
+	if streaming := tenantControlPlane.Spec.Kubernetes.Streaming; streaming != nil && streaming.IdleTimeout != nil {
+		config.Parameters.TenantStreamingIdleTimeout = streaming.IdleTimeout.Duration
+	}
+
+	if nodePools := tenantControlPlane.Spec.Kubernetes.Kubelet.NodePools; len(nodePools) > 0 {
+		config.Parameters.TenantNodePoolCGroupDrivers = make(map[string]string, len(nodePools))
+
+		for _, nodePool := range nodePools {
+			config.Parameters.TenantNodePoolCGroupDrivers[nodePool.Name] = nodePool.CGroupFS.String()
+		}
 	}
 
 	var checksum string
@@ -6,6 +6,7 @@ package resources
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,8 +38,13 @@ type KubeconfigResource struct {
 	TmpDirectory       string
 }
 
-func (r *KubeconfigResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
-	return false
+func (r *KubeconfigResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status, err := r.getKubeconfigStatus(tenantControlPlane)
+	if err != nil {
+		return false
+	}
+
+	return !reflect.DeepEqual(status.LegacyKeys, utilities.LegacyKubeconfigKeys(r.resource.Data, r.KubeConfigFileName))
 }
 
 func (r *KubeconfigResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -89,6 +95,7 @@ func (r *KubeconfigResource) UpdateTenantControlPlaneStatus(ctx context.Context,
 	status.LastUpdate = metav1.Now()
 	status.SecretName = r.resource.GetName()
 	status.Checksum = r.resource.Annotations[constants.Checksum]
+	status.LegacyKeys = utilities.LegacyKubeconfigKeys(r.resource.Data, r.KubeConfigFileName)
 
 	return nil
 }
@@ -153,6 +160,12 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 		}
 
 		if status.Checksum == checksum && kubeadm.IsKubeconfigValid(r.resource.Data[r.KubeConfigFileName]) {
+			if tenantControlPlane.LegacyKubeconfigFormatsDisabled() {
+				for _, key := range utilities.LegacyKubeconfigKeys(r.resource.Data, r.KubeConfigFileName) {
+					delete(r.resource.Data, key)
+				}
+			}
+
 			return nil
 		}
 
@@ -0,0 +1,274 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/secretstores"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KubeconfigExternalTargetsResource pushes the admin kubeconfig to the external secret stores specified by the user:
+// it's pushed again only when the kubeconfig, or the target, changes, and deleted from the stores of the removed targets.
+type KubeconfigExternalTargetsResource struct {
+	Client  client.Client
+	targets []kamajiv1alpha1.KubeconfigExternalTargetStatus
+}
+
+func (r *KubeconfigExternalTargetsResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !reflect.DeepEqual(tenantControlPlane.Status.KubeConfig.AdminExternalTargets, r.targets)
+}
+
+func (r *KubeconfigExternalTargetsResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *KubeconfigExternalTargetsResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *KubeconfigExternalTargetsResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.targets = tenantControlPlane.Status.KubeConfig.AdminExternalTargets
+
+	return nil
+}
+
+func (r *KubeconfigExternalTargetsResource) GetName() string {
+	return "admin-kubeconfig-external-targets"
+}
+
+func (r *KubeconfigExternalTargetsResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	tenantControlPlane.Status.KubeConfig.AdminExternalTargets = r.targets
+
+	return nil
+}
+
+func (r *KubeconfigExternalTargetsResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	result := controllerutil.OperationResultNone
+
+	if len(tenantControlPlane.Status.KubeConfig.Admin.SecretName) == 0 {
+		return result, nil
+	}
+
+	var desired []kamajiv1alpha1.KubeconfigExternalTarget
+
+	if tenantControlPlane.Spec.Kubeconfig != nil {
+		desired = tenantControlPlane.Spec.Kubeconfig.AdminExternalTargets
+	}
+
+	if len(desired) == 0 && len(r.targets) == 0 {
+		return result, nil
+	}
+
+	source := &corev1.Secret{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.KubeConfig.Admin.SecretName}, source); err != nil {
+		logger.Error(err, "cannot retrieve the admin kubeconfig")
+
+		return result, err
+	}
+
+	pushed := make(map[string]kamajiv1alpha1.KubeconfigExternalTargetStatus, len(r.targets))
+	for _, status := range r.targets {
+		pushed[status.Name] = status
+	}
+
+	targets := make([]kamajiv1alpha1.KubeconfigExternalTargetStatus, 0, len(desired))
+
+	for _, target := range desired {
+		checksum, err := r.checksum(source, target)
+		if err != nil {
+			return result, err
+		}
+
+		status, ok := pushed[target.Name]
+		delete(pushed, target.Name)
+		// The target pointing to a different store must be deleted from the previous one.
+		if ok && !reflect.DeepEqual(status.Target, target) {
+			if err = r.deleteFrom(ctx, tenantControlPlane, status.Target); err != nil {
+				logger.Error(err, "cannot delete the admin kubeconfig from the previous store", "target", target.Name)
+
+				return result, err
+			}
+		}
+
+		if ok && status.Checksum == checksum {
+			targets = append(targets, status)
+
+			continue
+		}
+
+		store, err := r.store(ctx, tenantControlPlane, target)
+		if err != nil {
+			logger.Error(err, "cannot configure the kubeconfig external target", "target", target.Name)
+
+			return result, err
+		}
+
+		if err = store.Put(ctx, r.data(source)); err != nil {
+			logger.Error(err, "cannot push the admin kubeconfig", "target", target.Name)
+
+			return result, err
+		}
+
+		result = controllerutil.OperationResultUpdated
+
+		targets = append(targets, kamajiv1alpha1.KubeconfigExternalTargetStatus{
+			Name:       target.Name,
+			Checksum:   checksum,
+			LastUpdate: metav1.Now(),
+			Target:     target,
+		})
+	}
+
+	for name, status := range pushed {
+		if err := r.deleteFrom(ctx, tenantControlPlane, status.Target); err != nil {
+			logger.Error(err, "cannot delete the admin kubeconfig from the removed target", "target", name)
+
+			return result, err
+		}
+
+		result = controllerutil.OperationResultUpdated
+	}
+
+	r.targets = nil
+	if len(targets) > 0 {
+		r.targets = targets
+	}
+
+	return result, nil
+}
+
+// Delete removes the admin kubeconfig from the external stores upon the Tenant Control Plane deletion: the failures
+// are logged, rather than returned, since an unreachable store must not prevent the deletion.
+func (r *KubeconfigExternalTargetsResource) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	for _, status := range tenantControlPlane.Status.KubeConfig.AdminExternalTargets {
+		if err := r.deleteFrom(ctx, tenantControlPlane, status.Target); err != nil {
+			logger.Error(err, "cannot delete the admin kubeconfig from the external target, it must be removed manually", "target", status.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *KubeconfigExternalTargetsResource) deleteFrom(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, target kamajiv1alpha1.KubeconfigExternalTarget) error {
+	store, err := r.store(ctx, tenantControlPlane, target)
+	if err != nil {
+		return err
+	}
+
+	return store.Delete(ctx)
+}
+
+// data returns the kubeconfig Secret content pushed to the stores.
+func (r *KubeconfigExternalTargetsResource) data(source *corev1.Secret) map[string]string {
+	data := make(map[string]string, len(source.Data))
+
+	for k, v := range source.Data {
+		data[k] = string(v)
+	}
+
+	return data
+}
+
+// checksum computes the checksum of the kubeconfig Secret content, along with the target settings.
+func (r *KubeconfigExternalTargetsResource) checksum(source *corev1.Secret, target kamajiv1alpha1.KubeconfigExternalTarget) (string, error) {
+	encoded, err := json.Marshal(target)
+	if err != nil {
+		return "", err
+	}
+
+	data := make(map[string][]byte, len(source.Data)+1)
+	for k, v := range source.Data {
+		data[k] = v
+	}
+
+	data["__target"] = encoded
+
+	return utilities.CalculateMapChecksum(data), nil
+}
+
+// store returns the secret store of the given target, reading its credentials from the Tenant Control Plane namespace.
+func (r *KubeconfigExternalTargetsResource) store(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, target kamajiv1alpha1.KubeconfigExternalTarget) (secretstores.Store, error) {
+	switch {
+	case target.Vault != nil:
+		credentials, err := r.credentials(ctx, tenantControlPlane, target.Vault.TokenSecretRef.Name, "token")
+		if err != nil {
+			return nil, err
+		}
+
+		return &secretstores.Vault{
+			Address:   target.Vault.Address,
+			Namespace: target.Vault.Namespace,
+			Mount:     target.Vault.Mount,
+			Path:      target.Vault.Path,
+			Token:     credentials["token"],
+		}, nil
+	case target.AWSSecretsManager != nil:
+		credentials, err := r.credentials(ctx, tenantControlPlane, target.AWSSecretsManager.CredentialsSecretRef.Name, "access-key-id", "secret-access-key")
+		if err != nil {
+			return nil, err
+		}
+
+		return &secretstores.AWSSecretsManager{
+			Region:          target.AWSSecretsManager.Region,
+			SecretName:      target.AWSSecretsManager.SecretName,
+			AccessKeyID:     credentials["access-key-id"],
+			SecretAccessKey: credentials["secret-access-key"],
+			SessionToken:    credentials["session-token"],
+		}, nil
+	case target.AzureKeyVault != nil:
+		credentials, err := r.credentials(ctx, tenantControlPlane, target.AzureKeyVault.CredentialsSecretRef.Name, "tenant-id", "client-id", "client-secret")
+		if err != nil {
+			return nil, err
+		}
+
+		return &secretstores.AzureKeyVault{
+			VaultURL:     target.AzureKeyVault.VaultURL,
+			SecretName:   target.AzureKeyVault.SecretName,
+			Key:          AdminKubeConfigFileName,
+			TenantID:     credentials["tenant-id"],
+			ClientID:     credentials["client-id"],
+			ClientSecret: credentials["client-secret"],
+		}, nil
+	default:
+		return nil, fmt.Errorf("the kubeconfig external target %s specifies no secret store", target.Name)
+	}
+}
+
+// credentials reads the given Secret, ensuring the required keys are present.
+func (r *KubeconfigExternalTargetsResource) credentials(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, name string, required ...string) (map[string]string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("cannot retrieve the credentials Secret %s: %w", name, err)
+	}
+
+	for _, key := range required {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("the credentials Secret %s has no %s key", name, key)
+		}
+	}
+
+	credentials := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		credentials[k] = string(v)
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// KubeconfigSourceNamespacesAnnotation is the Namespace annotation listing, comma separated, the Tenant Control Plane
+	// namespaces allowed to place a copy of their admin kubeconfig in it: the wildcard "*" allows any namespace.
+	KubeconfigSourceNamespacesAnnotation = "kamaji.clastix.io/kubeconfig-source-namespaces"
+
+	kubeconfigTargetComponent      = "admin-kubeconfig-target"
+	kubeconfigTargetNamespaceLabel = "kamaji.clastix.io/namespace"
+)
+
+// KubeconfigTargetsResource copies the admin kubeconfig Secret to the targets specified by the user:
+// the copies placed in a different namespace cannot be owned by the Tenant Control Plane, thus they're
+// tracked by labels, pruned when no more desired, and deleted along with the Tenant Control Plane.
+type KubeconfigTargetsResource struct {
+	Client  client.Client
+	targets []string
+}
+
+func (r *KubeconfigTargetsResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !reflect.DeepEqual(tenantControlPlane.Status.KubeConfig.AdminTargets, r.targets)
+}
+
+func (r *KubeconfigTargetsResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *KubeconfigTargetsResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *KubeconfigTargetsResource) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	r.targets = nil
+
+	return nil
+}
+
+func (r *KubeconfigTargetsResource) GetName() string {
+	return "admin-kubeconfig-targets"
+}
+
+func (r *KubeconfigTargetsResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	tenantControlPlane.Status.KubeConfig.AdminTargets = r.targets
+
+	return nil
+}
+
+func (r *KubeconfigTargetsResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	result := controllerutil.OperationResultNone
+
+	if len(tenantControlPlane.Status.KubeConfig.Admin.SecretName) == 0 {
+		return result, nil
+	}
+
+	source := &corev1.Secret{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.KubeConfig.Admin.SecretName}, source); err != nil {
+		logger.Error(err, "cannot retrieve the admin kubeconfig")
+
+		return result, err
+	}
+
+	desired := sets.NewString()
+
+	if tenantControlPlane.Spec.Kubeconfig != nil {
+		for _, target := range tenantControlPlane.Spec.Kubeconfig.AdminSecretTargets {
+			name := target.Name
+			if len(name) == 0 {
+				name = source.GetName()
+			}
+
+			if target.Namespace == source.GetNamespace() && name == source.GetName() {
+				continue
+			}
+
+			allowed, err := r.isNamespaceAllowed(ctx, target.Namespace, tenantControlPlane.GetNamespace())
+			if err != nil {
+				logger.Error(err, "cannot check the kubeconfig target namespace", "namespace", target.Namespace)
+
+				return result, err
+			}
+
+			if !allowed {
+				logger.Info("skipping the kubeconfig target, namespace is not allowing the Tenant Control Plane namespace", "namespace", target.Namespace, "annotation", KubeconfigSourceNamespacesAnnotation)
+
+				continue
+			}
+
+			res, managed, err := r.copyTo(ctx, tenantControlPlane, source, target.Namespace, name)
+			if err != nil {
+				logger.Error(err, "cannot copy the admin kubeconfig", "namespace", target.Namespace, "name", name)
+
+				return result, err
+			}
+
+			if !managed {
+				logger.Info("skipping the kubeconfig target, Secret is not managed by the Tenant Control Plane", "namespace", target.Namespace, "name", name)
+
+				continue
+			}
+
+			if res != controllerutil.OperationResultNone {
+				result = controllerutil.OperationResultUpdated
+			}
+
+			desired.Insert(fmt.Sprintf("%s/%s", target.Namespace, name))
+		}
+	}
+
+	pruned, err := r.prune(ctx, tenantControlPlane, desired)
+	if err != nil {
+		logger.Error(err, "cannot prune the stale kubeconfig targets")
+
+		return result, err
+	}
+
+	if pruned {
+		result = controllerutil.OperationResultUpdated
+	}
+
+	if desired.Len() > 0 {
+		r.targets = desired.List()
+	}
+
+	return result, nil
+}
+
+// Delete removes all the copies of the admin kubeconfig upon the Tenant Control Plane deletion.
+func (r *KubeconfigTargetsResource) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	_, err := r.prune(ctx, tenantControlPlane, sets.NewString())
+
+	return err
+}
+
+func (r *KubeconfigTargetsResource) labels(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	return map[string]string{
+		"kamaji.clastix.io/name":       tenantControlPlane.GetName(),
+		kubeconfigTargetNamespaceLabel: tenantControlPlane.GetNamespace(),
+		"kamaji.clastix.io/component":  kubeconfigTargetComponent,
+	}
+}
+
+// isNamespaceAllowed checks if the target namespace opted-in for the copies coming from the given source namespace.
+func (r *KubeconfigTargetsResource) isNamespaceAllowed(ctx context.Context, target, source string) (bool, error) {
+	if target == source {
+		return true, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Name: target}, ns); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	for _, allowed := range strings.Split(ns.GetAnnotations()[KubeconfigSourceNamespacesAnnotation], ",") {
+		if allowed = strings.TrimSpace(allowed); allowed == "*" || allowed == source {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// copyTo places the copy of the admin kubeconfig, returning false when a Secret with the same name
+// already exists and has not been created by the Tenant Control Plane, preventing to overwrite it.
+func (r *KubeconfigTargetsResource) copyTo(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, source *corev1.Secret, namespace, name string) (controllerutil.OperationResult, bool, error) {
+	secret := &corev1.Secret{}
+
+	switch err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: name}, secret); {
+	case k8serrors.IsNotFound(err):
+		break
+	case err != nil:
+		return controllerutil.OperationResultNone, false, err
+	default:
+		for k, v := range r.labels(tenantControlPlane) {
+			if secret.GetLabels()[k] != v {
+				return controllerutil.OperationResultNone, false, nil
+			}
+		}
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	res, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(), r.labels(tenantControlPlane)))
+		secret.Data = make(map[string][]byte, len(source.Data))
+
+		for k, v := range source.Data {
+			secret.Data[k] = v
+		}
+
+		if namespace != tenantControlPlane.GetNamespace() {
+			return nil
+		}
+
+		return ctrl.SetControllerReference(tenantControlPlane, secret, r.Client.Scheme())
+	})
+
+	return res, err == nil, err
+}
+
+// prune deletes the copies of the admin kubeconfig which are not desired anymore, returning if any has been deleted.
+func (r *KubeconfigTargetsResource) prune(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, desired sets.String) (bool, error) {
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(ctx, secrets, client.MatchingLabels(r.labels(tenantControlPlane))); err != nil {
+		return false, err
+	}
+
+	var pruned bool
+
+	for i := range secrets.Items {
+		secret := secrets.Items[i]
+
+		if desired.Has(fmt.Sprintf("%s/%s", secret.GetNamespace(), secret.GetName())) {
+			continue
+		}
+
+		if err := r.Client.Delete(ctx, &secret); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		pruned = true
+	}
+
+	return pruned, nil
+}
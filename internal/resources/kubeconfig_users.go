@@ -0,0 +1,191 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// UsersKubeConfigFileName is the key of the users kubeconfig Secret storing the kubeconfig.
+const UsersKubeConfigFileName = "users.conf"
+
+// UsersKubeconfigResource generates the kubeconfig handed out to the human users: it shares the cluster
+// of the admin kubeconfig, with no embedded client certificate, and authenticates against the OIDC issuer
+// using the kubelogin plugin, installed as the oidc-login kubectl plugin.
+type UsersKubeconfigResource struct {
+	resource *corev1.Secret
+	Client   client.Client
+}
+
+func (r *UsersKubeconfigResource) isEnabled(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Kubeconfig != nil && tenantControlPlane.Spec.Kubeconfig.Users != nil
+}
+
+func (r *UsersKubeconfigResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !r.isEnabled(tenantControlPlane) {
+		return len(tenantControlPlane.Status.KubeConfig.Users.SecretName) > 0
+	}
+
+	return tenantControlPlane.Status.KubeConfig.Users.Checksum != r.resource.GetAnnotations()[constants.Checksum]
+}
+
+func (r *UsersKubeconfigResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isEnabled(tenantControlPlane) && len(tenantControlPlane.Status.KubeConfig.Users.SecretName) > 0
+}
+
+func (r *UsersKubeconfigResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *UsersKubeconfigResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *UsersKubeconfigResource) GetName() string {
+	return "users-kubeconfig"
+}
+
+func (r *UsersKubeconfigResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.isEnabled(tenantControlPlane) {
+		tenantControlPlane.Status.KubeConfig.Users = kamajiv1alpha1.KubeconfigStatus{}
+
+		return nil
+	}
+
+	tenantControlPlane.Status.KubeConfig.Users.LastUpdate = metav1.Now()
+	tenantControlPlane.Status.KubeConfig.Users.SecretName = r.resource.GetName()
+	tenantControlPlane.Status.KubeConfig.Users.Checksum = r.resource.GetAnnotations()[constants.Checksum]
+
+	return nil
+}
+
+func (r *UsersKubeconfigResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.isEnabled(tenantControlPlane) || len(tenantControlPlane.Status.KubeConfig.Admin.SecretName) == 0 {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *UsersKubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		admin := &corev1.Secret{}
+		if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.KubeConfig.Admin.SecretName}, admin); err != nil {
+			logger.Error(err, "cannot retrieve the admin kubeconfig")
+
+			return err
+		}
+
+		kubeconfig, err := r.kubeconfig(tenantControlPlane, admin.Data[AdminKubeConfigFileName])
+		if err != nil {
+			logger.Error(err, "cannot generate the users kubeconfig")
+
+			return err
+		}
+
+		r.resource.Data = map[string][]byte{
+			UsersKubeConfigFileName: kubeconfig,
+		}
+
+		r.resource.SetLabels(utilities.MergeMaps(
+			utilities.KamajiLabels(),
+			map[string]string{
+				"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+				"kamaji.clastix.io/component": r.GetName(),
+			},
+		))
+
+		r.resource.SetAnnotations(map[string]string{
+			constants.Checksum: utilities.CalculateMapChecksum(r.resource.Data),
+		})
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *UsersKubeconfigResource) kubeconfig(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, adminKubeconfig []byte) ([]byte, error) {
+	admin, err := clientcmd.Load(adminKubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	adminContext, ok := admin.Contexts[admin.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("the admin kubeconfig has no current context")
+	}
+
+	cluster, ok := admin.Clusters[adminContext.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("the admin kubeconfig has no cluster for the current context")
+	}
+
+	issuerURL, clientID := tenantControlPlane.UsersKubeconfigOIDC()
+
+	args := []string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=" + issuerURL,
+		"--oidc-client-id=" + clientID,
+	}
+
+	for _, scope := range tenantControlPlane.Spec.Kubeconfig.Users.ExtraScopes {
+		args = append(args, "--oidc-extra-scope="+scope)
+	}
+
+	name, user := tenantControlPlane.GetName(), "oidc"
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   cluster.Server,
+		CertificateAuthorityData: cluster.CertificateAuthorityData,
+	}
+	config.AuthInfos[user] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion:      "client.authentication.k8s.io/v1beta1",
+			Command:         "kubectl",
+			Args:            args,
+			InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+		},
+	}
+	config.Contexts[fmt.Sprintf("%s@%s", user, name)] = &clientcmdapi.Context{
+		Cluster:  name,
+		AuthInfo: user,
+	}
+	config.CurrentContext = fmt.Sprintf("%s@%s", user, name)
+
+	return clientcmd.Write(*config)
+}
@@ -0,0 +1,197 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// kubeletServingCAPreviousCertName is the key storing the rotated Certificate Authority, still trusted until its expiration.
+const kubeletServingCAPreviousCertName = "kubelet-serving-ca-previous.crt"
+
+// KubeletServingCACertificate generates the Certificate Authority used by the kube-controller-manager to sign the kubelet serving certificates,
+// rotating it ahead of its expiration: the bundle verified by the kube-apiserver keeps the previous one until it expires,
+// letting the kubelets renew their serving certificates in the meanwhile.
+type KubeletServingCACertificate struct {
+	resource  *corev1.Secret
+	isRotated bool
+
+	Client client.Client
+}
+
+func (r *KubeletServingCACertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Certificates.KubeletServingCA
+
+	if !tenantControlPlane.IsKubeletServingCAManaged() {
+		return status != nil
+	}
+
+	return r.isRotated || status == nil || status.SecretName != r.resource.GetName() || status.Checksum != r.resource.GetAnnotations()[constants.Checksum]
+}
+
+func (r *KubeletServingCACertificate) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.IsKubeletServingCAManaged() && tenantControlPlane.Status.Certificates.KubeletServingCA != nil
+}
+
+func (r *KubeletServingCACertificate) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *KubeletServingCACertificate) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *KubeletServingCACertificate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !tenantControlPlane.IsKubeletServingCAManaged() {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *KubeletServingCACertificate) GetName() string {
+	return "kubelet-serving-ca"
+}
+
+func (r *KubeletServingCACertificate) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !tenantControlPlane.IsKubeletServingCAManaged() {
+		tenantControlPlane.Status.Certificates.KubeletServingCA = nil
+
+		return nil
+	}
+
+	status := tenantControlPlane.Status.Certificates.KubeletServingCA
+	if status == nil {
+		status = &kamajiv1alpha1.KubeletServingCAStatus{}
+	}
+
+	status.LastUpdate = metav1.Now()
+	status.SecretName = r.resource.GetName()
+	status.Checksum = r.resource.GetAnnotations()[constants.Checksum]
+
+	if crt, err := crypto.ParseCertificateBytes(r.resource.Data[builder.KubeletServingCACertName]); err == nil {
+		status.NotAfter = &metav1.Time{Time: crt.NotAfter}
+	}
+
+	if r.isRotated {
+		now := metav1.Now()
+		status.LastRotation = &now
+	}
+
+	tenantControlPlane.Status.Certificates.KubeletServingCA = status
+
+	return nil
+}
+
+// isValid returns true if the current Certificate Authority doesn't need to be rotated yet.
+func (r *KubeletServingCACertificate) isValid(ctx context.Context, spec *kamajiv1alpha1.KubeletServingCertificateAuthoritySpec) bool {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	isValid, err := crypto.CheckCertificateAndPrivateKeyPairValidity(r.resource.Data[builder.KubeletServingCACertName], r.resource.Data[builder.KubeletServingCAKeyName])
+	if err != nil {
+		logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", r.GetName(), err.Error()))
+	}
+
+	if !isValid {
+		return false
+	}
+
+	crt, err := crypto.ParseCertificateBytes(r.resource.Data[builder.KubeletServingCACertName])
+	if err != nil {
+		return false
+	}
+
+	return time.Now().Before(crt.NotAfter.Add(-spec.RenewBefore.Duration))
+}
+
+func (r *KubeletServingCACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		spec := tenantControlPlane.Spec.Kubernetes.Kubelet.TLS.ServingCertificateAuthority
+		// The mutation could be retried upon a conflict.
+		r.isRotated = false
+
+		current, previous := r.resource.Data[builder.KubeletServingCACertName], r.resource.Data[kubeletServingCAPreviousCertName]
+		key := r.resource.Data[builder.KubeletServingCAKeyName]
+
+		if !r.isValid(ctx, spec) {
+			crt, privateKey, err := crypto.GenerateCertificateAuthorityPrivateKeyPairWithValidity(r.GetName(), spec.Validity.Duration)
+			if err != nil {
+				logger.Error(err, "cannot generate the kubelet serving Certificate Authority")
+
+				return err
+			}
+
+			r.isRotated = len(current) > 0
+			previous, current, key = current, crt.Bytes(), privateKey.Bytes()
+		}
+
+		data := map[string][]byte{
+			builder.KubeletServingCACertName: current,
+			builder.KubeletServingCAKeyName:  key,
+			builder.KubeletCACertName:        current,
+		}
+		// The kubelets could still present a serving certificate signed by the previous Certificate Authority.
+		if crt, err := crypto.ParseCertificateBytes(previous); err == nil && time.Now().Before(crt.NotAfter) {
+			data[kubeletServingCAPreviousCertName] = previous
+			data[builder.KubeletCACertName] = bytes.Join([][]byte{current, previous}, nil)
+		}
+
+		r.resource.Data = data
+
+		r.resource.SetLabels(utilities.MergeMaps(
+			utilities.KamajiLabels(),
+			map[string]string{
+				"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+				"kamaji.clastix.io/component": r.GetName(),
+			},
+		))
+
+		annotations := r.resource.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[constants.Checksum] = utilities.CalculateMapChecksum(r.resource.Data)
+		r.resource.SetAnnotations(annotations)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
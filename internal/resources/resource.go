@@ -14,6 +14,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/metrics"
 )
 
 const (
@@ -59,6 +60,8 @@ type HandlerConfig struct {
 
 // Handle handles the given resource and returns a boolean to say if the tenantControlPlane has been modified.
 func Handle(ctx context.Context, resource Resource, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	ctx = metrics.WithHandlerScope(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.GetName(), resource.GetName())
+
 	if err := resource.Define(ctx, tenantControlPlane); err != nil {
 		return "", err
 	}
@@ -0,0 +1,117 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstores
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+const awsSecretsManagerService = "secretsmanager"
+
+// AWSSecretsManager writes the secrets to AWS Secrets Manager, encoded as a JSON object: the static credentials are
+// required, since the default chain would resolve the identity of the operator, such as IAM Roles for Service Accounts.
+type AWSSecretsManager struct {
+	Region          string
+	SecretName      string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+func (a *AWSSecretsManager) Put(ctx context.Context, data map[string]string) error {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	err = a.call(ctx, "PutSecretValue", map[string]string{"SecretId": a.SecretName, "SecretString": string(value)})
+	if !isAWSError(err, "ResourceNotFoundException") {
+		return err
+	}
+
+	return a.call(ctx, "CreateSecret", map[string]string{"Name": a.SecretName, "SecretString": string(value)})
+}
+
+// Delete removes the secret with no recovery window, allowing a new one with the same name to be created right away.
+func (a *AWSSecretsManager) Delete(ctx context.Context) error {
+	err := a.call(ctx, "DeleteSecret", map[string]interface{}{"SecretId": a.SecretName, "ForceDeleteWithoutRecovery": true})
+	if isAWSError(err, "ResourceNotFoundException") {
+		return nil
+	}
+
+	return err
+}
+
+// call invokes the given action of the Secrets Manager JSON API, signing the request with the Signature Version 4.
+func (a *AWSSecretsManager) call(ctx context.Context, action string, input interface{}) error {
+	if len(a.AccessKeyID) == 0 || len(a.SecretAccessKey) == 0 {
+		return fmt.Errorf("the AWS Secrets Manager credentials are missing")
+	}
+
+	creds, err := credentials.NewStaticCredentialsProvider(a.AccessKeyID, a.SecretAccessKey, a.SessionToken).Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot retrieve the AWS credentials: %w", err)
+	}
+
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancelFn := context.WithTimeout(ctx, requestTimeout)
+	defer cancelFn()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("https://%s.%s.amazonaws.com/", awsSecretsManagerService, a.Region), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	request.Header.Set("X-Amz-Target", "secretsmanager."+action)
+
+	hash := sha256.Sum256(payload)
+	if err = v4.NewSigner().SignHTTP(ctx, creds, request, hex.EncodeToString(hash[:]), awsSecretsManagerService, a.Region, time.Now()); err != nil {
+		return fmt.Errorf("cannot sign the AWS request: %w", err)
+	}
+
+	status, body, err := do(request)
+	if err != nil {
+		return fmt.Errorf("cannot perform the AWS Secrets Manager %s action: %w", action, err)
+	}
+
+	if status != http.StatusOK {
+		return &statusError{store: "AWS Secrets Manager", status: status, body: string(body)}
+	}
+
+	return nil
+}
+
+// isAWSError checks if the AWS JSON API replied with the given error type, reported in the __type field.
+func isAWSError(err error, errorType string) bool {
+	statusErr, ok := err.(*statusError) //nolint:errorlint
+	if !ok || statusErr.status != http.StatusBadRequest {
+		return false
+	}
+
+	var body struct {
+		Type string `json:"__type"`
+	}
+
+	if json.Unmarshal([]byte(statusErr.body), &body) != nil {
+		return false
+	}
+
+	return strings.HasSuffix(body.Type, errorType)
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	azureKeyVaultAPIVersion = "7.4"
+	azureKeyVaultScope      = "https://vault.azure.net/.default"
+	azureLoginEndpoint      = "https://login.microsoftonline.com"
+)
+
+// AzureKeyVault writes the secrets to Azure Key Vault, authenticating as a service principal with the client credentials:
+// since a Key Vault secret holds a single value, only the one of the Key field is written.
+type AzureKeyVault struct {
+	VaultURL     string
+	SecretName   string
+	Key          string
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+}
+
+func (a *AzureKeyVault) Put(ctx context.Context, data map[string]string) error {
+	value, ok := data[a.Key]
+	if !ok {
+		return fmt.Errorf("the secret data has no %s key", a.Key)
+	}
+
+	token, err := a.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, body, err := doJSON(ctx, http.MethodPut, a.url(), a.headers(token), map[string]string{"value": value})
+	if err != nil {
+		return fmt.Errorf("cannot write the Azure Key Vault secret: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return &statusError{store: "Azure Key Vault", status: status, body: string(body)}
+	}
+
+	return nil
+}
+
+// Delete removes the secret: with the soft-delete enabled, it's retained by the Key Vault until its purge.
+func (a *AzureKeyVault) Delete(ctx context.Context) error {
+	token, err := a.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, body, err := doJSON(ctx, http.MethodDelete, a.url(), a.headers(token), nil)
+	if err != nil {
+		return fmt.Errorf("cannot delete the Azure Key Vault secret: %w", err)
+	}
+
+	if status != http.StatusOK && status != http.StatusNotFound {
+		return &statusError{store: "Azure Key Vault", status: status, body: string(body)}
+	}
+
+	return nil
+}
+
+// token requests an access token to the Microsoft identity platform with the client credentials grant.
+func (a *AzureKeyVault) token(ctx context.Context) (string, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {a.ClientID},
+		"client_secret": {a.ClientSecret},
+		"scope":         {azureKeyVaultScope},
+	}
+
+	ctx, cancelFn := context.WithTimeout(ctx, requestTimeout)
+	defer cancelFn()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/oauth2/v2.0/token", azureLoginEndpoint, url.PathEscape(a.TenantID)), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	status, body, err := do(request)
+	if err != nil {
+		return "", fmt.Errorf("cannot request the Azure access token: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return "", &statusError{store: "Microsoft identity platform", status: status, body: string(body)}
+	}
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("cannot decode the Azure access token: %w", err)
+	}
+
+	return response.AccessToken, nil
+}
+
+func (a *AzureKeyVault) url() string {
+	return fmt.Sprintf("%s/secrets/%s?api-version=%s", strings.TrimSuffix(a.VaultURL, "/"), url.PathEscape(a.SecretName), azureKeyVaultAPIVersion)
+}
+
+func (a *AzureKeyVault) headers(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstores
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+	// responseLimit caps the response bodies read from the stores, which are only used for the error reporting.
+	responseLimit = 64 * 1024
+)
+
+// Store is an external secret store the kubeconfigs are pushed to.
+type Store interface {
+	// Put creates, or updates, the secret with the given data.
+	Put(ctx context.Context, data map[string]string) error
+	// Delete removes the secret, succeeding if it doesn't exist.
+	Delete(ctx context.Context) error
+}
+
+// statusError is returned when the store replies with an unexpected status code.
+type statusError struct {
+	store  string
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s replied with unexpected status %d: %s", e.store, e.status, e.body)
+}
+
+// doJSON sends the given payload encoded as JSON, returning the status code and the response body.
+func doJSON(ctx context.Context, method, url string, headers map[string]string, payload interface{}) (int, []byte, error) {
+	var body io.Reader
+
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		body = bytes.NewReader(encoded)
+	}
+
+	ctx, cancelFn := context.WithTimeout(ctx, requestTimeout)
+	defer cancelFn()
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+
+	return do(request)
+}
+
+func do(request *http.Request) (int, []byte, error) {
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(response.Body, responseLimit))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return response.StatusCode, content, nil
+}
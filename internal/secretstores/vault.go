@@ -0,0 +1,66 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package secretstores
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Vault writes the secrets to a HashiCorp Vault KV version 2 secrets engine, authenticating with a token.
+type Vault struct {
+	Address   string
+	Namespace string
+	Mount     string
+	Path      string
+	Token     string
+}
+
+func (v *Vault) Put(ctx context.Context, data map[string]string) error {
+	status, body, err := doJSON(ctx, http.MethodPut, v.url("data"), v.headers(), map[string]interface{}{"data": data})
+	if err != nil {
+		return fmt.Errorf("cannot write the Vault secret: %w", err)
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent {
+		return &statusError{store: "Vault", status: status, body: string(body)}
+	}
+
+	return nil
+}
+
+// Delete removes the secret along with all its versions.
+func (v *Vault) Delete(ctx context.Context) error {
+	status, body, err := doJSON(ctx, http.MethodDelete, v.url("metadata"), v.headers(), nil)
+	if err != nil {
+		return fmt.Errorf("cannot delete the Vault secret: %w", err)
+	}
+
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return &statusError{store: "Vault", status: status, body: string(body)}
+	}
+
+	return nil
+}
+
+func (v *Vault) url(kind string) string {
+	mount := v.Mount
+	if len(mount) == 0 {
+		mount = "secret"
+	}
+
+	return fmt.Sprintf("%s/v1/%s/%s/%s", strings.TrimSuffix(v.Address, "/"), strings.Trim(mount, "/"), kind, strings.Trim(v.Path, "/"))
+}
+
+func (v *Vault) headers() map[string]string {
+	headers := map[string]string{"X-Vault-Token": v.Token}
+
+	if len(v.Namespace) > 0 {
+		headers["X-Vault-Namespace"] = v.Namespace
+	}
+
+	return headers
+}
@@ -65,3 +65,32 @@ func ArgsAddFlagValue(args map[string]string, flag, value string) bool {
 
 	return !ok
 }
+
+// FeatureGatesFromString parses the value of the --feature-gates flag, such as Foo=true,Bar=false.
+func FeatureGatesFromString(value string) map[string]bool {
+	gates := make(map[string]bool)
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			continue
+		}
+
+		gates[parts[0]] = strings.EqualFold(parts[1], "true")
+	}
+
+	return gates
+}
+
+// FeatureGatesToString creates the value of the --feature-gates flag, sorting the gates to make it idempotent.
+func FeatureGatesToString(gates map[string]bool) string {
+	pairs := make([]string, 0, len(gates))
+
+	for gate, enabled := range gates {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", gate, enabled))
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
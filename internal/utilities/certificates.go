@@ -0,0 +1,48 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+// RequestCertificatesRotation removes the checksum annotation of the leaf certificate Secrets, used to tell if they're up-to-date:
+// the certificates are generated again by the next reconciliation, triggered by the Secrets update.
+func RequestCertificatesRotation(ctx context.Context, c client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	for _, secretName := range []string{
+		tenantControlPlane.Status.Certificates.APIServer.SecretName,
+		tenantControlPlane.Status.Certificates.APIServerKubeletClient.SecretName,
+		tenantControlPlane.Status.Certificates.FrontProxyClient.SecretName,
+	} {
+		if len(secretName) == 0 {
+			continue
+		}
+
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: secretName}, secret); err != nil {
+				return err
+			}
+
+			annotations := secret.GetAnnotations()
+			delete(annotations, constants.Checksum)
+			secret.SetAnnotations(annotations)
+
+			return c.Update(ctx, secret)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
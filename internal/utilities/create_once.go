@@ -0,0 +1,33 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// CreateOnce creates the object mutated by the given function only if it's missing, leaving the existing one untouched:
+// it's used by the addons installed once, which are handed over to the tenant, thus their changes must not be reverted.
+func CreateOnce(ctx context.Context, c client.Client, obj client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	switch err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); {
+	case err == nil:
+		return controllerutil.OperationResultNone, nil
+	case !errors.IsNotFound(err):
+		return controllerutil.OperationResultNone, err
+	}
+
+	if err := f(); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	if err := c.Create(ctx, obj); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return controllerutil.OperationResultCreated, nil
+}
@@ -11,13 +11,22 @@ import (
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/clastix/kamaji/internal/metrics"
 )
 
 // CreateOrUpdateWithConflict is a helper function that wraps the RetryOnConflict around the CreateOrUpdate function:
 // this allows to fetch from the cache the latest modified object an try to apply the changes defined in the MutateFn
 // without enqueuing back the request in order to get the latest changes of the resource.
+// The conflicts and the retries are published as metrics, labelled with the handler scope of the context.
 func CreateOrUpdateWithConflict(ctx context.Context, client client.Client, resource client.Object, f controllerutil.MutateFn) (res controllerutil.OperationResult, err error) {
+	var attempts int
+
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() (scopeErr error) {
+		if attempts++; attempts > 1 {
+			metrics.RecordRetry(ctx)
+		}
+
 		if scopeErr = client.Get(ctx, k8stypes.NamespacedName{Namespace: resource.GetNamespace(), Name: resource.GetName()}, resource); scopeErr != nil {
 			if !errors.IsNotFound(scopeErr) {
 				return scopeErr
@@ -25,6 +34,9 @@ func CreateOrUpdateWithConflict(ctx context.Context, client client.Client, resou
 		}
 
 		res, scopeErr = controllerutil.CreateOrUpdate(ctx, client, resource, f)
+		if errors.IsConflict(scopeErr) {
+			metrics.RecordConflict(ctx)
+		}
 
 		return scopeErr
 	})
@@ -0,0 +1,24 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"sort"
+)
+
+// LegacyKubeconfigKeys returns the keys of a kubeconfig Secret not belonging to the canonical format,
+// where the kubeconfig is stored under the given key only.
+func LegacyKubeconfigKeys(data map[string][]byte, canonicalKey string) []string {
+	var keys []string
+
+	for key := range data {
+		if key != canonicalKey {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// KamajiFieldManager is the field manager used by Kamaji to server-side apply the resources.
+const KamajiFieldManager = "kamaji"
+
+// ServerSideApply applies the given object with the Kamaji field manager, forcing the ownership of the declared fields only:
+// the ones set by other managers, such as the GitOps tools of the tenant, and not declared by Kamaji, are preserved.
+// The operation result is computed comparing the resource version before, and after, the apply.
+func ServerSideApply(ctx context.Context, c client.Client, obj client.Object) (controllerutil.OperationResult, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+
+	var resourceVersion string
+
+	switch err = c.Get(ctx, client.ObjectKeyFromObject(obj), current); {
+	case err == nil:
+		resourceVersion = current.GetResourceVersion()
+	case !errors.IsNotFound(err):
+		return controllerutil.OperationResultNone, err
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	if err = c.Patch(ctx, obj, client.Apply, client.FieldOwner(KamajiFieldManager), client.ForceOwnership); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	switch {
+	case len(resourceVersion) == 0:
+		return controllerutil.OperationResultCreated, nil
+	case resourceVersion != obj.GetResourceVersion():
+		return controllerutil.OperationResultUpdated, nil
+	default:
+		return controllerutil.OperationResultNone, nil
+	}
+}
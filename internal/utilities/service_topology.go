@@ -0,0 +1,50 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	serviceTopologyModeAnnotation  = "service.kubernetes.io/topology-mode"
+	serviceTopologyHintsAnnotation = "service.kubernetes.io/topology-aware-hints"
+)
+
+// SetServiceTopology applies the topology aware routing, and the internal traffic policy, to the given Service:
+// the topology annotations are removed when disabled, unless declared in the given additional ones.
+func SetServiceTopology(service *corev1.Service, topology kamajiv1alpha1.ServiceTopologySpec, additionalAnnotations map[string]string) {
+	annotations := service.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	for _, key := range []string{serviceTopologyModeAnnotation, serviceTopologyHintsAnnotation} {
+		if _, ok := additionalAnnotations[key]; ok {
+			continue
+		}
+
+		switch {
+		case len(topology.TopologyMode) == 0:
+			delete(annotations, key)
+		case key == serviceTopologyHintsAnnotation:
+			annotations[key] = strings.ToLower(topology.TopologyMode)
+		default:
+			annotations[key] = topology.TopologyMode
+		}
+	}
+
+	service.SetAnnotations(annotations)
+	// The API Server defaults the internal traffic policy to Cluster.
+	if policy := topology.InternalTrafficPolicy; policy != nil {
+		service.Spec.InternalTrafficPolicy = policy
+	} else if service.Spec.InternalTrafficPolicy != nil {
+		policy := corev1.ServiceInternalTrafficPolicyCluster
+		service.Spec.InternalTrafficPolicy = &policy
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	readOnlyDeniedMessage = "the current Control Plane is in read-only mode, all the changes are blocked until it's disabled"
+
+	kubeSystemServiceAccountsGroup = "system:serviceaccounts:kube-system"
+	serviceAccountUsernamePrefix   = "system:serviceaccount:"
+	systemUsernamePrefix           = "system:"
+)
+
+// ReadOnly denies the changes requested to a Tenant Control Plane in read-only mode, except the ones performed by
+// the Kubernetes components, such as the kubelets, the controllers, and the scheduler, keeping the Tenant Cluster running.
+type ReadOnly struct{}
+
+func (r *ReadOnly) Handle(_ context.Context, request admission.Request) admission.Response {
+	user := request.UserInfo
+
+	if strings.HasPrefix(user.Username, systemUsernamePrefix) && !strings.HasPrefix(user.Username, serviceAccountUsernamePrefix) {
+		return admission.Allowed("")
+	}
+
+	for _, group := range user.Groups {
+		if group == kubeSystemServiceAccountsGroup {
+			return admission.Allowed("")
+		}
+	}
+
+	return admission.Denied(readOnlyDeniedMessage)
+}
+
+func (r *ReadOnly) SetupWithManager(mgr controllerruntime.Manager) error {
+	mgr.GetWebhookServer().Register("/readonly", &webhook.Admission{Handler: r})
+
+	return nil
+}
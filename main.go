@@ -9,16 +9,24 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/clastix/kamaji/cmd"
+	"github.com/clastix/kamaji/cmd/export"
+	"github.com/clastix/kamaji/cmd/importer"
 	"github.com/clastix/kamaji/cmd/manager"
 	"github.com/clastix/kamaji/cmd/migrate"
+	"github.com/clastix/kamaji/cmd/rbac"
+	"github.com/clastix/kamaji/cmd/render"
 )
 
 func main() {
 	scheme := runtime.NewScheme()
 
-	root, mgr, migrator := cmd.NewCmd(scheme), manager.NewCmd(scheme), migrate.NewCmd(scheme)
+	root, mgr, migrator, renderer := cmd.NewCmd(scheme), manager.NewCmd(scheme), migrate.NewCmd(scheme), render.NewCmd(scheme)
 	root.AddCommand(mgr)
 	root.AddCommand(migrator)
+	root.AddCommand(renderer)
+	root.AddCommand(export.NewCmd(scheme))
+	root.AddCommand(importer.NewCmd(scheme))
+	root.AddCommand(rbac.NewCmd())
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)